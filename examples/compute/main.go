@@ -110,11 +110,10 @@ func main() { //nolint:gocyclo,cyclop // example: sequential GPU setup is inhere
 	defer readbackBuffer.Release()
 
 	// Get bind group layout from pipeline
-	bindGroupLayout := pipeline.GetBindGroupLayout(0)
-	if bindGroupLayout == nil {
-		log.Fatal("failed to get bind group layout")
+	bindGroupLayout, err := pipeline.GetBindGroupLayout(0)
+	if err != nil {
+		log.Fatalf("failed to get bind group layout: %v", err)
 	}
-	defer bindGroupLayout.Release()
 
 	// Create bind group
 	bindGroup, err := device.CreateBindGroupSimple(bindGroupLayout, []wgpu.BindGroupEntry{
@@ -193,17 +193,15 @@ func main() {
 			Buffers: []wgpu.VertexBufferLayout{
 				// Per-vertex buffer (slot 0)
 				{
-					ArrayStride:    uint64(unsafe.Sizeof(Vertex{})),
-					StepMode:       wgpu.VertexStepModeVertex,
-					AttributeCount: uintptr(len(vertexAttributes)),
-					Attributes:     &vertexAttributes[0],
+					ArrayStride: uint64(unsafe.Sizeof(Vertex{})),
+					StepMode:    wgpu.VertexStepModeVertex,
+					Attributes:  vertexAttributes,
 				},
 				// Per-instance buffer (slot 1)
 				{
-					ArrayStride:    uint64(unsafe.Sizeof(InstanceData{})),
-					StepMode:       wgpu.VertexStepModeInstance, // Key: advances per instance, not per vertex
-					AttributeCount: uintptr(len(instanceAttributes)),
-					Attributes:     &instanceAttributes[0],
+					ArrayStride: uint64(unsafe.Sizeof(InstanceData{})),
+					StepMode:    wgpu.VertexStepModeInstance, // Key: advances per instance, not per vertex
+					Attributes:  instanceAttributes,
 				},
 			},
 		},
@@ -1,6 +1,6 @@
 // Package main demonstrates Multiple Render Targets (MRT) using go-webgpu.
 // This example renders a rotating triangle to two render targets simultaneously:
-// - Target 0: Color output (BGRA8Unorm) - shown on screen
+// - Target 0: Color output (surface format) - shown on screen
 // - Target 1: Position-based output (RGBA8Unorm) - offscreen texture
 package main
 
@@ -9,12 +9,12 @@ import (
 	"log"
 	"math"
 	"os"
-	"syscall"
+	"runtime"
 	"time"
 	"unsafe"
 
 	"github.com/go-webgpu/webgpu/wgpu"
-	"golang.org/x/sys/windows"
+	"github.com/go-webgpu/webgpu/wgpu/minwin"
 )
 
 const (
@@ -23,72 +23,15 @@ const (
 	windowTitle  = "go-webgpu: Multiple Render Targets (MRT) Example"
 )
 
-// Win32 constants
-const (
-	csHRedraw                 = 0x0002
-	csVRedraw                 = 0x0001
-	wmDestroy                 = 0x0002
-	wmSize                    = 0x0005
-	idcArrow                  = 32512
-	colorWindow               = 5
-	swShowNormal              = 1
-	pmRemove                  = 0x0001
-	wsOverlappedWindow        = 0x00CF0000
-	wsVisible                 = 0x10000000
-	cwUseDefault       uint32 = 0x80000000
-)
-
-var (
-	user32               = windows.NewLazyDLL("user32.dll")
-	procRegisterClassExW = user32.NewProc("RegisterClassExW")
-	procCreateWindowExW  = user32.NewProc("CreateWindowExW")
-	procShowWindow       = user32.NewProc("ShowWindow")
-	procUpdateWindow     = user32.NewProc("UpdateWindow")
-	procPeekMessageW     = user32.NewProc("PeekMessageW")
-	procTranslateMessage = user32.NewProc("TranslateMessage")
-	procDispatchMessageW = user32.NewProc("DispatchMessageW")
-	procDefWindowProcW   = user32.NewProc("DefWindowProcW")
-	procPostQuitMessage  = user32.NewProc("PostQuitMessage")
-	procLoadCursorW      = user32.NewProc("LoadCursorW")
-	kernel32             = windows.NewLazyDLL("kernel32.dll")
-	procGetModuleHandleW = kernel32.NewProc("GetModuleHandleW")
-)
-
-// WNDCLASSEXW represents the Win32 WNDCLASSEXW structure.
-type WNDCLASSEXW struct {
-	cbSize        uint32
-	style         uint32
-	lpfnWndProc   uintptr
-	cbClsExtra    int32
-	cbWndExtra    int32
-	hInstance     windows.Handle
-	hIcon         windows.Handle
-	hCursor       windows.Handle
-	hbrBackground windows.Handle
-	lpszMenuName  *uint16
-	lpszClassName *uint16
-	hIconSm       windows.Handle
-}
-
-// MSG represents the Win32 MSG structure.
-type MSG struct {
-	hwnd    windows.HWND
-	message uint32
-	wParam  uintptr
-	lParam  uintptr
-	time    uint32
-	pt      struct{ x, y int32 }
-}
-
 // Application state
 type App struct {
-	hwnd             windows.HWND
-	hinstance        windows.Handle
+	window           *minwin.Window
 	instance         *wgpu.Instance
 	adapter          *wgpu.Adapter
 	device           *wgpu.Device
 	queue            *wgpu.Queue
 	surface          *wgpu.Surface
+	surfaceFormat    wgpu.TextureFormat
 	pipeline         *wgpu.RenderPipeline
 	vertexBuffer     *wgpu.Buffer
 	uniformBuffer    *wgpu.Buffer
@@ -149,6 +92,11 @@ fn fs_main(in: VertexOutput) -> FragmentOutput {
 }
 `
 
+func init() {
+	// The window must be created and polled on the main thread.
+	runtime.LockOSThread()
+}
+
 func main() {
 	app := &App{
 		width:     windowWidth,
@@ -167,10 +115,6 @@ func main() {
 
 // init initializes the application.
 func (app *App) init() error {
-	// Get HINSTANCE
-	ret, _, _ := procGetModuleHandleW.Call(0)
-	app.hinstance = windows.Handle(ret)
-
 	// Create window
 	if err := app.createWindow(); err != nil {
 		return fmt.Errorf("create window: %w", err)
@@ -221,89 +165,19 @@ func (app *App) init() error {
 
 // createWindow creates the main window.
 func (app *App) createWindow() error {
-	className, err := windows.UTF16PtrFromString("GoWebGPUMRT")
+	window, err := minwin.New(minwin.Config{Title: windowTitle, Width: app.width, Height: app.height})
 	if err != nil {
 		return err
 	}
-
-	wndClass := WNDCLASSEXW{
-		cbSize:        uint32(unsafe.Sizeof(WNDCLASSEXW{})),
-		style:         csHRedraw | csVRedraw,
-		lpfnWndProc:   syscall.NewCallback(app.wndProc),
-		hInstance:     app.hinstance,
-		lpszClassName: className,
-	}
-
-	// Load default cursor
-	cursor, _, _ := procLoadCursorW.Call(0, uintptr(idcArrow))
-	wndClass.hCursor = windows.Handle(cursor)
-	wndClass.hbrBackground = windows.Handle(colorWindow + 1)
-
-	// nolint:gosec // Required for Win32 FFI - passing struct to Windows API
-	ret, _, _ := procRegisterClassExW.Call(uintptr(unsafe.Pointer(&wndClass)))
-	if ret == 0 {
-		return fmt.Errorf("RegisterClassExW failed")
-	}
-
-	titlePtr, err := windows.UTF16PtrFromString(windowTitle)
-	if err != nil {
-		return err
-	}
-
-	// nolint:gosec // Required for Win32 FFI - passing string pointers to Windows API
-	hwnd, _, _ := procCreateWindowExW.Call(
-		0,
-		uintptr(unsafe.Pointer(className)),
-		uintptr(unsafe.Pointer(titlePtr)),
-		uintptr(wsOverlappedWindow|wsVisible),
-		uintptr(cwUseDefault),
-		uintptr(cwUseDefault),
-		uintptr(app.width),
-		uintptr(app.height),
-		0,
-		0,
-		uintptr(app.hinstance),
-		0,
-	)
-
-	if hwnd == 0 {
-		return fmt.Errorf("CreateWindowExW failed")
-	}
-
-	app.hwnd = windows.HWND(hwnd)
-
-	_, _, _ = procShowWindow.Call(uintptr(app.hwnd), swShowNormal)
-	_, _, _ = procUpdateWindow.Call(uintptr(app.hwnd))
-
+	window.OnResize(func(width, height uint32) {
+		app.width, app.height = width, height
+		app.needsRecreate = true
+	})
+	app.window = window
+	app.width, app.height = window.Size()
 	return nil
 }
 
-// wndProc is the window procedure callback.
-func (app *App) wndProc(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) uintptr {
-	switch msg {
-	case wmDestroy:
-		app.running = false
-		_, _, _ = procPostQuitMessage.Call(0)
-		return 0
-	case wmSize:
-		newWidth := uint32(lParam & 0xFFFF)
-		newHeight := uint32((lParam >> 16) & 0xFFFF)
-		if newWidth != app.width || newHeight != app.height {
-			app.width = newWidth
-			app.height = newHeight
-			app.needsRecreate = true
-		}
-		return 0
-	}
-	ret, _, _ := procDefWindowProcW.Call(
-		uintptr(hwnd),
-		uintptr(msg),
-		wParam,
-		lParam,
-	)
-	return ret
-}
-
 // initWebGPU initializes WebGPU resources.
 func (app *App) initWebGPU() error {
 	// Create instance
@@ -331,19 +205,26 @@ func (app *App) initWebGPU() error {
 	app.queue = device.Queue()
 
 	// Create surface
-	surface, err := inst.CreateSurfaceFromWindowsHWND(uintptr(app.hinstance), uintptr(app.hwnd))
+	surface, err := app.window.CreateSurface(inst)
 	if err != nil {
 		return fmt.Errorf("create surface: %w", err)
 	}
 	app.surface = surface
 
+	// Query the surface format preferred by the adapter
+	format, err := surface.PreferredFormat(adapter)
+	if err != nil {
+		return fmt.Errorf("surface format: %w", err)
+	}
+	app.surfaceFormat = format
+
 	return nil
 }
 
 // configureSurface configures the surface for rendering.
 func (app *App) configureSurface() error {
 	_ = app.surface.Configure(app.device, &wgpu.SurfaceConfiguration{
-		Format:      wgpu.TextureFormatBGRA8Unorm,
+		Format:      app.surfaceFormat,
 		Usage:       wgpu.TextureUsageRenderAttachment,
 		Width:       app.width,
 		Height:      app.height,
@@ -510,7 +391,8 @@ func getVertexAttributes() []wgpu.VertexAttribute {
 func createPipelineDescriptor(
 	layout *wgpu.PipelineLayout,
 	shader *wgpu.ShaderModule,
-	attributes *wgpu.VertexAttribute,
+	attributes []wgpu.VertexAttribute,
+	surfaceFormat wgpu.TextureFormat,
 ) *wgpu.RenderPipelineDescriptor {
 	return &wgpu.RenderPipelineDescriptor{
 		Label:  "",
@@ -519,10 +401,9 @@ func createPipelineDescriptor(
 			Module:     shader,
 			EntryPoint: "vs_main",
 			Buffers: []wgpu.VertexBufferLayout{{
-				ArrayStride:    20, // 5 floats * 4 bytes = 20 bytes per vertex
-				StepMode:       wgpu.VertexStepModeVertex,
-				AttributeCount: 2,
-				Attributes:     attributes,
+				ArrayStride: 20, // 5 floats * 4 bytes = 20 bytes per vertex
+				StepMode:    wgpu.VertexStepModeVertex,
+				Attributes:  attributes,
 			}},
 		},
 		Primitive: wgpu.PrimitiveState{
@@ -540,7 +421,7 @@ func createPipelineDescriptor(
 			// MRT: Two color targets
 			Targets: []wgpu.ColorTargetState{
 				{
-					Format:    wgpu.TextureFormatBGRA8Unorm,
+					Format:    surfaceFormat,
 					WriteMask: wgpu.ColorWriteMaskAll,
 				},
 				{
@@ -572,7 +453,7 @@ func (app *App) createPipeline() error {
 	attributes := getVertexAttributes()
 
 	// Create render pipeline with MRT: two color targets
-	desc := createPipelineDescriptor(pipelineLayout, shader, &attributes[0])
+	desc := createPipelineDescriptor(pipelineLayout, shader, attributes, app.surfaceFormat)
 	pipeline, _ := app.device.CreateRenderPipeline(desc)
 
 	if pipeline == nil {
@@ -613,10 +494,8 @@ func (app *App) updateUniformBuffer() {
 
 // releasePreviousFrame releases resources from the previous frame.
 func (app *App) releasePreviousFrame() {
-	if app.surfaceTexView != nil {
-		app.surfaceTexView.Release()
-		app.surfaceTexView = nil
-	}
+	// The view is owned by the surface texture and released with it.
+	app.surfaceTexView = nil
 	if app.surfaceTex != nil && app.surfaceTex.Texture != nil {
 		app.surfaceTex.Texture.Release()
 		app.surfaceTex = nil
@@ -636,8 +515,8 @@ func (app *App) acquireSurfaceTexture() error {
 	}
 	app.surfaceTex = surfaceTex
 
-	// Create texture view
-	view, _ := surfaceTex.Texture.CreateView(nil)
+	// Get the default view of the surface texture
+	view, _ := surfaceTex.Texture.DefaultView()
 	if view == nil {
 		return fmt.Errorf("failed to create texture view")
 	}
@@ -739,27 +618,7 @@ func (app *App) render() error {
 
 // run is the main application loop.
 func (app *App) run() {
-	for app.running {
-		// Process Windows messages
-		var msg MSG
-		for {
-			// nolint:gosec // Required for Win32 FFI - passing MSG struct to Windows API
-			ret, _, _ := procPeekMessageW.Call(
-				uintptr(unsafe.Pointer(&msg)),
-				0,
-				0,
-				0,
-				pmRemove,
-			)
-			if ret == 0 {
-				break
-			}
-			// nolint:gosec // Required for Win32 FFI - passing MSG struct to Windows API
-			_, _, _ = procTranslateMessage.Call(uintptr(unsafe.Pointer(&msg)))
-			// nolint:gosec // Required for Win32 FFI - passing MSG struct to Windows API
-			_, _, _ = procDispatchMessageW.Call(uintptr(unsafe.Pointer(&msg)))
-		}
-
+	for app.running && app.window.PollEvents() {
 		// Render frame
 		if err := app.render(); err != nil {
 			fmt.Fprintf(os.Stderr, "Render error: %v\n", err)
@@ -771,9 +630,6 @@ func (app *App) run() {
 // cleanup releases all resources.
 // nolint:cyclop // Resource cleanup naturally has many branches
 func (app *App) cleanup() {
-	if app.surfaceTexView != nil {
-		app.surfaceTexView.Release()
-	}
 	if app.surfaceTex != nil && app.surfaceTex.Texture != nil {
 		app.surfaceTex.Texture.Release()
 	}
@@ -813,4 +669,7 @@ func (app *App) cleanup() {
 	if app.instance != nil {
 		app.instance.Release()
 	}
+	if app.window != nil {
+		app.window.Close()
+	}
 }
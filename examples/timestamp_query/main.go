@@ -143,8 +143,10 @@ fn main(@builtin(global_invocation_id) global_id: vec3<u32>) {
 	defer dataBuffer.Release()
 
 	// Create bind group
-	bindGroupLayout := pipeline.GetBindGroupLayout(0)
-	defer bindGroupLayout.Release()
+	bindGroupLayout, err := pipeline.GetBindGroupLayout(0)
+	if err != nil {
+		return fmt.Errorf("get bind group layout: %w", err)
+	}
 
 	bindGroup, err := device.CreateBindGroupSimple(bindGroupLayout, []wgpu.BindGroupEntry{
 		wgpu.BufferBindingEntry(0, dataBuffer, 0, bufferSize),
@@ -292,8 +294,10 @@ fn main(@builtin(global_invocation_id) global_id: vec3<u32>) {
 	defer buffer.Release()
 
 	// Create bind group
-	bindGroupLayout := pipeline.GetBindGroupLayout(0)
-	defer bindGroupLayout.Release()
+	bindGroupLayout, err := pipeline.GetBindGroupLayout(0)
+	if err != nil {
+		return fmt.Errorf("get bind group layout: %w", err)
+	}
 
 	bindGroup, err := device.CreateBindGroupSimple(bindGroupLayout, []wgpu.BindGroupEntry{
 		wgpu.BufferBindingEntry(0, buffer, 0, bufferSize),
@@ -14,8 +14,8 @@ package wgpu
 //   - WGPULimits gained nextInChain as first field
 //   - MinUniform/StorageBufferOffsetAlignment moved after MaxStorageBufferBindingSize
 //   - WGPUStatus Success=0x01 (was 0x00 in v27)
-//   - WGPUVertexAttribute gained nextInChain (Go wire struct does NOT have it — known gap)
-//   - WGPUBindGroupLayoutEntry gained bindingArraySize (Go wire struct does NOT have it — known gap)
+//   - WGPUVertexAttribute gained nextInChain
+//   - WGPUBindGroupLayoutEntry gained bindingArraySize between visibility and buffer
 //   - WGPUPassTimestampWrites gained nextInChain
 
 import (
@@ -96,11 +96,42 @@ func TestABIStructSizes(t *testing.T) {
 		{"ProgrammableStageDescriptor", unsafe.Sizeof(ProgrammableStageDescriptor{}), 48},
 		// computePipelineDescriptorWire: nextInChain(8)+label(16)+layout(8)+compute(48) = 80
 		{"computePipelineDescriptorWire", unsafe.Sizeof(computePipelineDescriptorWire{}), 80},
+		// constantEntryWire: nextInChain(8)+key(16)+value(8) = 32
+		{"constantEntryWire", unsafe.Sizeof(constantEntryWire{}), 32},
+
+		// QuerySet (wgpu.h)
+		// querySetDescriptorExtras: chain(16)+pipelineStatistics(8)+pipelineStatisticCount(8) = 32
+		{"querySetDescriptorExtras", unsafe.Sizeof(querySetDescriptorExtras{}), 32},
+		// commandBufferDescriptorWire: nextInChain(8)+label(16) = 24
+		{"commandBufferDescriptorWire", unsafe.Sizeof(commandBufferDescriptorWire{}), 24},
+		// renderPassMaxDrawCount: chain(16)+maxDrawCount(8) = 24
+		{"renderPassMaxDrawCount", unsafe.Sizeof(renderPassMaxDrawCount{}), 24},
+		// deviceExtras: chain(16)+tracePath(16) = 32
+		{"deviceExtras", unsafe.Sizeof(deviceExtras{}), 32},
+		// surfaceColorManagement: chain(16)+colorSpace(4)+toneMappingMode(4) = 24
+		{"surfaceColorManagement", unsafe.Sizeof(surfaceColorManagement{}), 24},
+
+		// GLSL shader source (wgpu.h)
+		// shaderDefineWire: name(16)+value(16) = 32
+		{"shaderDefineWire", unsafe.Sizeof(shaderDefineWire{}), 32},
+		// shaderSourceGLSLWire: chain(16)+stage(8)+code(16)+defineCount(4)+pad(4)+defines(8) = 56
+		{"shaderSourceGLSLWire", unsafe.Sizeof(shaderSourceGLSLWire{}), 56},
+		// compilationMessageWire: nextInChain(8)+message(16)+type(4)+pad(4)+lineNum(8)+
+		//   linePos(8)+offset(8)+length(8) = 64
+		{"compilationMessageWire", unsafe.Sizeof(compilationMessageWire{}), 64},
+		// compilationInfoWire: nextInChain(8)+messageCount(8)+messages(8) = 24
+		{"compilationInfoWire", unsafe.Sizeof(compilationInfoWire{}), 24},
 
 		// BindGroup types
 		// bindGroupEntryWire: nextInChain(8)+binding(4)+pad(4)+buffer(8)+offset(8)+size(8)+
 		//   sampler(8)+textureView(8) = 56
 		{"bindGroupEntryWire", unsafe.Sizeof(bindGroupEntryWire{}), 56},
+		// bindGroupEntryExtrasWire: chain(16)+buffers(8)+bufferCount(8)+samplers(8)+
+		//   samplerCount(8)+textureViews(8)+textureViewCount(8) = 64
+		{"bindGroupEntryExtrasWire", unsafe.Sizeof(bindGroupEntryExtrasWire{}), 64},
+		// bindGroupLayoutEntryWire: nextInChain(8)+binding(4)+pad(4)+visibility(8)+
+		//   bindingArraySize(4)+pad(4)+buffer(24)+sampler(16)+texture(24)+storageTexture(24) = 120
+		{"bindGroupLayoutEntryWire", unsafe.Sizeof(bindGroupLayoutEntryWire{}), 120},
 
 		// Render pipeline types
 		// BlendComponent: operation(4)+srcFactor(4)+dstFactor(4) = 12
@@ -732,6 +763,59 @@ func TestABIGputypesEnumAlignment(t *testing.T) {
 		runEnumTests(t, tests)
 	})
 
+	t.Run("TextureFormat_compressed", func(t *testing.T) {
+		// Block-compressed formats are also passed straight through. Check the
+		// first and last value of each family against webgpu.h v29.
+		tests := []struct {
+			name     string
+			got      uint32
+			expected uint32
+		}{
+			{"BC1RGBAUnorm", uint32(gputypes.TextureFormatBC1RGBAUnorm), 0x00000032},
+			{"BC7RGBAUnormSrgb", uint32(gputypes.TextureFormatBC7RGBAUnormSrgb), 0x0000003f},
+			{"ETC2RGB8Unorm", uint32(gputypes.TextureFormatETC2RGB8Unorm), 0x00000040},
+			{"EACRG11Snorm", uint32(gputypes.TextureFormatEACRG11Snorm), 0x00000049},
+			{"ASTC4x4Unorm", uint32(gputypes.TextureFormatASTC4x4Unorm), 0x0000004a},
+			{"ASTC12x12UnormSrgb", uint32(gputypes.TextureFormatASTC12x12UnormSrgb), 0x00000065},
+		}
+		runEnumTests(t, tests)
+	})
+
+	t.Run("TextureDimension", func(t *testing.T) {
+		// WGPUTextureDimension and WGPUTextureViewDimension in webgpu.h v29.
+		tests := []struct {
+			name     string
+			got      uint32
+			expected uint32
+		}{
+			{"Dimension1D", uint32(gputypes.TextureDimension1D), 0x00000001},
+			{"Dimension2D", uint32(gputypes.TextureDimension2D), 0x00000002},
+			{"Dimension3D", uint32(gputypes.TextureDimension3D), 0x00000003},
+			{"View2DArray", uint32(gputypes.TextureViewDimension2DArray), 0x00000003},
+			{"ViewCube", uint32(gputypes.TextureViewDimensionCube), 0x00000004},
+			{"ViewCubeArray", uint32(gputypes.TextureViewDimensionCubeArray), 0x00000005},
+			{"View3D", uint32(gputypes.TextureViewDimension3D), 0x00000006},
+		}
+		runEnumTests(t, tests)
+	})
+
+	t.Run("TextureAspect", func(t *testing.T) {
+		// WGPUTextureAspect in webgpu.h v29. The native enum and gputypes agree,
+		// so aspects are passed through without conversion.
+		tests := []struct {
+			name     string
+			got      uint32
+			expected uint32
+		}{
+			{"All", uint32(TextureAspectAll), 0x00000001},
+			{"StencilOnly", uint32(TextureAspectStencilOnly), 0x00000002},
+			{"DepthOnly", uint32(TextureAspectDepthOnly), 0x00000003},
+			{"gputypes_StencilOnly", uint32(gputypes.TextureAspectStencilOnly), uint32(TextureAspectStencilOnly)},
+			{"gputypes_DepthOnly", uint32(gputypes.TextureAspectDepthOnly), uint32(TextureAspectDepthOnly)},
+		}
+		runEnumTests(t, tests)
+	})
+
 	t.Run("TextureUsage_bitflags", func(t *testing.T) {
 		// gputypes.TextureUsage bitflags passed directly as uint64 in wire structs.
 		// Must match WGPUTextureUsageFlags in webgpu.h v29.
@@ -837,69 +921,38 @@ func TestABIWireStructAlignment(t *testing.T) {
 		}
 	})
 
-	t.Run("vertexAttributeWire_size", func(t *testing.T) {
-		// v29 STATUS: WGPUVertexAttribute in C v29 has nextInChain as first field (32 bytes).
-		// Our vertexAttributeWire does NOT have nextInChain (24 bytes).
-		//
-		// This is a KNOWN MIGRATION GAP:
-		//   C v29 WGPUVertexAttribute:
-		//     nextInChain(8)+format(4)+pad(4)+offset(8)+shaderLocation(4)+pad(4) = 32 bytes
-		//   Go vertexAttributeWire (current):
-		//     format(4)+pad(4)+offset(8)+shaderLocation(4)+pad(4) = 24 bytes  [MISSING nextInChain]
-		//
-		// TODO(v29-migration): Add nextInChain to vertexAttributeWire when upgrading to wgpu-native v29.
-		// Tracked in: docs/dev/kanban/blocked/0010-webgpu-headers-upgrade.md
-		const gotSize = unsafe.Sizeof(vertexAttributeWire{})
-		const expectedCurrent = uintptr(24) // current Go wire (no nextInChain)
-		const expectedV29C = uintptr(32)    // C v29 target (has nextInChain)
-
-		if gotSize != expectedCurrent {
-			t.Errorf("sizeof(vertexAttributeWire) = %d, want %d (current Go layout)",
-				gotSize, expectedCurrent)
+	t.Run("vertexAttributeWire", func(t *testing.T) {
+		// v29: WGPUVertexAttribute gained nextInChain as its first field.
+		// nextInChain(0)+format(8)+pad(12)+offset(16)+shaderLocation(24)+pad(28) = 32
+		var w vertexAttributeWire
+		if got := unsafe.Sizeof(w); got != 32 {
+			t.Errorf("sizeof(vertexAttributeWire) = %d, want 32", got)
 		}
-		// Document the gap: once v29 migration is complete, this must be 32.
-		if gotSize == expectedV29C {
-			t.Log("vertexAttributeWire already matches C v29 size (32 bytes) — remove migration TODO")
-		} else {
-			t.Logf("MIGRATION GAP: vertexAttributeWire is %d bytes, C v29 target is %d bytes (missing nextInChain)",
-				gotSize, expectedV29C)
+		for _, o := range []struct {
+			name          string
+			got, expected uintptr
+		}{
+			{"Format", unsafe.Offsetof(w.Format), 8},
+			{"Offset", unsafe.Offsetof(w.Offset), 16},
+			{"ShaderLocation", unsafe.Offsetof(w.ShaderLocation), 24},
+		} {
+			if o.got != o.expected {
+				t.Errorf("offsetof(vertexAttributeWire.%s) = %d, want %d", o.name, o.got, o.expected)
+			}
 		}
 	})
 
-	t.Run("bindGroupLayoutEntryWire_knownGap", func(t *testing.T) {
-		// v29 STATUS: WGPUBindGroupLayoutEntry in C v29 has bindingArraySize (uint32)
-		// between visibility (uint64) and buffer (bufferBindingLayoutWire).
-		//
-		// This is a KNOWN MIGRATION GAP:
-		//   C v29 layout after visibility:
-		//     bindingArraySize(4)+pad(4)+buffer(...)+sampler(...)+...
-		//   Go bindGroupLayoutEntryWire (current):
-		//     NO bindingArraySize field between visibility and buffer
-		//
-		// Impact: buffer, sampler, texture, storageTexture offsets are all shifted
-		// by -8 relative to C v29. This will cause incorrect binding when binding arrays
-		// are used (NativeFeatureTextureBindingArray).
-		//
-		// TODO(v29-migration): Add bindingArraySize uint32 + padding after Visibility
-		// in bindGroupLayoutEntryWire when upgrading to wgpu-native v29.
-		// Tracked in: docs/dev/kanban/blocked/0010-webgpu-headers-upgrade.md
-
+	t.Run("bindGroupLayoutEntryWire_bindingArraySize", func(t *testing.T) {
+		// v29: WGPUBindGroupLayoutEntry has bindingArraySize (uint32) between
+		// visibility (uint64) and buffer, padded so buffer stays 8-byte aligned.
 		var e bindGroupLayoutEntryWire
-		// Verify current layout is self-consistent (no accidental regressions)
 		visibilityOffset := unsafe.Offsetof(e.Visibility)
-		bufferOffset := uintptr(unsafe.Pointer(&e.Buffer)) - uintptr(unsafe.Pointer(&e))
-
-		// Current: visibility at some offset, buffer directly after (no bindingArraySize gap)
-		// In C v29: buffer should be at visibility+8+8 = visibility+16 (bindingArraySize+pad)
-		// Currently buffer is at visibility+8 (just uint64 visibility, no bindingArraySize)
-		expectedCurrentGap := uintptr(8) // sizeof(Visibility uint64) = 8, buffer follows directly
-		actualGap := bufferOffset - visibilityOffset
-		if actualGap != expectedCurrentGap {
-			t.Errorf("gap(Visibility→Buffer) = %d bytes, want %d (current layout without bindingArraySize)",
-				actualGap, expectedCurrentGap)
+		if got, want := unsafe.Offsetof(e.BindingArraySize), visibilityOffset+8; got != want {
+			t.Errorf("offsetof(BindingArraySize) = %d, want %d", got, want)
+		}
+		if got, want := unsafe.Offsetof(e.Buffer), visibilityOffset+16; got != want {
+			t.Errorf("offsetof(Buffer) = %d, want %d", got, want)
 		}
-		t.Logf("MIGRATION GAP: C v29 expects gap(Visibility→Buffer)=16 bytes (bindingArraySize+pad), current Go has %d bytes",
-			actualGap)
 	})
 
 	t.Run("colorTargetStateWire", func(t *testing.T) {
@@ -933,8 +986,7 @@ func TestABIWireStructAlignment(t *testing.T) {
 		// Verify the Visibility field size via its offset and the next field offset.
 		var e bindGroupLayoutEntryWire
 		visibilityOffset := unsafe.Offsetof(e.Visibility)
-		bufferOffset := uintptr(unsafe.Pointer(&e.Buffer)) - uintptr(unsafe.Pointer(&e))
-		visibilitySize := bufferOffset - visibilityOffset
+		visibilitySize := unsafe.Offsetof(e.BindingArraySize) - visibilityOffset
 		const expectedVisibilitySize = uintptr(8) // must be uint64 = 8 bytes
 		if visibilitySize != expectedVisibilitySize {
 			t.Errorf("sizeof(Visibility in bindGroupLayoutEntryWire) = %d, want %d (must be uint64)",
@@ -19,6 +19,15 @@ func EmptyStringView() StringView {
 	return StringView{Data: 0, Length: 0}
 }
 
+// strlen is WGPU_STRLEN, the length marking a null-terminated or null string.
+const strlen = ^uintptr(0)
+
+// NullStringView returns the null string view, which webgpu.h distinguishes
+// from the empty string for optional values such as entry point names.
+func NullStringView() StringView {
+	return StringView{Data: 0, Length: strlen}
+}
+
 // Future represents an async operation handle.
 type Future struct {
 	ID uint64
@@ -94,7 +103,7 @@ func handleAdapterCallback(status uintptr, adapter uintptr, message StringView,
 	if ok && req != nil {
 		req.status = RequestAdapterStatus(status)
 		if adapter != 0 {
-			trackResource(adapter, "Adapter")
+			trackResource(adapter, "Adapter", "")
 			req.adapter = &Adapter{handle: adapter}
 		}
 		req.message = stringViewToString(message)
@@ -219,8 +228,9 @@ func (a *Adapter) Release() {
 // Limits are cached at creation time (RequestAdapter/RequestDevice) and
 // returned by value — no FFI call is made on each access.
 //
-// Note: wgpu-native-specific fields (MaxImmediateSize, MaxNonSamplerBindings)
-// are not exposed here. Use NativeLimits for native extensions.
+// Note: wgpu-native-specific fields (MaxNonSamplerBindings,
+// MaxBindingArrayElementsPerShaderStage) are not exposed here. Use
+// NativeLimits for native extensions.
 type Limits struct {
 	// MaxTextureDimension1D is the maximum 1D texture dimension.
 	MaxTextureDimension1D uint32
@@ -284,6 +294,10 @@ type Limits struct {
 	MaxComputeWorkgroupSizeZ uint32
 	// MaxComputeWorkgroupsPerDimension is the max compute workgroups per dimension.
 	MaxComputeWorkgroupsPerDimension uint32
+	// MaxImmediateSize is the max bytes of immediate data (push constants) a
+	// pipeline layout can declare. It is 0 unless requested together with
+	// NativeFeatureImmediates.
+	MaxImmediateSize uint32
 }
 
 // limitsWire is the FFI-compatible C-layout struct for wgpu-native v29 WGPULimits.
@@ -363,6 +377,7 @@ func limitsFromWire(w *limitsWire) Limits {
 		MaxComputeWorkgroupSizeY:                  w.MaxComputeWorkgroupSizeY,
 		MaxComputeWorkgroupSizeZ:                  w.MaxComputeWorkgroupSizeZ,
 		MaxComputeWorkgroupsPerDimension:          w.MaxComputeWorkgroupsPerDimension,
+		MaxImmediateSize:                          w.MaxImmediateSize,
 	}
 }
 
@@ -401,6 +416,10 @@ type AdapterInfoGo struct {
 	AdapterType  AdapterType
 	VendorID     uint32
 	DeviceID     uint32
+	// SubgroupMinSize and SubgroupMaxSize bound the subgroup size shaders
+	// using FeatureNameSubgroups run with; both are 0 when unsupported.
+	SubgroupMinSize uint32
+	SubgroupMaxSize uint32
 }
 
 // Limits returns the resource limits of this adapter.
@@ -471,6 +490,23 @@ func (a *Adapter) HasFeature(feature FeatureName) bool {
 	return Bool(result) == True
 }
 
+// SupportedFeatures returns the features in want that the adapter supports,
+// in order, for requesting optional features such as FeatureNameShaderF16
+// or FeatureNameSubgroups only where available:
+//
+//	device, err := adapter.RequestDevice(&wgpu.DeviceDescriptor{
+//		RequiredFeatures: adapter.SupportedFeatures(wgpu.FeatureNameShaderF16, wgpu.FeatureNameSubgroups),
+//	})
+func (a *Adapter) SupportedFeatures(want ...FeatureName) []FeatureName {
+	var supported []FeatureName
+	for _, f := range want {
+		if a.HasFeature(f) {
+			supported = append(supported, f)
+		}
+	}
+	return supported
+}
+
 // Info retrieves information about this adapter.
 // The returned AdapterInfoGo contains Go strings copied from C memory.
 // Returns nil if the adapter is nil or if the operation fails.
@@ -499,6 +535,9 @@ func (a *Adapter) Info() (*AdapterInfoGo, error) {
 		AdapterType: nativeInfo.AdapterType,
 		VendorID:    nativeInfo.VendorID,
 		DeviceID:    nativeInfo.DeviceID,
+
+		SubgroupMinSize: nativeInfo.SubgroupMinSize,
+		SubgroupMaxSize: nativeInfo.SubgroupMaxSize,
 	}
 
 	// Copy strings from C memory to Go memory
@@ -0,0 +1,214 @@
+// Package atlas packs many small images into a single texture.
+//
+// An [Atlas] owns an RGBA8 or R8 texture and a shelf [Packer]. Each call to
+// [Atlas.Add] reserves space, uploads the image into it with a
+// sub-region WriteTexture and returns a [Region] whose UV rectangle can be
+// passed to a sprite or glyph shader:
+//
+//	a, _ := atlas.New(device, 1024, 1024, gputypes.TextureFormatRGBA8UnormSrgb, 1)
+//	defer a.Release()
+//
+//	icon, err := a.Add(img)
+//	if errors.Is(err, atlas.ErrFull) {
+//	    // start a new page
+//	}
+//	// bind a.View, draw with icon.UV
+//
+// Regions can be rewritten in place with [Atlas.Update], e.g. for animated
+// sprites, without touching the rest of the texture.
+package atlas
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+
+	"github.com/go-webgpu/webgpu/wgpu"
+	"github.com/gogpu/gputypes"
+)
+
+// ErrFull is returned by [Atlas.Add] when the image does not fit.
+var ErrFull = errors.New("atlas: no space left")
+
+// ErrEmptyImage is returned by [Atlas.Add] and [Atlas.AddPixels] for an
+// image with zero width or height, which no atlas can hold.
+var ErrEmptyImage = errors.New("atlas: image has zero width or height")
+
+// UVRect is a region in normalized texture coordinates, (U0, V0) being the
+// top-left corner.
+type UVRect struct {
+	U0, V0, U1, V1 float32
+}
+
+// Region is an allocated part of an atlas.
+type Region struct {
+	Rect
+	UV UVRect
+}
+
+// Atlas is a texture with a packer that hands out regions of it.
+type Atlas struct {
+	Texture *wgpu.Texture
+	View    *wgpu.TextureView
+	Format  gputypes.TextureFormat
+
+	queue  *wgpu.Queue
+	packer *Packer
+}
+
+// New creates a width x height atlas texture. format must be R8Unorm (for
+// glyph coverage masks), RGBA8Unorm or RGBA8UnormSrgb. padding texels are
+// left between regions to avoid bleeding under linear filtering.
+func New(device *wgpu.Device, width, height uint32, format gputypes.TextureFormat, padding uint32) (*Atlas, error) {
+	if channels(format) == 0 {
+		return nil, fmt.Errorf("atlas: unsupported format %s", format)
+	}
+	tex, err := device.CreateTexture(&wgpu.TextureDescriptor{
+		Label:         "atlas",
+		Usage:         gputypes.TextureUsageTextureBinding | gputypes.TextureUsageCopyDst,
+		Dimension:     gputypes.TextureDimension2D,
+		Size:          gputypes.Extent3D{Width: width, Height: height, DepthOrArrayLayers: 1},
+		Format:        format,
+		MipLevelCount: 1,
+		SampleCount:   1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	view, err := tex.CreateView(nil)
+	if err != nil {
+		tex.Release()
+		return nil, err
+	}
+	queue := device.Queue()
+	if queue == nil {
+		view.Release()
+		tex.Release()
+		return nil, errors.New("atlas: device queue unavailable")
+	}
+	return &Atlas{
+		Texture: tex,
+		View:    view,
+		Format:  format,
+		queue:   queue,
+		packer:  NewPacker(width, height, padding),
+	}, nil
+}
+
+// Add packs img into the atlas and uploads it. It returns [ErrFull] if there
+// is no room left and [ErrEmptyImage] if img is empty.
+func (a *Atlas) Add(img image.Image) (Region, error) {
+	b := img.Bounds()
+	if b.Empty() {
+		return Region{}, ErrEmptyImage
+	}
+	r, ok := a.packer.Pack(uint32(b.Dx()), uint32(b.Dy()))
+	if !ok {
+		return Region{}, ErrFull
+	}
+	region := a.region(r)
+	if err := a.Update(region, img); err != nil {
+		return Region{}, err
+	}
+	return region, nil
+}
+
+// AddPixels packs a width x height block of tightly packed pixels in the
+// atlas format and uploads it.
+func (a *Atlas) AddPixels(pix []byte, width, height uint32) (Region, error) {
+	if width == 0 || height == 0 {
+		return Region{}, ErrEmptyImage
+	}
+	r, ok := a.packer.Pack(width, height)
+	if !ok {
+		return Region{}, ErrFull
+	}
+	region := a.region(r)
+	if err := a.UpdatePixels(region, pix); err != nil {
+		return Region{}, err
+	}
+	return region, nil
+}
+
+// Update replaces the contents of region with img, which must have the
+// same size as the region.
+func (a *Atlas) Update(region Region, img image.Image) error {
+	b := img.Bounds()
+	if uint32(b.Dx()) != region.Width || uint32(b.Dy()) != region.Height {
+		return fmt.Errorf("atlas: image is %dx%d, region is %dx%d", b.Dx(), b.Dy(), region.Width, region.Height)
+	}
+	return a.UpdatePixels(region, pixels(img, channels(a.Format)))
+}
+
+// UpdatePixels replaces the contents of region with tightly packed pixels
+// in the atlas format.
+func (a *Atlas) UpdatePixels(region Region, pix []byte) error {
+	want := int(region.Width) * int(region.Height) * channels(a.Format)
+	if len(pix) != want {
+		return fmt.Errorf("atlas: got %d bytes of pixels, region needs %d", len(pix), want)
+	}
+	return a.queue.WriteTextureData(a.Texture, 0, gputypes.Origin3D{X: region.X, Y: region.Y},
+		pix, region.Width, region.Height)
+}
+
+// Reset forgets every region so the atlas can be refilled. The texture
+// contents are left as they are.
+func (a *Atlas) Reset() { a.packer.Reset() }
+
+// Release releases the queue, view and texture.
+func (a *Atlas) Release() {
+	if a.queue != nil {
+		a.queue.Release()
+		a.queue = nil
+	}
+	if a.View != nil {
+		a.View.Release()
+		a.View = nil
+	}
+	if a.Texture != nil {
+		a.Texture.Release()
+		a.Texture = nil
+	}
+}
+
+// region computes the UV rectangle of r.
+func (a *Atlas) region(r Rect) Region {
+	w, h := a.packer.Size()
+	return Region{Rect: r, UV: uvRect(r, w, h)}
+}
+
+func uvRect(r Rect, width, height uint32) UVRect {
+	w, h := float32(width), float32(height)
+	return UVRect{
+		U0: float32(r.X) / w,
+		V0: float32(r.Y) / h,
+		U1: float32(r.X+r.Width) / w,
+		V1: float32(r.Y+r.Height) / h,
+	}
+}
+
+// channels returns the bytes per texel of a supported atlas format, or 0.
+func channels(format gputypes.TextureFormat) int {
+	switch format {
+	case gputypes.TextureFormatR8Unorm:
+		return 1
+	case gputypes.TextureFormatRGBA8Unorm, gputypes.TextureFormatRGBA8UnormSrgb:
+		return 4
+	}
+	return 0
+}
+
+// pixels converts img to tightly packed Gray (1 channel) or NRGBA (4 channels) rows.
+func pixels(img image.Image, channels int) []byte {
+	b := img.Bounds()
+	r := image.Rect(0, 0, b.Dx(), b.Dy())
+	if channels == 1 {
+		dst := image.NewGray(r)
+		draw.Draw(dst, r, img, b.Min, draw.Src)
+		return dst.Pix
+	}
+	dst := image.NewNRGBA(r)
+	draw.Draw(dst, r, img, b.Min, draw.Src)
+	return dst.Pix
+}
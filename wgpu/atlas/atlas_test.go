@@ -0,0 +1,109 @@
+package atlas
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func overlaps(a, b Rect) bool {
+	return a.X < b.X+b.Width && b.X < a.X+a.Width && a.Y < b.Y+b.Height && b.Y < a.Y+a.Height
+}
+
+func TestPackerNoOverlap(t *testing.T) {
+	p := NewPacker(128, 128, 1)
+	sizes := [][2]uint32{{30, 10}, {20, 16}, {40, 10}, {10, 10}, {60, 30}, {8, 16}, {127, 5}, {16, 16}}
+	var placed []Rect
+	for _, s := range sizes {
+		r, ok := p.Pack(s[0], s[1])
+		if !ok {
+			t.Fatalf("Pack(%d, %d) failed", s[0], s[1])
+		}
+		if r.X+r.Width > 128 || r.Y+r.Height > 128 {
+			t.Fatalf("rect %+v out of bounds", r)
+		}
+		for _, q := range placed {
+			if overlaps(r, q) {
+				t.Fatalf("rect %+v overlaps %+v", r, q)
+			}
+		}
+		placed = append(placed, r)
+	}
+}
+
+func TestPackerReusesShortestShelf(t *testing.T) {
+	p := NewPacker(64, 64, 0)
+	a, _ := p.Pack(48, 16)
+	b, _ := p.Pack(32, 8) // too wide for the first shelf
+	c, _ := p.Pack(8, 8)  // fits both shelves, goes on the 8-texel one
+	if b.Y != a.Y+16 || c.Y != b.Y || c.X != 32 {
+		t.Errorf("got a=%+v b=%+v c=%+v", a, b, c)
+	}
+}
+
+func TestPackerFull(t *testing.T) {
+	p := NewPacker(32, 32, 0)
+	if _, ok := p.Pack(33, 1); ok {
+		t.Error("oversized rect packed")
+	}
+	if _, ok := p.Pack(0, 4); ok {
+		t.Error("empty rect packed")
+	}
+	for i := 0; i < 4; i++ {
+		if _, ok := p.Pack(32, 8); !ok {
+			t.Fatalf("row %d did not fit", i)
+		}
+	}
+	if _, ok := p.Pack(1, 1); ok {
+		t.Error("packed into a full area")
+	}
+	p.Reset()
+	if _, ok := p.Pack(32, 32); !ok {
+		t.Error("Reset did not free the area")
+	}
+}
+
+func TestPackerPaddingAtEdges(t *testing.T) {
+	// Padding separates neighbors but is not required past the right or bottom edge.
+	p := NewPacker(20, 10, 2)
+	a, _ := p.Pack(9, 10)
+	b, ok := p.Pack(9, 10)
+	if !ok || b.X != a.Width+2 {
+		t.Errorf("got a=%+v b=%+v ok=%v", a, b, ok)
+	}
+}
+
+func TestAddEmptyImage(t *testing.T) {
+	a := &Atlas{packer: NewPacker(32, 32, 0)}
+	if _, err := a.Add(image.NewRGBA(image.Rect(0, 0, 0, 8))); !errors.Is(err, ErrEmptyImage) {
+		t.Errorf("Add(0x8) = %v, want ErrEmptyImage", err)
+	}
+	if _, err := a.AddPixels(nil, 8, 0); !errors.Is(err, ErrEmptyImage) {
+		t.Errorf("AddPixels(8x0) = %v, want ErrEmptyImage", err)
+	}
+}
+
+func TestUVRect(t *testing.T) {
+	got := uvRect(Rect{X: 64, Y: 32, Width: 64, Height: 32}, 256, 128)
+	want := UVRect{U0: 0.25, V0: 0.25, U1: 0.5, V1: 0.5}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestPixels(t *testing.T) {
+	mask := image.NewAlpha(image.Rect(4, 4, 6, 5))
+	mask.SetAlpha(5, 4, color.Alpha{A: 200})
+	if got := pixels(mask, channels(gputypes.TextureFormatR8Unorm)); len(got) != 2 || got[0] != 0 || got[1] != 200 {
+		t.Errorf("gray pixels = %v", got)
+	}
+	if got := pixels(mask, channels(gputypes.TextureFormatRGBA8Unorm)); len(got) != 8 || got[7] != 200 {
+		t.Errorf("rgba pixels = %v", got)
+	}
+	if channels(gputypes.TextureFormatBGRA8Unorm) != 0 {
+		t.Error("BGRA8 should not be a supported atlas format")
+	}
+}
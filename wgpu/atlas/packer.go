@@ -0,0 +1,75 @@
+package atlas
+
+// Rect is a region of the atlas in texels.
+type Rect struct {
+	X, Y, Width, Height uint32
+}
+
+// shelf is a horizontal strip of the atlas that fills left to right.
+type shelf struct {
+	y, height, used uint32
+}
+
+// Packer allocates rectangles in a fixed-size area using shelf packing:
+// rectangles are placed left to right on horizontal shelves, choosing the
+// shortest shelf they fit on and opening a new shelf below the last one when
+// none fits. It works well for sprites and glyphs of similar heights and does
+// no GPU work, so it can also back atlases managed by other code.
+type Packer struct {
+	width, height uint32
+	padding       uint32
+	shelves       []shelf
+	bottom        uint32
+}
+
+// NewPacker returns a packer for a width x height area that keeps padding
+// texels of empty space to the right of and below every rectangle to avoid
+// filtering bleed between neighbors.
+func NewPacker(width, height, padding uint32) *Packer {
+	return &Packer{width: width, height: height, padding: padding}
+}
+
+// Size returns the dimensions of the packed area.
+func (p *Packer) Size() (width, height uint32) { return p.width, p.height }
+
+// Pack reserves a width x height rectangle. ok is false if the area has no
+// room left for it.
+func (p *Packer) Pack(width, height uint32) (r Rect, ok bool) {
+	if width == 0 || height == 0 {
+		return Rect{}, false
+	}
+	w, h := width+p.padding, height+p.padding
+	if w > p.width+p.padding || h > p.height+p.padding {
+		return Rect{}, false
+	}
+
+	best := -1
+	for i := range p.shelves {
+		s := &p.shelves[i]
+		if s.height < h || s.used+w > p.width+p.padding {
+			continue
+		}
+		if best < 0 || s.height < p.shelves[best].height {
+			best = i
+		}
+	}
+	if best < 0 {
+		if p.bottom+h > p.height+p.padding {
+			return Rect{}, false
+		}
+		p.shelves = append(p.shelves, shelf{y: p.bottom, height: h})
+		p.bottom += h
+		best = len(p.shelves) - 1
+	}
+
+	s := &p.shelves[best]
+	r = Rect{X: s.used, Y: s.y, Width: width, Height: height}
+	s.used += w
+	return r, true
+}
+
+// Reset discards every allocation.
+func (p *Packer) Reset() {
+	p.shelves = p.shelves[:0]
+	p.bottom = 0
+}
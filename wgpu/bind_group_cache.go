@@ -0,0 +1,176 @@
+package wgpu
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// BindGroupCache reuses bind groups created from identical descriptors: the
+// same layout, and the same buffers, ranges, samplers and texture views at
+// each binding. Per-object rendering that rebuilds its bind groups every
+// frame then creates each distinct group once.
+//
+// Bind groups returned by the cache are owned by it: do not release them.
+// A cached group is dropped, and released, once its layout or any resource
+// it binds has been released or destroyed, including the texture of a bound
+// view; the cache checks for this only when some resource was released
+// since its previous lookup.
+//
+// A BindGroupCache is safe for concurrent use.
+type BindGroupCache struct {
+	create func(*BindGroupDescriptor) (*BindGroup, error)
+
+	mu         sync.Mutex
+	groups     map[string]*cachedBindGroup
+	generation uint64 // releaseGeneration when groups was last swept
+}
+
+// cachedBindGroup is a cached group with the objects it depends on.
+type cachedBindGroup struct {
+	group    *BindGroup
+	layout   *BindGroupLayout
+	buffers  []*Buffer
+	samplers []*Sampler
+	views    []*TextureView
+}
+
+// NewBindGroupCache returns an empty cache creating bind groups on device.
+func NewBindGroupCache(device *Device) *BindGroupCache {
+	return &BindGroupCache{
+		create:     device.CreateBindGroup,
+		groups:     make(map[string]*cachedBindGroup),
+		generation: releaseGeneration.Load(),
+	}
+}
+
+// GetOrCreate returns the cached bind group for desc, creating it with
+// [Device.CreateBindGroup] on first use. Labels are not part of the key.
+// Creation errors are returned and not cached.
+func (c *BindGroupCache) GetOrCreate(desc *BindGroupDescriptor) (*BindGroup, error) {
+	if desc == nil || desc.Layout == nil {
+		return nil, &WGPUError{Op: "BindGroupCache.GetOrCreate", Message: "descriptor or layout is nil"}
+	}
+	key := bindGroupKey(desc)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sweep()
+	if cg, ok := c.groups[key]; ok {
+		return cg.group, nil
+	}
+	group, err := c.create(desc)
+	if err != nil {
+		return nil, err
+	}
+	cg := &cachedBindGroup{group: group, layout: desc.Layout}
+	for i := range desc.Entries {
+		e := &desc.Entries[i]
+		if e.Buffer != nil {
+			cg.buffers = append(cg.buffers, e.Buffer)
+		}
+		if e.Sampler != nil {
+			cg.samplers = append(cg.samplers, e.Sampler)
+		}
+		if e.TextureView != nil {
+			cg.views = append(cg.views, e.TextureView)
+		}
+		cg.buffers = append(cg.buffers, e.Buffers...)
+		cg.samplers = append(cg.samplers, e.Samplers...)
+		cg.views = append(cg.views, e.TextureViews...)
+	}
+	c.groups[key] = cg
+	return group, nil
+}
+
+// sweep drops groups that depend on released objects if any resource was
+// released since the last sweep. c.mu must be held.
+func (c *BindGroupCache) sweep() {
+	gen := releaseGeneration.Load()
+	if gen == c.generation {
+		return
+	}
+	c.generation = gen
+	for key, cg := range c.groups {
+		if !cg.alive() {
+			cg.group.Release()
+			delete(c.groups, key)
+		}
+	}
+}
+
+// alive reports whether the group and everything it binds are unreleased,
+// including the textures of the bound views. Buffers and textures that were
+// destroyed count as released.
+func (cg *cachedBindGroup) alive() bool {
+	if cg.group.released.isSet() || cg.layout.released.isSet() {
+		return false
+	}
+	for _, b := range cg.buffers {
+		if b.released.isSet() {
+			return false
+		}
+	}
+	for _, s := range cg.samplers {
+		if s.released.isSet() {
+			return false
+		}
+	}
+	for _, v := range cg.views {
+		if v.released.isSet() || v.texture != nil && v.texture.released.isSet() {
+			return false
+		}
+	}
+	return true
+}
+
+// Len returns the number of cached bind groups.
+func (c *BindGroupCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sweep()
+	return len(c.groups)
+}
+
+// Release releases every cached bind group and empties the cache. The cache
+// can be used again afterwards.
+func (c *BindGroupCache) Release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, cg := range c.groups {
+		cg.group.Release()
+		delete(c.groups, key)
+	}
+}
+
+// bindGroupKey identifies the layout and bound resources of desc. Resources
+// are identified by their wrapper, which the cache keeps referenced, so a
+// key cannot be reused by a different object while its entry exists.
+func bindGroupKey(desc *BindGroupDescriptor) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%p|", desc.Layout)
+	for i := range desc.Entries {
+		e := &desc.Entries[i]
+		fmt.Fprintf(&b, "%d:", e.Binding)
+		if e.Buffer != nil {
+			fmt.Fprintf(&b, "b%p+%d,%d", e.Buffer, e.Offset, e.Size)
+		}
+		if e.Sampler != nil {
+			fmt.Fprintf(&b, "s%p", e.Sampler)
+		}
+		if e.TextureView != nil {
+			fmt.Fprintf(&b, "t%p", e.TextureView)
+		}
+		for _, r := range e.Buffers {
+			fmt.Fprintf(&b, "b%p,", r)
+		}
+		for _, r := range e.Samplers {
+			fmt.Fprintf(&b, "s%p,", r)
+		}
+		for _, r := range e.TextureViews {
+			fmt.Fprintf(&b, "t%p,", r)
+		}
+		b.WriteByte('|')
+	}
+	return b.String()
+}
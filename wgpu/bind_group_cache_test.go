@@ -0,0 +1,98 @@
+package wgpu
+
+import "testing"
+
+func TestBindGroupKey(t *testing.T) {
+	layout := &BindGroupLayout{handle: 1}
+	buf := &Buffer{handle: 2}
+	view := &TextureView{handle: 3}
+	base := func() *BindGroupDescriptor {
+		return &BindGroupDescriptor{
+			Layout: layout,
+			Entries: []BindGroupEntry{
+				{Binding: 0, Buffer: buf, Size: 64},
+				{Binding: 1, TextureView: view},
+			},
+		}
+	}
+	key := bindGroupKey(base())
+
+	renamed := base()
+	renamed.Label = "renamed"
+	if got := bindGroupKey(renamed); got != key {
+		t.Errorf("label changed the key: %q != %q", got, key)
+	}
+
+	different := map[string]func(*BindGroupDescriptor){
+		"layout":  func(d *BindGroupDescriptor) { d.Layout = &BindGroupLayout{handle: 1} },
+		"buffer":  func(d *BindGroupDescriptor) { d.Entries[0].Buffer = &Buffer{handle: 2} },
+		"offset":  func(d *BindGroupDescriptor) { d.Entries[0].Offset = 256 },
+		"size":    func(d *BindGroupDescriptor) { d.Entries[0].Size = 128 },
+		"binding": func(d *BindGroupDescriptor) { d.Entries[1].Binding = 2 },
+		"view":    func(d *BindGroupDescriptor) { d.Entries[1].TextureView = &TextureView{handle: 3} },
+	}
+	for name, mutate := range different {
+		d := base()
+		mutate(d)
+		if bindGroupKey(d) == key {
+			t.Errorf("%s: key unchanged", name)
+		}
+	}
+}
+
+func TestBindGroupCache(t *testing.T) {
+	created := 0
+	c := &BindGroupCache{
+		create: func(d *BindGroupDescriptor) (*BindGroup, error) {
+			created++
+			return &BindGroup{handle: d.Entries[0].Buffer.handle}, nil
+		},
+		groups:     make(map[string]*cachedBindGroup),
+		generation: releaseGeneration.Load(),
+	}
+	layout := &BindGroupLayout{handle: 1}
+	a, b := &Buffer{handle: 2}, &Buffer{handle: 3}
+	desc := func(buf *Buffer) *BindGroupDescriptor {
+		return &BindGroupDescriptor{Layout: layout, Entries: []BindGroupEntry{{Binding: 0, Buffer: buf}}}
+	}
+
+	first, _ := c.GetOrCreate(desc(a))
+	again, _ := c.GetOrCreate(desc(a))
+	if first != again || created != 1 {
+		t.Fatalf("identical descriptor created %d groups", created)
+	}
+	if _, err := c.GetOrCreate(desc(b)); err != nil || created != 2 || c.Len() != 2 {
+		t.Fatalf("created %d groups, cached %d, err %v", created, c.Len(), err)
+	}
+
+	// Releasing a buffer drops the groups that bind it on the next lookup.
+	// The group's handle is zeroed so that sweeping it makes no native call.
+	a.handle, first.handle = 0, 0
+	a.released.set()
+	if n := c.Len(); n != 1 {
+		t.Fatalf("Len after release = %d, want 1", n)
+	}
+	if g, _ := c.GetOrCreate(desc(b)); g.handle != 3 || created != 2 {
+		t.Errorf("group for the live buffer was not kept")
+	}
+	if _, err := c.GetOrCreate(nil); err == nil {
+		t.Error("nil descriptor: expected error")
+	}
+
+	// Releasing the texture of a bound view drops the group as well.
+	tex := &Texture{}
+	view := &TextureView{handle: 4, texture: tex}
+	withView := &BindGroupDescriptor{Layout: layout, Entries: []BindGroupEntry{
+		{Binding: 0, Buffer: b},
+		{Binding: 1, TextureView: view},
+	}}
+	g, _ := c.GetOrCreate(withView)
+	if c.Len() != 2 {
+		t.Fatalf("Len = %d, want 2", c.Len())
+	}
+	g.handle = 0
+	tex.released.set()
+	if n := c.Len(); n != 1 {
+		t.Errorf("Len after releasing the view's texture = %d, want 1", n)
+	}
+}
@@ -1,6 +1,8 @@
 package wgpu
 
 import (
+	"runtime"
+	"slices"
 	"unsafe"
 
 	"github.com/gogpu/gputypes"
@@ -47,6 +49,10 @@ type BindGroupLayoutEntry struct {
 	Texture *TextureBindingLayout
 	// StorageTexture describes a storage texture binding (nil if not a storage texture binding).
 	StorageTexture *StorageTextureBindingLayout
+	// Count makes the binding an array of Count resources (binding_array in
+	// WGSL); 0 means a single resource. Arrays require the native binding
+	// array features, see [Device.CreateBindGroupLayout].
+	Count uint32
 }
 
 // BindGroupLayoutDescriptor describes a bind group layout.
@@ -99,22 +105,25 @@ type storageTextureBindingLayoutWire struct {
 // bindGroupLayoutEntryWire is the FFI-compatible struct with converted enums.
 // CRITICAL: Visibility is uint64 because wgpu-native defines WGPUShaderStageFlags as uint64!
 type bindGroupLayoutEntryWire struct {
-	NextInChain    uintptr
-	Binding        uint32
-	_pad           [4]byte // padding to align Visibility to 8 bytes
-	Visibility     uint64  // WGPUShaderStageFlags = uint64 in wgpu-native!
-	Buffer         bufferBindingLayoutWire
-	Sampler        samplerBindingLayoutWire
-	Texture        textureBindingLayoutWire
-	StorageTexture storageTextureBindingLayoutWire
+	NextInChain      uintptr
+	Binding          uint32
+	_pad             [4]byte // padding to align Visibility to 8 bytes
+	Visibility       uint64  // WGPUShaderStageFlags = uint64 in wgpu-native!
+	BindingArraySize uint32  // 0 = not an array
+	_pad2            [4]byte // padding to align Buffer to 8 bytes
+	Buffer           bufferBindingLayoutWire
+	Sampler          samplerBindingLayoutWire
+	Texture          textureBindingLayoutWire
+	StorageTexture   storageTextureBindingLayoutWire
 }
 
 // toWire converts a BindGroupLayoutEntry to its wire representation.
 // Nil sub-layout pointers produce zero-value wire structs (BindingNotUsed sentinel).
 func (e *BindGroupLayoutEntry) toWire() bindGroupLayoutEntryWire {
 	wire := bindGroupLayoutEntryWire{
-		Binding:    e.Binding,
-		Visibility: uint64(e.Visibility), // widen uint32 to uint64
+		Binding:          e.Binding,
+		Visibility:       uint64(e.Visibility), // widen uint32 to uint64
+		BindingArraySize: e.Count,
 	}
 	if e.Buffer != nil {
 		wire.Buffer = bufferBindingLayoutWire{
@@ -136,8 +145,13 @@ func (e *BindGroupLayoutEntry) toWire() bindGroupLayoutEntryWire {
 		}
 	}
 	if e.StorageTexture != nil {
+		access := e.StorageTexture.Access
+		if access == gputypes.StorageTextureAccessUndefined {
+			// WebGPU defaults to write-only; a zero wire value would mean BindingNotUsed.
+			access = gputypes.StorageTextureAccessWriteOnly
+		}
 		wire.StorageTexture = storageTextureBindingLayoutWire{
-			Access:        toWGPUStorageTextureAccess(e.StorageTexture.Access),
+			Access:        toWGPUStorageTextureAccess(access),
 			Format:        uint32(e.StorageTexture.Format),
 			ViewDimension: uint32(e.StorageTexture.ViewDimension),
 		}
@@ -154,7 +168,11 @@ type bindGroupLayoutDescriptorWire struct {
 }
 
 // BindGroupEntry describes a single binding in a bind group.
-// Exactly one of Buffer, Sampler, or TextureView must be non-nil.
+// Exactly one of Buffer, Sampler, or TextureView must be non-nil, or, for a
+// binding array, one of Buffers, Samplers, or TextureViews must be non-empty.
+// The constructors [BufferBindingEntry], [SamplerBindingEntry],
+// [TextureBindingEntry], [StorageTextureBindingEntry] and their binding array
+// counterparts take a resource of the right type and set only its fields.
 type BindGroupEntry struct {
 	Binding     uint32
 	Buffer      *Buffer      // For buffer bindings (nil if not used)
@@ -162,6 +180,13 @@ type BindGroupEntry struct {
 	Size        uint64       // Buffer binding size; 0 = whole buffer
 	Sampler     *Sampler     // For sampler bindings (nil if not used)
 	TextureView *TextureView // For texture view bindings (nil if not used)
+
+	// Buffers, Samplers and TextureViews fill a binding array element by
+	// element. Whole buffers are bound. Fewer elements than the layout's
+	// Count require NativeFeaturePartiallyBoundBindingArray.
+	Buffers      []*Buffer
+	Samplers     []*Sampler
+	TextureViews []*TextureView
 }
 
 // bindGroupEntryWire is the FFI-compatible C-layout struct for wgpu-native.
@@ -178,7 +203,32 @@ type bindGroupEntryWire struct {
 	TextureView uintptr // WGPUTextureView (nullable)
 }
 
+// bindGroupEntryExtrasWire is WGPUBindGroupEntryExtras from wgpu.h, chained
+// to a bindGroupEntryWire to bind the elements of a binding array.
+// chain(16)+buffers(8)+bufferCount(8)+samplers(8)+samplerCount(8)+
+// textureViews(8)+textureViewCount(8) = 64 bytes.
+type bindGroupEntryExtrasWire struct {
+	Chain            ChainedStruct // SType = STypeBindGroupEntryExtras
+	Buffers          uintptr       // *WGPUBuffer
+	BufferCount      uintptr       // size_t
+	Samplers         uintptr       // *WGPUSampler
+	SamplerCount     uintptr       // size_t
+	TextureViews     uintptr       // *WGPUTextureView
+	TextureViewCount uintptr       // size_t
+}
+
+// isArray reports whether the entry binds a binding array.
+func (e *BindGroupEntry) isArray() bool {
+	return len(e.Buffers) > 0 || len(e.Samplers) > 0 || len(e.TextureViews) > 0
+}
+
+// arrayLen returns the number of binding array elements of the entry.
+func (e *BindGroupEntry) arrayLen() int {
+	return len(e.Buffers) + len(e.Samplers) + len(e.TextureViews)
+}
+
 // toWire converts a BindGroupEntry to its FFI wire representation.
+// Binding array elements are not included; see arrayToWire.
 func (e *BindGroupEntry) toWire() bindGroupEntryWire {
 	wire := bindGroupEntryWire{
 		Binding: e.Binding,
@@ -197,6 +247,36 @@ func (e *BindGroupEntry) toWire() bindGroupEntryWire {
 	return wire
 }
 
+// arrayToWire fills extras with the binding array elements of e. The
+// returned handle slice backs the pointers in extras and must be kept alive
+// until the FFI call returns.
+func (e *BindGroupEntry) arrayToWire(extras *bindGroupEntryExtrasWire) []uintptr {
+	handles := make([]uintptr, 0, e.arrayLen())
+	for _, b := range e.Buffers {
+		handles = append(handles, b.Handle())
+	}
+	for _, s := range e.Samplers {
+		handles = append(handles, s.Handle())
+	}
+	for _, v := range e.TextureViews {
+		handles = append(handles, v.Handle())
+	}
+	extras.Chain.SType = uint32(STypeBindGroupEntryExtras)
+	base := uintptr(unsafe.Pointer(&handles[0]))
+	ptrAt := func(i int) uintptr { return base + uintptr(i)*unsafe.Sizeof(uintptr(0)) }
+	nb, ns := len(e.Buffers), len(e.Samplers)
+	if nb > 0 {
+		extras.Buffers, extras.BufferCount = ptrAt(0), uintptr(nb)
+	}
+	if ns > 0 {
+		extras.Samplers, extras.SamplerCount = ptrAt(nb), uintptr(ns)
+	}
+	if nv := len(e.TextureViews); nv > 0 {
+		extras.TextureViews, extras.TextureViewCount = ptrAt(nb+ns), uintptr(nv)
+	}
+	return handles
+}
+
 // BindGroupDescriptor describes a bind group.
 type BindGroupDescriptor struct {
 	Label   string
@@ -215,8 +295,22 @@ type bindGroupDescriptorWire struct {
 
 // CreateBindGroupLayout creates a bind group layout.
 // Entries are converted from gputypes to wgpu-native enum values before FFI call.
-// Returns an error if the FFI call fails or the device/descriptor is nil.
+// Returns an error if the FFI call fails or the device/descriptor is nil, or
+// if an entry does not set exactly one binding type or repeats a binding
+// number. The label is shown by debuggers and in validation messages.
 func (d *Device) CreateBindGroupLayout(desc *BindGroupLayoutDescriptor) (*BindGroupLayout, error) {
+	return d.createBindGroupLayout(desc, false)
+}
+
+// CreateBindGroupLayoutE is CreateBindGroupLayout that also returns the
+// validation error raised by wgpu-native for a layout it rejects.
+func (d *Device) CreateBindGroupLayoutE(desc *BindGroupLayoutDescriptor) (*BindGroupLayout, error) {
+	return d.createBindGroupLayout(desc, true)
+}
+
+// createBindGroupLayout creates a bind group layout, inside an error scope
+// when capture is set.
+func (d *Device) createBindGroupLayout(desc *BindGroupLayoutDescriptor, capture bool) (*BindGroupLayout, error) {
 	if err := checkInit(); err != nil {
 		return nil, err
 	}
@@ -227,6 +321,34 @@ func (d *Device) CreateBindGroupLayout(desc *BindGroupLayoutDescriptor) (*BindGr
 		return nil, &WGPUError{Op: "CreateBindGroupLayout", Message: "descriptor is nil"}
 	}
 
+	if err := validateBindGroupLayoutEntries(desc.Entries); err != nil {
+		return nil, &WGPUError{Op: "CreateBindGroupLayout", Type: ErrorTypeValidation, Message: err.Error()}
+	}
+
+	var arrayCounts map[uint32]uint32
+	for i := range desc.Entries {
+		e := &desc.Entries[i]
+		if e.Buffer != nil {
+			if err := validateBufferLayout(e, d.HasFeature); err != nil {
+				return nil, &WGPUError{Op: "CreateBindGroupLayout", Type: ErrorTypeValidation, Message: err.Error()}
+			}
+		}
+		if e.StorageTexture != nil {
+			if err := validateStorageTextureLayout(e, d.HasFeature); err != nil {
+				return nil, &WGPUError{Op: "CreateBindGroupLayout", Type: ErrorTypeValidation, Message: err.Error()}
+			}
+		}
+		if e.Count > 0 {
+			if err := validateBindingArrayLayout(e, d.HasFeature); err != nil {
+				return nil, &WGPUError{Op: "CreateBindGroupLayout", Type: ErrorTypeValidation, Message: err.Error()}
+			}
+			if arrayCounts == nil {
+				arrayCounts = make(map[uint32]uint32)
+			}
+			arrayCounts[e.Binding] = e.Count
+		}
+	}
+
 	var wireDesc bindGroupLayoutDescriptorWire
 	wireDesc.Label = stringToStringView(desc.Label)
 	wireDesc.EntryCount = uintptr(len(desc.Entries))
@@ -240,15 +362,39 @@ func (d *Device) CreateBindGroupLayout(desc *BindGroupLayoutDescriptor) (*BindGr
 		wireDesc.Entries = uintptr(unsafe.Pointer(&wireEntries[0]))
 	}
 
-	handle, _, _ := procDeviceCreateBindGroupLayout.Call(
-		d.handle,
-		uintptr(unsafe.Pointer(&wireDesc)),
-	)
+	var handle uintptr
+	create := func() {
+		handle, _, _ = procDeviceCreateBindGroupLayout.Call(
+			d.handle,
+			uintptr(unsafe.Pointer(&wireDesc)),
+		)
+	}
+	var err error
+	if capture {
+		err = d.captureValidation("CreateBindGroupLayout", create)
+	} else {
+		create()
+	}
+	runtime.KeepAlive(wireEntries)
 	if handle == 0 {
 		return nil, &WGPUError{Op: "CreateBindGroupLayout", Message: "wgpu returned null handle"}
 	}
-	trackResource(handle, "BindGroupLayout")
-	return &BindGroupLayout{handle: handle}, nil
+	trackResource(handle, "BindGroupLayout", desc.Label)
+	bgl := &BindGroupLayout{
+		handle:      handle,
+		label:       desc.Label,
+		arrayCounts: arrayCounts,
+		entries:     slices.Clone(desc.Entries),
+	}
+	if err != nil {
+		// wgpu-native returns an invalid layout rather than null.
+		bgl.Release()
+		return nil, err
+	}
+	if traceActive.Load() {
+		traceCall("Device.CreateBindGroupLayout", nil, bgl, desc)
+	}
+	return bgl, nil
 }
 
 // CreateBindGroupLayoutSimple creates a bind group layout with the given entries.
@@ -260,20 +406,44 @@ func (d *Device) CreateBindGroupLayoutSimple(entries []BindGroupLayoutEntry) (*B
 }
 
 // Release releases the bind group layout.
+// Layouts returned by a pipeline's GetBindGroupLayout are released with the
+// pipeline instead; calling Release on them does nothing.
 func (bgl *BindGroupLayout) Release() {
-	if bgl.handle != 0 {
+	if bgl.handle != 0 && !bgl.pipelineOwned {
 		untrackResource(bgl.handle)
 		procBindGroupLayoutRelease.Call(bgl.handle) //nolint:errcheck
 		bgl.handle = 0
+		bgl.released.set()
 	}
 }
 
 // Handle returns the underlying handle.
 func (bgl *BindGroupLayout) Handle() uintptr { return bgl.handle }
 
+// Label returns the label the layout was created with.
+func (bgl *BindGroupLayout) Label() string { return bgl.label }
+
 // CreateBindGroup creates a bind group.
-// Returns an error if the FFI call fails or the device/descriptor is nil.
+// Returns an error if the FFI call fails or the device/descriptor is nil, or
+// if the entries do not cover the layout's bindings with resources of the
+// declared types. In debug mode (see [SetDebugMode]) buffer usages,
+// multisampling and texture formats are also checked against the layout
+// before the native call, so mismatches are reported with the binding
+// number.
 func (d *Device) CreateBindGroup(desc *BindGroupDescriptor) (*BindGroup, error) {
+	return d.createBindGroup(desc, false)
+}
+
+// CreateBindGroupE is CreateBindGroup that also returns the validation error
+// raised by wgpu-native for a bind group it rejects. Bind groups are often
+// created per object and frame; keep it to code that needs the error.
+func (d *Device) CreateBindGroupE(desc *BindGroupDescriptor) (*BindGroup, error) {
+	return d.createBindGroup(desc, true)
+}
+
+// createBindGroup creates a bind group, inside an error scope when capture
+// is set.
+func (d *Device) createBindGroup(desc *BindGroupDescriptor, capture bool) (*BindGroup, error) {
 	if err := checkInit(); err != nil {
 		return nil, err
 	}
@@ -287,13 +457,40 @@ func (d *Device) CreateBindGroup(desc *BindGroupDescriptor) (*BindGroup, error)
 		return nil, &WGPUError{Op: "CreateBindGroup", Message: "layout is nil"}
 	}
 
-	// Convert Go-idiomatic entries to FFI wire entries
+	if err := validateBindGroupEntries(desc.Layout.entries, desc.Entries); err != nil {
+		return nil, &WGPUError{Op: "CreateBindGroup", Type: ErrorTypeValidation, Message: err.Error()}
+	}
+	if err := checkBufferBindingSizes(desc.Layout.entries, desc.Entries, (*Buffer).Size); err != nil {
+		return nil, &WGPUError{Op: "CreateBindGroup", Type: ErrorTypeValidation, Message: err.Error()}
+	}
+	if debugMode.Load() {
+		if err := crossCheckBindGroupEntries(desc.Layout.entries, desc.Entries, (*Buffer).Usage); err != nil {
+			return nil, &WGPUError{Op: "CreateBindGroup", Type: ErrorTypeValidation, Message: err.Error()}
+		}
+	}
+
+	// Convert Go-idiomatic entries to FFI wire entries. Binding arrays chain
+	// a WGPUBindGroupEntryExtras whose handle arrays must outlive the call.
 	var wireEntries []bindGroupEntryWire
 	var wireEntriesPtr uintptr
+	var extras []bindGroupEntryExtrasWire
+	var arrayHandles [][]uintptr
 	if len(desc.Entries) > 0 {
 		wireEntries = make([]bindGroupEntryWire, len(desc.Entries))
 		for i := range desc.Entries {
-			wireEntries[i] = desc.Entries[i].toWire()
+			e := &desc.Entries[i]
+			wireEntries[i] = e.toWire()
+			if !e.isArray() {
+				continue
+			}
+			if err := validateBindGroupArrayEntry(e, desc.Layout.arrayCounts[e.Binding], d.HasFeature); err != nil {
+				return nil, &WGPUError{Op: "CreateBindGroup", Type: ErrorTypeValidation, Message: err.Error()}
+			}
+			if extras == nil {
+				extras = make([]bindGroupEntryExtrasWire, len(desc.Entries))
+			}
+			arrayHandles = append(arrayHandles, e.arrayToWire(&extras[i]))
+			wireEntries[i].NextInChain = uintptr(unsafe.Pointer(&extras[i]))
 		}
 		wireEntriesPtr = uintptr(unsafe.Pointer(&wireEntries[0]))
 	}
@@ -305,15 +502,39 @@ func (d *Device) CreateBindGroup(desc *BindGroupDescriptor) (*BindGroup, error)
 		Entries:    wireEntriesPtr,
 	}
 
-	handle, _, _ := procDeviceCreateBindGroup.Call(
-		d.handle,
-		uintptr(unsafe.Pointer(&wire)),
-	)
+	var handle uintptr
+	create := func() {
+		handle, _, _ = procDeviceCreateBindGroup.Call(
+			d.handle,
+			uintptr(unsafe.Pointer(&wire)),
+		)
+	}
+	var err error
+	if capture {
+		err = d.captureValidation("CreateBindGroup", create)
+	} else {
+		create()
+	}
+	runtime.KeepAlive(wireEntries)
+	runtime.KeepAlive(extras)
+	runtime.KeepAlive(arrayHandles)
 	if handle == 0 {
 		return nil, &WGPUError{Op: "CreateBindGroup", Message: "wgpu returned null handle"}
 	}
-	trackResource(handle, "BindGroup")
-	return &BindGroup{handle: handle}, nil
+	trackResource(handle, "BindGroup", desc.Label)
+	bg := &BindGroup{handle: handle, label: desc.Label, dynamicKnown: desc.Layout.entries != nil}
+	if bg.dynamicKnown {
+		bg.dynamic = dynamicBindings(desc.Layout, desc.Entries, &d.limits)
+	}
+	if err != nil {
+		// wgpu-native returns an invalid bind group rather than null.
+		bg.Release()
+		return nil, err
+	}
+	if traceActive.Load() {
+		traceCall("Device.CreateBindGroup", nil, bg, desc)
+	}
+	return bg, nil
 }
 
 // CreateBindGroupSimple creates a bind group with the given entries.
@@ -331,12 +552,16 @@ func (bg *BindGroup) Release() {
 		untrackResource(bg.handle)
 		procBindGroupRelease.Call(bg.handle) //nolint:errcheck
 		bg.handle = 0
+		bg.released.set()
 	}
 }
 
 // Handle returns the underlying handle.
 func (bg *BindGroup) Handle() uintptr { return bg.handle }
 
+// Label returns the label the bind group was created with.
+func (bg *BindGroup) Label() string { return bg.label }
+
 // BufferBindingEntry creates a BindGroupEntry for a buffer.
 func BufferBindingEntry(binding uint32, buffer *Buffer, offset, size uint64) BindGroupEntry {
 	return BindGroupEntry{
@@ -197,3 +197,139 @@ func TestBindGroupWithMultipleBindings(t *testing.T) {
 
 	t.Logf("BindGroup with %d bindings created: handle=%#x", len(entries), bindGroup.Handle())
 }
+
+func TestValidateBindGroupLayoutEntries(t *testing.T) {
+	uniform := &BufferBindingLayout{Type: gputypes.BufferBindingTypeUniform}
+	sampler := &SamplerBindingLayout{Type: gputypes.SamplerBindingTypeFiltering}
+	tests := []struct {
+		name    string
+		entries []BindGroupLayoutEntry
+		wantErr bool
+	}{
+		{"empty", nil, false},
+		{"buffer and sampler", []BindGroupLayoutEntry{{Binding: 0, Buffer: uniform}, {Binding: 1, Sampler: sampler}}, false},
+		{"no binding type", []BindGroupLayoutEntry{{Binding: 0}}, true},
+		{"two binding types", []BindGroupLayoutEntry{{Binding: 0, Buffer: uniform, Sampler: sampler}}, true},
+		{"duplicate binding", []BindGroupLayoutEntry{{Binding: 2, Buffer: uniform}, {Binding: 2, Sampler: sampler}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateBindGroupLayoutEntries(tt.entries); (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateBindGroupEntries(t *testing.T) {
+	layout := []BindGroupLayoutEntry{
+		{Binding: 0, Buffer: &BufferBindingLayout{Type: gputypes.BufferBindingTypeUniform}},
+		{Binding: 1, Texture: &TextureBindingLayout{SampleType: gputypes.TextureSampleTypeFloat}},
+		{Binding: 2, StorageTexture: &StorageTextureBindingLayout{Format: gputypes.TextureFormatRGBA8Unorm}},
+	}
+	buf, view, smp := &Buffer{handle: 1}, &TextureView{handle: 2}, &Sampler{handle: 3}
+	complete := func() []BindGroupEntry {
+		return []BindGroupEntry{
+			{Binding: 0, Buffer: buf},
+			{Binding: 1, TextureView: view},
+			{Binding: 2, TextureView: view},
+		}
+	}
+	tests := []struct {
+		name    string
+		modify  func([]BindGroupEntry) []BindGroupEntry
+		wantErr bool
+	}{
+		{"complete", func(e []BindGroupEntry) []BindGroupEntry { return e }, false},
+		{"missing binding", func(e []BindGroupEntry) []BindGroupEntry { return e[:2] }, true},
+		{"extra binding", func(e []BindGroupEntry) []BindGroupEntry {
+			return append(e, BindGroupEntry{Binding: 7, Sampler: smp})
+		}, true},
+		{"duplicate binding", func(e []BindGroupEntry) []BindGroupEntry {
+			return append(e, BindGroupEntry{Binding: 0, Buffer: buf})
+		}, true},
+		{"wrong resource type", func(e []BindGroupEntry) []BindGroupEntry {
+			e[1] = BindGroupEntry{Binding: 1, Sampler: smp}
+			return e
+		}, true},
+		{"no resource", func(e []BindGroupEntry) []BindGroupEntry {
+			e[0] = BindGroupEntry{Binding: 0}
+			return e
+		}, true},
+		{"two resources", func(e []BindGroupEntry) []BindGroupEntry {
+			e[0].Sampler = smp
+			return e
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBindGroupEntries(layout, tt.modify(complete()))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Layouts from a pipeline have no recorded entries; only the entries
+	// themselves are checked.
+	if err := validateBindGroupEntries(nil, []BindGroupEntry{{Binding: 5, Sampler: smp}}); err != nil {
+		t.Errorf("unknown layout: %v", err)
+	}
+}
+
+func TestCrossCheckBindGroupEntries(t *testing.T) {
+	layout := []BindGroupLayoutEntry{
+		{Binding: 0, Buffer: &BufferBindingLayout{Type: gputypes.BufferBindingTypeUniform}},
+		{Binding: 1, Buffer: &BufferBindingLayout{Type: gputypes.BufferBindingTypeReadOnlyStorage}},
+		{Binding: 2, Texture: &TextureBindingLayout{SampleType: gputypes.TextureSampleTypeFloat}},
+		{Binding: 3, Texture: &TextureBindingLayout{SampleType: gputypes.TextureSampleTypeDepth}},
+		{Binding: 4, StorageTexture: &StorageTextureBindingLayout{Format: gputypes.TextureFormatRGBA8Unorm}},
+	}
+	uniform, storage := &Buffer{handle: 1}, &Buffer{handle: 2}
+	usage := func(b *Buffer) gputypes.BufferUsage {
+		if b == uniform {
+			return gputypes.BufferUsageUniform | gputypes.BufferUsageCopyDst
+		}
+		return gputypes.BufferUsageStorage
+	}
+	color := &TextureView{handle: 3, sampleCount: 1, format: gputypes.TextureFormatRGBA8Unorm}
+	depth := &TextureView{handle: 4, sampleCount: 1, format: gputypes.TextureFormatDepth32Float}
+	msaa := &TextureView{handle: 5, sampleCount: 4, format: gputypes.TextureFormatRGBA8Unorm}
+	srgb := &TextureView{handle: 6, sampleCount: 1, format: gputypes.TextureFormatRGBA8UnormSrgb}
+	unknown := &TextureView{handle: 7}
+	complete := func() []BindGroupEntry {
+		return []BindGroupEntry{
+			BufferBindingEntry(0, uniform, 0, 0),
+			BufferBindingEntry(1, storage, 0, 0),
+			TextureBindingEntry(2, color),
+			TextureBindingEntry(3, depth),
+			StorageTextureBindingEntry(4, color),
+		}
+	}
+	tests := []struct {
+		name    string
+		index   int
+		entry   BindGroupEntry
+		wantErr bool
+	}{
+		{"complete", 0, BufferBindingEntry(0, uniform, 0, 0), false},
+		{"storage buffer as uniform", 0, BufferBindingEntry(0, storage, 0, 0), true},
+		{"uniform buffer as storage", 1, BufferBindingEntry(1, uniform, 0, 0), true},
+		{"multisampled view", 2, TextureBindingEntry(2, msaa), true},
+		{"depth view as float", 2, TextureBindingEntry(2, depth), true},
+		{"color view as depth", 3, TextureBindingEntry(3, color), true},
+		{"storage format mismatch", 4, StorageTextureBindingEntry(4, srgb), true},
+		{"unknown view properties", 2, TextureBindingEntry(2, unknown), false},
+		{"buffer array", 1, BufferBindingArrayEntry(1, []*Buffer{storage, uniform}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := complete()
+			entries[tt.index] = tt.entry
+			err := crossCheckBindGroupEntries(layout, entries, usage)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+)
+
+// bindingKind returns which resource type a layout entry declares, or an
+// error unless exactly one of its binding layouts is set.
+func (e *BindGroupLayoutEntry) bindingKind() (string, error) {
+	var kinds []string
+	if e.Buffer != nil {
+		kinds = append(kinds, "buffer")
+	}
+	if e.Sampler != nil {
+		kinds = append(kinds, "sampler")
+	}
+	if e.Texture != nil {
+		kinds = append(kinds, "texture")
+	}
+	if e.StorageTexture != nil {
+		kinds = append(kinds, "storage texture")
+	}
+	switch len(kinds) {
+	case 0:
+		return "", fmt.Errorf("binding %d: set one of Buffer, Sampler, Texture or StorageTexture", e.Binding)
+	case 1:
+		return kinds[0], nil
+	}
+	return "", fmt.Errorf("binding %d: only one of Buffer, Sampler, Texture or StorageTexture may be set, got %v", e.Binding, kinds)
+}
+
+// resourceKind returns the layout kinds a bind group entry can satisfy, or an
+// error unless exactly one resource is set.
+func (e *BindGroupEntry) resourceKind() ([]string, error) {
+	n := 0
+	var kinds []string
+	set := func(ok bool, k ...string) {
+		if ok {
+			n++
+			kinds = k
+		}
+	}
+	set(e.Buffer != nil, "buffer")
+	set(len(e.Buffers) > 0, "buffer")
+	set(e.Sampler != nil, "sampler")
+	set(len(e.Samplers) > 0, "sampler")
+	set(e.TextureView != nil, "texture", "storage texture")
+	set(len(e.TextureViews) > 0, "texture", "storage texture")
+	if n != 1 {
+		return nil, fmt.Errorf("binding %d: set exactly one of Buffer, Sampler, TextureView, Buffers, Samplers or TextureViews", e.Binding)
+	}
+	return kinds, nil
+}
+
+// validateBindGroupLayoutEntries checks that every entry declares exactly one
+// resource type and that binding numbers are unique.
+func validateBindGroupLayoutEntries(entries []BindGroupLayoutEntry) error {
+	seen := make(map[uint32]bool, len(entries))
+	for i := range entries {
+		e := &entries[i]
+		if _, err := e.bindingKind(); err != nil {
+			return err
+		}
+		if seen[e.Binding] {
+			return fmt.Errorf("binding %d is declared more than once", e.Binding)
+		}
+		seen[e.Binding] = true
+	}
+	return nil
+}
+
+// validateBindGroupEntries checks entries against the layout they are bound
+// with: each layout binding must be given exactly once, by a resource of the
+// type the layout declares. layout is nil for layouts obtained from a
+// pipeline, whose entries are unknown; only the entries themselves are
+// checked then.
+func validateBindGroupEntries(layout []BindGroupLayoutEntry, entries []BindGroupEntry) error {
+	byBinding := make(map[uint32]*BindGroupLayoutEntry, len(layout))
+	for i := range layout {
+		byBinding[layout[i].Binding] = &layout[i]
+	}
+	seen := make(map[uint32]bool, len(entries))
+	for i := range entries {
+		e := &entries[i]
+		kinds, err := e.resourceKind()
+		if err != nil {
+			return err
+		}
+		if seen[e.Binding] {
+			return fmt.Errorf("binding %d is set more than once", e.Binding)
+		}
+		seen[e.Binding] = true
+		if layout == nil {
+			continue
+		}
+		le, ok := byBinding[e.Binding]
+		if !ok {
+			return fmt.Errorf("binding %d is not in the bind group layout", e.Binding)
+		}
+		want, _ := le.bindingKind()
+		match := false
+		for _, k := range kinds {
+			match = match || k == want
+		}
+		if !match {
+			return fmt.Errorf("binding %d: layout expects a %s but the entry binds a %s", e.Binding, want, kinds[0])
+		}
+	}
+	for _, le := range layout {
+		if !seen[le.Binding] {
+			return fmt.Errorf("binding %d of the bind group layout has no entry", le.Binding)
+		}
+	}
+	return nil
+}
+
+// checkBufferBindingSizes checks that each buffer bound by entries covers the
+// MinBindingSize of its layout entry. bufferSize returns the size of a
+// buffer, or 0 when it is unknown.
+func checkBufferBindingSizes(layout []BindGroupLayoutEntry, entries []BindGroupEntry, bufferSize func(*Buffer) uint64) error {
+	for i := range entries {
+		e := &entries[i]
+		if e.Buffer == nil {
+			continue
+		}
+		for j := range layout {
+			le := &layout[j]
+			if le.Binding != e.Binding || le.Buffer == nil || le.Buffer.MinBindingSize == 0 {
+				continue
+			}
+			size := e.Size
+			if size == 0 {
+				total := bufferSize(e.Buffer)
+				if total == 0 || total < e.Offset {
+					break
+				}
+				size = total - e.Offset
+			}
+			if size < le.Buffer.MinBindingSize {
+				return fmt.Errorf("binding %d: bound range is %d bytes but the layout's MinBindingSize is %d",
+					e.Binding, size, le.Buffer.MinBindingSize)
+			}
+		}
+	}
+	return nil
+}
+
+// crossCheckBindGroupEntries checks the resources of entries against their
+// layout entries beyond their kind: buffer usages, multisampling, depth
+// formats and storage texture formats. It runs in debug mode only because
+// bufferUsage queries wgpu-native for every bound buffer.
+func crossCheckBindGroupEntries(layout []BindGroupLayoutEntry, entries []BindGroupEntry, bufferUsage func(*Buffer) gputypes.BufferUsage) error {
+	byBinding := make(map[uint32]*BindGroupLayoutEntry, len(layout))
+	for i := range layout {
+		byBinding[layout[i].Binding] = &layout[i]
+	}
+	for i := range entries {
+		e := &entries[i]
+		le, ok := byBinding[e.Binding]
+		if !ok {
+			continue
+		}
+		switch {
+		case le.Buffer != nil:
+			buffers := e.Buffers
+			if e.Buffer != nil {
+				buffers = []*Buffer{e.Buffer}
+			}
+			for _, b := range buffers {
+				if err := checkBufferUsage(e.Binding, le.Buffer.Type, bufferUsage(b)); err != nil {
+					return err
+				}
+			}
+		case le.Texture != nil, le.StorageTexture != nil:
+			views := e.TextureViews
+			if e.TextureView != nil {
+				views = []*TextureView{e.TextureView}
+			}
+			for _, v := range views {
+				if err := checkViewBinding(le, v); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkBufferUsage checks that a buffer with usage can be bound as typ.
+func checkBufferUsage(binding uint32, typ gputypes.BufferBindingType, usage gputypes.BufferUsage) error {
+	need, name := gputypes.BufferUsageStorage, "Storage"
+	if typ == gputypes.BufferBindingTypeUniform || typ == gputypes.BufferBindingTypeUndefined {
+		need, name = gputypes.BufferUsageUniform, "Uniform"
+	}
+	if usage != gputypes.BufferUsageNone && usage&need == 0 {
+		return fmt.Errorf("binding %d: buffer lacks BufferUsage%s required by its %v binding", binding, name, typ)
+	}
+	return nil
+}
+
+// checkViewBinding checks v against a texture or storage texture layout
+// entry, using the sample count and format recorded when v was created.
+// Properties recorded as zero are unknown and not checked.
+func checkViewBinding(le *BindGroupLayoutEntry, v *TextureView) error {
+	if v == nil {
+		return nil
+	}
+	if st := le.StorageTexture; st != nil {
+		if v.format != gputypes.TextureFormatUndefined && st.Format != gputypes.TextureFormatUndefined && v.format != st.Format {
+			return fmt.Errorf("binding %d: storage texture view is %v but the layout expects %v", le.Binding, v.format, st.Format)
+		}
+		if v.sampleCount > 1 {
+			return fmt.Errorf("binding %d: storage textures cannot be multisampled", le.Binding)
+		}
+		return nil
+	}
+	t := le.Texture
+	if v.sampleCount != 0 && t.Multisampled != (v.sampleCount > 1) {
+		return fmt.Errorf("binding %d: view has %d samples but the layout's Multisampled is %v", le.Binding, v.sampleCount, t.Multisampled)
+	}
+	if v.format == gputypes.TextureFormatUndefined {
+		return nil
+	}
+	switch depth := v.format.HasDepth(); {
+	case t.SampleType == gputypes.TextureSampleTypeDepth && !depth:
+		return fmt.Errorf("binding %d: layout expects a depth texture but the view is %v", le.Binding, v.format)
+	case depth && (t.SampleType == gputypes.TextureSampleTypeFloat || t.SampleType == gputypes.TextureSampleTypeSint || t.SampleType == gputypes.TextureSampleTypeUint):
+		return fmt.Errorf("binding %d: depth view %v needs sample type Depth or UnfilterableFloat, not %v", le.Binding, v.format, t.SampleType)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package wgpu
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+)
+
+// TextureBindingArrayLayoutEntry returns a layout entry for an array of count 2D
+// float textures, declared in WGSL as
+//
+//	@group(0) @binding(0) var textures: binding_array<texture_2d<f32>>;
+//
+// It is unrelated to [TextureArrayLayoutEntry], which binds one 2D array
+// texture. Requires NativeFeatureTextureBindingArray. Indexing the array with values
+// that differ between invocations (such as a per-material index) also
+// requires NativeFeatureSampledTextureAndStorageBufferArrayNonUniformIndexing.
+func TextureBindingArrayLayoutEntry(binding uint32, visibility gputypes.ShaderStage, count uint32) BindGroupLayoutEntry {
+	return BindGroupLayoutEntry{
+		Binding:    binding,
+		Visibility: visibility,
+		Texture: &TextureBindingLayout{
+			SampleType:    gputypes.TextureSampleTypeFloat,
+			ViewDimension: gputypes.TextureViewDimension2D,
+		},
+		Count: count,
+	}
+}
+
+// SamplerBindingArrayLayoutEntry returns a layout entry for an array of count
+// filtering samplers. Requires NativeFeatureTextureBindingArray.
+func SamplerBindingArrayLayoutEntry(binding uint32, visibility gputypes.ShaderStage, count uint32) BindGroupLayoutEntry {
+	return BindGroupLayoutEntry{
+		Binding:    binding,
+		Visibility: visibility,
+		Sampler:    &SamplerBindingLayout{Type: gputypes.SamplerBindingTypeFiltering},
+		Count:      count,
+	}
+}
+
+// TextureBindingArrayEntry creates a BindGroupEntry binding views to the
+// elements of a texture binding array.
+func TextureBindingArrayEntry(binding uint32, views []*TextureView) BindGroupEntry {
+	return BindGroupEntry{
+		Binding:      binding,
+		TextureViews: views,
+	}
+}
+
+// SamplerBindingArrayEntry creates a BindGroupEntry binding samplers to the
+// elements of a sampler binding array.
+func SamplerBindingArrayEntry(binding uint32, samplers []*Sampler) BindGroupEntry {
+	return BindGroupEntry{
+		Binding:  binding,
+		Samplers: samplers,
+	}
+}
+
+// BindlessFeatures are the features a "bindless" texture system needs: arrays
+// of sampled textures indexed by a per-draw or per-material value, with only
+// the elements in use bound. Request the ones the adapter has with
+// [Adapter.SupportedFeatures] and check for the rest with [Device.HasFeature].
+var BindlessFeatures = []FeatureName{
+	FeatureName(NativeFeatureTextureBindingArray),
+	FeatureName(NativeFeatureSampledTextureAndStorageBufferArrayNonUniformIndexing),
+	FeatureName(NativeFeaturePartiallyBoundBindingArray),
+}
+
+// BufferBindingArrayEntry creates a BindGroupEntry binding whole buffers to
+// the elements of a buffer binding array.
+func BufferBindingArrayEntry(binding uint32, buffers []*Buffer) BindGroupEntry {
+	return BindGroupEntry{
+		Binding: binding,
+		Buffers: buffers,
+	}
+}
+
+// validateBindingArrayLayout checks that the binding array declared by e is
+// allowed by the device features reported by hasFeature.
+func validateBindingArrayLayout(e *BindGroupLayoutEntry, hasFeature func(FeatureName) bool) error {
+	need := []NativeFeature{NativeFeatureTextureBindingArray}
+	switch {
+	case e.Buffer != nil:
+		if e.Buffer.HasDynamicOffset {
+			return fmt.Errorf("binding %d: binding arrays cannot use dynamic offsets", e.Binding)
+		}
+		need = []NativeFeature{NativeFeatureBufferBindingArray}
+		if e.Buffer.Type != gputypes.BufferBindingTypeUniform {
+			need = append(need, NativeFeatureStorageResourceBindingArray)
+		}
+	case e.StorageTexture != nil:
+		need = append(need, NativeFeatureStorageResourceBindingArray)
+	case e.Texture != nil && e.Texture.Multisampled:
+		return fmt.Errorf("binding %d: binding arrays of multisampled textures are not supported", e.Binding)
+	}
+	for _, f := range need {
+		if !hasFeature(FeatureName(f)) {
+			return fmt.Errorf("binding %d: binding array requires %s", e.Binding, bindingArrayFeatureNames[f])
+		}
+	}
+	return nil
+}
+
+// bindingArrayFeatureNames names the features checked by
+// validateBindingArrayLayout in error messages.
+var bindingArrayFeatureNames = map[NativeFeature]string{
+	NativeFeatureTextureBindingArray:         "NativeFeatureTextureBindingArray",
+	NativeFeatureBufferBindingArray:          "NativeFeatureBufferBindingArray",
+	NativeFeatureStorageResourceBindingArray: "NativeFeatureStorageResourceBindingArray",
+}
+
+// validateBindGroupArrayEntry checks the binding array elements of e against
+// the layout's array size count. count is 0 when the layout entry is not an
+// array or is unknown.
+func validateBindGroupArrayEntry(e *BindGroupEntry, count uint32, hasFeature func(FeatureName) bool) error {
+	kinds := 0
+	for _, n := range []int{len(e.Buffers), len(e.Samplers), len(e.TextureViews)} {
+		if n > 0 {
+			kinds++
+		}
+	}
+	if kinds > 1 || e.Buffer != nil || e.Sampler != nil || e.TextureView != nil {
+		return fmt.Errorf("binding %d: a binding array entry must set only one of Buffers, Samplers or TextureViews", e.Binding)
+	}
+	for _, b := range e.Buffers {
+		if b == nil {
+			return fmt.Errorf("binding %d: nil buffer in binding array", e.Binding)
+		}
+	}
+	for _, s := range e.Samplers {
+		if s == nil {
+			return fmt.Errorf("binding %d: nil sampler in binding array", e.Binding)
+		}
+	}
+	for _, v := range e.TextureViews {
+		if v == nil {
+			return fmt.Errorf("binding %d: nil texture view in binding array", e.Binding)
+		}
+	}
+	if count == 0 {
+		return nil
+	}
+	n := e.arrayLen()
+	if uint32(n) > count {
+		return fmt.Errorf("binding %d: %d elements exceed the binding array size %d", e.Binding, n, count)
+	}
+	if uint32(n) < count && !hasFeature(FeatureName(NativeFeaturePartiallyBoundBindingArray)) {
+		return fmt.Errorf("binding %d: %d of %d binding array elements bound: %w",
+			e.Binding, n, count, errPartiallyBound)
+	}
+	return nil
+}
+
+// errPartiallyBound reports a binding array with unbound elements on a device
+// without NativeFeaturePartiallyBoundBindingArray.
+var errPartiallyBound = errors.New("partially bound binding arrays require NativeFeaturePartiallyBoundBindingArray")
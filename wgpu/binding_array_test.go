@@ -0,0 +1,132 @@
+package wgpu
+
+import (
+	"errors"
+	"testing"
+	"unsafe"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestValidateBindingArrayLayout(t *testing.T) {
+	none := func(FeatureName) bool { return false }
+	has := func(want ...NativeFeature) func(FeatureName) bool {
+		return func(f FeatureName) bool {
+			for _, w := range want {
+				if f == FeatureName(w) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	storageBuffers := BindGroupLayoutEntry{
+		Binding: 2,
+		Buffer:  &BufferBindingLayout{Type: gputypes.BufferBindingTypeReadOnlyStorage},
+		Count:   4,
+	}
+	dynamic := BindGroupLayoutEntry{
+		Binding: 3,
+		Buffer:  &BufferBindingLayout{Type: gputypes.BufferBindingTypeUniform, HasDynamicOffset: true},
+		Count:   4,
+	}
+	tests := []struct {
+		name       string
+		entry      BindGroupLayoutEntry
+		hasFeature func(FeatureName) bool
+		wantErr    bool
+	}{
+		{"textures without feature", TextureBindingArrayLayoutEntry(0, gputypes.ShaderStageFragment, 64), none, true},
+		{"textures", TextureBindingArrayLayoutEntry(0, gputypes.ShaderStageFragment, 64),
+			has(NativeFeatureTextureBindingArray), false},
+		{"samplers", SamplerBindingArrayLayoutEntry(1, gputypes.ShaderStageFragment, 8),
+			has(NativeFeatureTextureBindingArray), false},
+		{"storage buffers need storage arrays", storageBuffers, has(NativeFeatureBufferBindingArray), true},
+		{"storage buffers", storageBuffers,
+			has(NativeFeatureBufferBindingArray, NativeFeatureStorageResourceBindingArray), false},
+		{"dynamic offset", dynamic, has(NativeFeatureBufferBindingArray), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBindingArrayLayout(&tt.entry, tt.hasFeature)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateBindGroupArrayEntry(t *testing.T) {
+	views := []*TextureView{{handle: 1}, {handle: 2}}
+	none := func(FeatureName) bool { return false }
+	partial := func(f FeatureName) bool { return f == FeatureName(NativeFeaturePartiallyBoundBindingArray) }
+
+	tests := []struct {
+		name       string
+		entry      BindGroupEntry
+		count      uint32
+		hasFeature func(FeatureName) bool
+		want       error
+		wantErr    bool
+	}{
+		{"fully bound", TextureBindingArrayEntry(0, views), 2, none, nil, false},
+		{"unknown layout", TextureBindingArrayEntry(0, views), 0, none, nil, false},
+		{"too many", TextureBindingArrayEntry(0, views), 1, none, nil, true},
+		{"partially bound", TextureBindingArrayEntry(0, views), 8, none, errPartiallyBound, true},
+		{"partially bound with feature", TextureBindingArrayEntry(0, views), 8, partial, nil, false},
+		{"nil element", TextureBindingArrayEntry(0, []*TextureView{views[0], nil}), 2, none, nil, true},
+		{"mixed kinds", BindGroupEntry{TextureViews: views, Samplers: []*Sampler{{handle: 3}}}, 0, none, nil, true},
+		{"array and single", BindGroupEntry{TextureViews: views, TextureView: views[0]}, 0, none, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBindGroupArrayEntry(&tt.entry, tt.count, tt.hasFeature)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBindGroupEntryArrayToWire(t *testing.T) {
+	e := SamplerBindingArrayEntry(5, []*Sampler{{handle: 0x10}, {handle: 0x20}, {handle: 0x30}})
+	if !e.isArray() || e.arrayLen() != 3 {
+		t.Fatalf("isArray = %v, arrayLen = %d", e.isArray(), e.arrayLen())
+	}
+	var extras bindGroupEntryExtrasWire
+	handles := e.arrayToWire(&extras)
+
+	if extras.Chain.SType != uint32(STypeBindGroupEntryExtras) {
+		t.Errorf("sType = %#x, want %#x", extras.Chain.SType, uint32(STypeBindGroupEntryExtras))
+	}
+	if extras.SamplerCount != 3 || extras.BufferCount != 0 || extras.TextureViewCount != 0 {
+		t.Errorf("counts = %d/%d/%d, want 0/3/0", extras.BufferCount, extras.SamplerCount, extras.TextureViewCount)
+	}
+	if extras.Samplers != uintptr(unsafe.Pointer(&handles[0])) {
+		t.Errorf("samplers pointer does not point at the returned handles")
+	}
+	for i, want := range []uintptr{0x10, 0x20, 0x30} {
+		if handles[i] != want {
+			t.Errorf("sampler[%d] = %#x, want %#x", i, handles[i], want)
+		}
+	}
+
+	wire := e.toWire()
+	if wire.Binding != 5 || wire.Sampler != 0 {
+		t.Errorf("wire = %+v, want binding 5 and no single sampler", wire)
+	}
+}
+
+func TestBindGroupLayoutEntryCountWire(t *testing.T) {
+	e := TextureBindingArrayLayoutEntry(0, gputypes.ShaderStageFragment, 256)
+	if wire := e.toWire(); wire.BindingArraySize != 256 {
+		t.Errorf("BindingArraySize = %d, want 256", wire.BindingArraySize)
+	}
+	single := TextureArrayLayoutEntry(0, gputypes.ShaderStageFragment)
+	if wire := single.toWire(); wire.BindingArraySize != 0 {
+		t.Errorf("BindingArraySize = %d, want 0 for a single binding", wire.BindingArraySize)
+	}
+}
@@ -0,0 +1,76 @@
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+)
+
+// BlendOpaque returns a blend state that replaces the destination with the
+// source. It renders the same as a nil Blend but can be combined with
+// blending on other targets.
+func BlendOpaque() BlendState {
+	return blendStateFrom(gputypes.BlendStateReplace())
+}
+
+// BlendAlpha returns standard (straight) alpha blending:
+// color = src*srcAlpha + dst*(1-srcAlpha).
+func BlendAlpha() BlendState {
+	return blendStateFrom(gputypes.BlendStateAlpha())
+}
+
+// BlendPremultiplied returns blending for colors already multiplied by their
+// alpha: color = src + dst*(1-srcAlpha).
+func BlendPremultiplied() BlendState {
+	return blendStateFrom(gputypes.BlendStatePremultiplied())
+}
+
+// BlendAdditive returns additive blending, color = src + dst, for particles,
+// glows and light accumulation.
+func BlendAdditive() BlendState {
+	add := BlendComponent{
+		Operation: gputypes.BlendOperationAdd,
+		SrcFactor: gputypes.BlendFactorOne,
+		DstFactor: gputypes.BlendFactorOne,
+	}
+	return BlendState{Color: add, Alpha: add}
+}
+
+// blendStateFrom converts a gputypes blend state, whose fields are ordered
+// differently from WGPUBlendComponent.
+func blendStateFrom(s gputypes.BlendState) BlendState {
+	component := func(c gputypes.BlendComponent) BlendComponent {
+		return BlendComponent{Operation: c.Operation, SrcFactor: c.SrcFactor, DstFactor: c.DstFactor}
+	}
+	return BlendState{Color: component(s.Color), Alpha: component(s.Alpha)}
+}
+
+// validateBlend checks that target can blend with its blend state on a device
+// with the features reported by hasFeature.
+func validateBlend(target *ColorTargetState, hasFeature func(FeatureName) bool) error {
+	if target.Blend == nil {
+		return nil
+	}
+	if info, ok := FormatInfo(target.Format); ok && !info.Blendable {
+		switch target.Format {
+		case gputypes.TextureFormatR32Float, gputypes.TextureFormatRG32Float, gputypes.TextureFormatRGBA32Float:
+			if !hasFeature(FeatureNameFloat32Blendable) {
+				return fmt.Errorf("blending %v requires FeatureNameFloat32Blendable", target.Format)
+			}
+		default:
+			return fmt.Errorf("format %v is not blendable", target.Format)
+		}
+	}
+	for _, c := range []struct {
+		name string
+		BlendComponent
+	}{{"color", target.Blend.Color}, {"alpha", target.Blend.Alpha}} {
+		switch c.Operation {
+		case gputypes.BlendOperationMin, gputypes.BlendOperationMax:
+			if c.SrcFactor != gputypes.BlendFactorOne || c.DstFactor != gputypes.BlendFactorOne {
+				return fmt.Errorf("%s blend operation %v requires both factors to be One", c.name, c.Operation)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package wgpu
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestBlendPresets(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    BlendState
+		srcColor gputypes.BlendFactor
+		dstColor gputypes.BlendFactor
+	}{
+		{"opaque", BlendOpaque(), gputypes.BlendFactorOne, gputypes.BlendFactorZero},
+		{"alpha", BlendAlpha(), gputypes.BlendFactorSrcAlpha, gputypes.BlendFactorOneMinusSrcAlpha},
+		{"premultiplied", BlendPremultiplied(), gputypes.BlendFactorOne, gputypes.BlendFactorOneMinusSrcAlpha},
+		{"additive", BlendAdditive(), gputypes.BlendFactorOne, gputypes.BlendFactorOne},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := tt.state.Color
+			if c.Operation != gputypes.BlendOperationAdd || c.SrcFactor != tt.srcColor || c.DstFactor != tt.dstColor {
+				t.Errorf("color = %+v, want Add %v %v", c, tt.srcColor, tt.dstColor)
+			}
+			if tt.state.Alpha.Operation != gputypes.BlendOperationAdd {
+				t.Errorf("alpha operation = %v, want Add", tt.state.Alpha.Operation)
+			}
+		})
+	}
+}
+
+func TestBlendStateWireLayout(t *testing.T) {
+	// BlendState is passed to wgpu-native by pointer, so it must match
+	// WGPUBlendState: two {operation, srcFactor, dstFactor} uint32 triples.
+	if got := unsafe.Sizeof(BlendState{}); got != 24 {
+		t.Errorf("sizeof(BlendState) = %d, want 24", got)
+	}
+	if got := unsafe.Offsetof(BlendComponent{}.SrcFactor); got != 4 {
+		t.Errorf("offsetof(BlendComponent.SrcFactor) = %d, want 4", got)
+	}
+}
+
+func TestValidateBlend(t *testing.T) {
+	none := func(FeatureName) bool { return false }
+	float32Blendable := func(f FeatureName) bool { return f == FeatureNameFloat32Blendable }
+	alpha := BlendAlpha()
+	minMax := BlendState{
+		Color: BlendComponent{Operation: gputypes.BlendOperationMax, SrcFactor: gputypes.BlendFactorOne, DstFactor: gputypes.BlendFactorOne},
+		Alpha: BlendComponent{Operation: gputypes.BlendOperationMin, SrcFactor: gputypes.BlendFactorSrcAlpha, DstFactor: gputypes.BlendFactorOne},
+	}
+	tests := []struct {
+		name       string
+		target     ColorTargetState
+		hasFeature func(FeatureName) bool
+		wantErr    bool
+	}{
+		{"no blend on integer format", ColorTargetState{Format: gputypes.TextureFormatRGBA8Uint}, none, false},
+		{"unorm", ColorTargetState{Format: gputypes.TextureFormatBGRA8Unorm, Blend: &alpha}, none, false},
+		{"float16", ColorTargetState{Format: gputypes.TextureFormatRGBA16Float, Blend: &alpha}, none, false},
+		{"integer", ColorTargetState{Format: gputypes.TextureFormatRGBA8Uint, Blend: &alpha}, none, true},
+		{"float32 without feature", ColorTargetState{Format: gputypes.TextureFormatRGBA32Float, Blend: &alpha}, none, true},
+		{"float32 with feature", ColorTargetState{Format: gputypes.TextureFormatRGBA32Float, Blend: &alpha}, float32Blendable, false},
+		{"depth", ColorTargetState{Format: gputypes.TextureFormatDepth32Float, Blend: &alpha}, none, true},
+		{"min with non-One factor", ColorTargetState{Format: gputypes.TextureFormatRGBA8Unorm, Blend: &minMax}, none, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateBlend(&tt.target, tt.hasFeature); (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
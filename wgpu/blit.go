@@ -0,0 +1,349 @@
+package wgpu
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/gogpu/gputypes"
+)
+
+// BlitRect is a rectangle in texels of the base mip level of a view.
+type BlitRect struct {
+	X, Y, Width, Height uint32
+}
+
+// BlitOptions controls [Blit]. A nil *BlitOptions copies the whole source
+// view onto the whole destination view with linear filtering.
+type BlitOptions struct {
+	// SrcRect selects the source region; nil means the whole source view.
+	SrcRect *BlitRect
+	// DstRect selects the destination region; nil means the whole
+	// destination view. Texels outside it are preserved.
+	DstRect *BlitRect
+	// FlipX and FlipY mirror the source region horizontally and vertically.
+	FlipX, FlipY bool
+	// Nearest selects nearest-neighbor sampling instead of linear filtering.
+	// Required for sources in unfilterable formats such as R32Float.
+	Nearest bool
+}
+
+// blitShader draws a fullscreen triangle whose UVs are remapped by the
+// offset/scale uniform onto the source region.
+const blitShader = `
+struct Params {
+    offset: vec2<f32>,
+    scale: vec2<f32>,
+}
+
+@group(0) @binding(0) var src: texture_2d<f32>;
+@group(0) @binding(1) var src_sampler: sampler;
+@group(0) @binding(2) var<uniform> params: Params;
+
+struct VertexOutput {
+    @builtin(position) position: vec4<f32>,
+    @location(0) uv: vec2<f32>,
+}
+
+@vertex
+fn vs_main(@builtin(vertex_index) index: u32) -> VertexOutput {
+    let xy = vec2<f32>(f32((index << 1u) & 2u), f32(index & 2u));
+    var out: VertexOutput;
+    out.position = vec4<f32>(xy * 2.0 - 1.0, 0.0, 1.0);
+    out.uv = params.offset + vec2<f32>(xy.x, 1.0 - xy.y) * params.scale;
+    return out;
+}
+
+@fragment
+fn fs_main(in: VertexOutput) -> @location(0) vec4<f32> {
+    return textureSampleLevel(src, src_sampler, in.uv, 0.0);
+}
+`
+
+// blitParamsSize is the size of the Params uniform in blitShader.
+const blitParamsSize = 16
+
+// blitKey identifies a cached blit pipeline.
+type blitKey struct {
+	device  uintptr
+	format  gputypes.TextureFormat
+	nearest bool
+}
+
+// blitPipeline holds the objects shared by every blit with the same key.
+type blitPipeline struct {
+	pipeline *RenderPipeline
+	layout   *BindGroupLayout
+	sampler  *Sampler
+}
+
+// blitCache holds blit pipelines per device; entries are dropped by
+// releaseBlitCache when the device is released.
+var blitCache = struct {
+	sync.Mutex
+	pipelines map[blitKey]*blitPipeline
+}{pipelines: make(map[blitKey]*blitPipeline)}
+
+// Blit draws the source view onto the destination view with a fullscreen
+// triangle, for copies that CopyTextureToTexture cannot express: different
+// formats (including sRGB encode/decode), scaling between rects of different
+// sizes, and flipping.
+//
+// src must be a single-sampled 2D view with a float sample type, dst a
+// single-sampled 2D view usable as a render attachment. Both must come from
+// [Texture.CreateView] so their format and size are known. The pipeline for
+// each destination format is created on first use and cached per device.
+func Blit(encoder *CommandEncoder, src, dst *TextureView, opts *BlitOptions) error {
+	if err := checkInit(); err != nil {
+		return err
+	}
+	if encoder == nil || encoder.handle == 0 || encoder.device == nil {
+		return &WGPUError{Op: "Blit", Message: "command encoder is nil or released"}
+	}
+	if src == nil || src.handle == 0 || dst == nil || dst.handle == 0 {
+		return &WGPUError{Op: "Blit", Message: "texture view is nil or released"}
+	}
+	if opts == nil {
+		opts = &BlitOptions{}
+	}
+	srcRect, dstRect, err := blitRects(src, dst, opts)
+	if err != nil {
+		return &WGPUError{Op: "Blit", Type: ErrorTypeValidation, Message: err.Error()}
+	}
+
+	device := encoder.device
+	bp, err := blitPipelineFor(device, dst.format, opts.Nearest)
+	if err != nil {
+		return err
+	}
+
+	params, err := device.CreateBuffer(&BufferDescriptor{
+		Label: "blit params",
+		Usage: gputypes.BufferUsageUniform | gputypes.BufferUsageCopyDst,
+		Size:  blitParamsSize,
+	})
+	if err != nil {
+		return err
+	}
+	defer params.Release()
+
+	queue := device.Queue()
+	if queue == nil {
+		return &WGPUError{Op: "Blit", Message: "device queue unavailable"}
+	}
+	defer queue.Release()
+	uv := blitUVTransform(srcRect, src.width, src.height, opts.FlipX, opts.FlipY)
+	if err := queue.WriteBuffer(params, 0, encodeFloats(uv[:])); err != nil {
+		return err
+	}
+
+	group, err := device.CreateBindGroupSimple(bp.layout, []BindGroupEntry{
+		TextureBindingEntry(0, src),
+		SamplerBindingEntry(1, bp.sampler),
+		BufferBindingEntry(2, params, 0, blitParamsSize),
+	})
+	if err != nil {
+		return err
+	}
+	defer group.Release()
+
+	pass, err := encoder.BeginRenderPass(&RenderPassDescriptor{
+		Label: "blit",
+		ColorAttachments: []RenderPassColorAttachment{{
+			View:    dst,
+			LoadOp:  gputypes.LoadOpLoad,
+			StoreOp: gputypes.StoreOpStore,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	defer pass.Release()
+	pass.SetPipeline(bp.pipeline)
+	pass.SetBindGroup(0, group, nil)
+	pass.SetViewport(float32(dstRect.X), float32(dstRect.Y), float32(dstRect.Width), float32(dstRect.Height), 0, 1)
+	pass.SetScissorRect(dstRect.X, dstRect.Y, dstRect.Width, dstRect.Height)
+	pass.Draw(3, 1, 0, 0)
+	pass.End()
+	return nil
+}
+
+// blitRects resolves the source and destination rects of a blit and checks
+// them against the view sizes.
+func blitRects(src, dst *TextureView, opts *BlitOptions) (srcRect, dstRect BlitRect, err error) {
+	if src.width == 0 || dst.width == 0 || dst.format == gputypes.TextureFormatUndefined {
+		return srcRect, dstRect, fmt.Errorf("texture view size or format unknown; create views with Texture.CreateView")
+	}
+	if src.sampleCount > 1 || dst.sampleCount > 1 {
+		return srcRect, dstRect, fmt.Errorf("multisampled views cannot be blitted; resolve them first")
+	}
+	if info, ok := FormatInfo(dst.format); !ok || !info.HasColor || !info.Renderable {
+		return srcRect, dstRect, fmt.Errorf("destination format %s is not color-renderable", dst.format)
+	}
+	if info, ok := FormatInfo(src.format); ok && !blitSampleable(info.SampleType, opts.Nearest) {
+		return srcRect, dstRect, fmt.Errorf("source format %s cannot be read by the blit shader (set Nearest for unfilterable formats)", src.format)
+	}
+	srcRect = BlitRect{Width: src.width, Height: src.height}
+	if opts.SrcRect != nil {
+		srcRect = *opts.SrcRect
+	}
+	dstRect = BlitRect{Width: dst.width, Height: dst.height}
+	if opts.DstRect != nil {
+		dstRect = *opts.DstRect
+	}
+	if err := checkBlitRect("source", srcRect, src.width, src.height); err != nil {
+		return srcRect, dstRect, err
+	}
+	if err := checkBlitRect("destination", dstRect, dst.width, dst.height); err != nil {
+		return srcRect, dstRect, err
+	}
+	return srcRect, dstRect, nil
+}
+
+// blitSampleable reports whether a source of sample type st can be bound to
+// the texture_2d<f32> of the blit pipeline selected by nearest.
+func blitSampleable(st gputypes.TextureSampleType, nearest bool) bool {
+	return st == gputypes.TextureSampleTypeFloat || nearest && st == gputypes.TextureSampleTypeUnfilterableFloat
+}
+
+// checkBlitRect reports an error if r is empty or extends past width x height.
+func checkBlitRect(name string, r BlitRect, width, height uint32) error {
+	if r.Width == 0 || r.Height == 0 {
+		return fmt.Errorf("%s rect is empty", name)
+	}
+	if uint64(r.X)+uint64(r.Width) > uint64(width) || uint64(r.Y)+uint64(r.Height) > uint64(height) {
+		return fmt.Errorf("%s rect %dx%d+%d+%d exceeds view size %dx%d",
+			name, r.Width, r.Height, r.X, r.Y, width, height)
+	}
+	return nil
+}
+
+// blitUVTransform returns the offset and scale mapping unit UVs onto r in a
+// width x height source, mirrored as requested.
+func blitUVTransform(r BlitRect, width, height uint32, flipX, flipY bool) [4]float32 {
+	w, h := float32(width), float32(height)
+	offX, offY := float32(r.X)/w, float32(r.Y)/h
+	scaleX, scaleY := float32(r.Width)/w, float32(r.Height)/h
+	if flipX {
+		offX, scaleX = offX+scaleX, -scaleX
+	}
+	if flipY {
+		offY, scaleY = offY+scaleY, -scaleY
+	}
+	return [4]float32{offX, offY, scaleX, scaleY}
+}
+
+// encodeFloats packs values as little-endian float32s.
+func encodeFloats(values []float32) []byte {
+	out := make([]byte, 4*len(values))
+	for i, v := range values {
+		binary.LittleEndian.PutUint32(out[4*i:], math.Float32bits(v))
+	}
+	return out
+}
+
+// blitPipelineFor returns the cached blit pipeline for the device and
+// destination format, creating it on first use.
+func blitPipelineFor(device *Device, format gputypes.TextureFormat, nearest bool) (*blitPipeline, error) {
+	key := blitKey{device: device.handle, format: format, nearest: nearest}
+	blitCache.Lock()
+	defer blitCache.Unlock()
+	if bp, ok := blitCache.pipelines[key]; ok {
+		return bp, nil
+	}
+	bp, err := newBlitPipeline(device, format, nearest)
+	if err != nil {
+		return nil, err
+	}
+	blitCache.pipelines[key] = bp
+	return bp, nil
+}
+
+func newBlitPipeline(device *Device, format gputypes.TextureFormat, nearest bool) (*blitPipeline, error) {
+	sampleType := gputypes.TextureSampleTypeFloat
+	samplerType := gputypes.SamplerBindingTypeFiltering
+	createSampler := device.CreateLinearSampler
+	if nearest {
+		sampleType = gputypes.TextureSampleTypeUnfilterableFloat
+		samplerType = gputypes.SamplerBindingTypeNonFiltering
+		createSampler = device.CreateNearestSampler
+	}
+
+	bp := &blitPipeline{}
+	var err error
+	bp.layout, err = device.CreateBindGroupLayoutSimple([]BindGroupLayoutEntry{
+		{
+			Binding:    0,
+			Visibility: gputypes.ShaderStageFragment,
+			Texture: &TextureBindingLayout{
+				SampleType:    sampleType,
+				ViewDimension: gputypes.TextureViewDimension2D,
+			},
+		},
+		{
+			Binding:    1,
+			Visibility: gputypes.ShaderStageFragment,
+			Sampler:    &SamplerBindingLayout{Type: samplerType},
+		},
+		{
+			Binding:    2,
+			Visibility: gputypes.ShaderStageVertex,
+			Buffer: &BufferBindingLayout{
+				Type:           gputypes.BufferBindingTypeUniform,
+				MinBindingSize: blitParamsSize,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if bp.sampler, err = createSampler(); err != nil {
+		bp.release()
+		return nil, err
+	}
+
+	shader, err := device.createShaderModuleWGSL("blit", blitShader, false)
+	if err != nil {
+		bp.release()
+		return nil, err
+	}
+	defer shader.Release()
+	layout, err := device.CreatePipelineLayoutSimple([]*BindGroupLayout{bp.layout})
+	if err != nil {
+		bp.release()
+		return nil, err
+	}
+	defer layout.Release()
+
+	bp.pipeline, err = device.CreateRenderPipelineSimple(layout, shader, "vs_main", shader, "fs_main", format)
+	if err != nil {
+		bp.release()
+		return nil, err
+	}
+	return bp, nil
+}
+
+func (bp *blitPipeline) release() {
+	if bp.pipeline != nil {
+		bp.pipeline.Release()
+	}
+	if bp.sampler != nil {
+		bp.sampler.Release()
+	}
+	if bp.layout != nil {
+		bp.layout.Release()
+	}
+}
+
+// releaseBlitCache releases the blit pipelines created for device.
+func releaseBlitCache(device uintptr) {
+	blitCache.Lock()
+	defer blitCache.Unlock()
+	for key, bp := range blitCache.pipelines {
+		if key.device == device {
+			bp.release()
+			delete(blitCache.pipelines, key)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package wgpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestBlitUVTransform(t *testing.T) {
+	tests := []struct {
+		name         string
+		rect         BlitRect
+		flipX, flipY bool
+		want         [4]float32
+	}{
+		{"whole", BlitRect{0, 0, 64, 32}, false, false, [4]float32{0, 0, 1, 1}},
+		{"sub-rect", BlitRect{16, 8, 32, 16}, false, false, [4]float32{0.25, 0.25, 0.5, 0.5}},
+		{"flipY", BlitRect{0, 0, 64, 32}, false, true, [4]float32{0, 1, 1, -1}},
+		{"flipX sub-rect", BlitRect{16, 0, 32, 32}, true, false, [4]float32{0.75, 0, -0.5, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := blitUVTransform(tt.rect, 64, 32, tt.flipX, tt.flipY)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlitRects(t *testing.T) {
+	src := &TextureView{handle: 1, sampleCount: 1, format: gputypes.TextureFormatRGBA8Unorm, width: 64, height: 64}
+	dst := &TextureView{handle: 2, sampleCount: 1, format: gputypes.TextureFormatBGRA8UnormSrgb, width: 128, height: 32}
+
+	s, d, err := blitRects(src, dst, &BlitOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s != (BlitRect{Width: 64, Height: 64}) || d != (BlitRect{Width: 128, Height: 32}) {
+		t.Errorf("default rects = %+v, %+v", s, d)
+	}
+
+	tests := []struct {
+		name string
+		src  *TextureView
+		opts BlitOptions
+	}{
+		{"src rect out of bounds", src, BlitOptions{SrcRect: &BlitRect{X: 32, Width: 64, Height: 1}}},
+		{"dst rect out of bounds", src, BlitOptions{DstRect: &BlitRect{Y: 16, Width: 1, Height: 32}}},
+		{"empty rect", src, BlitOptions{SrcRect: &BlitRect{Width: 0, Height: 4}}},
+		{"multisampled source", &TextureView{handle: 3, sampleCount: 4, width: 64, height: 64}, BlitOptions{}},
+		{"unknown size", &TextureView{handle: 3}, BlitOptions{}},
+		{"unfilterable source with linear filter", &TextureView{handle: 3, sampleCount: 1, format: gputypes.TextureFormatR32Float, width: 64, height: 64}, BlitOptions{}},
+		{"integer source", &TextureView{handle: 3, sampleCount: 1, format: gputypes.TextureFormatR8Uint, width: 64, height: 64}, BlitOptions{Nearest: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := blitRects(tt.src, dst, &tt.opts); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestBlitRectsRejectsNonRenderableDestination(t *testing.T) {
+	src := &TextureView{handle: 1, sampleCount: 1, format: gputypes.TextureFormatR32Float, width: 4, height: 4}
+	dst := &TextureView{handle: 2, sampleCount: 1, format: gputypes.TextureFormatRGBA8Snorm, width: 4, height: 4}
+	if _, _, err := blitRects(src, dst, &BlitOptions{Nearest: true}); err == nil {
+		t.Error("expected error for RGBA8Snorm destination")
+	}
+	dst.format = gputypes.TextureFormatRGBA16Float
+	if _, _, err := blitRects(src, dst, &BlitOptions{Nearest: true}); err != nil {
+		t.Errorf("R32Float -> RGBA16Float with nearest filtering: %v", err)
+	}
+}
@@ -113,7 +113,23 @@ type bufferDescriptorWire struct {
 
 // CreateBuffer creates a new GPU buffer.
 // Returns an error if the FFI call fails or the device/descriptor is nil.
+// A buffer wgpu-native rejects is returned invalid, and the error goes to the
+// enclosing error scope or [Device.Errors]; see [Device.CreateBufferE].
 func (d *Device) CreateBuffer(desc *BufferDescriptor) (*Buffer, error) {
+	return d.createBuffer(desc, false)
+}
+
+// CreateBufferE is CreateBuffer that also returns the validation error raised
+// by wgpu-native, such as an invalid usage combination or a size over the
+// device limit. Out-of-memory errors are returned after the device's
+// [OutOfMemoryHandler] had a chance to retry. See "Errors" in the package
+// documentation for what capturing the error costs.
+func (d *Device) CreateBufferE(desc *BufferDescriptor) (*Buffer, error) {
+	return d.createBuffer(desc, true)
+}
+
+// createBuffer creates a buffer, inside error scopes when capture is set.
+func (d *Device) createBuffer(desc *BufferDescriptor, capture bool) (*Buffer, error) {
 	if err := checkInit(); err != nil {
 		return nil, err
 	}
@@ -123,21 +139,45 @@ func (d *Device) CreateBuffer(desc *BufferDescriptor) (*Buffer, error) {
 	if desc == nil {
 		return nil, &WGPUError{Op: "CreateBuffer", Message: "descriptor is nil"}
 	}
+	if debugBuild {
+		if err := checkBufferDescriptor(desc); err != nil {
+			return nil, &WGPUError{Op: "CreateBuffer", Type: ErrorTypeValidation, Message: err.Error()}
+		}
+	}
 	wire := bufferDescriptorWire{
 		Label:            stringToStringView(desc.Label),
 		Usage:            desc.Usage,
 		Size:             desc.Size,
 		MappedAtCreation: boolToWGPU(desc.MappedAtCreation),
 	}
-	handle, _, _ := procDeviceCreateBuffer.Call(
-		d.handle,
-		uintptr(unsafe.Pointer(&wire)),
-	)
+	create := func() uintptr {
+		h, _, _ := procDeviceCreateBuffer.Call(
+			d.handle,
+			uintptr(unsafe.Pointer(&wire)),
+		)
+		return h
+	}
+	var handle uintptr
+	var err error
+	if capture {
+		handle, err = d.allocate("CreateBuffer", create, func(h uintptr) { procBufferRelease.Call(h) }) //nolint:errcheck
+	} else {
+		handle = create()
+	}
 	if handle == 0 {
 		return nil, &WGPUError{Op: "CreateBuffer", Message: "wgpu returned null handle"}
 	}
-	trackResource(handle, "Buffer")
-	return &Buffer{handle: handle, device: d}, nil
+	trackResource(handle, "Buffer", desc.Label)
+	buf := &Buffer{handle: handle, device: d}
+	if err != nil {
+		// wgpu-native returns an invalid buffer rather than null.
+		buf.Release()
+		return nil, err
+	}
+	if traceActive.Load() {
+		traceCall("Device.CreateBuffer", nil, buf, desc)
+	}
+	return buf, nil
 }
 
 // GetMappedRange returns a pointer to the mapped buffer data.
@@ -198,6 +238,7 @@ func (b *Buffer) Destroy() {
 	mustInit()
 	if b.handle != 0 {
 		procBufferDestroy.Call(b.handle) //nolint:errcheck
+		b.released.set()
 	}
 }
 
@@ -207,6 +248,7 @@ func (b *Buffer) Release() {
 		untrackResource(b.handle)
 		procBufferRelease.Call(b.handle) //nolint:errcheck
 		b.handle = 0
+		b.released.set()
 	}
 }
 
@@ -218,6 +260,9 @@ func (q *Queue) WriteBuffer(buffer *Buffer, offset uint64, data []byte) error {
 	if q == nil || q.handle == 0 || buffer == nil || buffer.handle == 0 || len(data) == 0 {
 		return nil
 	}
+	if traceActive.Load() {
+		traceCall("Queue.WriteBuffer", nil, nil, buffer, offset, data)
+	}
 	procQueueWriteBuffer.Call( //nolint:errcheck
 		q.handle,
 		buffer.handle,
@@ -1,6 +1,7 @@
 package wgpu
 
 import (
+	"errors"
 	"testing"
 	"unsafe"
 
@@ -53,6 +54,44 @@ func TestCreateBuffer(t *testing.T) {
 	t.Logf("Buffer size: %d bytes", size)
 }
 
+func TestCreateBufferValidationError(t *testing.T) {
+	inst, err := CreateInstance(nil)
+	if err != nil {
+		t.Fatalf("CreateInstance failed: %v", err)
+	}
+	defer inst.Release()
+
+	adapter, err := inst.RequestAdapter(nil)
+	if err != nil {
+		t.Fatalf("RequestAdapter failed: %v", err)
+	}
+	defer adapter.Release()
+
+	device, err := adapter.RequestDevice(nil)
+	if err != nil {
+		t.Fatalf("RequestDevice failed: %v", err)
+	}
+	defer device.Release()
+
+	// MapRead may only be combined with CopyDst without the
+	// MappablePrimaryBuffers native feature.
+	buffer, err := device.CreateBufferE(&BufferDescriptor{
+		Usage: gputypes.BufferUsageMapRead | gputypes.BufferUsageStorage,
+		Size:  256,
+	})
+	if err == nil {
+		buffer.Release()
+		t.Fatal("CreateBufferE with MapRead|Storage succeeded, want a validation error")
+	}
+	var wgpuErr *WGPUError
+	if !errors.As(err, &wgpuErr) || wgpuErr.Type != ErrorTypeValidation || wgpuErr.Op != "CreateBuffer" {
+		t.Errorf("CreateBufferE error = %#v, want a CreateBuffer validation error", err)
+	}
+	if buffer != nil {
+		t.Error("CreateBufferE returned a buffer with its error")
+	}
+}
+
 func TestBufferMappedAtCreation(t *testing.T) {
 	inst, err := CreateInstance(nil)
 	if err != nil {
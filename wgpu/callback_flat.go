@@ -26,3 +26,11 @@ func mapCallbackEntry(status, messageData, messageLength, userdata1, _ uintptr)
 func errorScopeCallbackEntry(status, errType, messageData, messageLength, userdata1, _ uintptr) uintptr {
 	return handleErrorScopeCallback(status, errType, StringView{Data: messageData, Length: messageLength}, userdata1)
 }
+
+func queueWorkDoneCallbackEntry(status, _, _, userdata1, _ uintptr) uintptr {
+	return handleWorkDoneCallback(status, userdata1)
+}
+
+func uncapturedErrorCallbackEntry(_, errType, messageData, messageLength, userdata1, _ uintptr) uintptr {
+	return handleUncapturedErrorCallback(errType, StringView{Data: messageData, Length: messageLength}, userdata1)
+}
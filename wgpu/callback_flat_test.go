@@ -94,6 +94,44 @@ func TestABICallbackEntriesPreserveStringViewAndUserdata(t *testing.T) {
 			t.Fatalf("error type = %d, want 11", result.errType)
 		}
 	})
+
+	t.Run("uncaptured error", func(t *testing.T) {
+		const requestID = uintptr(107)
+		sink := registerDeviceErrors(requestID)
+		t.Cleanup(func() { unregisterDeviceErrors(requestID) })
+		ch := sink.channel()
+
+		uncapturedErrorCallbackEntry(0, uintptr(ErrorTypeValidation), messageData, messageLength, requestID, 0)
+
+		select {
+		case e := <-ch:
+			if e.Type != ErrorTypeValidation || e.Message != string(message) {
+				t.Fatalf("error = %+v, want validation %q", e, message)
+			}
+		default:
+			t.Fatal("uncaptured error was not delivered")
+		}
+	})
+
+	t.Run("queue work done", func(t *testing.T) {
+		const requestID = uintptr(108)
+		var got QueueWorkDoneStatus
+		workDoneCallbacksMu.Lock()
+		workDoneCallbacks[requestID] = func(status QueueWorkDoneStatus) { got = status }
+		workDoneCallbacksMu.Unlock()
+
+		queueWorkDoneCallbackEntry(uintptr(QueueWorkDoneStatusSuccess), messageData, messageLength, requestID, 0)
+
+		if got != QueueWorkDoneStatusSuccess {
+			t.Fatalf("status = %d, want success", got)
+		}
+		workDoneCallbacksMu.Lock()
+		_, pending := workDoneCallbacks[requestID]
+		workDoneCallbacksMu.Unlock()
+		if pending {
+			t.Fatal("callback still registered after it ran")
+		}
+	})
 }
 
 func TestABICallbackEntriesHandleMessageEdges(t *testing.T) {
@@ -22,6 +22,14 @@ func errorScopeCallbackEntry(status, errType, message, userdata1, _ uintptr) uin
 	return handleErrorScopeCallback(status, errType, callbackStringView(message), userdata1)
 }
 
+func queueWorkDoneCallbackEntry(status, _, userdata1, _ uintptr) uintptr {
+	return handleWorkDoneCallback(status, userdata1)
+}
+
+func uncapturedErrorCallbackEntry(_, errType, message, userdata1, _ uintptr) uintptr {
+	return handleUncapturedErrorCallback(errType, callbackStringView(message), userdata1)
+}
+
 func callbackStringView(message uintptr) StringView {
 	if message == 0 {
 		return StringView{}
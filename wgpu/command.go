@@ -1,6 +1,8 @@
 package wgpu
 
 import (
+	"errors"
+	"fmt"
 	"unsafe"
 
 	"github.com/gogpu/gputypes"
@@ -20,8 +22,14 @@ type commandEncoderDescriptorWire struct {
 
 // CommandBufferDescriptor describes a command buffer.
 type CommandBufferDescriptor struct {
-	NextInChain uintptr // *ChainedStruct
-	Label       StringView
+	Label string
+}
+
+// commandBufferDescriptorWire is the FFI-compatible C-layout struct.
+// nextInChain(8)+label(16) = 24 bytes.
+type commandBufferDescriptorWire struct {
+	nextInChain uintptr
+	label       StringView
 }
 
 // ComputePassTimestampWrites is a deprecated alias for PassTimestampWrites.
@@ -52,7 +60,9 @@ func (d *Device) CreateCommandEncoder(desc *CommandEncoderDescriptor) (*CommandE
 		return nil, &WGPUError{Op: "CreateCommandEncoder", Message: "device is nil or released"}
 	}
 	var descPtr uintptr
+	var label string
 	if desc != nil {
+		label = desc.Label
 		wire := commandEncoderDescriptorWire{
 			Label: stringToStringView(desc.Label),
 		}
@@ -65,8 +75,12 @@ func (d *Device) CreateCommandEncoder(desc *CommandEncoderDescriptor) (*CommandE
 	if handle == 0 {
 		return nil, &WGPUError{Op: "CreateCommandEncoder", Message: "wgpu returned null handle"}
 	}
-	trackResource(handle, "CommandEncoder")
-	return &CommandEncoder{handle: handle}, nil
+	trackResource(handle, "CommandEncoder", label)
+	enc := &CommandEncoder{handle: handle, device: d}
+	if traceActive.Load() {
+		traceCall("Device.CreateCommandEncoder", nil, enc, desc)
+	}
+	return enc, nil
 }
 
 // BeginComputePass begins a compute pass.
@@ -78,32 +92,27 @@ func (enc *CommandEncoder) BeginComputePass(desc *ComputePassDescriptor) (*Compu
 	if enc == nil || enc.handle == 0 {
 		return nil, &WGPUError{Op: "BeginComputePass", Message: "encoder is nil or released"}
 	}
+	if err := enc.checkBegin(); err != nil {
+		return nil, &WGPUError{Op: "BeginComputePass", Type: ErrorTypeValidation, Message: err.Error()}
+	}
 
-	var wireDesc computePassDescriptorWire
-	var wireTimestamp passTimestampWrites
 	var descPtr uintptr
-
+	var label string
 	if desc != nil {
-		wireDesc.nextInChain = 0
-		if desc.Label != "" {
-			labelBytes := []byte(desc.Label)
-			wireDesc.label = StringView{
-				Data:   uintptr(unsafe.Pointer(&labelBytes[0])),
-				Length: uintptr(len(labelBytes)),
-			}
-		} else {
-			wireDesc.label = EmptyStringView()
-		}
+		scratch := enc.computeScratch()
+		label = desc.Label
+		scratch.desc.label = stringToStringView(label)
 		if desc.TimestampWrites != nil {
-			wireTimestamp = passTimestampWrites{
+			scratch.timestamps = passTimestampWrites{
 				nextInChain:               0,
 				querySet:                  desc.TimestampWrites.QuerySet.handle,
 				beginningOfPassWriteIndex: desc.TimestampWrites.BeginningOfPassWriteIndex,
 				endOfPassWriteIndex:       desc.TimestampWrites.EndOfPassWriteIndex,
 			}
-			wireDesc.timestampWrites = uintptr(unsafe.Pointer(&wireTimestamp))
+			scratch.desc.timestampWrites = uintptr(unsafe.Pointer(&scratch.timestamps))
 		}
-		descPtr = uintptr(unsafe.Pointer(&wireDesc))
+		descPtr = uintptr(unsafe.Pointer(&scratch.desc))
+		enc.pins.add(desc)
 	}
 
 	handle, _, _ := procCommandEncoderBeginComputePass.Call(
@@ -113,8 +122,13 @@ func (enc *CommandEncoder) BeginComputePass(desc *ComputePassDescriptor) (*Compu
 	if handle == 0 {
 		return nil, &WGPUError{Op: "BeginComputePass", Message: "wgpu returned null handle"}
 	}
-	trackResource(handle, "ComputePassEncoder")
-	return &ComputePassEncoder{handle: handle}, nil
+	trackResource(handle, "ComputePassEncoder", label)
+	enc.openPasses++
+	cpe := &ComputePassEncoder{handle: handle, encoder: enc}
+	if traceActive.Load() {
+		traceCall("CommandEncoder.BeginComputePass", enc, cpe, desc)
+	}
+	return cpe, nil
 }
 
 // CopyBufferToBuffer copies data between buffers.
@@ -123,6 +137,12 @@ func (enc *CommandEncoder) CopyBufferToBuffer(src *Buffer, srcOffset uint64, dst
 	if enc == nil || enc.handle == 0 || src == nil || src.handle == 0 || dst == nil || dst.handle == 0 {
 		return
 	}
+	if !enc.recording("CommandEncoder.CopyBufferToBuffer") {
+		return
+	}
+	if traceActive.Load() {
+		traceCall("CommandEncoder.CopyBufferToBuffer", enc, nil, src, srcOffset, dst, dstOffset, size)
+	}
 	procCommandEncoderCopyBufferToBuffer.Call( //nolint:errcheck
 		enc.handle,
 		src.handle,
@@ -140,6 +160,12 @@ func (enc *CommandEncoder) ClearBuffer(buffer *Buffer, offset, size uint64) {
 	if enc == nil || enc.handle == 0 || buffer == nil || buffer.handle == 0 {
 		return
 	}
+	if !enc.recording("CommandEncoder.ClearBuffer") {
+		return
+	}
+	if traceActive.Load() {
+		traceCall("CommandEncoder.ClearBuffer", enc, nil, buffer, offset, size)
+	}
 	procCommandEncoderClearBuffer.Call( //nolint:errcheck
 		enc.handle,
 		buffer.handle,
@@ -155,6 +181,9 @@ func (enc *CommandEncoder) InsertDebugMarker(markerLabel string) {
 	if enc == nil || enc.handle == 0 {
 		return
 	}
+	if !enc.recording("CommandEncoder.InsertDebugMarker") {
+		return
+	}
 	labelBytes := []byte(markerLabel)
 	if len(labelBytes) == 0 {
 		return
@@ -163,6 +192,9 @@ func (enc *CommandEncoder) InsertDebugMarker(markerLabel string) {
 		Data:   uintptr(unsafe.Pointer(&labelBytes[0])),
 		Length: uintptr(len(labelBytes)),
 	}
+	if traceActive.Load() {
+		traceCall("CommandEncoder.InsertDebugMarker", enc, nil, markerLabel)
+	}
 	procCommandEncoderInsertDebugMarker.Call( //nolint:errcheck
 		enc.handle,
 		uintptr(unsafe.Pointer(&label)),
@@ -176,6 +208,9 @@ func (enc *CommandEncoder) PushDebugGroup(groupLabel string) {
 	if enc == nil || enc.handle == 0 {
 		return
 	}
+	if !enc.recording("CommandEncoder.PushDebugGroup") {
+		return
+	}
 	labelBytes := []byte(groupLabel)
 	if len(labelBytes) == 0 {
 		return
@@ -184,6 +219,9 @@ func (enc *CommandEncoder) PushDebugGroup(groupLabel string) {
 		Data:   uintptr(unsafe.Pointer(&labelBytes[0])),
 		Length: uintptr(len(labelBytes)),
 	}
+	if traceActive.Load() {
+		traceCall("CommandEncoder.PushDebugGroup", enc, nil, groupLabel)
+	}
 	procCommandEncoderPushDebugGroup.Call( //nolint:errcheck
 		enc.handle,
 		uintptr(unsafe.Pointer(&label)),
@@ -197,6 +235,12 @@ func (enc *CommandEncoder) PopDebugGroup() {
 	if enc == nil || enc.handle == 0 {
 		return
 	}
+	if !enc.recording("CommandEncoder.PopDebugGroup") {
+		return
+	}
+	if traceActive.Load() {
+		traceCall("CommandEncoder.PopDebugGroup", enc, nil)
+	}
 	procCommandEncoderPopDebugGroup.Call(enc.handle) //nolint:errcheck
 }
 
@@ -207,6 +251,22 @@ func (enc *CommandEncoder) CopyBufferToTexture(source *TexelCopyBufferInfo, dest
 	if enc == nil || enc.handle == 0 || source == nil || destination == nil || copySize == nil {
 		return
 	}
+	if !enc.recording("CommandEncoder.CopyBufferToTexture") {
+		return
+	}
+	if debugBuild {
+		if err := checkCopyLayout(source.Layout, textureFormatOf(destination.Texture), *copySize, true); err != nil {
+			enc.recordErr("CommandEncoder.CopyBufferToTexture", err)
+			return
+		}
+	}
+	if traceActive.Load() {
+		traceCall("CommandEncoder.CopyBufferToTexture", enc, nil,
+			traceBufferCopy{Buffer: &Buffer{handle: source.Buffer}, Layout: source.Layout},
+			ImageCopyTexture{Texture: &Texture{handle: destination.Texture}, MipLevel: destination.MipLevel,
+				Origin: destination.Origin, Aspect: destination.Aspect},
+			copySize)
+	}
 	procCommandEncoderCopyBufferToTexture.Call( //nolint:errcheck
 		enc.handle,
 		uintptr(unsafe.Pointer(source)),
@@ -224,6 +284,12 @@ func (enc *CommandEncoder) CopyTextureToBuffer(src *Texture, dst *Buffer, region
 	if enc == nil || enc.handle == 0 || src == nil || dst == nil || len(regions) == 0 {
 		return
 	}
+	if !enc.recording("CommandEncoder.CopyTextureToBuffer") {
+		return
+	}
+	if traceActive.Load() {
+		traceCall("CommandEncoder.CopyTextureToBuffer", enc, nil, src, dst, regions)
+	}
 	for i := range regions {
 		r := &regions[i]
 		srcWire := r.TextureBase.toWire()
@@ -236,6 +302,12 @@ func (enc *CommandEncoder) CopyTextureToBuffer(src *Texture, dst *Buffer, region
 			Buffer: dst.handle,
 		}
 		size := r.Size
+		if debugBuild {
+			if err := checkCopyLayout(dstWire.Layout, src.Format(), size, true); err != nil {
+				enc.recordErr("CommandEncoder.CopyTextureToBuffer", fmt.Errorf("region %d: %w", i, err))
+				continue
+			}
+		}
 		procCommandEncoderCopyTextureToBuffer.Call( //nolint:errcheck
 			enc.handle,
 			uintptr(unsafe.Pointer(&srcWire)),
@@ -252,6 +324,15 @@ func (enc *CommandEncoder) CopyTextureToBufferRaw(source *TexelCopyTextureInfo,
 	if enc == nil || enc.handle == 0 || source == nil || destination == nil || copySize == nil {
 		return
 	}
+	if !enc.recording("CommandEncoder.CopyTextureToBufferRaw") {
+		return
+	}
+	if debugBuild {
+		if err := checkCopyLayout(destination.Layout, textureFormatOf(source.Texture), *copySize, true); err != nil {
+			enc.recordErr("CommandEncoder.CopyTextureToBufferRaw", err)
+			return
+		}
+	}
 	procCommandEncoderCopyTextureToBuffer.Call( //nolint:errcheck
 		enc.handle,
 		uintptr(unsafe.Pointer(source)),
@@ -262,15 +343,36 @@ func (enc *CommandEncoder) CopyTextureToBufferRaw(source *TexelCopyTextureInfo,
 
 // CopyTextureToTexture copies data from one texture to another.
 // Accepts gogpu/wgpu-compatible types: src *Texture, dst *Texture, regions []TextureCopy.
-// Each region specifies the source and destination subresource origins and copy extent.
-// Errors are reported via Device error scopes, not as return values.
+// Each region specifies the source and destination subresource origins and copy extent;
+// a region whose Source or Destination Texture is nil uses src or dst.
+// Regions are validated on the Go side (mip level, aspect, bounds, block
+// alignment, format and sample count compatibility); an invalid region is
+// skipped and its error returned by [CommandEncoder.Finish]. Other errors are
+// reported via Device error scopes.
 func (enc *CommandEncoder) CopyTextureToTexture(src, dst *Texture, regions []TextureCopy) {
 	mustInit()
 	if enc == nil || enc.handle == 0 || src == nil || dst == nil || len(regions) == 0 {
 		return
 	}
+	if !enc.recording("CommandEncoder.CopyTextureToTexture") {
+		return
+	}
+	if traceActive.Load() {
+		traceCall("CommandEncoder.CopyTextureToTexture", enc, nil, src, dst, regions)
+	}
 	for i := range regions {
-		r := &regions[i]
+		r := regions[i]
+		if r.Source.Texture == nil {
+			r.Source.Texture = src
+		}
+		if r.Destination.Texture == nil {
+			r.Destination.Texture = dst
+		}
+		srcInfo, dstInfo := newCopySubresource(&r.Source), newCopySubresource(&r.Destination)
+		if err := validateTextureCopy(&srcInfo, &dstInfo, r.Size); err != nil {
+			enc.recordErr("CommandEncoder.CopyTextureToTexture", fmt.Errorf("region %d: %w", i, err))
+			continue
+		}
 		srcWire := r.Source.toWire()
 		dstWire := r.Destination.toWire()
 		size := r.Size
@@ -290,6 +392,9 @@ func (enc *CommandEncoder) CopyTextureToTextureRaw(source *TexelCopyTextureInfo,
 	if enc == nil || enc.handle == 0 || source == nil || destination == nil || copySize == nil {
 		return
 	}
+	if !enc.recording("CommandEncoder.CopyTextureToTextureRaw") {
+		return
+	}
 	procCommandEncoderCopyTextureToTexture.Call( //nolint:errcheck
 		enc.handle,
 		uintptr(unsafe.Pointer(source)),
@@ -301,7 +406,12 @@ func (enc *CommandEncoder) CopyTextureToTextureRaw(source *TexelCopyTextureInfo,
 // Finish finishes recording and returns a command buffer.
 // The optional desc argument allows setting a label; pass nothing for defaults.
 // This variadic signature matches the gogpu/wgpu API for compatibility.
-// Returns an error if the FFI call fails or the encoder is nil.
+//
+// Finish returns an error, and no command buffer, if the encoder is nil,
+// released or already finished, if a pass begun on it has not been ended,
+// or if a command recorded an error on the Go side. Validation errors
+// wgpu-native raises for the encoded commands go to the enclosing error
+// scope or [Device.Errors], as for any other call.
 func (enc *CommandEncoder) Finish(desc ...*CommandBufferDescriptor) (*CommandBuffer, error) {
 	if err := checkInit(); err != nil {
 		return nil, err
@@ -309,23 +419,101 @@ func (enc *CommandEncoder) Finish(desc ...*CommandBufferDescriptor) (*CommandBuf
 	if enc == nil || enc.handle == 0 {
 		return nil, &WGPUError{Op: "CommandEncoder.Finish", Message: "encoder is nil or released"}
 	}
-	var descPtr uintptr
+	if err := enc.checkFinish(); err != nil {
+		return nil, &WGPUError{Op: "CommandEncoder.Finish", Type: ErrorTypeValidation, Message: err.Error()}
+	}
+	if enc.err != nil {
+		return nil, enc.err
+	}
+	var wire commandBufferDescriptorWire
+	var label string
 	if len(desc) > 0 && desc[0] != nil {
-		descPtr = uintptr(unsafe.Pointer(desc[0]))
+		label = desc[0].Label
+		wire.label = stringToStringView(label)
 	}
+	enc.pins.add(desc)
 	handle, _, _ := procCommandEncoderFinish.Call(
 		enc.handle,
-		descPtr,
+		uintptr(unsafe.Pointer(&wire)),
 	)
+	enc.finished = true
+	enc.pins.release()
+	enc.releaseScratch()
 	if handle == 0 {
 		return nil, &WGPUError{Op: "CommandEncoder.Finish", Message: "wgpu returned null handle"}
 	}
-	trackResource(handle, "CommandBuffer")
-	return &CommandBuffer{handle: handle}, nil
+	trackResource(handle, "CommandBuffer", label)
+	cb := &CommandBuffer{handle: handle, label: label}
+	if traceActive.Load() {
+		traceCall("CommandEncoder.Finish", enc, cb, desc)
+	}
+	return cb, nil
+}
+
+// checkFinish reports why the encoder cannot be finished yet.
+func (enc *CommandEncoder) checkFinish() error {
+	switch {
+	case enc.finished:
+		return fmt.Errorf("encoder was already finished")
+	case enc.openPasses == 1:
+		return fmt.Errorf("a pass has not been ended")
+	case enc.openPasses > 1:
+		return fmt.Errorf("%d passes have not been ended", enc.openPasses)
+	}
+	return nil
+}
+
+// checkBegin reports why a pass cannot be begun on enc: passes do not nest,
+// and a finished encoder records nothing.
+func (enc *CommandEncoder) checkBegin() error {
+	switch {
+	case enc.finished:
+		return fmt.Errorf("encoder was already finished")
+	case enc.openPasses > 0:
+		return fmt.Errorf("another pass is still open on this encoder")
+	}
+	return nil
+}
+
+// recording reports whether commands can be recorded on enc itself. While
+// a pass is open or after Finish they cannot; the command is then dropped
+// and the error returned by Finish instead of reaching wgpu-native.
+func (enc *CommandEncoder) recording(op string) bool {
+	var err error
+	switch {
+	case enc.finished:
+		err = fmt.Errorf("encoder was already finished")
+	case enc.openPasses > 0:
+		err = fmt.Errorf("a pass is open on this encoder; end it first")
+	default:
+		return true
+	}
+	enc.recordErr(op, err)
+	return false
+}
+
+// errPassEnded is recorded when End is called on a pass that already ended.
+var errPassEnded = errors.New("pass was already ended")
+
+// passEnded records that a pass begun on enc was ended. enc may be nil.
+func (enc *CommandEncoder) passEnded() {
+	if enc != nil && enc.openPasses > 0 {
+		enc.openPasses--
+	}
+}
+
+// recordErr keeps the first error found while recording a pass so Finish
+// can return it. enc may be nil for passes not begun by this package.
+func (enc *CommandEncoder) recordErr(op string, err error) {
+	if enc != nil && enc.err == nil {
+		enc.err = &WGPUError{Op: op, Type: ErrorTypeValidation, Message: err.Error()}
+	}
 }
 
 // Release releases the command encoder.
 func (enc *CommandEncoder) Release() {
+	enc.pins.release()
+	enc.releaseScratch()
 	if enc.handle != 0 {
 		untrackResource(enc.handle)
 		procCommandEncoderRelease.Call(enc.handle) //nolint:errcheck
@@ -341,6 +529,9 @@ func (enc *CommandEncoder) WriteTimestamp(querySet *QuerySet, queryIndex uint32)
 	if enc == nil || enc.handle == 0 || querySet == nil || querySet.handle == 0 {
 		return
 	}
+	if !enc.recording("CommandEncoder.WriteTimestamp") {
+		return
+	}
 	procCommandEncoderWriteTimestamp.Call( //nolint:errcheck
 		enc.handle,
 		querySet.handle,
@@ -355,6 +546,9 @@ func (enc *CommandEncoder) ResolveQuerySet(querySet *QuerySet, firstQuery, query
 	if enc == nil || enc.handle == 0 || querySet == nil || querySet.handle == 0 || destination == nil || destination.handle == 0 {
 		return
 	}
+	if !enc.recording("CommandEncoder.ResolveQuerySet") {
+		return
+	}
 	procCommandEncoderResolveQuerySet.Call( //nolint:errcheck
 		enc.handle,
 		querySet.handle,
@@ -374,6 +568,9 @@ func (cpe *ComputePassEncoder) SetPipeline(pipeline *ComputePipeline) {
 	if cpe == nil || cpe.handle == 0 || pipeline == nil || pipeline.handle == 0 {
 		return
 	}
+	if traceActive.Load() {
+		traceCall("ComputePassEncoder.SetPipeline", cpe, nil, pipeline)
+	}
 	procComputePassEncoderSetPipeline.Call( //nolint:errcheck
 		cpe.handle,
 		pipeline.handle,
@@ -381,17 +578,26 @@ func (cpe *ComputePassEncoder) SetPipeline(pipeline *ComputePipeline) {
 }
 
 // SetBindGroup sets a bind group.
+// Dynamic offsets are checked with [BindGroup.ValidateDynamicOffsets]; on
+// failure the call is skipped and [CommandEncoder.Finish] returns the error.
 func (cpe *ComputePassEncoder) SetBindGroup(groupIndex uint32, group *BindGroup, dynamicOffsets []uint32) {
 	mustInit()
 	if cpe == nil || cpe.handle == 0 || group == nil || group.handle == 0 {
 		return
 	}
+	if err := group.ValidateDynamicOffsets(dynamicOffsets); err != nil {
+		cpe.encoder.recordErr("ComputePassEncoder.SetBindGroup", err)
+		return
+	}
 	var offsetsPtr uintptr
 	offsetCount := uintptr(0)
 	if len(dynamicOffsets) > 0 {
 		offsetsPtr = uintptr(unsafe.Pointer(&dynamicOffsets[0]))
 		offsetCount = uintptr(len(dynamicOffsets))
 	}
+	if traceActive.Load() {
+		traceCall("ComputePassEncoder.SetBindGroup", cpe, nil, groupIndex, group, dynamicOffsets)
+	}
 	procComputePassEncoderSetBindGroup.Call( //nolint:errcheck
 		cpe.handle,
 		uintptr(groupIndex),
@@ -401,12 +607,39 @@ func (cpe *ComputePassEncoder) SetBindGroup(groupIndex uint32, group *BindGroup,
 	)
 }
 
+// SetBindGroups sets groups at consecutive indices starting at first, with
+// offsets[i] as the dynamic offsets of groups[i]. offsets may be shorter than
+// groups, or nil, for groups without dynamic offsets; nil groups leave their
+// index unchanged. Each group is set as by [ComputePassEncoder.SetBindGroup].
+func (cpe *ComputePassEncoder) SetBindGroups(first uint32, groups []*BindGroup, offsets [][]uint32) {
+	if cpe == nil {
+		return
+	}
+	if err := checkBindGroupBatch(groups, offsets); err != nil {
+		cpe.encoder.recordErr("ComputePassEncoder.SetBindGroups", err)
+		return
+	}
+	for i, group := range groups {
+		if group == nil {
+			continue
+		}
+		var dyn []uint32
+		if i < len(offsets) {
+			dyn = offsets[i]
+		}
+		cpe.SetBindGroup(first+uint32(i), group, dyn)
+	}
+}
+
 // DispatchWorkgroups dispatches compute work.
 func (cpe *ComputePassEncoder) DispatchWorkgroups(x, y, z uint32) {
 	mustInit()
 	if cpe == nil || cpe.handle == 0 {
 		return
 	}
+	if traceActive.Load() {
+		traceCall("ComputePassEncoder.DispatchWorkgroups", cpe, nil, x, y, z)
+	}
 	procComputePassEncoderDispatchWorkgroups.Call( //nolint:errcheck
 		cpe.handle,
 		uintptr(x),
@@ -425,6 +658,9 @@ func (cpe *ComputePassEncoder) DispatchWorkgroupsIndirect(indirectBuffer *Buffer
 	if cpe == nil || cpe.handle == 0 || indirectBuffer == nil || indirectBuffer.handle == 0 {
 		return
 	}
+	if traceActive.Load() {
+		traceCall("ComputePassEncoder.DispatchWorkgroupsIndirect", cpe, nil, indirectBuffer, indirectOffset)
+	}
 	procComputePassEncoderDispatchWorkgroupsIndirect.Call( //nolint:errcheck
 		cpe.handle,
 		indirectBuffer.handle,
@@ -432,13 +668,78 @@ func (cpe *ComputePassEncoder) DispatchWorkgroupsIndirect(indirectBuffer *Buffer
 	)
 }
 
-// End ends the compute pass.
+// InsertDebugMarker inserts a single debug marker label into the compute
+// pass, shown by GPU debugging tools such as RenderDoc and PIX.
+func (cpe *ComputePassEncoder) InsertDebugMarker(markerLabel string) {
+	mustInit()
+	if cpe == nil || cpe.handle == 0 || markerLabel == "" {
+		return
+	}
+	labelBytes := []byte(markerLabel)
+	label := StringView{
+		Data:   uintptr(unsafe.Pointer(&labelBytes[0])),
+		Length: uintptr(len(labelBytes)),
+	}
+	if traceActive.Load() {
+		traceCall("ComputePassEncoder.InsertDebugMarker", cpe, nil, markerLabel)
+	}
+	procComputePassEncoderInsertDebugMarker.Call( //nolint:errcheck
+		cpe.handle,
+		uintptr(unsafe.Pointer(&label)),
+	)
+}
+
+// PushDebugGroup begins a labeled debug group in the compute pass.
+// Use PopDebugGroup to end the group. Groups can be nested.
+func (cpe *ComputePassEncoder) PushDebugGroup(groupLabel string) {
+	mustInit()
+	if cpe == nil || cpe.handle == 0 || groupLabel == "" {
+		return
+	}
+	labelBytes := []byte(groupLabel)
+	label := StringView{
+		Data:   uintptr(unsafe.Pointer(&labelBytes[0])),
+		Length: uintptr(len(labelBytes)),
+	}
+	if traceActive.Load() {
+		traceCall("ComputePassEncoder.PushDebugGroup", cpe, nil, groupLabel)
+	}
+	procComputePassEncoderPushDebugGroup.Call( //nolint:errcheck
+		cpe.handle,
+		uintptr(unsafe.Pointer(&label)),
+	)
+}
+
+// PopDebugGroup ends the current debug group in the compute pass.
+// Must match a preceding PushDebugGroup call.
+func (cpe *ComputePassEncoder) PopDebugGroup() {
+	mustInit()
+	if cpe == nil || cpe.handle == 0 {
+		return
+	}
+	if traceActive.Load() {
+		traceCall("ComputePassEncoder.PopDebugGroup", cpe, nil)
+	}
+	procComputePassEncoderPopDebugGroup.Call(cpe.handle) //nolint:errcheck
+}
+
+// End ends the compute pass. Ending a pass twice is an error returned by
+// [CommandEncoder.Finish]; the second call does not reach wgpu-native.
 func (cpe *ComputePassEncoder) End() {
 	mustInit()
 	if cpe == nil || cpe.handle == 0 {
 		return
 	}
+	if cpe.ended {
+		cpe.encoder.recordErr("ComputePassEncoder.End", errPassEnded)
+		return
+	}
+	if traceActive.Load() {
+		traceCall("ComputePassEncoder.End", cpe, nil)
+	}
 	procComputePassEncoderEnd.Call(cpe.handle) //nolint:errcheck
+	cpe.ended = true
+	cpe.encoder.passEnded()
 }
 
 // Release releases the compute pass encoder.
@@ -453,20 +754,24 @@ func (cpe *ComputePassEncoder) Release() {
 // Handle returns the underlying handle.
 func (cpe *ComputePassEncoder) Handle() uintptr { return cpe.handle }
 
-// Submit submits command buffers for execution.
-// Returns the submission index (uint64) and nil on success. The submission
-// index can be used with Device.Poll to track when work completes.
+// Submit submits command buffers for execution, in order, with a single
+// native call. Returns the submission index (uint64) and nil on success. The
+// submission index can be used with Device.Poll to track when work completes.
 // Matches gogpu/wgpu Queue.Submit(commands ...*CommandBuffer) (uint64, error).
+//
+// If any command buffer is nil, released, repeated in the call or already
+// submitted, Submit returns a validation error and submits nothing.
 func (q *Queue) Submit(commands ...*CommandBuffer) (uint64, error) {
 	mustInit()
 	if q == nil || q.handle == 0 || len(commands) == 0 {
 		return 0, nil
 	}
-	handles := make([]uintptr, len(commands))
-	for i, cmd := range commands {
-		if cmd != nil {
-			handles[i] = cmd.handle
-		}
+	handles, err := submitHandles(commands)
+	if err != nil {
+		return 0, &WGPUError{Op: "Queue.Submit", Type: ErrorTypeValidation, Message: err.Error()}
+	}
+	if traceActive.Load() {
+		traceCall("Queue.Submit", nil, nil, commands)
 	}
 	// wgpuQueueSubmitForIndex is a wgpu-native extension that returns WGPUSubmissionIndex (uint64).
 	// This enables callers to poll for GPU completion of a specific submission.
@@ -475,9 +780,40 @@ func (q *Queue) Submit(commands ...*CommandBuffer) (uint64, error) {
 		uintptr(len(handles)),
 		uintptr(unsafe.Pointer(&handles[0])),
 	)
+	for _, cmd := range commands {
+		cmd.submitted = true
+	}
+	if q.device != nil {
+		if w := q.device.watchdog.Load(); w != nil {
+			index := uint64(submissionIndex)
+			w.submitted(index, commands)
+			q.OnSubmittedWorkDone(func(QueueWorkDoneStatus) { w.completed(index) }) //nolint:errcheck
+		}
+	}
 	return uint64(submissionIndex), nil
 }
 
+// submitHandles returns the native handles of commands, or an error if one
+// of them cannot be submitted.
+func submitHandles(commands []*CommandBuffer) ([]uintptr, error) {
+	handles := make([]uintptr, len(commands))
+	for i, cmd := range commands {
+		switch {
+		case cmd == nil || cmd.handle == 0:
+			return nil, fmt.Errorf("command buffer %d is nil or released", i)
+		case cmd.submitted:
+			return nil, fmt.Errorf("command buffer %d was already submitted", i)
+		}
+		for j := 0; j < i; j++ {
+			if commands[j] == cmd {
+				return nil, fmt.Errorf("command buffer %d is also command buffer %d", i, j)
+			}
+		}
+		handles[i] = cmd.handle
+	}
+	return handles, nil
+}
+
 // GetTimestampPeriod returns the duration of one GPU timestamp tick in
 // nanoseconds, as reported by wgpu-native. It returns zero for a nil or
 // released queue, or when the native call is unavailable.
@@ -0,0 +1,51 @@
+package wgpu
+
+import "testing"
+
+func TestCommandEncoderCheckFinish(t *testing.T) {
+	enc := &CommandEncoder{handle: 1, openPasses: 2}
+	if err := enc.checkFinish(); err == nil {
+		t.Error("two open passes: expected an error")
+	}
+	enc.passEnded()
+	enc.passEnded()
+	enc.passEnded() // extra End calls must not underflow
+	if err := enc.checkFinish(); err != nil {
+		t.Errorf("all passes ended: %v", err)
+	}
+	enc.finished = true
+	if err := enc.checkFinish(); err == nil {
+		t.Error("finished encoder: expected an error")
+	}
+	var nilEnc *CommandEncoder
+	nilEnc.passEnded() // passes not begun by this package have no encoder
+}
+
+func TestCommandEncoderLifecycle(t *testing.T) {
+	enc := &CommandEncoder{handle: 1}
+	if err := enc.checkBegin(); err != nil {
+		t.Fatalf("idle encoder: %v", err)
+	}
+	if !enc.recording("op") {
+		t.Fatal("idle encoder should accept commands")
+	}
+
+	enc.openPasses = 1
+	if err := enc.checkBegin(); err == nil {
+		t.Error("nested pass: expected an error")
+	}
+	if enc.recording("CommandEncoder.CopyBufferToBuffer") {
+		t.Error("encoder with an open pass accepted a command")
+	}
+	if enc.err == nil {
+		t.Error("dropped command did not record an error for Finish")
+	}
+
+	enc = &CommandEncoder{handle: 1, finished: true}
+	if err := enc.checkBegin(); err == nil {
+		t.Error("finished encoder: expected an error")
+	}
+	if enc.recording("op") {
+		t.Error("finished encoder accepted a command")
+	}
+}
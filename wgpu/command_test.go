@@ -126,11 +126,10 @@ func TestComputePassDispatch(t *testing.T) {
 	defer pipeline.Release()
 
 	// Get bind group layout from pipeline
-	bindGroupLayout := pipeline.GetBindGroupLayout(0)
-	if bindGroupLayout == nil {
-		t.Fatal("GetBindGroupLayout returned nil")
+	bindGroupLayout, err := pipeline.GetBindGroupLayout(0)
+	if err != nil {
+		t.Fatalf("GetBindGroupLayout failed: %v", err)
 	}
-	defer bindGroupLayout.Release()
 
 	// Create buffer with initial data
 	const numElements = 64
@@ -239,11 +238,10 @@ func TestFullComputeExample(t *testing.T) {
 	}
 	defer pipeline.Release()
 
-	bindGroupLayout := pipeline.GetBindGroupLayout(0)
-	if bindGroupLayout == nil {
-		t.Fatal("GetBindGroupLayout returned nil")
+	bindGroupLayout, err := pipeline.GetBindGroupLayout(0)
+	if err != nil {
+		t.Fatalf("GetBindGroupLayout failed: %v", err)
 	}
-	defer bindGroupLayout.Release()
 
 	// Create storage buffer
 	const numElements = 64
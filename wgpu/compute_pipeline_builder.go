@@ -0,0 +1,84 @@
+package wgpu
+
+import "github.com/gogpu/gputypes"
+
+// ComputePipelineBuilder assembles a ComputePipelineDescriptor and checks it
+// against the shader before anything reaches wgpu-native.
+//
+//	pipeline, err := wgpu.NewComputePipelineBuilder(device).
+//		Layout(layout).
+//		Shader(shader, "main").
+//		Constant("workgroup_size", 64).
+//		Build()
+type ComputePipelineBuilder struct {
+	device *Device
+	desc   ComputePipelineDescriptor
+}
+
+// NewComputePipelineBuilder returns a builder for a compute pipeline on device.
+func NewComputePipelineBuilder(device *Device) *ComputePipelineBuilder {
+	return &ComputePipelineBuilder{device: device}
+}
+
+// Label sets the pipeline label.
+func (b *ComputePipelineBuilder) Label(label string) *ComputePipelineBuilder {
+	b.desc.Label = label
+	return b
+}
+
+// Layout sets the pipeline layout. Without it the layout is derived from the
+// shader.
+func (b *ComputePipelineBuilder) Layout(layout *PipelineLayout) *ComputePipelineBuilder {
+	b.desc.Layout = layout
+	return b
+}
+
+// Shader sets the shader module and entry point. An empty entry point selects
+// the module's only @compute function.
+func (b *ComputePipelineBuilder) Shader(module *ShaderModule, entryPoint string) *ComputePipelineBuilder {
+	b.desc.Module = module
+	b.desc.EntryPoint = entryPoint
+	return b
+}
+
+// Constant sets the value of a WGSL override declaration by name or numeric @id.
+func (b *ComputePipelineBuilder) Constant(key string, value float64) *ComputePipelineBuilder {
+	if b.desc.Constants == nil {
+		b.desc.Constants = make(map[string]float64)
+	}
+	b.desc.Constants[key] = value
+	return b
+}
+
+// Descriptor returns the descriptor assembled so far after checking that the
+// shader is set, that the entry point exists and is a @compute function, and
+// that the bindings it uses match the layout. The checks that need reflection
+// run only for modules created from WGSL.
+func (b *ComputePipelineBuilder) Descriptor() (*ComputePipelineDescriptor, error) {
+	if b.desc.Module == nil {
+		return nil, &WGPUError{Op: "ComputePipelineBuilder", Type: ErrorTypeValidation, Message: "shader not set; call Shader"}
+	}
+	ep, err := reflectEntryPoint(b.desc.Module, b.desc.EntryPoint, gputypes.ShaderStageCompute)
+	if err == nil {
+		err = checkLayoutCompatible(b.desc.Layout, ep)
+	}
+	if err != nil {
+		return nil, &WGPUError{Op: "ComputePipelineBuilder", Type: ErrorTypeValidation, Message: err.Error()}
+	}
+	if _, err := toConstantsWire(b.desc.Constants); err != nil {
+		return nil, &WGPUError{Op: "ComputePipelineBuilder", Type: ErrorTypeValidation, Message: err.Error()}
+	}
+	return &b.desc, nil
+}
+
+// Build creates the compute pipeline.
+// Returns the first validation error found by Descriptor, or any error from
+// [Device.CreateComputePipelineE], including the message wgpu-native reports
+// for an invalid pipeline.
+func (b *ComputePipelineBuilder) Build() (*ComputePipeline, error) {
+	desc, err := b.Descriptor()
+	if err != nil {
+		return nil, err
+	}
+	return b.device.CreateComputePipelineE(desc)
+}
@@ -21,7 +21,9 @@
 //
 // # Enums matching v29 exactly — use direct uint32 cast, no converter needed
 //
-//   - TextureFormat (gputypes v0.3.0 matches v29 exactly, including R16*/RG16*/RGBA16* Unorm/Snorm)
+//   - TextureFormat (gputypes v0.3.0 matches v29 exactly, including R16*/RG16*/RGBA16* Unorm/Snorm
+//     and the BC 0x32–0x3F, ETC2/EAC 0x40–0x49 and ASTC 0x4A–0x65 ranges; block sizes and
+//     feature requirements for compressed formats live in texture_format.go)
 //   - TextureViewDimension, TextureDimension, TextureAspect
 //   - LoadOp (Undefined=0, Load=1, Clear=2), StoreOp (Undefined=0, Store=1, Discard=2)
 //   - BlendFactor values 0x00–0x0D match v29; gputypes lacks Src1* (0x0E–0x11) but those
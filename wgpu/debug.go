@@ -2,6 +2,11 @@ package wgpu
 
 import (
 	"fmt"
+	"io"
+	"log/slog"
+	"runtime"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 )
@@ -14,16 +19,25 @@ var debugMode atomic.Bool
 // resourceTracker tracks live GPU resources for leak detection.
 var resourceTracker struct {
 	mu        sync.Mutex
-	resources map[uintptr]resourceInfo
+	resources map[uintptr]ResourceInfo
+	seq       uint64 // creation counter, orders LiveResources
 }
 
-type resourceInfo struct {
-	Type string // "Buffer", "Texture", "Device", etc.
-	// No stack traces - keep it simple
+// ResourceInfo describes a GPU resource that has been created and not yet
+// released.
+type ResourceInfo struct {
+	Type   string // "Buffer", "Texture", "Device", etc.
+	Label  string // descriptor label, empty if none was given
+	Handle uintptr
+	// Stack is the stack of the goroutine that created the resource. It is
+	// only recorded in builds with the wgpudebug tag.
+	Stack string
+
+	seq uint64
 }
 
 func init() {
-	resourceTracker.resources = make(map[uintptr]resourceInfo)
+	resourceTracker.resources = make(map[uintptr]ResourceInfo)
 }
 
 // SetDebugMode enables or disables resource tracking.
@@ -40,18 +54,71 @@ func DebugMode() bool {
 }
 
 // trackResource records a resource allocation (debug mode only).
-func trackResource(handle uintptr, typeName string) {
+func trackResource(handle uintptr, typeName, label string) {
 	if !debugMode.Load() || handle == 0 {
 		return
 	}
+	info := ResourceInfo{Type: typeName, Label: label, Handle: handle}
+	if debugBuild {
+		info.Stack = callerStack(1)
+	}
 	resourceTracker.mu.Lock()
-	resourceTracker.resources[handle] = resourceInfo{Type: typeName}
+	resourceTracker.seq++
+	info.seq = resourceTracker.seq
+	resourceTracker.resources[handle] = info
 	resourceTracker.mu.Unlock()
 }
 
-// untrackResource records a resource release (debug mode only).
+// callerStack formats the stack from its caller up, minus skip frames, one
+// "function\n\tfile:line" entry per frame like a panic trace.
+func callerStack(skip int) string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	var b strings.Builder
+	for {
+		f, more := frames.Next()
+		if f.Function != "" {
+			fmt.Fprintf(&b, "%s\n\t%s:%d\n", f.Function, f.File, f.Line)
+		}
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
+
+// releaseGeneration counts resource releases. Caches holding objects that
+// reference other resources, such as [BindGroupCache], compare it to decide
+// whether to look for released resources.
+var releaseGeneration atomic.Uint64
+
+// releaseFlag records that an object was released or destroyed. Release
+// zeroes the object's handle without synchronization, so code that checks
+// objects owned by other goroutines, such as [BindGroupCache], reads this
+// flag instead.
+type releaseFlag struct{ v atomic.Bool }
+
+// set marks the object released and advances releaseGeneration.
+func (f *releaseFlag) set() {
+	f.v.Store(true)
+	releaseGeneration.Add(1)
+}
+
+// isSet reports whether the object was released or destroyed.
+func (f *releaseFlag) isSet() bool { return f.v.Load() }
+
+// untrackResource records a resource release: it advances releaseGeneration
+// and, in debug mode, removes the resource from the tracker.
 func untrackResource(handle uintptr) {
-	if !debugMode.Load() || handle == 0 {
+	if handle == 0 {
+		return
+	}
+	releaseGeneration.Add(1)
+	if traceActive.Load() {
+		traceRelease(handle)
+	}
+	if !debugMode.Load() {
 		return
 	}
 	resourceTracker.mu.Lock()
@@ -59,12 +126,31 @@ func untrackResource(handle uintptr) {
 	resourceTracker.mu.Unlock()
 }
 
+// LiveResources returns the resources created and not yet released, oldest
+// first. Only resources created while debug mode is enabled are tracked;
+// nil is returned when it is disabled.
+func LiveResources() []ResourceInfo {
+	if !debugMode.Load() {
+		return nil
+	}
+	resourceTracker.mu.Lock()
+	live := make([]ResourceInfo, 0, len(resourceTracker.resources))
+	for _, info := range resourceTracker.resources {
+		live = append(live, info)
+	}
+	resourceTracker.mu.Unlock()
+	sort.Slice(live, func(i, j int) bool { return live[i].seq < live[j].seq })
+	return live
+}
+
 // LeakReport contains information about unreleased GPU resources.
 type LeakReport struct {
 	// Count is the total number of unreleased resources.
 	Count int
 	// Types maps resource type names to their counts.
 	Types map[string]int
+	// Resources lists the unreleased resources, oldest first.
+	Resources []ResourceInfo
 }
 
 // String returns a human-readable summary of the leak report.
@@ -73,41 +159,91 @@ func (r *LeakReport) String() string {
 		return "no resource leaks detected"
 	}
 	s := fmt.Sprintf("%d unreleased GPU resource(s):", r.Count)
-	for typ, count := range r.Types {
-		s += fmt.Sprintf(" %s=%d", typ, count)
+	for _, typ := range r.sortedTypes() {
+		s += fmt.Sprintf(" %s=%d", typ, r.Types[typ])
 	}
 	return s
 }
 
+func (r *LeakReport) sortedTypes() []string {
+	types := make([]string, 0, len(r.Types))
+	for typ := range r.Types {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// WriteTo writes the summary followed by one line per unreleased resource
+// with its label and, in wgpudebug builds, the stack that created it:
+//
+//	2 unreleased GPU resource(s): Buffer=1 Texture=1
+//	  Buffer 0x1f2e3d40 "vertices"
+//	  Texture 0x1f2e3e80 "shadow map"
+//	    main.loadScene
+//	    	/src/app/scene.go:42
+//
+// It implements [io.WriterTo], so a nil-checked report can be written with
+// report.WriteTo(os.Stderr).
+func (r *LeakReport) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	b.WriteString(r.String())
+	b.WriteByte('\n')
+	for _, res := range r.Resources {
+		fmt.Fprintf(&b, "  %s %#x", res.Type, res.Handle)
+		if res.Label != "" {
+			fmt.Fprintf(&b, " %q", res.Label)
+		}
+		b.WriteByte('\n')
+		for line := range strings.Lines(res.Stack) {
+			b.WriteString("    ")
+			b.WriteString(line)
+		}
+	}
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// LogValue implements [slog.LogValuer], logging the count, the counts per
+// type and each resource's type, label and handle.
+func (r *LeakReport) LogValue() slog.Value {
+	types := make([]slog.Attr, 0, len(r.Types))
+	for _, typ := range r.sortedTypes() {
+		types = append(types, slog.Int(typ, r.Types[typ]))
+	}
+	resources := make([]string, len(r.Resources))
+	for i, res := range r.Resources {
+		resources[i] = fmt.Sprintf("%s %#x %q", res.Type, res.Handle, res.Label)
+	}
+	return slog.GroupValue(
+		slog.Int("count", r.Count),
+		slog.Attr{Key: "types", Value: slog.GroupValue(types...)},
+		slog.Any("resources", resources),
+	)
+}
+
 // ReportLeaks returns information about unreleased GPU resources.
 // Only meaningful when debug mode is enabled via SetDebugMode(true).
 // Returns nil if no leaks are detected.
 func ReportLeaks() *LeakReport {
-	if !debugMode.Load() {
+	live := LiveResources()
+	if len(live) == 0 {
 		return nil
 	}
-	resourceTracker.mu.Lock()
-	defer resourceTracker.mu.Unlock()
-
-	count := len(resourceTracker.resources)
-	if count == 0 {
-		return nil
-	}
-
 	types := make(map[string]int)
-	for _, info := range resourceTracker.resources {
+	for _, info := range live {
 		types[info.Type]++
 	}
-
 	return &LeakReport{
-		Count: count,
-		Types: types,
+		Count:     len(live),
+		Types:     types,
+		Resources: live,
 	}
 }
 
 // ResetLeakTracker clears the resource tracker. Useful for test cleanup.
 func ResetLeakTracker() {
 	resourceTracker.mu.Lock()
-	resourceTracker.resources = make(map[uintptr]resourceInfo)
+	resourceTracker.resources = make(map[uintptr]ResourceInfo)
 	resourceTracker.mu.Unlock()
 }
@@ -0,0 +1,7 @@
+//go:build wgpudebug
+
+package wgpu
+
+// debugBuild is set by the wgpudebug build tag, which adds costlier
+// diagnostics such as resource creation stacks.
+const debugBuild = true
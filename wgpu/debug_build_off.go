@@ -0,0 +1,5 @@
+//go:build !wgpudebug
+
+package wgpu
+
+const debugBuild = false
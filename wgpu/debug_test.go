@@ -1,6 +1,9 @@
 package wgpu
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestLeakDetection(t *testing.T) {
 	SetDebugMode(true)
@@ -47,3 +50,67 @@ func TestLeakDetectionDisabled(t *testing.T) {
 		t.Errorf("expected nil report when debug disabled, got: %s", report)
 	}
 }
+
+func TestLiveResources(t *testing.T) {
+	SetDebugMode(true)
+	defer SetDebugMode(false)
+	defer ResetLeakTracker()
+	ResetLeakTracker()
+
+	trackResource(0x30, "Texture", "shadow map")
+	trackResource(0x10, "Buffer", "vertices")
+	trackResource(0x20, "Buffer", "")
+	untrackResource(0x20)
+
+	live := LiveResources()
+	if len(live) != 2 {
+		t.Fatalf("LiveResources() = %d resources, want 2", len(live))
+	}
+	// Oldest first, not by handle.
+	if live[0].Handle != 0x30 || live[0].Type != "Texture" || live[0].Label != "shadow map" {
+		t.Errorf("live[0] = %+v", live[0])
+	}
+	if live[1].Handle != 0x10 || live[1].Label != "vertices" {
+		t.Errorf("live[1] = %+v", live[1])
+	}
+	if got := live[0].Stack != ""; got != debugBuild {
+		t.Errorf("stack recorded = %v, want %v", got, debugBuild)
+	}
+
+	report := ReportLeaks()
+	if report == nil || report.Count != 2 || report.Types["Buffer"] != 1 || report.Types["Texture"] != 1 {
+		t.Fatalf("ReportLeaks() = %+v", report)
+	}
+	if got, want := report.String(), "2 unreleased GPU resource(s): Buffer=1 Texture=1"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	var b strings.Builder
+	n, err := report.WriteTo(&b)
+	if err != nil || n != int64(b.Len()) {
+		t.Fatalf("WriteTo() = %d, %v", n, err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"2 unreleased GPU resource(s): Buffer=1 Texture=1\n",
+		"  Texture 0x30 \"shadow map\"\n",
+		"  Buffer 0x10 \"vertices\"\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("WriteTo output missing %q:\n%s", want, out)
+		}
+	}
+	if debugBuild && !strings.Contains(out, "TestLiveResources") {
+		t.Errorf("WriteTo output has no creation stack:\n%s", out)
+	}
+}
+
+func TestLiveResourcesDisabled(t *testing.T) {
+	SetDebugMode(false)
+	defer ResetLeakTracker()
+
+	trackResource(0x10, "Buffer", "")
+	if live := LiveResources(); live != nil {
+		t.Errorf("LiveResources() = %v with debug mode off, want nil", live)
+	}
+}
@@ -0,0 +1,162 @@
+package wgpu
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/gogpu/gputypes"
+)
+
+// Checks run before the native call in builds with the wgpudebug tag. They
+// catch descriptor mistakes that wgpu-native reports as an abort or a
+// null handle, and name the offending field instead. Call sites test
+// debugBuild first, so release builds compile them out.
+
+// copyStrideUndefined is WGPU_COPY_STRIDE_UNDEFINED, accepted like zero for
+// an unset BytesPerRow or RowsPerImage.
+const copyStrideUndefined = 0xFFFFFFFF
+
+// checkBufferDescriptor validates a buffer descriptor.
+func checkBufferDescriptor(desc *BufferDescriptor) error {
+	if desc.Usage == gputypes.BufferUsageNone {
+		return errors.New("buffer usage is empty")
+	}
+	// Without the MappablePrimaryBuffers native feature, mappable buffers
+	// may only be copied to or from.
+	if desc.Usage&gputypes.BufferUsageMapRead != 0 && desc.Usage&^(gputypes.BufferUsageMapRead|gputypes.BufferUsageCopyDst) != 0 {
+		return fmt.Errorf("buffer usage %v: MapRead can only be combined with CopyDst", desc.Usage)
+	}
+	if desc.Usage&gputypes.BufferUsageMapWrite != 0 && desc.Usage&^(gputypes.BufferUsageMapWrite|gputypes.BufferUsageCopySrc) != 0 {
+		return fmt.Errorf("buffer usage %v: MapWrite can only be combined with CopySrc", desc.Usage)
+	}
+	if desc.MappedAtCreation && desc.Size%4 != 0 {
+		return fmt.Errorf("buffer size %d must be a multiple of 4 when mapped at creation", desc.Size)
+	}
+	return nil
+}
+
+// checkTextureDescriptor validates a texture descriptor. Zero mip level and
+// sample counts mean 1, as in CreateTexture.
+func checkTextureDescriptor(desc *TextureDescriptor) error {
+	if desc.Usage == gputypes.TextureUsageNone {
+		return errors.New("texture usage is empty")
+	}
+	size := desc.Size
+	if size.Width == 0 || size.Height == 0 || size.DepthOrArrayLayers == 0 {
+		return fmt.Errorf("texture size %dx%dx%d has a zero dimension", size.Width, size.Height, size.DepthOrArrayLayers)
+	}
+	if desc.Dimension == gputypes.TextureDimension1D && (size.Height != 1 || size.DepthOrArrayLayers != 1) {
+		return fmt.Errorf("1D texture size %dx%dx%d must have height and depth 1", size.Width, size.Height, size.DepthOrArrayLayers)
+	}
+	if mips, most := desc.MipLevelCount, maxMipLevelCount(desc.Dimension, size); mips > most {
+		return fmt.Errorf("mip level count %d exceeds %d, the full chain of a %dx%dx%d texture",
+			mips, most, size.Width, size.Height, size.DepthOrArrayLayers)
+	}
+
+	samples := max(desc.SampleCount, 1)
+	if !validMultisampleCount(samples) {
+		return fmt.Errorf("sample count %d must be a power of two between 1 and 16", samples)
+	}
+	if samples == 1 {
+		return nil
+	}
+	switch {
+	case desc.Dimension != gputypes.TextureDimension2D && desc.Dimension != gputypes.TextureDimensionUndefined:
+		return fmt.Errorf("multisampled texture must be 2D, not %v", desc.Dimension)
+	case size.DepthOrArrayLayers != 1:
+		return fmt.Errorf("multisampled texture cannot have %d array layers", size.DepthOrArrayLayers)
+	case desc.MipLevelCount > 1:
+		return fmt.Errorf("multisampled texture cannot have %d mip levels", desc.MipLevelCount)
+	case desc.Usage&gputypes.TextureUsageRenderAttachment == 0:
+		return fmt.Errorf("multisampled texture usage %v must include RenderAttachment", desc.Usage)
+	case desc.Usage&gputypes.TextureUsageStorageBinding != 0:
+		return fmt.Errorf("multisampled texture usage %v cannot include StorageBinding", desc.Usage)
+	}
+	return nil
+}
+
+// maxMipLevelCount returns the length of the full mip chain of a texture.
+func maxMipLevelCount(dim gputypes.TextureDimension, size gputypes.Extent3D) uint32 {
+	largest := size.Width
+	switch dim {
+	case gputypes.TextureDimension1D:
+		return 1
+	case gputypes.TextureDimension3D:
+		largest = max(largest, size.Height, size.DepthOrArrayLayers)
+	default:
+		largest = max(largest, size.Height)
+	}
+	return uint32(bits.Len32(largest))
+}
+
+// checkRenderPassViews checks that attachments in use have live views. A
+// color attachment with no view and no load, store or resolve is an unused
+// slot and is allowed.
+func checkRenderPassViews(desc *RenderPassDescriptor) error {
+	for i, ca := range desc.ColorAttachments {
+		switch {
+		case ca.View == nil:
+			if ca.ResolveTarget != nil || ca.LoadOp != gputypes.LoadOpUndefined || ca.StoreOp != gputypes.StoreOpUndefined {
+				return fmt.Errorf("color attachment %d has no view", i)
+			}
+		case ca.View.handle == 0:
+			return fmt.Errorf("color attachment %d view is released", i)
+		case ca.LoadOp == gputypes.LoadOpUndefined || ca.StoreOp == gputypes.StoreOpUndefined:
+			return fmt.Errorf("color attachment %d needs both a load and a store op", i)
+		}
+		if ca.ResolveTarget != nil && ca.ResolveTarget.handle == 0 {
+			return fmt.Errorf("color attachment %d resolve target is released", i)
+		}
+	}
+	if ds := desc.DepthStencilAttachment; ds != nil && ds.View != nil && ds.View.handle == 0 {
+		return errors.New("depth/stencil attachment view is released")
+	}
+	return nil
+}
+
+// textureFormatOf returns the format of the texture with handle h.
+func textureFormatOf(h uintptr) gputypes.TextureFormat {
+	if h == 0 {
+		return gputypes.TextureFormatUndefined
+	}
+	format, _, _ := procTextureGetFormat.Call(h)
+	return gputypes.TextureFormat(format)
+}
+
+// checkCopyLayout validates the buffer side of a buffer/texture copy of
+// size in format. bytesPerRow must be a multiple of
+// CopyBytesPerRowAlignment and hold a row of texel blocks; it and
+// rowsPerImage may only be left unset when the copy is a single row or
+// image. Pass aligned=false for Queue.WriteTexture, which has no alignment
+// requirement.
+func checkCopyLayout(layout TexelCopyBufferLayout, format gputypes.TextureFormat, size gputypes.Extent3D, aligned bool) error {
+	tight, _, rows, err := textureRowLayout(format, size.Width, size.Height)
+	if err != nil {
+		// Depth/stencil and other formats without a copy size are left to
+		// wgpu-native.
+		return nil
+	}
+	bpr := layout.BytesPerRow
+	if bpr == 0 || bpr == copyStrideUndefined {
+		if rows > 1 || size.DepthOrArrayLayers > 1 {
+			return fmt.Errorf("bytesPerRow is unset for a copy of %d rows", rows*size.DepthOrArrayLayers)
+		}
+	} else {
+		if aligned && bpr%CopyBytesPerRowAlignment != 0 {
+			return fmt.Errorf("bytesPerRow %d is not a multiple of %d (use %d)", bpr, CopyBytesPerRowAlignment, alignBytesPerRow(bpr))
+		}
+		if bpr < tight {
+			return fmt.Errorf("bytesPerRow %d is less than the %d bytes of a %d texel %v row", bpr, tight, size.Width, format)
+		}
+	}
+	rpi := layout.RowsPerImage
+	if rpi == 0 || rpi == copyStrideUndefined {
+		if size.DepthOrArrayLayers > 1 {
+			return fmt.Errorf("rowsPerImage is unset for a copy of %d images", size.DepthOrArrayLayers)
+		}
+	} else if rpi < rows {
+		return fmt.Errorf("rowsPerImage %d is less than the %d block rows of the copy", rpi, rows)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package wgpu
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestCheckBufferDescriptor(t *testing.T) {
+	tests := []struct {
+		name string
+		desc BufferDescriptor
+		want string // substring of the error, empty if valid
+	}{
+		{"vertex", BufferDescriptor{Usage: gputypes.BufferUsageVertex | gputypes.BufferUsageCopyDst, Size: 6}, ""},
+		{"readback", BufferDescriptor{Usage: gputypes.BufferUsageMapRead | gputypes.BufferUsageCopyDst, Size: 64}, ""},
+		{"upload", BufferDescriptor{Usage: gputypes.BufferUsageMapWrite | gputypes.BufferUsageCopySrc, Size: 64, MappedAtCreation: true}, ""},
+		{"no usage", BufferDescriptor{Size: 64}, "usage is empty"},
+		{"map read uniform", BufferDescriptor{Usage: gputypes.BufferUsageMapRead | gputypes.BufferUsageUniform, Size: 64}, "MapRead can only be combined with CopyDst"},
+		{"map write vertex", BufferDescriptor{Usage: gputypes.BufferUsageMapWrite | gputypes.BufferUsageVertex, Size: 64}, "MapWrite can only be combined with CopySrc"},
+		{"mapped odd size", BufferDescriptor{Usage: gputypes.BufferUsageVertex, Size: 6, MappedAtCreation: true}, "multiple of 4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkError(t, checkBufferDescriptor(&tt.desc), tt.want)
+		})
+	}
+}
+
+func TestCheckTextureDescriptor(t *testing.T) {
+	rt := gputypes.TextureUsageRenderAttachment
+	size := gputypes.Extent3D{Width: 256, Height: 128, DepthOrArrayLayers: 1}
+	tests := []struct {
+		name string
+		desc TextureDescriptor
+		want string
+	}{
+		{"defaults", TextureDescriptor{Usage: rt, Size: size}, ""},
+		{"full mip chain", TextureDescriptor{Usage: rt, Size: size, MipLevelCount: 9}, ""},
+		{"msaa", TextureDescriptor{Usage: rt, Size: size, SampleCount: 4, Dimension: gputypes.TextureDimension2D}, ""},
+		{"3D mips", TextureDescriptor{Usage: rt, Dimension: gputypes.TextureDimension3D,
+			Size: gputypes.Extent3D{Width: 4, Height: 4, DepthOrArrayLayers: 64}, MipLevelCount: 7}, ""},
+		{"no usage", TextureDescriptor{Size: size}, "usage is empty"},
+		{"zero size", TextureDescriptor{Usage: rt, Size: gputypes.Extent3D{Width: 4, Height: 4}}, "zero dimension"},
+		{"1D height", TextureDescriptor{Usage: rt, Dimension: gputypes.TextureDimension1D, Size: size}, "height and depth 1"},
+		{"too many mips", TextureDescriptor{Usage: rt, Size: size, MipLevelCount: 10}, "mip level count 10 exceeds 9"},
+		{"3 samples", TextureDescriptor{Usage: rt, Size: size, SampleCount: 3}, "sample count 3 must be a power of two"},
+		{"32 samples", TextureDescriptor{Usage: rt, Size: size, SampleCount: 32}, "sample count 32"},
+		{"msaa 3D", TextureDescriptor{Usage: rt, Size: size, SampleCount: 4, Dimension: gputypes.TextureDimension3D}, "must be 2D"},
+		{"msaa layers", TextureDescriptor{Usage: rt, Size: gputypes.Extent3D{Width: 4, Height: 4, DepthOrArrayLayers: 2}, SampleCount: 4}, "2 array layers"},
+		{"msaa mips", TextureDescriptor{Usage: rt, Size: size, SampleCount: 4, MipLevelCount: 2}, "2 mip levels"},
+		{"msaa sampled only", TextureDescriptor{Usage: gputypes.TextureUsageTextureBinding, Size: size, SampleCount: 4}, "must include RenderAttachment"},
+		{"msaa storage", TextureDescriptor{Usage: rt | gputypes.TextureUsageStorageBinding, Size: size, SampleCount: 4}, "cannot include StorageBinding"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkError(t, checkTextureDescriptor(&tt.desc), tt.want)
+		})
+	}
+}
+
+func TestCheckRenderPassViews(t *testing.T) {
+	live := &TextureView{handle: 1}
+	released := &TextureView{}
+	color := func(view, resolve *TextureView) RenderPassColorAttachment {
+		return RenderPassColorAttachment{View: view, ResolveTarget: resolve, LoadOp: gputypes.LoadOpClear, StoreOp: gputypes.StoreOpStore}
+	}
+	tests := []struct {
+		name string
+		desc RenderPassDescriptor
+		want string
+	}{
+		{"color", RenderPassDescriptor{ColorAttachments: []RenderPassColorAttachment{color(live, live)}}, ""},
+		{"unused slot", RenderPassDescriptor{ColorAttachments: []RenderPassColorAttachment{{}, color(live, nil)}}, ""},
+		{"missing view", RenderPassDescriptor{ColorAttachments: []RenderPassColorAttachment{color(live, nil), color(nil, nil)}}, "color attachment 1 has no view"},
+		{"released view", RenderPassDescriptor{ColorAttachments: []RenderPassColorAttachment{color(released, nil)}}, "color attachment 0 view is released"},
+		{"released resolve", RenderPassDescriptor{ColorAttachments: []RenderPassColorAttachment{color(live, released)}}, "resolve target is released"},
+		{"no store op", RenderPassDescriptor{ColorAttachments: []RenderPassColorAttachment{{View: live, LoadOp: gputypes.LoadOpLoad}}}, "load and a store op"},
+		{"released depth", RenderPassDescriptor{DepthStencilAttachment: &RenderPassDepthStencilAttachment{View: released}}, "depth/stencil attachment view is released"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkError(t, checkRenderPassViews(&tt.desc), tt.want)
+		})
+	}
+}
+
+func TestCheckCopyLayout(t *testing.T) {
+	rgba := gputypes.TextureFormatRGBA8Unorm
+	tests := []struct {
+		name    string
+		layout  TexelCopyBufferLayout
+		format  gputypes.TextureFormat
+		size    gputypes.Extent3D
+		aligned bool
+		want    string
+	}{
+		{"aligned", TexelCopyBufferLayout{BytesPerRow: 256, RowsPerImage: 4}, rgba, gputypes.Extent3D{Width: 64, Height: 4, DepthOrArrayLayers: 1}, true, ""},
+		{"single row unset", TexelCopyBufferLayout{}, rgba, gputypes.Extent3D{Width: 64, Height: 1, DepthOrArrayLayers: 1}, true, ""},
+		{"undefined stride", TexelCopyBufferLayout{BytesPerRow: copyStrideUndefined, RowsPerImage: copyStrideUndefined}, rgba, gputypes.Extent3D{Width: 8, Height: 1, DepthOrArrayLayers: 1}, true, ""},
+		{"write unaligned", TexelCopyBufferLayout{BytesPerRow: 40}, rgba, gputypes.Extent3D{Width: 10, Height: 3, DepthOrArrayLayers: 1}, false, ""},
+		{"copy unaligned", TexelCopyBufferLayout{BytesPerRow: 40}, rgba, gputypes.Extent3D{Width: 10, Height: 3, DepthOrArrayLayers: 1}, true, "bytesPerRow 40 is not a multiple of 256 (use 256)"},
+		{"row too short", TexelCopyBufferLayout{BytesPerRow: 256}, rgba, gputypes.Extent3D{Width: 100, Height: 2, DepthOrArrayLayers: 1}, true, "less than the 400 bytes"},
+		{"unset multi row", TexelCopyBufferLayout{}, rgba, gputypes.Extent3D{Width: 4, Height: 2, DepthOrArrayLayers: 1}, false, "bytesPerRow is unset for a copy of 2 rows"},
+		{"unset rows per image", TexelCopyBufferLayout{BytesPerRow: 256}, rgba, gputypes.Extent3D{Width: 4, Height: 2, DepthOrArrayLayers: 3}, true, "rowsPerImage is unset"},
+		{"rows per image short", TexelCopyBufferLayout{BytesPerRow: 256, RowsPerImage: 1}, rgba, gputypes.Extent3D{Width: 4, Height: 2, DepthOrArrayLayers: 3}, true, "rowsPerImage 1 is less than the 2 block rows"},
+		{"bc1 block rows", TexelCopyBufferLayout{BytesPerRow: 256, RowsPerImage: 2}, gputypes.TextureFormatBC1RGBAUnorm, gputypes.Extent3D{Width: 16, Height: 8, DepthOrArrayLayers: 2}, true, ""},
+		{"depth left to native", TexelCopyBufferLayout{}, gputypes.TextureFormatDepth24Plus, gputypes.Extent3D{Width: 16, Height: 16, DepthOrArrayLayers: 1}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkError(t, checkCopyLayout(tt.layout, tt.format, tt.size, tt.aligned), tt.want)
+		})
+	}
+}
+
+// checkError fails t unless err is nil when want is empty, or contains want.
+func checkError(t *testing.T, err error, want string) {
+	t.Helper()
+	switch {
+	case want == "" && err != nil:
+		t.Errorf("unexpected error: %v", err)
+	case want != "" && err == nil:
+		t.Errorf("expected error containing %q", want)
+	case want != "" && !strings.Contains(err.Error(), want):
+		t.Errorf("error %q does not contain %q", err, want)
+	}
+}
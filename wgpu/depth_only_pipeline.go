@@ -0,0 +1,100 @@
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+)
+
+// DepthBias is the rasterizer depth bias of a depth-only pipeline, used to
+// keep shadow maps free of acne. Bias is only applied to triangle topologies.
+type DepthBias struct {
+	Constant   int32
+	SlopeScale float32
+	Clamp      float32
+}
+
+// DepthOnlyPipelineDescriptor returns a render pipeline descriptor with no
+// fragment stage, for shadow maps and depth pre-passes: triangle lists with
+// back-face culling, depth writes with Less comparison, the stencil test
+// disabled, and the given bias.
+func DepthOnlyPipelineDescriptor(layout *PipelineLayout, module *ShaderModule, entryPoint string,
+	buffers []VertexBufferLayout, format gputypes.TextureFormat, bias DepthBias) *RenderPipelineDescriptor {
+	depth := NewDepthState(format, gputypes.CompareFunctionLess, true)
+	depth.DepthBias = bias.Constant
+	depth.DepthBiasSlopeScale = bias.SlopeScale
+	depth.DepthBiasClamp = bias.Clamp
+	return &RenderPipelineDescriptor{
+		Label:  "depth-only pipeline",
+		Layout: layout,
+		Vertex: VertexState{
+			Module:     module,
+			EntryPoint: entryPoint,
+			Buffers:    buffers,
+		},
+		Primitive: PrimitiveState{
+			Topology:  gputypes.PrimitiveTopologyTriangleList,
+			FrontFace: gputypes.FrontFaceCCW,
+			CullMode:  gputypes.CullModeBack,
+		},
+		DepthStencil: depth,
+		Multisample:  MultisampleState{Count: 1, Mask: 0xFFFFFFFF},
+	}
+}
+
+// CreateDepthOnlyPipeline creates a render pipeline that only writes depth,
+// as described by [DepthOnlyPipelineDescriptor].
+// Returns an error if format has no depth aspect, module is nil, or the FFI
+// call fails.
+func (d *Device) CreateDepthOnlyPipeline(layout *PipelineLayout, module *ShaderModule, entryPoint string,
+	buffers []VertexBufferLayout, format gputypes.TextureFormat, bias DepthBias) (*RenderPipeline, error) {
+	if module == nil {
+		return nil, &WGPUError{Op: "CreateDepthOnlyPipeline", Message: "shader module is nil"}
+	}
+	if !format.HasDepth() {
+		return nil, &WGPUError{Op: "CreateDepthOnlyPipeline", Type: ErrorTypeValidation,
+			Message: fmt.Sprintf("format %v has no depth aspect", format)}
+	}
+	return d.CreateRenderPipeline(DepthOnlyPipelineDescriptor(layout, module, entryPoint, buffers, format, bias))
+}
+
+// validateDepthStencil checks the parts of a render pipeline descriptor that
+// wgpu-native rejects with an invalid pipeline rather than a useful message,
+// most of which only come up in pipelines without a fragment stage.
+func validateDepthStencil(desc *RenderPipelineDescriptor) error {
+	ds := desc.DepthStencil
+	if ds == nil {
+		if desc.Fragment == nil {
+			return fmt.Errorf("pipeline has neither a fragment stage nor depth-stencil state, so it writes nothing")
+		}
+		return nil
+	}
+	if !ds.Format.IsDepthStencil() {
+		return fmt.Errorf("depth-stencil format %v is not a depth or stencil format", ds.Format)
+	}
+	if ds.Format.HasDepth() {
+		if ds.DepthCompare == gputypes.CompareFunctionUndefined {
+			return fmt.Errorf("DepthCompare must be set for depth format %v", ds.Format)
+		}
+	} else if ds.DepthWriteEnabled {
+		return fmt.Errorf("depth writes enabled but format %v has no depth aspect", ds.Format)
+	}
+	if ds.DepthBias != 0 || ds.DepthBiasSlopeScale != 0 {
+		switch desc.Primitive.Topology {
+		case gputypes.PrimitiveTopologyTriangleList, gputypes.PrimitiveTopologyTriangleStrip:
+		default:
+			return fmt.Errorf("depth bias requires a triangle topology")
+		}
+	}
+	return nil
+}
+
+// stencilFaceOrDefault returns f, or a face that always passes and keeps the
+// stencil value when f is the zero value, so descriptors that leave stencil
+// state unset disable the stencil test instead of failing validation.
+func stencilFaceOrDefault(f StencilFaceState) StencilFaceState {
+	if f == (StencilFaceState{}) {
+		return defaultStencilFace()
+	}
+	return f
+}
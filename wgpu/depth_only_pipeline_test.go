@@ -0,0 +1,92 @@
+package wgpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestDepthOnlyPipelineDescriptor(t *testing.T) {
+	module := &ShaderModule{handle: 1}
+	desc := DepthOnlyPipelineDescriptor(nil, module, "vs_depth", nil,
+		gputypes.TextureFormatDepth32Float, DepthBias{Constant: 2, SlopeScale: 1.5})
+	if desc.Fragment != nil {
+		t.Error("Fragment should be nil")
+	}
+	ds := desc.DepthStencil
+	if ds == nil || !ds.DepthWriteEnabled || ds.DepthCompare != gputypes.CompareFunctionLess {
+		t.Fatalf("depth stencil = %+v", ds)
+	}
+	if ds.DepthBias != 2 || ds.DepthBiasSlopeScale != 1.5 {
+		t.Errorf("bias = %d/%v, want 2/1.5", ds.DepthBias, ds.DepthBiasSlopeScale)
+	}
+	if ds.StencilFront.Compare != gputypes.CompareFunctionAlways || ds.StencilBack.PassOp != gputypes.StencilOperationKeep {
+		t.Errorf("stencil faces should disable the stencil test: %+v / %+v", ds.StencilFront, ds.StencilBack)
+	}
+	if err := validateDepthStencil(desc); err != nil {
+		t.Errorf("validateDepthStencil: %v", err)
+	}
+}
+
+func TestNewDepthState(t *testing.T) {
+	ds := NewDepthState(gputypes.TextureFormatDepth24Plus, gputypes.CompareFunctionLessEqual, false)
+	if ds.Format != gputypes.TextureFormatDepth24Plus || ds.DepthCompare != gputypes.CompareFunctionLessEqual || ds.DepthWriteEnabled {
+		t.Errorf("depth state = %+v", ds)
+	}
+	if ds.StencilFront != defaultStencilFace() || ds.StencilBack != defaultStencilFace() {
+		t.Errorf("stencil faces = %+v / %+v, want the defaults", ds.StencilFront, ds.StencilBack)
+	}
+	if ds.StencilReadMask != 0xFFFFFFFF || ds.StencilWriteMask != 0xFFFFFFFF {
+		t.Errorf("stencil masks = %#x / %#x, want all ones", ds.StencilReadMask, ds.StencilWriteMask)
+	}
+	desc := &RenderPipelineDescriptor{DepthStencil: ds, Primitive: PrimitiveState{Topology: gputypes.PrimitiveTopologyTriangleList}}
+	if err := validateDepthStencil(desc); err != nil {
+		t.Errorf("validateDepthStencil: %v", err)
+	}
+}
+
+func TestValidateDepthStencil(t *testing.T) {
+	base := func() *RenderPipelineDescriptor {
+		return DepthOnlyPipelineDescriptor(nil, &ShaderModule{handle: 1}, "vs", nil,
+			gputypes.TextureFormatDepth24Plus, DepthBias{Constant: 1})
+	}
+	tests := []struct {
+		name    string
+		modify  func(*RenderPipelineDescriptor)
+		wantErr bool
+	}{
+		{"depth only", func(*RenderPipelineDescriptor) {}, false},
+		{"no outputs", func(d *RenderPipelineDescriptor) { d.DepthStencil = nil }, true},
+		{"color format", func(d *RenderPipelineDescriptor) { d.DepthStencil.Format = gputypes.TextureFormatRGBA8Unorm }, true},
+		{"missing compare", func(d *RenderPipelineDescriptor) { d.DepthStencil.DepthCompare = gputypes.CompareFunctionUndefined }, true},
+		{"stencil-only format with depth writes", func(d *RenderPipelineDescriptor) {
+			d.DepthStencil.Format = gputypes.TextureFormatStencil8
+			d.DepthStencil.DepthBias = 0
+		}, true},
+		{"bias on lines", func(d *RenderPipelineDescriptor) { d.Primitive.Topology = gputypes.PrimitiveTopologyLineList }, true},
+		{"lines without bias", func(d *RenderPipelineDescriptor) {
+			d.Primitive.Topology = gputypes.PrimitiveTopologyLineList
+			d.DepthStencil.DepthBias = 0
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desc := base()
+			tt.modify(desc)
+			if err := validateDepthStencil(desc); (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStencilFaceOrDefault(t *testing.T) {
+	got := stencilFaceOrDefault(StencilFaceState{})
+	if got.Compare != gputypes.CompareFunctionAlways || got.FailOp != gputypes.StencilOperationKeep {
+		t.Errorf("zero face = %+v, want Always/Keep", got)
+	}
+	set := StencilFaceState{Compare: gputypes.CompareFunctionEqual, PassOp: gputypes.StencilOperationReplace}
+	if stencilFaceOrDefault(set) != set {
+		t.Error("a configured face must be passed through unchanged")
+	}
+}
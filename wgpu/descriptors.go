@@ -17,10 +17,11 @@ func stringToStringView(s string) StringView {
 	if len(s) == 0 {
 		return EmptyStringView()
 	}
-	b := []byte(s)
+	// Point at the string itself rather than a copy: the copy would be
+	// unreachable from Go as soon as this function returns.
 	return StringView{
-		Data:   uintptr(unsafe.Pointer(&b[0])),
-		Length: uintptr(len(b)),
+		Data:   uintptr(unsafe.Pointer(unsafe.StringData(s))),
+		Length: uintptr(len(s)),
 	}
 }
 
@@ -1,6 +1,7 @@
 package wgpu
 
 import (
+	"runtime"
 	"sync"
 	"unsafe"
 
@@ -52,7 +53,7 @@ func handleDeviceCallback(status uintptr, device uintptr, message StringView, us
 	if ok && req != nil {
 		req.status = RequestDeviceStatus(status)
 		if device != 0 {
-			trackResource(device, "Device")
+			trackResource(device, "Device", "")
 			req.device = &Device{handle: device}
 		}
 		req.message = stringViewToString(message)
@@ -76,8 +77,9 @@ func (a *Adapter) RequestDevice(options *DeviceDescriptor) (*Device, error) {
 		return nil, &WGPUError{Op: "RequestDevice", Message: "adapter is nil or released"}
 	}
 
-	// Initialize callback once
+	// Initialize callbacks once
 	deviceCallbackOnce.Do(initDeviceCallback)
+	uncapturedErrorCallbackOnce.Do(initUncapturedErrorCallback)
 
 	// Create request state
 	req := &deviceRequest{
@@ -91,13 +93,19 @@ func (a *Adapter) RequestDevice(options *DeviceDescriptor) (*Device, error) {
 	deviceRequests[reqID] = req
 	deviceRequestsMu.Unlock()
 
-	// Convert Go-idiomatic descriptor to wire format.
-	var optionsPtr uintptr
+	// Convert Go-idiomatic descriptor to wire format. The descriptor is
+	// always passed, to install the uncaptured error callback.
 	var reqLimitsWire limitsWire // kept alive for the duration of the FFI call
+	var extras deviceExtras      // chained when a trace path is set
+	wire := deviceDescriptorWire{
+		UncapturedErrorCallbackInfo: UncapturedErrorCallbackInfo{
+			Callback:  uncapturedErrorCallbackPtr,
+			Userdata1: reqID,
+		},
+	}
+	errs := registerDeviceErrors(reqID)
 	if options != nil {
-		wire := deviceDescriptorWire{
-			Label: stringToStringView(options.Label),
-		}
+		wire.Label = stringToStringView(options.Label)
 		if len(options.RequiredFeatures) > 0 {
 			wire.RequiredFeatureCount = uintptr(len(options.RequiredFeatures))
 			wire.RequiredFeatures = uintptr(unsafe.Pointer(&options.RequiredFeatures[0]))
@@ -106,7 +114,13 @@ func (a *Adapter) RequestDevice(options *DeviceDescriptor) (*Device, error) {
 			reqLimitsWire = limitsToWire(options.RequiredLimits)
 			wire.RequiredLimits = uintptr(unsafe.Pointer(&reqLimitsWire))
 		}
-		optionsPtr = uintptr(unsafe.Pointer(&wire))
+		if options.TracePath != "" {
+			extras = deviceExtras{
+				Chain:     ChainedStruct{SType: uint32(STypeDeviceExtras)},
+				TracePath: stringToStringView(options.TracePath),
+			}
+			wire.NextInChain = uintptr(unsafe.Pointer(&extras))
+		}
 	}
 	_ = reqLimitsWire // ensure not optimised away before the call below
 
@@ -122,9 +136,11 @@ func (a *Adapter) RequestDevice(options *DeviceDescriptor) (*Device, error) {
 	// Call wgpuAdapterRequestDevice
 	procAdapterRequestDevice.Call( //nolint:errcheck
 		a.handle,
-		optionsPtr,
+		uintptr(unsafe.Pointer(&wire)),
 		uintptr(unsafe.Pointer(&callbackInfo)),
 	)
+	runtime.KeepAlive(&extras)
+	runtime.KeepAlive(options)
 
 	// Process events until callback fires
 	for {
@@ -132,6 +148,7 @@ func (a *Adapter) RequestDevice(options *DeviceDescriptor) (*Device, error) {
 		case <-req.done:
 			// Callback completed
 			if req.status != RequestDeviceStatusSuccess {
+				unregisterDeviceErrors(reqID)
 				msg := req.message
 				if msg == "" {
 					msg = "device request failed"
@@ -141,6 +158,7 @@ func (a *Adapter) RequestDevice(options *DeviceDescriptor) (*Device, error) {
 			// Cache limits at creation time so Limits() returns value without FFI.
 			if req.device != nil {
 				req.device.limits = fetchDeviceLimits(req.device.handle)
+				req.device.errors, req.device.errorsID = errs, reqID
 			}
 			return req.device, nil
 		default:
@@ -174,8 +192,8 @@ func (d *Device) Queue() *Queue {
 	if handle == 0 {
 		return nil
 	}
-	trackResource(handle, "Queue")
-	return &Queue{handle: handle}
+	trackResource(handle, "Queue", "")
+	return &Queue{handle: handle, device: d}
 }
 
 // Poll polls the device for completed work.
@@ -198,9 +216,14 @@ func (d *Device) Poll(wait bool) bool {
 // Release releases the device resources.
 func (d *Device) Release() {
 	if d.handle != 0 {
+		d.watchdog.Load().Stop()
+		releaseBlitCache(d.handle)
 		untrackResource(d.handle)
 		procDeviceRelease.Call(d.handle) //nolint:errcheck
 		d.handle = 0
+		if d.errors != nil {
+			unregisterDeviceErrors(d.errorsID)
+		}
 	}
 }
 
@@ -240,6 +263,12 @@ type DeviceDescriptor struct {
 	// RequiredLimits, if non-nil, specifies minimum resource limits the device must meet.
 	// Pass nil to use the adapter's default limits.
 	RequiredLimits *Limits
+	// TracePath, if set, is a directory where wgpu-native records a trace of
+	// every API call on the device, for attaching to wgpu bug reports. The
+	// library must be built with wgpu's "trace" feature; release builds
+	// ignore it and log a warning. Unlike [StartTrace], which records calls
+	// made through this package, the trace is replayable by wgpu's own tools.
+	TracePath string
 }
 
 // limitsToWire converts public Limits to the FFI-compatible limitsWire struct.
@@ -280,6 +309,7 @@ func limitsToWire(l *Limits) limitsWire {
 		MaxComputeWorkgroupSizeY:                  l.MaxComputeWorkgroupSizeY,
 		MaxComputeWorkgroupSizeZ:                  l.MaxComputeWorkgroupSizeZ,
 		MaxComputeWorkgroupsPerDimension:          l.MaxComputeWorkgroupsPerDimension,
+		MaxImmediateSize:                          l.MaxImmediateSize,
 	}
 }
 
@@ -297,6 +327,13 @@ type deviceDescriptorWire struct {
 	UncapturedErrorCallbackInfo UncapturedErrorCallbackInfo
 }
 
+// deviceExtras matches WGPUDeviceExtras in wgpu.h.
+// chain(16)+tracePath(16) = 32 bytes.
+type deviceExtras struct {
+	Chain     ChainedStruct
+	TracePath StringView
+}
+
 // QueueDescriptor configures queue creation.
 type QueueDescriptor struct {
 	NextInChain uintptr // *ChainedStruct
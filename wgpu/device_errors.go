@@ -0,0 +1,219 @@
+package wgpu
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/go-webgpu/goffi/ffi"
+)
+
+// GPUError is an error wgpu-native raised outside any error scope, such as
+// a validation error of a draw call or a queue submission. It is delivered
+// on the channel returned by [Device.Errors].
+type GPUError struct {
+	Type    ErrorType
+	Message string
+}
+
+// Error returns the message prefixed with the error type.
+func (e GPUError) Error() string {
+	return fmt.Sprintf("wgpu: uncaptured %s error: %s", e.Type, e.Message)
+}
+
+// Is reports whether target is the sentinel of the error type, so
+// errors.Is(err, ErrValidation) works as for [WGPUError].
+func (e GPUError) Is(target error) bool {
+	t, ok := target.(*WGPUError)
+	return ok && t.Op == "" && t.Message == "" && t.Type == e.Type
+}
+
+// errorsBuffer is the capacity of the channel returned by Device.Errors.
+const errorsBuffer = 64
+
+// deviceErrors receives the uncaptured errors of one device and holds its
+// out-of-memory handler.
+type deviceErrors struct {
+	mu     sync.Mutex
+	ch     chan GPUError // created by Device.Errors
+	closed bool
+	oom    OutOfMemoryHandler
+}
+
+var (
+	// deviceErrorSinks maps the userdata of each device's uncaptured error
+	// callback to its sink. Protected by deviceErrorSinksMu.
+	deviceErrorSinks   = make(map[uintptr]*deviceErrors)
+	deviceErrorSinksMu sync.Mutex
+
+	// uncapturedErrorOutput receives uncaptured errors that were neither
+	// received from Device.Errors nor logged; replaced in tests.
+	uncapturedErrorOutput io.Writer = os.Stderr
+
+	// uncapturedErrorCallbackPtr is the callback function pointer (created once).
+	uncapturedErrorCallbackPtr  uintptr
+	uncapturedErrorCallbackOnce sync.Once
+)
+
+// initUncapturedErrorCallback creates the platform-correct C callback function pointer.
+func initUncapturedErrorCallback() {
+	uncapturedErrorCallbackPtr = ffi.NewCallback(uncapturedErrorCallbackEntry)
+}
+
+// registerDeviceErrors returns the sink for the device requested with id.
+func registerDeviceErrors(id uintptr) *deviceErrors {
+	sink := &deviceErrors{}
+	deviceErrorSinksMu.Lock()
+	deviceErrorSinks[id] = sink
+	deviceErrorSinksMu.Unlock()
+	return sink
+}
+
+// unregisterDeviceErrors forgets the sink registered for id and closes its
+// channel.
+func unregisterDeviceErrors(id uintptr) {
+	deviceErrorSinksMu.Lock()
+	sink := deviceErrorSinks[id]
+	delete(deviceErrorSinks, id)
+	deviceErrorSinksMu.Unlock()
+	if sink != nil {
+		sink.close()
+	}
+}
+
+// handleUncapturedErrorCallback delivers an error after the platform
+// callback entry normalizes the ABI-specific WGPUStringView representation.
+func handleUncapturedErrorCallback(errType uintptr, message StringView, userdata1 uintptr) uintptr {
+	deviceErrorSinksMu.Lock()
+	sink := deviceErrorSinks[userdata1]
+	deviceErrorSinksMu.Unlock()
+	e := GPUError{Type: ErrorType(errType), Message: stringViewToString(message)}
+	if sink != nil && e.Type == ErrorTypeOutOfMemory {
+		if h := sink.outOfMemoryHandler(); h != nil {
+			// The callback runs inside a native call; freeing memory from
+			// it could reenter wgpu-native.
+			go h(e)
+		}
+	}
+	if sink == nil || !sink.send(e) {
+		reportUncapturedError(e)
+	}
+	return 0 // void return
+}
+
+// reportUncapturedError logs an error nobody received from Device.Errors.
+// Without a logger it is printed to standard error: wgpu-native would
+// abort on it, and it must not go unnoticed.
+func reportUncapturedError(e GPUError) {
+	if l := libLogger.Load(); l != nil {
+		l.Warn("wgpu: uncaptured GPU error", "type", e.Type, "message", e.Message)
+		return
+	}
+	fmt.Fprintln(uncapturedErrorOutput, e.Error()) //nolint:errcheck
+}
+
+// send queues e without blocking. It returns false when nobody asked for the
+// errors or the channel is full.
+func (s *deviceErrors) send(e GPUError) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ch == nil || s.closed {
+		return false
+	}
+	select {
+	case s.ch <- e:
+		return true
+	default:
+		return false
+	}
+}
+
+// channel returns the channel, creating it on first use.
+func (s *deviceErrors) channel() chan GPUError {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ch == nil {
+		s.ch = make(chan GPUError, errorsBuffer)
+		if s.closed {
+			close(s.ch)
+		}
+	}
+	return s.ch
+}
+
+func (s *deviceErrors) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		if s.ch != nil {
+			close(s.ch)
+		}
+	}
+}
+
+// Errors returns a channel receiving the errors wgpu-native raises for the
+// device outside any error scope. Without it such errors abort the process
+// in wgpu-native; with this package, errors raised before Errors is first
+// called or while the channel's buffer of 64 errors is full go to the
+// logger set with [SetLogger], or to standard error when none is set.
+//
+// Errors are raised during the call that caused them, so a render loop or a
+// test can check that a frame produced none by draining the channel after
+// submitting it:
+//
+//	queue.Submit(cmd)
+//	select {
+//	case err := <-device.Errors():
+//	    t.Fatal(err)
+//	default:
+//	}
+//
+// The channel is closed when the device is released. Errors returns nil
+// for a nil device or one not created by [Adapter.RequestDevice].
+func (d *Device) Errors() <-chan GPUError {
+	if d == nil || d.errors == nil {
+		return nil
+	}
+	return d.errors.channel()
+}
+
+// OutOfMemoryHandler frees GPU memory after an out-of-memory error, for
+// example by trimming a [TexturePool] or dropping cached staging buffers.
+// err is the error wgpu-native raised.
+//
+// When [Device.CreateBufferE], [Device.CreateTextureE] or
+// [Device.CreateQuerySetE] runs out of memory, the handler is called on the
+// creating goroutine and returning true retries the allocation once; its
+// error is returned if that fails too. For out-of-memory errors raised
+// outside those calls, such as by a queue submission or by CreateBuffer, the
+// handler runs on a goroutine of its own and its result is ignored.
+type OutOfMemoryHandler func(err error) (retry bool)
+
+// SetOutOfMemoryHandler sets the handler called when the device runs out of
+// memory, replacing any previous one. Pass nil to remove it.
+func (d *Device) SetOutOfMemoryHandler(h OutOfMemoryHandler) {
+	if d == nil || d.errors == nil {
+		return
+	}
+	d.errors.mu.Lock()
+	d.errors.oom = h
+	d.errors.mu.Unlock()
+}
+
+func (s *deviceErrors) outOfMemoryHandler() OutOfMemoryHandler {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.oom
+}
+
+// outOfMemory calls the out-of-memory handler of d for an allocation that
+// failed with err, reporting whether to retry it.
+func (d *Device) outOfMemory(err error) bool {
+	if d.errors == nil {
+		return false
+	}
+	h := d.errors.outOfMemoryHandler()
+	return h != nil && h(err)
+}
@@ -0,0 +1,118 @@
+package wgpu
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+	"unsafe"
+)
+
+// captureUncapturedErrorOutput redirects the standard error fallback of
+// uncaptured errors for the rest of the test.
+func captureUncapturedErrorOutput(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	old := uncapturedErrorOutput
+	uncapturedErrorOutput = &buf
+	t.Cleanup(func() { uncapturedErrorOutput = old })
+	return &buf
+}
+
+func TestDeviceErrors(t *testing.T) {
+	const id = uintptr(1 << 40)
+	d := &Device{errors: registerDeviceErrors(id), errorsID: id}
+	out := captureUncapturedErrorOutput(t)
+
+	// Errors raised before Errors is called are reported, not queued.
+	handleUncapturedErrorCallback(uintptr(ErrorTypeValidation), StringView{}, id)
+	if out.Len() == 0 {
+		t.Error("error raised before Errors was called was not reported")
+	}
+	ch := d.Errors()
+	if ch != d.Errors() {
+		t.Fatal("Errors returned a different channel on the second call")
+	}
+	select {
+	case e := <-ch:
+		t.Fatalf("received %v raised before Errors was called", e)
+	default:
+	}
+
+	for range errorsBuffer + 1 {
+		handleUncapturedErrorCallback(uintptr(ErrorTypeOutOfMemory), StringView{}, id)
+	}
+	if len(ch) != errorsBuffer {
+		t.Errorf("channel holds %d errors, want %d", len(ch), errorsBuffer)
+	}
+	if e := <-ch; !errors.Is(e, ErrOutOfMemory) || errors.Is(e, ErrValidation) {
+		t.Errorf("errors.Is(%v) does not match its type", e)
+	}
+
+	unregisterDeviceErrors(id)
+	for range ch {
+	}
+	if got := (&Device{}).Errors(); got != nil {
+		t.Error("Errors of a device without a sink is not nil")
+	}
+}
+
+func TestUncapturedErrorFallback(t *testing.T) {
+	t.Cleanup(func() { SetLogger(nil) })
+	out := captureUncapturedErrorOutput(t)
+
+	// Without a sink or a logger the error is printed.
+	message := []byte("Buffer is invalid")
+	handleUncapturedErrorCallback(uintptr(ErrorTypeValidation), StringView{Data: uintptr(unsafe.Pointer(&message[0])), Length: uintptr(len(message))}, 0)
+	if got, want := out.String(), "wgpu: uncaptured validation error: Buffer is invalid\n"; got != want {
+		t.Errorf("standard error output = %q, want %q", got, want)
+	}
+
+	// With a logger it is logged instead.
+	out.Reset()
+	var logged bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&logged, nil)))
+	handleUncapturedErrorCallback(uintptr(ErrorTypeValidation), StringView{}, 0)
+	if out.Len() != 0 || logged.Len() == 0 {
+		t.Errorf("with a logger: printed %q, logged %q", out, &logged)
+	}
+}
+
+func TestGPUErrorString(t *testing.T) {
+	e := GPUError{Type: ErrorTypeValidation, Message: "Buffer is invalid"}
+	if got, want := e.Error(), "wgpu: uncaptured validation error: Buffer is invalid"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestOutOfMemoryHandler(t *testing.T) {
+	const id = uintptr(1<<40 + 1)
+	d := &Device{errors: registerDeviceErrors(id), errorsID: id}
+	t.Cleanup(func() { unregisterDeviceErrors(id) })
+	captureUncapturedErrorOutput(t)
+
+	if d.outOfMemory(ErrOutOfMemory) {
+		t.Fatal("outOfMemory without a handler asked for a retry")
+	}
+
+	called := make(chan error, 1)
+	d.SetOutOfMemoryHandler(func(err error) bool {
+		called <- err
+		return true
+	})
+	if !d.outOfMemory(ErrOutOfMemory) {
+		t.Error("outOfMemory ignored the handler's retry")
+	}
+	<-called
+
+	// Uncaptured out-of-memory errors reach the handler too, asynchronously.
+	handleUncapturedErrorCallback(uintptr(ErrorTypeOutOfMemory), StringView{}, id)
+	if err := <-called; !errors.Is(err, ErrOutOfMemory) {
+		t.Errorf("handler got %v, want an out-of-memory error", err)
+	}
+	handleUncapturedErrorCallback(uintptr(ErrorTypeValidation), StringView{}, id)
+	select {
+	case err := <-called:
+		t.Errorf("handler called for %v", err)
+	default:
+	}
+}
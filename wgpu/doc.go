@@ -80,6 +80,47 @@
 //	buffer := device.CreateBuffer(&wgpu.BufferDescriptor{...})
 //	defer buffer.Release()
 //
+// [SetDebugMode] tracks live resources so leaks can be listed with
+// [LiveResources] and [ReportLeaks].
+//
+// # Debug Builds
+//
+// Building with the wgpudebug tag (go build -tags wgpudebug) adds checks
+// that would cost too much in release builds. Resource tracking records the
+// stack that created each resource, and descriptors are validated before
+// they reach wgpu-native: buffer and texture usages, texture sizes, mip
+// and sample counts, render pass attachment views and the bytesPerRow and
+// rowsPerImage of texture copies. Mistakes that would otherwise abort
+// inside the native library come back as validation errors naming the
+// field, from the call itself or, for encoder commands, from
+// [CommandEncoder.Finish].
+//
+// # Errors
+//
+// Constructors return an error for mistakes caught on the Go side, such as
+// a nil descriptor or a released parent object, and when wgpu-native
+// returns no object at all. For most invalid descriptors wgpu-native
+// instead returns an invalid object and raises a validation error, which
+// goes to the innermost error scope pushed with [Device.PushErrorScope] or,
+// outside any scope, to [Device.Errors].
+//
+// Constructors with an E suffix, such as [Device.CreateBufferE] and
+// [Device.CreateRenderPipelineE], return that error instead, releasing the
+// invalid object. They wrap the native call in an error scope of their own
+// and pop it by polling the device, so they cost a round trip, can run
+// pending callbacks such as those of [Buffer.MapAsync], and keep the error
+// from scopes pushed by the caller. Use them while loading assets or
+// building pipelines, not on per-frame paths.
+//
+// # Logging
+//
+// The package logs its own diagnostics, such as symbols missing from an
+// older wgpu-native, suboptimal surfaces and resources leaked at
+// [Instance.Release] in debug mode, to the [log/slog] logger set with
+// [SetLogger]. Until one is set it stays silent. GPU errors raised outside
+// an error scope are delivered on [Device.Errors], and otherwise logged or,
+// without a logger, printed to standard error.
+//
 // # Render Pipeline
 //
 // A typical render pipeline setup:
@@ -147,7 +188,25 @@
 //	defer bundle.Release()
 //
 //	// Later, in a render pass:
-//	renderPass.ExecuteBundles([]*wgpu.RenderBundle{bundle})
+//	renderPass.ExecuteBundles(bundle)
+//
+// # Multisampling
+//
+// Render into a multisampled texture and resolve into the surface view.
+// [MultisampleTarget] owns the MSAA texture; the pipeline's
+// [MultisampleState].Count must equal its sample count:
+//
+//	msaa, _ := device.NewMultisampleTarget(width, height, surfaceFormat, 4)
+//	defer msaa.Release()
+//
+//	pass, _ := encoder.BeginRenderPass(&wgpu.RenderPassDescriptor{
+//	    ColorAttachments: []wgpu.RenderPassColorAttachment{
+//	        msaa.ColorAttachment(surfaceView, wgpu.Color{A: 1}),
+//	    },
+//	})
+//
+// [CommandEncoder.BeginRenderPass] rejects attachments with mismatched sample
+// counts and resolve targets paired with single-sampled views.
 //
 // # Platform Support
 //
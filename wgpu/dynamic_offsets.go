@@ -0,0 +1,111 @@
+package wgpu
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gogpu/gputypes"
+)
+
+// dynamicBinding is a buffer binding of a bind group whose layout entry has
+// HasDynamicOffset set, recorded so SetBindGroup offsets can be checked.
+type dynamicBinding struct {
+	binding uint32
+	storage bool
+	// align is the device's minimum offset alignment for the binding type.
+	align uint32
+	// end is the offset of the end of the bound range; limit is the buffer
+	// size. Dynamic offsets may move the range by at most limit-end. limit
+	// is 0 when the buffer size is unknown.
+	end, limit uint64
+}
+
+// DynamicOffsetCount returns the number of dynamic offsets SetBindGroup takes
+// for bind groups with this layout: one per buffer entry with
+// HasDynamicOffset. It is 0 for layouts obtained from a pipeline, whose
+// entries are unknown.
+func (bgl *BindGroupLayout) DynamicOffsetCount() int {
+	n := 0
+	for i := range bgl.entries {
+		if b := bgl.entries[i].Buffer; b != nil && b.HasDynamicOffset {
+			n++
+		}
+	}
+	return n
+}
+
+// dynamicBindings returns the dynamic buffer bindings of a bind group, in
+// binding order as SetBindGroup expects their offsets. It returns nil when
+// the layout's entries are unknown.
+func dynamicBindings(layout *BindGroupLayout, entries []BindGroupEntry, limits *Limits) []dynamicBinding {
+	var dyn []dynamicBinding
+	for i := range layout.entries {
+		le := &layout.entries[i]
+		if le.Buffer == nil || !le.Buffer.HasDynamicOffset {
+			continue
+		}
+		db := dynamicBinding{binding: le.Binding, align: limits.MinUniformBufferOffsetAlignment}
+		if le.Buffer.Type != gputypes.BufferBindingTypeUniform {
+			db.storage = true
+			db.align = limits.MinStorageBufferOffsetAlignment
+		}
+		for j := range entries {
+			e := &entries[j]
+			if e.Binding != le.Binding || e.Buffer == nil {
+				continue
+			}
+			db.limit = e.Buffer.Size()
+			size := e.Size
+			if size == 0 && db.limit > e.Offset {
+				size = db.limit - e.Offset
+			}
+			db.end = e.Offset + size
+		}
+		dyn = append(dyn, db)
+	}
+	slices.SortFunc(dyn, func(a, b dynamicBinding) int { return int(a.binding) - int(b.binding) })
+	return dyn
+}
+
+// ValidateDynamicOffsets checks offsets as SetBindGroup would pass them for
+// this bind group: one per dynamic buffer binding in binding order, each a
+// multiple of the device's MinUniformBufferOffsetAlignment or
+// MinStorageBufferOffsetAlignment, and keeping the bound range inside its
+// buffer. Bind groups created with a layout obtained from a pipeline are not
+// checked.
+//
+// The SetBindGroup methods of pass and bundle encoders run this check and
+// skip the call on failure; the error is returned by Finish.
+func (bg *BindGroup) ValidateDynamicOffsets(offsets []uint32) error {
+	if bg == nil || !bg.dynamicKnown {
+		return nil
+	}
+	if len(offsets) != len(bg.dynamic) {
+		return fmt.Errorf("bind group %q takes %d dynamic offsets, got %d", bg.label, len(bg.dynamic), len(offsets))
+	}
+	for i, db := range bg.dynamic {
+		off := offsets[i]
+		if db.align != 0 && off%db.align != 0 {
+			limit := "MinUniformBufferOffsetAlignment"
+			if db.storage {
+				limit = "MinStorageBufferOffsetAlignment"
+			}
+			return fmt.Errorf("bind group %q binding %d: dynamic offset %d is not a multiple of %s (%d)",
+				bg.label, db.binding, off, limit, db.align)
+		}
+		if db.limit != 0 && db.end+uint64(off) > db.limit {
+			return fmt.Errorf("bind group %q binding %d: dynamic offset %d moves the binding past the end of its %d-byte buffer",
+				bg.label, db.binding, off, db.limit)
+		}
+	}
+	return nil
+}
+
+// checkBindGroupBatch checks the arguments of a SetBindGroups call: offsets
+// may have at most one list per group.
+func checkBindGroupBatch(groups []*BindGroup, offsets [][]uint32) error {
+	if len(offsets) > len(groups) {
+		return fmt.Errorf("%d dynamic offset lists for %d bind groups", len(offsets), len(groups))
+	}
+	return nil
+}
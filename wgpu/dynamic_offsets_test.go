@@ -0,0 +1,102 @@
+package wgpu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestDynamicOffsetCount(t *testing.T) {
+	layout := &BindGroupLayout{entries: []BindGroupLayoutEntry{
+		{Binding: 3, Buffer: &BufferBindingLayout{Type: gputypes.BufferBindingTypeStorage, HasDynamicOffset: true}},
+		{Binding: 0, Buffer: &BufferBindingLayout{Type: gputypes.BufferBindingTypeUniform, HasDynamicOffset: true}},
+		{Binding: 1, Buffer: &BufferBindingLayout{Type: gputypes.BufferBindingTypeUniform}},
+		{Binding: 2, Sampler: &SamplerBindingLayout{Type: gputypes.SamplerBindingTypeFiltering}},
+	}}
+	if got := layout.DynamicOffsetCount(); got != 2 {
+		t.Errorf("DynamicOffsetCount = %d, want 2", got)
+	}
+	if got := (&BindGroupLayout{pipelineOwned: true}).DynamicOffsetCount(); got != 0 {
+		t.Errorf("pipeline layout DynamicOffsetCount = %d, want 0", got)
+	}
+
+	limits := &Limits{MinUniformBufferOffsetAlignment: 256, MinStorageBufferOffsetAlignment: 32}
+	dyn := dynamicBindings(layout, nil, limits)
+	if len(dyn) != 2 || dyn[0].binding != 0 || dyn[1].binding != 3 {
+		t.Fatalf("dynamic bindings = %+v, want bindings 0 and 3 in order", dyn)
+	}
+	if dyn[0].align != 256 || dyn[1].align != 32 || !dyn[1].storage {
+		t.Errorf("alignments = %d/%d, want 256/32", dyn[0].align, dyn[1].align)
+	}
+}
+
+func TestValidateDynamicOffsets(t *testing.T) {
+	bg := &BindGroup{
+		label:        "per-object",
+		dynamicKnown: true,
+		dynamic: []dynamicBinding{
+			{binding: 0, align: 256, end: 64, limit: 1024},
+			{binding: 1, storage: true, align: 32, end: 128, limit: 0},
+		},
+	}
+	tests := []struct {
+		name    string
+		offsets []uint32
+		wantErr bool
+	}{
+		{"aligned", []uint32{512, 64}, false},
+		{"last object", []uint32{768, 0}, false},
+		{"too few", []uint32{0}, true},
+		{"too many", []uint32{0, 0, 0}, true},
+		{"misaligned uniform", []uint32{128, 0}, true},
+		{"misaligned storage", []uint32{0, 16}, true},
+		{"past the end", []uint32{1024, 0}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := bg.ValidateDynamicOffsets(tt.offsets); (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Bind groups whose layout entries are unknown are not checked.
+	if err := (&BindGroup{}).ValidateDynamicOffsets([]uint32{3}); err != nil {
+		t.Errorf("unknown layout: %v", err)
+	}
+	var nilGroup *BindGroup
+	if err := nilGroup.ValidateDynamicOffsets(nil); err != nil {
+		t.Errorf("nil bind group: %v", err)
+	}
+}
+
+func TestCommandEncoderRecordErr(t *testing.T) {
+	enc := &CommandEncoder{}
+	enc.recordErr("RenderPassEncoder.SetBindGroup", errors.New("first"))
+	enc.recordErr("RenderPassEncoder.SetBindGroup", errors.New("second"))
+	if enc.err == nil || enc.err.Error() != "wgpu: RenderPassEncoder.SetBindGroup: first" {
+		t.Errorf("err = %v, want the first error", enc.err)
+	}
+	var nilEnc *CommandEncoder
+	nilEnc.recordErr("op", errors.New("ignored"))
+}
+
+func TestSetBindGroupsOffsetCount(t *testing.T) {
+	enc := &CommandEncoder{}
+	rpe := &RenderPassEncoder{encoder: enc}
+	rpe.SetBindGroups(0, []*BindGroup{nil}, [][]uint32{nil, {256}})
+	if enc.err == nil {
+		t.Error("expected an error for more offset lists than groups")
+	}
+
+	rbe := &RenderBundleEncoder{}
+	rbe.SetBindGroups(0, nil, [][]uint32{{0}})
+	if rbe.Err() == nil {
+		t.Error("bundle encoder: expected an error for more offset lists than groups")
+	}
+	// Fewer offset lists than groups is fine; nil groups are skipped.
+	if err := checkBindGroupBatch([]*BindGroup{nil, nil}, [][]uint32{{0}}); err != nil {
+		t.Error(err)
+	}
+}
@@ -88,6 +88,8 @@ const (
 	// STypeTextureComponentSwizzleDescriptor identifies a texture component swizzle descriptor. New in v29.
 	STypeTextureComponentSwizzleDescriptor SType = 0x0000000C
 	// STypeExternalTextureBindingLayout identifies an external texture binding layout. New in v29.
+	// wgpu-native exports no function creating external textures, so this
+	// package has no binding path for them.
 	STypeExternalTextureBindingLayout SType = 0x0000000D
 	// STypeExternalTextureBindingEntry identifies an external texture binding entry. New in v29.
 	STypeExternalTextureBindingEntry SType = 0x0000000E
@@ -179,6 +181,27 @@ const (
 	QueryTypeOcclusion QueryType = 0x00000001
 	// QueryTypeTimestamp specifies timestamp queries for GPU profiling.
 	QueryTypeTimestamp QueryType = 0x00000002
+	// QueryTypePipelineStatistics specifies pipeline statistics queries
+	// (WGPUNativeQueryType_PipelineStatistics). Requires
+	// NativeFeaturePipelineStatisticsQuery.
+	QueryTypePipelineStatistics QueryType = 0x00030000
+)
+
+// PipelineStatisticName selects a counter recorded by a pipeline statistics
+// query. wgpu-native extension.
+type PipelineStatisticName uint32
+
+const (
+	// PipelineStatisticVertexShaderInvocations counts vertex shader invocations.
+	PipelineStatisticVertexShaderInvocations PipelineStatisticName = 0x00000000
+	// PipelineStatisticClipperInvocations counts primitives entering clipping.
+	PipelineStatisticClipperInvocations PipelineStatisticName = 0x00000001
+	// PipelineStatisticClipperPrimitivesOut counts primitives leaving clipping.
+	PipelineStatisticClipperPrimitivesOut PipelineStatisticName = 0x00000002
+	// PipelineStatisticFragmentShaderInvocations counts fragment shader invocations.
+	PipelineStatisticFragmentShaderInvocations PipelineStatisticName = 0x00000003
+	// PipelineStatisticComputeShaderInvocations counts compute shader invocations.
+	PipelineStatisticComputeShaderInvocations PipelineStatisticName = 0x00000004
 )
 
 // FeatureName describes a WebGPU feature that can be requested.
@@ -203,7 +226,9 @@ const (
 	FeatureNameTextureCompressionASTCSliced3D FeatureName = 0x00000008
 	// FeatureNameTimestampQuery enables timestamp query support.
 	FeatureNameTimestampQuery FeatureName = 0x00000009
-	// FeatureNameIndirectFirstInstance enables indirect first instance.
+	// FeatureNameIndirectFirstInstance allows a non-zero FirstInstance in
+	// indirect draw arguments. Without it such draws are skipped; see
+	// [Device.WriteDrawIndirect].
 	FeatureNameIndirectFirstInstance FeatureName = 0x0000000A
 	// FeatureNameShaderF16 enables f16 in shaders.
 	FeatureNameShaderF16 FeatureName = 0x0000000B
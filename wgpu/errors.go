@@ -1,6 +1,7 @@
 package wgpu
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 	"unsafe"
@@ -135,6 +136,12 @@ func (d *Device) PopErrorScopeAsync(instance *Instance) (ErrorType, string, erro
 		return ErrorTypeNoError, "", &WGPUError{Op: "PopErrorScopeAsync", Message: "instance is required for PopErrorScope"}
 	}
 
+	return d.popErrorScope("PopErrorScopeAsync", CallbackModeAllowProcessEvents, instance.ProcessEvents)
+}
+
+// popErrorScope pops the current error scope, calling pump until the
+// callback fires.
+func (d *Device) popErrorScope(op string, mode CallbackMode, pump func()) (ErrorType, string, error) {
 	// Initialize callback once
 	errorScopeCallbackOnce.Do(initErrorScopeCallback)
 
@@ -153,7 +160,7 @@ func (d *Device) PopErrorScopeAsync(instance *Instance) (ErrorType, string, erro
 	// Prepare callback info
 	callbackInfo := popErrorScopeCallbackInfo{
 		nextInChain: 0,
-		mode:        CallbackModeAllowProcessEvents,
+		mode:        mode,
 		callback:    errorScopeCallbackPtr,
 		userdata1:   resultID,
 		userdata2:   0,
@@ -167,8 +174,6 @@ func (d *Device) PopErrorScopeAsync(instance *Instance) (ErrorType, string, erro
 		uintptr(unsafe.Pointer(&callbackInfo)),
 	)
 
-	// Process events until callback fires
-	// With CallbackModeAllowProcessEvents, we need to call ProcessEvents
 	for {
 		select {
 		case <-result.done:
@@ -176,17 +181,56 @@ func (d *Device) PopErrorScopeAsync(instance *Instance) (ErrorType, string, erro
 			if result.status != PopErrorScopeStatusSuccess {
 				switch result.status {
 				case PopErrorScopeStatusEmptyStack:
-					return ErrorTypeNoError, "", &WGPUError{Op: "PopErrorScopeAsync", Message: "error scope stack is empty"}
+					return ErrorTypeNoError, "", &WGPUError{Op: op, Message: "error scope stack is empty"}
 				case PopErrorScopeStatusInstanceDropped:
-					return ErrorTypeNoError, "", &WGPUError{Op: "PopErrorScopeAsync", Message: "instance was dropped"}
+					return ErrorTypeNoError, "", &WGPUError{Op: op, Message: "instance was dropped"}
 				default:
-					return ErrorTypeNoError, "", &WGPUError{Op: "PopErrorScopeAsync", Message: fmt.Sprintf("pop error scope failed with status %d", result.status)}
+					return ErrorTypeNoError, "", &WGPUError{Op: op, Message: fmt.Sprintf("pop error scope failed with status %d", result.status)}
 				}
 			}
 			return result.errType, result.message, nil
 		default:
-			// Process events to fire callbacks
-			instance.ProcessEvents()
+			pump()
 		}
 	}
 }
+
+// captureValidation runs create inside a validation error scope and returns
+// the message of the first validation error it produced, if any. The scope is
+// popped by polling the device, so no Instance is needed.
+func (d *Device) captureValidation(op string, create func()) error {
+	d.PushErrorScope(ErrorFilterValidation)
+	create()
+	errType, message, err := d.popErrorScope(op, CallbackModeAllowSpontaneous, func() { d.Poll(false) })
+	if err != nil || errType == ErrorTypeNoError {
+		return nil //nolint:nilerr // a failed pop leaves errors to the uncaptured error callback
+	}
+	return &WGPUError{Op: op, Type: errType, Message: message}
+}
+
+// captureAllocation is captureValidation for calls that allocate GPU
+// memory: an enclosing out-of-memory scope also catches allocation
+// failures, which are returned when validation passed.
+func (d *Device) captureAllocation(op string, create func()) error {
+	d.PushErrorScope(ErrorFilterOutOfMemory)
+	err := d.captureValidation(op, create)
+	errType, message, popErr := d.popErrorScope(op, CallbackModeAllowSpontaneous, func() { d.Poll(false) })
+	if err == nil && popErr == nil && errType != ErrorTypeNoError {
+		err = &WGPUError{Op: op, Type: errType, Message: message}
+	}
+	return err
+}
+
+// allocate runs create with captureAllocation and returns the handle it
+// produced. After an out-of-memory error it calls the device's
+// [OutOfMemoryHandler]; when that asks for a retry, the invalid object is
+// released with release and create runs once more.
+func (d *Device) allocate(op string, create func() uintptr, release func(uintptr)) (uintptr, error) {
+	var handle uintptr
+	err := d.captureAllocation(op, func() { handle = create() })
+	if handle != 0 && errors.Is(err, ErrOutOfMemory) && d.outOfMemory(err) {
+		release(handle)
+		err = d.captureAllocation(op, func() { handle = create() })
+	}
+	return handle, err
+}
@@ -0,0 +1,233 @@
+// Package framestats collects per-frame performance data for a HUD or log:
+// frame time and FPS on the CPU side, GPU time from [gputimer] results, and
+// how often acquiring the surface texture did not simply succeed. A
+// [Limiter] caps the frame rate when vsync is off or unavailable.
+//
+//	stats := framestats.New(120) // average over the last 120 frames
+//	limit := framestats.NewLimiter(60)
+//	for running {
+//	    limit.Wait()
+//	    stats.BeginFrame()
+//	    st, suboptimal, err := surface.GetCurrentTexture()
+//	    stats.RecordAcquire(suboptimal, err)
+//	    // ... render, submit, present ...
+//	    results, _ := timer.Collect(queue)
+//	    stats.AddGPUResults(results)
+//	    stats.EndFrame()
+//
+//	    s := stats.Snapshot()
+//	    hud.Printf("%.0f fps  cpu %v  gpu %v", s.FPS, s.FrameTime, s.GPUTime)
+//	}
+package framestats
+
+import (
+	"errors"
+	"time"
+
+	"github.com/go-webgpu/webgpu/wgpu"
+	"github.com/go-webgpu/webgpu/wgpu/gputimer"
+)
+
+// Stats is a snapshot of a [Collector]. Durations are averages over the
+// collector's window.
+type Stats struct {
+	// Frames is the number of frames begun since the collector was created.
+	Frames uint64
+	// FPS is the frame rate implied by FrameTime.
+	FPS float64
+	// FrameTime is the time between the starts of consecutive frames, and
+	// FrameTimeMax its largest value in the window.
+	FrameTime    time.Duration
+	FrameTimeMax time.Duration
+	// CPUTime is the time between BeginFrame and EndFrame.
+	CPUTime time.Duration
+	// GPUTime is the summed duration of the GPU sections of a frame; zero
+	// when no GPU results were added.
+	GPUTime time.Duration
+
+	// Acquire counts the outcomes of RecordAcquire.
+	Acquire AcquireCounts
+}
+
+// AcquireCounts counts surface texture acquisitions by outcome.
+type AcquireCounts struct {
+	Optimal    uint64
+	Suboptimal uint64
+	Outdated   uint64
+	Lost       uint64
+	Timeout    uint64
+	Occluded   uint64
+	Failed     uint64 // any other error
+}
+
+// Collector accumulates frame statistics over a sliding window of frames.
+// A Collector is not safe for concurrent use.
+type Collector struct {
+	frame   window
+	cpu     window
+	gpu     window
+	frames  uint64
+	start   time.Time // start of the current frame
+	acquire AcquireCounts
+
+	now func() time.Time
+}
+
+// New returns a collector averaging over the last n frames (at least 1).
+func New(n int) *Collector {
+	n = max(n, 1)
+	return &Collector{
+		frame: newWindow(n),
+		cpu:   newWindow(n),
+		gpu:   newWindow(n),
+		now:   time.Now,
+	}
+}
+
+// BeginFrame marks the start of a frame.
+func (c *Collector) BeginFrame() {
+	now := c.now()
+	if c.frames > 0 {
+		c.frame.add(now.Sub(c.start))
+	}
+	c.start = now
+	c.frames++
+}
+
+// EndFrame marks the end of the CPU work of the frame begun last.
+func (c *Collector) EndFrame() {
+	if c.frames > 0 {
+		c.cpu.add(c.now().Sub(c.start))
+	}
+}
+
+// AddGPUTime records the GPU time of a frame.
+func (c *Collector) AddGPUTime(d time.Duration) { c.gpu.add(d) }
+
+// AddGPUResults records the summed durations of results as the GPU time of
+// a frame. Nothing is recorded for an empty slice.
+func (c *Collector) AddGPUResults(results []gputimer.Result) {
+	if len(results) == 0 {
+		return
+	}
+	var total time.Duration
+	for _, r := range results {
+		total += r.Duration
+	}
+	c.gpu.add(total)
+}
+
+// RecordAcquire counts the outcome of a [wgpu.Surface.GetCurrentTexture]
+// call from its suboptimal flag and error.
+func (c *Collector) RecordAcquire(suboptimal bool, err error) {
+	a := &c.acquire
+	switch {
+	case err == nil && suboptimal:
+		a.Suboptimal++
+	case err == nil:
+		a.Optimal++
+	case errors.Is(err, wgpu.ErrSurfaceNeedsReconfigure):
+		a.Outdated++
+	case errors.Is(err, wgpu.ErrSurfaceLost):
+		a.Lost++
+	case errors.Is(err, wgpu.ErrSurfaceTimeout):
+		a.Timeout++
+	case errors.Is(err, wgpu.ErrSurfaceOccluded):
+		a.Occluded++
+	default:
+		a.Failed++
+	}
+}
+
+// Snapshot returns the current statistics.
+func (c *Collector) Snapshot() Stats {
+	s := Stats{
+		Frames:       c.frames,
+		FrameTime:    c.frame.mean(),
+		FrameTimeMax: c.frame.max(),
+		CPUTime:      c.cpu.mean(),
+		GPUTime:      c.gpu.mean(),
+		Acquire:      c.acquire,
+	}
+	if s.FrameTime > 0 {
+		s.FPS = float64(time.Second) / float64(s.FrameTime)
+	}
+	return s
+}
+
+// window is a ring of the last len(samples) durations.
+type window struct {
+	samples []time.Duration
+	next    int
+	full    bool
+	sum     time.Duration
+}
+
+func newWindow(n int) window { return window{samples: make([]time.Duration, n)} }
+
+func (w *window) add(d time.Duration) {
+	w.sum += d - w.samples[w.next]
+	w.samples[w.next] = d
+	w.next++
+	if w.next == len(w.samples) {
+		w.next, w.full = 0, true
+	}
+}
+
+func (w *window) count() int {
+	if w.full {
+		return len(w.samples)
+	}
+	return w.next
+}
+
+func (w *window) mean() time.Duration {
+	n := w.count()
+	if n == 0 {
+		return 0
+	}
+	return w.sum / time.Duration(n)
+}
+
+func (w *window) max() time.Duration {
+	var m time.Duration
+	for _, d := range w.samples[:w.count()] {
+		m = max(m, d)
+	}
+	return m
+}
+
+// Limiter paces a loop to a target frame rate.
+type Limiter struct {
+	interval time.Duration
+	next     time.Time
+
+	now   func() time.Time
+	sleep func(time.Duration)
+}
+
+// NewLimiter returns a limiter for fps frames per second. A non-positive
+// fps disables limiting.
+func NewLimiter(fps float64) *Limiter {
+	l := &Limiter{now: time.Now, sleep: time.Sleep}
+	if fps > 0 {
+		l.interval = time.Duration(float64(time.Second) / fps)
+	}
+	return l
+}
+
+// Wait blocks until the next frame is due. A frame that runs late starts
+// the schedule again from now rather than rushing to catch up.
+func (l *Limiter) Wait() {
+	if l.interval <= 0 {
+		return
+	}
+	now := l.now()
+	if l.next.IsZero() || now.Sub(l.next) > l.interval {
+		l.next = now
+	}
+	if d := l.next.Sub(now); d > 0 {
+		l.sleep(d)
+	}
+	l.next = l.next.Add(l.interval)
+}
@@ -0,0 +1,95 @@
+package framestats
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-webgpu/webgpu/wgpu"
+	"github.com/go-webgpu/webgpu/wgpu/gputimer"
+)
+
+// fakeClock is advanced by hand.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func TestCollectorTimes(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	c := New(2)
+	c.now = clock.now
+
+	for _, frame := range []time.Duration{10, 20, 30} {
+		c.BeginFrame()
+		clock.advance(4 * time.Millisecond)
+		c.EndFrame()
+		clock.advance(frame*time.Millisecond - 4*time.Millisecond)
+	}
+	c.BeginFrame()
+	c.AddGPUResults([]gputimer.Result{{Duration: 2 * time.Millisecond}, {Duration: 3 * time.Millisecond}})
+
+	s := c.Snapshot()
+	if s.Frames != 4 {
+		t.Errorf("Frames = %d, want 4", s.Frames)
+	}
+	// The window holds the last two frame times, 20ms and 30ms.
+	if s.FrameTime != 25*time.Millisecond || s.FrameTimeMax != 30*time.Millisecond {
+		t.Errorf("FrameTime = %v, max %v; want 25ms, 30ms", s.FrameTime, s.FrameTimeMax)
+	}
+	if s.FPS != 40 {
+		t.Errorf("FPS = %v, want 40", s.FPS)
+	}
+	if s.CPUTime != 4*time.Millisecond {
+		t.Errorf("CPUTime = %v, want 4ms", s.CPUTime)
+	}
+	if s.GPUTime != 5*time.Millisecond {
+		t.Errorf("GPUTime = %v, want 5ms", s.GPUTime)
+	}
+}
+
+func TestCollectorEmpty(t *testing.T) {
+	s := New(0).Snapshot()
+	if s.FPS != 0 || s.FrameTime != 0 || s.GPUTime != 0 {
+		t.Errorf("empty snapshot = %+v", s)
+	}
+}
+
+func TestRecordAcquire(t *testing.T) {
+	c := New(1)
+	c.RecordAcquire(false, nil)
+	c.RecordAcquire(true, nil)
+	c.RecordAcquire(false, wgpu.ErrSurfaceNeedsReconfigure)
+	c.RecordAcquire(false, wgpu.ErrSurfaceLost)
+	c.RecordAcquire(false, wgpu.ErrSurfaceTimeout)
+	c.RecordAcquire(false, wgpu.ErrSurfaceOccluded)
+	c.RecordAcquire(false, errors.New("boom"))
+	want := AcquireCounts{Optimal: 1, Suboptimal: 1, Outdated: 1, Lost: 1, Timeout: 1, Occluded: 1, Failed: 1}
+	if got := c.Snapshot().Acquire; got != want {
+		t.Errorf("Acquire = %+v, want %+v", got, want)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	var slept []time.Duration
+	l := NewLimiter(50) // 20ms
+	l.now = clock.now
+	l.sleep = func(d time.Duration) { slept = append(slept, d); clock.advance(d) }
+
+	l.Wait() // first frame starts immediately
+	clock.advance(5 * time.Millisecond)
+	l.Wait() // waits out the rest of the 20ms
+	clock.advance(100 * time.Millisecond)
+	l.Wait() // late: no sleep, schedule restarts
+
+	if len(slept) != 1 || slept[0] != 15*time.Millisecond {
+		t.Errorf("slept = %v, want [15ms]", slept)
+	}
+}
+
+func TestLimiterDisabled(t *testing.T) {
+	l := NewLimiter(0)
+	l.sleep = func(time.Duration) { t.Fatal("disabled limiter slept") }
+	l.Wait()
+}
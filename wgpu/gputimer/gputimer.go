@@ -0,0 +1,161 @@
+// Package gputimer measures GPU time spent in labeled sections of a frame
+// using timestamp queries.
+//
+// A [Timer] owns a timestamp query set with room for a fixed number of
+// sections. Time whole passes by putting the writes returned by [Timer.Pass]
+// in the pass descriptor, or arbitrary encoder ranges with [Timer.Begin] and
+// [Timer.End], then read every section back after submitting:
+//
+//	t, _ := gputimer.New(device, 8)
+//	defer t.Release()
+//
+//	writes, _ := t.Pass("shadow")
+//	pass, _ := encoder.BeginRenderPass(&wgpu.RenderPassDescriptor{
+//	    DepthStencilAttachment: ..., TimestampWrites: writes,
+//	})
+//	// ... draw, End, Finish, Submit ...
+//
+//	results, _ := t.Collect(queue)
+//	for _, r := range results {
+//	    fmt.Printf("%s: %v\n", r.Label, r.Duration)
+//	}
+//
+// The device needs wgpu.FeatureNameTimestampQuery; Begin and End also need
+// wgpu.NativeFeatureTimestampQueryInsideEncoders.
+package gputimer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-webgpu/webgpu/wgpu"
+)
+
+// Result is the GPU time measured for one section.
+type Result struct {
+	Label    string
+	Duration time.Duration
+}
+
+// Timer records up to a fixed number of timed sections per Collect.
+type Timer struct {
+	device   *wgpu.Device
+	querySet *wgpu.QuerySet
+	scopes   scopes
+}
+
+// scopes tracks the labels of recorded sections and the one begun on an
+// encoder and not yet ended. Section i uses queries 2i and 2i+1.
+type scopes struct {
+	capacity int
+	labels   []string
+	open     int // index of the section begun by Begin, or -1
+}
+
+// New creates a Timer for up to n sections between calls to Collect.
+func New(device *wgpu.Device, n int) (*Timer, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("gputimer: section count %d must be positive", n)
+	}
+	if !device.HasFeature(wgpu.FeatureNameTimestampQuery) {
+		return nil, fmt.Errorf("gputimer: device lacks FeatureNameTimestampQuery")
+	}
+	qs, err := device.CreateQuerySet(&wgpu.QuerySetDescriptor{
+		Label: "gputimer",
+		Type:  wgpu.QueryTypeTimestamp,
+		Count: uint32(2 * n),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Timer{device: device, querySet: qs, scopes: scopes{capacity: n, open: -1}}, nil
+}
+
+// reserve adds a section named label and returns its index.
+func (s *scopes) reserve(label string) (int, error) {
+	if len(s.labels) == s.capacity {
+		return 0, fmt.Errorf("gputimer: all %d sections in use; call Collect first", s.capacity)
+	}
+	s.labels = append(s.labels, label)
+	return len(s.labels) - 1, nil
+}
+
+// Pass reserves a section named label and returns the timestamp writes
+// that time a render or compute pass, to be set as the TimestampWrites of
+// its descriptor.
+func (t *Timer) Pass(label string) (*wgpu.PassTimestampWrites, error) {
+	i, err := t.scopes.reserve(label)
+	if err != nil {
+		return nil, err
+	}
+	return &wgpu.PassTimestampWrites{
+		QuerySet:                  t.querySet,
+		BeginningOfPassWriteIndex: uint32(2 * i),
+		EndOfPassWriteIndex:       uint32(2*i + 1),
+	}, nil
+}
+
+// Begin starts a section named label on enc, outside of any pass. Sections
+// begun with Begin do not nest: End the current one first.
+func (t *Timer) Begin(enc *wgpu.CommandEncoder, label string) error {
+	if t.scopes.open >= 0 {
+		return fmt.Errorf("gputimer: section %q is still open", t.scopes.labels[t.scopes.open])
+	}
+	i, err := t.scopes.reserve(label)
+	if err != nil {
+		return err
+	}
+	t.scopes.open = i
+	enc.WriteTimestamp(t.querySet, uint32(2*i))
+	return nil
+}
+
+// End ends the section started by Begin.
+func (t *Timer) End(enc *wgpu.CommandEncoder) error {
+	if t.scopes.open < 0 {
+		return fmt.Errorf("gputimer: End without Begin")
+	}
+	enc.WriteTimestamp(t.querySet, uint32(2*t.scopes.open+1))
+	t.scopes.open = -1
+	return nil
+}
+
+// Collect reads back every section recorded since the previous Collect, in
+// the order they were reserved, and frees them for reuse. The command
+// buffers that recorded them must already be submitted to queue.
+func (t *Timer) Collect(queue *wgpu.Queue) ([]Result, error) {
+	if t.scopes.open >= 0 {
+		return nil, fmt.Errorf("gputimer: section %q is still open", t.scopes.labels[t.scopes.open])
+	}
+	labels := t.scopes.labels
+	t.scopes.labels = t.scopes.labels[:0]
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	values, err := wgpu.ResolveAndReadQueries(t.device, queue, t.querySet, 0, uint32(2*len(labels)))
+	if err != nil {
+		return nil, err
+	}
+	period := queue.GetTimestampPeriod()
+	if period <= 0 {
+		period = 1
+	}
+	return results(labels, values, period), nil
+}
+
+// results pairs each label with the interval between its two timestamps.
+func results(labels []string, values []uint64, period float32) []Result {
+	out := make([]Result, len(labels))
+	for i, label := range labels {
+		out[i] = Result{Label: label, Duration: wgpu.TimestampDuration(values[2*i], values[2*i+1], period)}
+	}
+	return out
+}
+
+// Release releases the query set. The Timer cannot be used afterwards.
+func (t *Timer) Release() {
+	if t.querySet != nil {
+		t.querySet.Release()
+		t.querySet = nil
+	}
+}
@@ -0,0 +1,29 @@
+package gputimer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScopesReserve(t *testing.T) {
+	s := scopes{capacity: 2, open: -1}
+	for i, label := range []string{"shadow", "main"} {
+		got, err := s.reserve(label)
+		if err != nil || got != i {
+			t.Fatalf("reserve(%q) = %d, %v; want %d", label, got, err, i)
+		}
+	}
+	if _, err := s.reserve("post"); err == nil {
+		t.Error("reserve past capacity: expected an error")
+	}
+}
+
+func TestResults(t *testing.T) {
+	got := results([]string{"shadow", "main"}, []uint64{100, 300, 300, 1300}, 2)
+	want := []Result{{"shadow", 400 * time.Nanosecond}, {"main", 2 * time.Microsecond}}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("results[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package wgpu
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/gogpu/gputypes"
+)
+
+// HeadlessSurfaceDescriptor describes a [HeadlessSurface].
+type HeadlessSurfaceDescriptor struct {
+	Label  string
+	Width  uint32
+	Height uint32
+	// Format defaults to BGRA8Unorm, the format most surfaces prefer.
+	Format gputypes.TextureFormat
+	// Usage defaults to RenderAttachment. CopySrc is added when Sink is set.
+	Usage gputypes.TextureUsage
+	// BufferCount is the number of textures in the ring, 3 if zero.
+	BufferCount int
+	// Sink receives every presented frame. Frames are discarded if nil.
+	Sink FrameSink
+}
+
+// FrameSink receives the frames presented to a [HeadlessSurface]. frame
+// counts presented frames from zero.
+type FrameSink interface {
+	WriteFrame(frame uint64, img *image.NRGBA) error
+}
+
+// FrameSinkFunc adapts a function to a [FrameSink].
+type FrameSinkFunc func(frame uint64, img *image.NRGBA) error
+
+// WriteFrame calls f.
+func (f FrameSinkFunc) WriteFrame(frame uint64, img *image.NRGBA) error { return f(frame, img) }
+
+// PNGFrameSink returns a sink writing each frame to dir as
+// frame-000000.png, frame-000001.png, ...
+func PNGFrameSink(dir string) FrameSink {
+	return FrameSinkFunc(func(frame uint64, img *image.NRGBA) error {
+		f, err := os.Create(filepath.Join(dir, fmt.Sprintf("frame-%06d.png", frame)))
+		if err != nil {
+			return err
+		}
+		if err := png.Encode(f, img); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	})
+}
+
+// RawFrameSink returns a sink writing each frame to w as tightly packed
+// RGBA rows. This is the rawvideo input of video encoders, for example
+//
+//	ffmpeg -f rawvideo -pixel_format rgba -video_size 640x480 -framerate 60 -i - out.mp4
+func RawFrameSink(w io.Writer) FrameSink {
+	return FrameSinkFunc(func(_ uint64, img *image.NRGBA) error {
+		_, err := w.Write(img.Pix)
+		return err
+	})
+}
+
+// HeadlessSurface is a virtual swapchain: a ring of textures with the
+// GetCurrentTexture and Present methods of [Surface], for rendering on
+// servers and testing windowed render loops in CI without a display.
+//
+//	hs, _ := wgpu.NewHeadlessSurface(device, queue, &wgpu.HeadlessSurfaceDescriptor{
+//	    Width: 640, Height: 480, Sink: wgpu.PNGFrameSink("frames"),
+//	})
+//	defer hs.Release()
+//
+//	st, _, err := hs.GetCurrentTexture()
+//	// ... render to st.Texture, submit ...
+//	err = hs.Present()
+//	st.Texture.Release()
+//
+// As with a surface, the texture returned by GetCurrentTexture is released
+// by the caller; the ring keeps its own reference. A HeadlessSurface is not
+// safe for concurrent use.
+type HeadlessSurface struct {
+	device  *Device
+	queue   *Queue
+	desc    HeadlessSurfaceDescriptor
+	ring    []*Texture
+	next    int // ring index handed out by the next GetCurrentTexture
+	current int // ring index acquired and not yet presented, or -1
+	frames  uint64
+}
+
+// NewHeadlessSurface creates a headless surface of desc.Width x desc.Height
+// textures. queue is used to read presented frames back for desc.Sink.
+func NewHeadlessSurface(device *Device, queue *Queue, desc *HeadlessSurfaceDescriptor) (*HeadlessSurface, error) {
+	const op = "NewHeadlessSurface"
+	if err := checkInit(); err != nil {
+		return nil, err
+	}
+	if device == nil || device.handle == 0 || queue == nil || queue.handle == 0 {
+		return nil, &WGPUError{Op: op, Message: "device or queue is nil or released"}
+	}
+	if desc == nil {
+		return nil, &WGPUError{Op: op, Message: "descriptor is nil"}
+	}
+	d, err := headlessDefaults(desc)
+	if err != nil {
+		return nil, &WGPUError{Op: op, Type: ErrorTypeValidation, Message: err.Error()}
+	}
+	h := &HeadlessSurface{device: device, queue: queue, desc: d, current: -1}
+	if err := h.createRing(d.Width, d.Height); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// headlessDefaults validates desc and fills in its defaults.
+func headlessDefaults(desc *HeadlessSurfaceDescriptor) (HeadlessSurfaceDescriptor, error) {
+	d := *desc
+	if d.Width == 0 || d.Height == 0 {
+		return d, fmt.Errorf("size %dx%d must be non-zero", d.Width, d.Height)
+	}
+	if d.BufferCount < 0 {
+		return d, fmt.Errorf("negative buffer count %d", d.BufferCount)
+	}
+	if d.BufferCount == 0 {
+		d.BufferCount = 3
+	}
+	if d.Format == gputypes.TextureFormatUndefined {
+		d.Format = gputypes.TextureFormatBGRA8Unorm
+	}
+	if d.Usage == 0 {
+		d.Usage = gputypes.TextureUsageRenderAttachment
+	}
+	if d.Sink != nil {
+		if _, ok := readbackFormat(d.Format); !ok {
+			return d, fmt.Errorf("a frame sink needs an 8-bit RGBA or BGRA format, not %v", d.Format)
+		}
+		d.Usage |= gputypes.TextureUsageCopySrc
+	}
+	return d, nil
+}
+
+// createRing replaces the ring with BufferCount textures of width x height.
+func (h *HeadlessSurface) createRing(width, height uint32) error {
+	ring := make([]*Texture, 0, h.desc.BufferCount)
+	for range h.desc.BufferCount {
+		tex, err := h.device.CreateTexture(&TextureDescriptor{
+			Label:         h.desc.Label,
+			Usage:         h.desc.Usage,
+			Dimension:     gputypes.TextureDimension2D,
+			Size:          gputypes.Extent3D{Width: width, Height: height, DepthOrArrayLayers: 1},
+			Format:        h.desc.Format,
+			MipLevelCount: 1,
+			SampleCount:   1,
+		})
+		if err != nil {
+			for _, t := range ring {
+				t.Release()
+			}
+			return err
+		}
+		ring = append(ring, tex)
+	}
+	h.releaseRing()
+	h.ring = ring
+	h.desc.Width, h.desc.Height = width, height
+	h.next, h.current = 0, -1
+	return nil
+}
+
+func (h *HeadlessSurface) releaseRing() {
+	for _, t := range h.ring {
+		t.Release()
+	}
+	h.ring = nil
+}
+
+// Width returns the width of the surface textures.
+func (h *HeadlessSurface) Width() uint32 { return h.desc.Width }
+
+// Height returns the height of the surface textures.
+func (h *HeadlessSurface) Height() uint32 { return h.desc.Height }
+
+// Format returns the format of the surface textures, for pipeline color
+// targets.
+func (h *HeadlessSurface) Format() gputypes.TextureFormat { return h.desc.Format }
+
+// Frames returns the number of frames presented so far.
+func (h *HeadlessSurface) Frames() uint64 { return h.frames }
+
+// Resize recreates the ring at width x height, like reconfiguring a
+// surface. A frame acquired but not presented is dropped.
+func (h *HeadlessSurface) Resize(width, height uint32) error {
+	if width == 0 || height == 0 {
+		return &WGPUError{Op: "HeadlessSurface.Resize", Type: ErrorTypeValidation,
+			Message: fmt.Sprintf("size %dx%d must be non-zero", width, height)}
+	}
+	if width == h.desc.Width && height == h.desc.Height && h.ring != nil {
+		return nil
+	}
+	return h.createRing(width, height)
+}
+
+// GetCurrentTexture returns the next texture of the ring with status
+// SuccessOptimal. The suboptimal flag is always false. Only one texture can
+// be acquired between presents.
+func (h *HeadlessSurface) GetCurrentTexture() (*SurfaceTexture, bool, error) {
+	const op = "HeadlessSurface.GetCurrentTexture"
+	if err := checkInit(); err != nil {
+		return nil, false, err
+	}
+	if h == nil || h.ring == nil {
+		return nil, false, &WGPUError{Op: op, Message: "headless surface is nil or released"}
+	}
+	if h.current >= 0 {
+		return nil, false, &WGPUError{Op: op, Type: ErrorTypeValidation,
+			Message: "texture already acquired, call Present first"}
+	}
+	tex := h.ring[h.next]
+	procTextureAddRef.Call(tex.handle) //nolint:errcheck
+	h.current = h.next
+	h.next = (h.next + 1) % len(h.ring)
+	return &SurfaceTexture{
+		Texture: &Texture{handle: tex.handle, device: tex.device},
+		Status:  SurfaceGetCurrentTextureStatusSuccessOptimal,
+	}, false, nil
+}
+
+// Present finishes the acquired frame. With a sink, the frame is read back
+// (waiting for the GPU to finish it) and handed to the sink, whose error is
+// returned. The texture argument is accepted for compatibility with
+// [Surface.Present] and unused.
+func (h *HeadlessSurface) Present(texture ...*SurfaceTexture) error {
+	const op = "HeadlessSurface.Present"
+	if err := checkInit(); err != nil {
+		return err
+	}
+	if h == nil || h.ring == nil {
+		return &WGPUError{Op: op, Message: "headless surface is nil or released"}
+	}
+	if h.current < 0 {
+		return &WGPUError{Op: op, Type: ErrorTypeValidation, Message: "no texture acquired"}
+	}
+	tex := h.ring[h.current]
+	h.current = -1
+	frame := h.frames
+	h.frames++
+	if h.desc.Sink == nil {
+		return nil
+	}
+	img, err := readTextureNRGBA(op, h.device, h.queue, tex)
+	if err != nil {
+		return err
+	}
+	return h.desc.Sink.WriteFrame(frame, img)
+}
+
+// Release releases the ring. Textures returned by GetCurrentTexture and not
+// yet released stay valid until they are.
+func (h *HeadlessSurface) Release() {
+	if h == nil {
+		return
+	}
+	h.releaseRing()
+	h.current = -1
+}
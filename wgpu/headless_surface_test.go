@@ -0,0 +1,109 @@
+package wgpu
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestHeadlessDefaults(t *testing.T) {
+	sink := RawFrameSink(&bytes.Buffer{})
+	tests := []struct {
+		name    string
+		desc    HeadlessSurfaceDescriptor
+		want    HeadlessSurfaceDescriptor
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			desc: HeadlessSurfaceDescriptor{Width: 64, Height: 32},
+			want: HeadlessSurfaceDescriptor{Width: 64, Height: 32, BufferCount: 3,
+				Format: gputypes.TextureFormatBGRA8Unorm, Usage: gputypes.TextureUsageRenderAttachment},
+		},
+		{
+			name: "sink adds CopySrc",
+			desc: HeadlessSurfaceDescriptor{Width: 1, Height: 1, BufferCount: 2,
+				Format: gputypes.TextureFormatRGBA8UnormSrgb, Sink: sink},
+			want: HeadlessSurfaceDescriptor{Width: 1, Height: 1, BufferCount: 2,
+				Format: gputypes.TextureFormatRGBA8UnormSrgb,
+				Usage:  gputypes.TextureUsageRenderAttachment | gputypes.TextureUsageCopySrc},
+		},
+		{name: "zero size", desc: HeadlessSurfaceDescriptor{Width: 0, Height: 1}, wantErr: true},
+		{name: "negative buffers", desc: HeadlessSurfaceDescriptor{Width: 1, Height: 1, BufferCount: -1}, wantErr: true},
+		{
+			name:    "sink needs 8-bit format",
+			desc:    HeadlessSurfaceDescriptor{Width: 1, Height: 1, Format: gputypes.TextureFormatRGBA16Float, Sink: sink},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := headlessDefaults(&tt.desc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("headlessDefaults() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got.Sink = nil
+			if got != tt.want {
+				t.Errorf("headlessDefaults() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrameSinks(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	copy(img.Pix, []byte{1, 2, 3, 255, 4, 5, 6, 255})
+
+	var raw bytes.Buffer
+	if err := RawFrameSink(&raw).WriteFrame(0, img); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(raw.Bytes(), img.Pix) {
+		t.Errorf("raw frame = %v, want %v", raw.Bytes(), img.Pix)
+	}
+
+	dir := t.TempDir()
+	if err := PNGFrameSink(dir).WriteFrame(7, img); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(filepath.Join(dir, "frame-000007.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	decoded, err := png.Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, g, b, _ := decoded.At(1, 0).RGBA(); r>>8 != 4 || g>>8 != 5 || b>>8 != 6 {
+		t.Errorf("pixel (1,0) = %d,%d,%d, want 4,5,6", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestDecodeRGBA8(t *testing.T) {
+	// 2x2 image read back with 256-byte aligned rows.
+	src := make([]byte, 256+8)
+	copy(src[0:], []byte{1, 2, 3, 4, 5, 6, 7, 8})
+	copy(src[256:], []byte{9, 10, 11, 12, 13, 14, 15, 16})
+	for _, tt := range []struct {
+		bgra bool
+		want []byte
+	}{
+		{false, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}},
+		{true, []byte{3, 2, 1, 4, 7, 6, 5, 8, 11, 10, 9, 12, 15, 14, 13, 16}},
+	} {
+		dst := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+		decodeRGBA8(dst, src, 256, tt.bgra)
+		if !bytes.Equal(dst.Pix, tt.want) {
+			t.Errorf("bgra=%v: Pix = %v, want %v", tt.bgra, dst.Pix, tt.want)
+		}
+	}
+}
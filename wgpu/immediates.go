@@ -0,0 +1,107 @@
+package wgpu
+
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+
+	"github.com/gogpu/gputypes"
+)
+
+// checkImmediates checks a write of size bytes of immediate data at offset.
+// limit is the device's MaxImmediateSize, or 0 when it is not known.
+func checkImmediates(offset uint32, size int, limit uint32) error {
+	if offset%4 != 0 || size%4 != 0 {
+		return fmt.Errorf("immediate data offset %d and size %d must be multiples of 4", offset, size)
+	}
+	if end := uint64(offset) + uint64(size); limit > 0 && end > uint64(limit) {
+		return fmt.Errorf("immediate data ends at byte %d, past the device limit MaxImmediateSize %d", end, limit)
+	}
+	return nil
+}
+
+// immediateLimit returns MaxImmediateSize of the device enc records for, or
+// 0 when it is not known.
+func (enc *CommandEncoder) immediateLimit() uint32 {
+	if enc == nil || enc.device == nil {
+		return 0
+	}
+	return enc.device.limits.MaxImmediateSize
+}
+
+// callImmediates passes data to a Set*Immediates procedure.
+func callImmediates(proc Proc, handle uintptr, offset uint32, data []byte) {
+	proc.Call( //nolint:errcheck
+		handle,
+		uintptr(offset),
+		uintptr(unsafe.Pointer(&data[0])),
+		uintptr(len(data)),
+	)
+	runtime.KeepAlive(data)
+}
+
+// SetImmediates writes data to the immediate data block (push constants) at
+// byte offset, for the draws that follow. The block is declared in WGSL as
+// var<immediate> and sized by [PipelineLayoutDescriptor.ImmediateSize];
+// small per-draw values written this way avoid a uniform buffer update.
+// Requires NativeFeatureImmediates.
+//
+// offset and len(data) must be multiples of 4 and stay within the device's
+// MaxImmediateSize; otherwise the call is skipped and [CommandEncoder.Finish]
+// returns the error.
+func (rpe *RenderPassEncoder) SetImmediates(offset uint32, data []byte) {
+	mustInit()
+	if rpe == nil || rpe.handle == 0 || len(data) == 0 {
+		return
+	}
+	if err := checkImmediates(offset, len(data), rpe.encoder.immediateLimit()); err != nil {
+		rpe.encoder.recordErr("RenderPassEncoder.SetImmediates", err)
+		return
+	}
+	callImmediates(procRenderPassEncoderSetImmediates, rpe.handle, offset, data)
+}
+
+// SetPushConstants is [RenderPassEncoder.SetImmediates] under the name used
+// by earlier wgpu-native releases and other engines. wgpu-native v29 shares
+// immediate data between all stages, so stages is not passed on; it only
+// documents which stages read the data.
+func (rpe *RenderPassEncoder) SetPushConstants(stages gputypes.ShaderStage, offset uint32, data []byte) {
+	rpe.SetImmediates(offset, data)
+}
+
+// SetImmediates writes data to the immediate data block at byte offset for
+// the dispatches that follow. See [RenderPassEncoder.SetImmediates].
+func (cpe *ComputePassEncoder) SetImmediates(offset uint32, data []byte) {
+	mustInit()
+	if cpe == nil || cpe.handle == 0 || len(data) == 0 {
+		return
+	}
+	if err := checkImmediates(offset, len(data), cpe.encoder.immediateLimit()); err != nil {
+		cpe.encoder.recordErr("ComputePassEncoder.SetImmediates", err)
+		return
+	}
+	callImmediates(procComputePassEncoderSetImmediates, cpe.handle, offset, data)
+}
+
+// SetPushConstants is [ComputePassEncoder.SetImmediates]; see
+// [RenderPassEncoder.SetPushConstants].
+func (cpe *ComputePassEncoder) SetPushConstants(stages gputypes.ShaderStage, offset uint32, data []byte) {
+	cpe.SetImmediates(offset, data)
+}
+
+// SetImmediates writes data to the immediate data block at byte offset for
+// the draws that follow in the bundle. Offsets are checked for alignment
+// only; errors are reported by [RenderBundleEncoder.Err].
+func (rbe *RenderBundleEncoder) SetImmediates(offset uint32, data []byte) {
+	mustInit()
+	if rbe == nil || rbe.handle == 0 || len(data) == 0 {
+		return
+	}
+	if err := checkImmediates(offset, len(data), 0); err != nil {
+		if rbe.err == nil {
+			rbe.err = &WGPUError{Op: "RenderBundleEncoder.SetImmediates", Type: ErrorTypeValidation, Message: err.Error()}
+		}
+		return
+	}
+	callImmediates(procRenderBundleEncoderSetImmediates, rbe.handle, offset, data)
+}
@@ -0,0 +1,32 @@
+package wgpu
+
+import "testing"
+
+func TestCheckImmediates(t *testing.T) {
+	tests := []struct {
+		name    string
+		offset  uint32
+		size    int
+		limit   uint32
+		wantErr bool
+	}{
+		{"fits", 16, 64, 128, false},
+		{"fills the block", 64, 64, 128, false},
+		{"past the limit", 68, 64, 128, true},
+		{"unaligned offset", 2, 4, 128, true},
+		{"unaligned size", 0, 6, 128, true},
+		{"unknown limit", 1024, 64, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkImmediates(tt.offset, tt.size, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	var enc *CommandEncoder
+	if got := enc.immediateLimit(); got != 0 {
+		t.Errorf("nil encoder limit = %d, want 0", got)
+	}
+}
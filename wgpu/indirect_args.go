@@ -0,0 +1,94 @@
+package wgpu
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Bytes returns the 16-byte little-endian encoding of a read by
+// [RenderPassEncoder.DrawIndirect].
+func (a DrawIndirectArgs) Bytes() []byte {
+	b := make([]byte, drawIndirectStride)
+	binary.LittleEndian.PutUint32(b[0:], a.VertexCount)
+	binary.LittleEndian.PutUint32(b[4:], a.InstanceCount)
+	binary.LittleEndian.PutUint32(b[8:], a.FirstVertex)
+	binary.LittleEndian.PutUint32(b[12:], a.FirstInstance)
+	return b
+}
+
+// Bytes returns the 20-byte little-endian encoding of a read by
+// [RenderPassEncoder.DrawIndexedIndirect].
+func (a DrawIndexedIndirectArgs) Bytes() []byte {
+	b := make([]byte, drawIndexedIndirectStride)
+	binary.LittleEndian.PutUint32(b[0:], a.IndexCount)
+	binary.LittleEndian.PutUint32(b[4:], a.InstanceCount)
+	binary.LittleEndian.PutUint32(b[8:], a.FirstIndex)
+	binary.LittleEndian.PutUint32(b[12:], uint32(a.BaseVertex))
+	binary.LittleEndian.PutUint32(b[16:], a.FirstInstance)
+	return b
+}
+
+// checkFirstInstance rejects a non-zero FirstInstance in the i-th indirect
+// draw when FeatureNameIndirectFirstInstance is not enabled. Without the
+// feature the device skips such draws instead of reporting an error.
+func checkFirstInstance(i int, firstInstance uint32, enabled bool) error {
+	if firstInstance != 0 && !enabled {
+		return fmt.Errorf("draw %d: FirstInstance %d requires FeatureNameIndirectFirstInstance", i, firstInstance)
+	}
+	return nil
+}
+
+// WriteDrawIndirect writes args to buffer at offset as consecutive
+// [DrawIndirectArgs] records, ready for DrawIndirect or MultiDrawIndirect.
+// It returns a validation error, and writes nothing, if a FirstInstance is
+// non-zero and the device lacks FeatureNameIndirectFirstInstance.
+func (d *Device) WriteDrawIndirect(buffer *Buffer, offset uint64, args ...DrawIndirectArgs) error {
+	if err := checkInit(); err != nil {
+		return err
+	}
+	if d == nil || d.handle == 0 {
+		return &WGPUError{Op: "WriteDrawIndirect", Message: "device is nil or released"}
+	}
+	enabled := d.HasFeature(FeatureNameIndirectFirstInstance)
+	data := make([]byte, 0, len(args)*int(drawIndirectStride))
+	for i, a := range args {
+		if err := checkFirstInstance(i, a.FirstInstance, enabled); err != nil {
+			return &WGPUError{Op: "WriteDrawIndirect", Type: ErrorTypeValidation, Message: err.Error()}
+		}
+		data = append(data, a.Bytes()...)
+	}
+	return d.writeIndirect("WriteDrawIndirect", buffer, offset, data)
+}
+
+// WriteDrawIndexedIndirect is [Device.WriteDrawIndirect] for
+// [DrawIndexedIndirectArgs] records.
+func (d *Device) WriteDrawIndexedIndirect(buffer *Buffer, offset uint64, args ...DrawIndexedIndirectArgs) error {
+	if err := checkInit(); err != nil {
+		return err
+	}
+	if d == nil || d.handle == 0 {
+		return &WGPUError{Op: "WriteDrawIndexedIndirect", Message: "device is nil or released"}
+	}
+	enabled := d.HasFeature(FeatureNameIndirectFirstInstance)
+	data := make([]byte, 0, len(args)*int(drawIndexedIndirectStride))
+	for i, a := range args {
+		if err := checkFirstInstance(i, a.FirstInstance, enabled); err != nil {
+			return &WGPUError{Op: "WriteDrawIndexedIndirect", Type: ErrorTypeValidation, Message: err.Error()}
+		}
+		data = append(data, a.Bytes()...)
+	}
+	return d.writeIndirect("WriteDrawIndexedIndirect", buffer, offset, data)
+}
+
+// writeIndirect writes encoded indirect records through the device queue.
+func (d *Device) writeIndirect(op string, buffer *Buffer, offset uint64, data []byte) error {
+	if offset%4 != 0 {
+		return &WGPUError{Op: op, Type: ErrorTypeValidation, Message: fmt.Sprintf("offset %d is not a multiple of 4", offset)}
+	}
+	queue := d.Queue()
+	if queue == nil {
+		return &WGPUError{Op: op, Message: "device queue unavailable"}
+	}
+	defer queue.Release()
+	return queue.WriteBuffer(buffer, offset, data)
+}
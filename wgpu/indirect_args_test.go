@@ -0,0 +1,40 @@
+package wgpu
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestIndirectArgsBytes(t *testing.T) {
+	got := DrawIndirectArgs{VertexCount: 3, InstanceCount: 2, FirstVertex: 1, FirstInstance: 0x0100}.Bytes()
+	want := []byte{3, 0, 0, 0, 2, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0}
+	if !bytes.Equal(got, want) {
+		t.Errorf("DrawIndirectArgs.Bytes() = %v, want %v", got, want)
+	}
+	got = DrawIndexedIndirectArgs{IndexCount: 6, InstanceCount: 1, BaseVertex: -1, FirstInstance: 4}.Bytes()
+	want = []byte{6, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 4, 0, 0, 0}
+	if !bytes.Equal(got, want) {
+		t.Errorf("DrawIndexedIndirectArgs.Bytes() = %v, want %v", got, want)
+	}
+}
+
+func TestCheckFirstInstance(t *testing.T) {
+	tests := []struct {
+		name          string
+		firstInstance uint32
+		enabled       bool
+		wantErr       bool
+	}{
+		{"zero without feature", 0, false, false},
+		{"non-zero with feature", 5, true, false},
+		{"non-zero without feature", 5, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkFirstInstance(0, tt.firstInstance, tt.enabled)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -103,11 +103,10 @@ fn main(@builtin(global_invocation_id) global_id: vec3<u32>) {
 	indirectBuffer.Unmap()
 
 	// Create bind group
-	bindGroupLayout := pipeline.GetBindGroupLayout(0)
-	if bindGroupLayout == nil {
-		t.Fatal("GetBindGroupLayout returned nil")
+	bindGroupLayout, err := pipeline.GetBindGroupLayout(0)
+	if err != nil {
+		t.Fatalf("GetBindGroupLayout failed: %v", err)
 	}
-	defer bindGroupLayout.Release()
 
 	bindGroup, err := device.CreateBindGroupSimple(bindGroupLayout, []BindGroupEntry{
 		BufferBindingEntry(0, storageBuffer, 0, bufferSize),
@@ -77,16 +77,20 @@ func CreateInstance(desc *InstanceDescriptor) (*Instance, error) {
 		return nil, &WGPUError{Op: "CreateInstance", Message: "failed to create instance"}
 	}
 
-	trackResource(handle, "Instance")
+	trackResource(handle, "Instance", "")
 	return &Instance{handle: handle}, nil
 }
 
-// Release releases the instance resources.
+// Release releases the instance resources. In debug mode, resources still
+// alive afterwards are logged as leaks; see [SetLogger].
 func (i *Instance) Release() {
 	if i.handle != 0 {
 		untrackResource(i.handle)
 		procInstanceRelease.Call(i.handle) //nolint:errcheck
 		i.handle = 0
+		if report := ReportLeaks(); report != nil {
+			logger().Warn("wgpu: GPU resources still alive at instance release", "leaks", report)
+		}
 	}
 }
 
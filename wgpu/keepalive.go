@@ -0,0 +1,24 @@
+package wgpu
+
+// pinSet retains Go allocations that native descriptors reference through
+// uintptr fields. The garbage collector does not follow those fields, so
+// without a live Go reference a converted attachment array or label could
+// be collected, and its memory reused, while wgpu-native still reads it.
+//
+// Device-level creators keep a pinSet on the stack and pass it to
+// runtime.KeepAlive after the native call. A CommandEncoder owns one that
+// lives until Finish or Release, covering every pass begun on it.
+type pinSet struct {
+	objs []any
+}
+
+// add retains objs until release.
+func (p *pinSet) add(objs ...any) {
+	p.objs = append(p.objs, objs...)
+}
+
+// release drops every retained object. The backing array is kept for reuse.
+func (p *pinSet) release() {
+	clear(p.objs)
+	p.objs = p.objs[:0]
+}
@@ -0,0 +1,31 @@
+package wgpu
+
+import "testing"
+
+func TestPinSetRelease(t *testing.T) {
+	var p pinSet
+	a, b := new(int), []byte("label")
+	p.add(a, b)
+	if len(p.objs) != 2 {
+		t.Fatalf("len = %d, want 2", len(p.objs))
+	}
+	backing := p.objs[:2]
+	p.release()
+	if len(p.objs) != 0 {
+		t.Errorf("len after release = %d, want 0", len(p.objs))
+	}
+	if backing[0] != nil || backing[1] != nil {
+		t.Error("release left references in the backing array")
+	}
+}
+
+func TestStringToStringViewSharesData(t *testing.T) {
+	s := "frame 123"
+	v := stringToStringView(s)
+	if v.Length != uintptr(len(s)) || v.Data == 0 {
+		t.Fatalf("view = %+v", v)
+	}
+	if got := EmptyStringView(); stringToStringView("") != got {
+		t.Errorf("empty string view = %+v, want %+v", stringToStringView(""), got)
+	}
+}
@@ -19,6 +19,12 @@ type Proc interface {
 	Call(args ...uintptr) (uintptr, uintptr, error)
 }
 
+// symbolLister is implemented by platform loaders that remember the
+// procedures looked up in the library, to report the ones it lacks.
+type symbolLister interface {
+	missingSymbols() []string
+}
+
 // float32Proc is implemented by platform loaders for procedures whose native
 // return type is float32. Proc.Call intentionally keeps the existing integer
 // return contract for the rest of the WebGPU API; this narrow interface lets
@@ -13,8 +13,9 @@ import (
 
 // unixLibrary wraps goffi library handle to implement the Library interface.
 type unixLibrary struct {
-	handle unsafe.Pointer
-	name   string
+	handle  unsafe.Pointer
+	name    string
+	missing []string // symbols NewProc did not find
 }
 
 // unixProc wraps a goffi function pointer and prepared CIF.
@@ -55,6 +56,7 @@ func (u *unixLibrary) NewProc(name string) Proc {
 
 	fnPtr, err := ffi.GetSymbol(u.handle, name)
 	if err != nil {
+		u.missing = append(u.missing, name)
 		// Return a proc that will fail on Call
 		return &unixProc{
 			lib:      u,
@@ -72,6 +74,11 @@ func (u *unixLibrary) NewProc(name string) Proc {
 	}
 }
 
+// missingSymbols returns the symbols NewProc did not find.
+func (u *unixLibrary) missingSymbols() []string {
+	return u.missing
+}
+
 // Call invokes the Unix procedure with the given arguments.
 // This uses goffi's CallFunction with lazy CIF preparation.
 //
@@ -10,7 +10,8 @@ import (
 
 // windowsLibrary wraps syscall.LazyDLL to implement the Library interface.
 type windowsLibrary struct {
-	dll *syscall.LazyDLL
+	dll   *syscall.LazyDLL
+	procs []*syscall.LazyProc // looked up with NewProc
 }
 
 // windowsProc wraps syscall.LazyProc to implement the Proc interface.
@@ -32,11 +33,25 @@ func loadLibrary(name string) (Library, error) {
 
 // NewProc retrieves a procedure from the Windows DLL.
 func (w *windowsLibrary) NewProc(name string) Proc {
+	proc := w.dll.NewProc(name)
+	w.procs = append(w.procs, proc)
 	return &windowsProc{
-		proc: w.dll.NewProc(name),
+		proc: proc,
 	}
 }
 
+// missingSymbols resolves the procedures looked up so far and returns the
+// names of those the DLL does not export.
+func (w *windowsLibrary) missingSymbols() []string {
+	var missing []string
+	for _, p := range w.procs {
+		if p.Find() != nil {
+			missing = append(missing, p.Name)
+		}
+	}
+	return missing
+}
+
 // Call invokes the Windows procedure with the given arguments.
 // This directly delegates to syscall.LazyProc.Call().
 func (w *windowsProc) Call(args ...uintptr) (uintptr, uintptr, error) {
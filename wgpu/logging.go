@@ -0,0 +1,36 @@
+package wgpu
+
+import (
+	"log/slog"
+	"sync/atomic"
+)
+
+// libLogger is the logger set with SetLogger; nil discards.
+var libLogger atomic.Pointer[slog.Logger]
+
+// discardLogger is used while no logger is set.
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// SetLogger sets the logger for diagnostics of the package itself, as
+// opposed to GPU errors, which are returned as errors:
+//
+//   - Debug: the native library that was loaded.
+//   - Warn: symbols missing from the library, fallbacks such as a linear
+//     surface format when sRGB was asked for, suboptimal surfaces,
+//     resources still alive when an instance is released in debug mode, and
+//     uncaptured GPU errors not received from [Device.Errors].
+//
+// The package is silent until a logger is set, except for those uncaptured
+// GPU errors, which are printed to standard error. Pass slog.Default() to
+// log with the rest of the application; passing nil restores the default.
+func SetLogger(l *slog.Logger) {
+	libLogger.Store(l)
+}
+
+// logger returns the logger set with SetLogger, or one that discards.
+func logger() *slog.Logger {
+	if l := libLogger.Load(); l != nil {
+		return l
+	}
+	return discardLogger
+}
@@ -0,0 +1,47 @@
+package wgpu
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSetLogger(t *testing.T) {
+	t.Cleanup(func() { SetLogger(nil) })
+
+	if logger() != discardLogger {
+		t.Fatal("logger() without SetLogger does not discard")
+	}
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})))
+
+	report := &LeakReport{
+		Count: 2,
+		Types: map[string]int{"Texture": 1, "Buffer": 1},
+		Resources: []ResourceInfo{
+			{Type: "Buffer", Label: "vertices", Handle: 0x10},
+			{Type: "Texture", Handle: 0x20},
+		},
+	}
+	logger().Warn("leaks", "leaks", report)
+	want := `level=WARN msg=leaks leaks.count=2 leaks.types.Buffer=1 leaks.types.Texture=1 ` +
+		`leaks.resources="[Buffer 0x10 \"vertices\" Texture 0x20 \"\"]"` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("log output:\n got %s\nwant %s", got, want)
+	}
+
+	SetLogger(nil)
+	buf.Reset()
+	logger().Warn("dropped")
+	if strings.Contains(buf.String(), "dropped") {
+		t.Error("SetLogger(nil) did not silence the package")
+	}
+}
@@ -0,0 +1,81 @@
+//go:build (linux && !android) || darwin
+
+package minwin
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/go-webgpu/goffi/ffi"
+	"github.com/go-webgpu/goffi/types"
+)
+
+// library is a system library opened with dlopen.
+type library struct {
+	handle unsafe.Pointer
+	name   string
+}
+
+// openLibrary opens the first of names that loads.
+func openLibrary(names ...string) (*library, error) {
+	var err error
+	for _, name := range names {
+		var h unsafe.Pointer
+		if h, err = ffi.LoadLibrary(name); err == nil {
+			return &library{handle: h, name: name}, nil
+		}
+	}
+	return nil, fmt.Errorf("minwin: dlopen %s: %w", names[0], err)
+}
+
+// cfunc is a C function with a prepared call interface.
+type cfunc struct {
+	ptr unsafe.Pointer
+	cif types.CallInterface
+}
+
+// fn looks up name and prepares it for calls taking nargs pointer-sized
+// integer or pointer arguments and returning one.
+func (l *library) fn(name string, nargs int) (*cfunc, error) {
+	args := make([]*types.TypeDescriptor, nargs)
+	for i := range args {
+		args[i] = types.PointerTypeDescriptor
+	}
+	return l.typedFn(name, types.PointerTypeDescriptor, args...)
+}
+
+// typedFn looks up name and prepares it for the given signature.
+func (l *library) typedFn(name string, ret *types.TypeDescriptor, args ...*types.TypeDescriptor) (*cfunc, error) {
+	ptr, err := ffi.GetSymbol(l.handle, name)
+	if err != nil {
+		return nil, fmt.Errorf("minwin: %s: %w", name, err)
+	}
+	f := &cfunc{ptr: ptr}
+	if err := ffi.PrepareCallInterface(&f.cif, types.DefaultCall, ret, args); err != nil {
+		return nil, fmt.Errorf("minwin: %s: %w", name, err)
+	}
+	return f, nil
+}
+
+// call calls a function prepared by fn.
+func (f *cfunc) call(args ...uintptr) uintptr {
+	ptrs := make([]unsafe.Pointer, len(args))
+	for i := range args {
+		ptrs[i] = unsafe.Pointer(&args[i])
+	}
+	var ret uintptr
+	f.callPtrs(unsafe.Pointer(&ret), ptrs...)
+	return ret
+}
+
+// callPtrs calls f with pointers to its arguments, storing the result at
+// ret. The call interface was validated when f was prepared, so the call
+// itself cannot fail.
+func (f *cfunc) callPtrs(ret unsafe.Pointer, args ...unsafe.Pointer) {
+	_, _ = ffi.CallFunction(&f.cif, f.ptr, ret, args)
+}
+
+// cString returns s as a NUL-terminated byte slice.
+func cString(s string) []byte {
+	return append([]byte(s), 0)
+}
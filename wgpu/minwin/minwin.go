@@ -0,0 +1,121 @@
+// Package minwin opens a minimal native window to render into with WebGPU,
+// without cgo: Win32 on Windows, Xlib on Linux and Cocoa on macOS. It is
+// meant for examples, tests and small tools; it handles resizing and
+// closing, and nothing else.
+//
+// Windows belong to the thread that created them, and Cocoa only runs on
+// the main thread, so lock main to its thread before creating a window:
+//
+//	func init() { runtime.LockOSThread() }
+//
+//	func main() {
+//	    win, err := minwin.New(minwin.Config{Title: "demo", Width: 800, Height: 600})
+//	    if err != nil { ... }
+//	    defer win.Close()
+//	    surface, err := win.CreateSurface(instance)
+//	    win.OnResize(func(w, h uint32) { swapchain.Resize(w, h) })
+//	    for win.PollEvents() {
+//	        // render a frame
+//	    }
+//	}
+//
+// On Wayland sessions the Linux backend runs through XWayland.
+package minwin
+
+import (
+	"errors"
+
+	"github.com/go-webgpu/webgpu/wgpu"
+)
+
+// Config describes a window.
+type Config struct {
+	Title string
+	// Width and Height are the size of the drawable area in pixels.
+	Width, Height uint32
+	// Fixed disables resizing by the user.
+	Fixed bool
+}
+
+// Window is a native window. Its methods must be called on the thread that
+// created it.
+type Window struct {
+	width, height uint32
+	closed        bool // the user closed the window, or Close was called
+	destroyed     bool
+	onResize      func(width, height uint32)
+
+	native
+}
+
+// New opens a window.
+func New(cfg Config) (*Window, error) {
+	if cfg.Width == 0 || cfg.Height == 0 {
+		return nil, errors.New("minwin: width and height must be non-zero")
+	}
+	w := &Window{width: cfg.Width, height: cfg.Height}
+	if err := w.open(cfg); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// OnResize sets the function called from PollEvents when the drawable
+// area changes size. The size is zero while the window is minimized.
+func (w *Window) OnResize(fn func(width, height uint32)) {
+	w.onResize = fn
+}
+
+// Size returns the size of the drawable area in pixels.
+func (w *Window) Size() (width, height uint32) {
+	return w.width, w.height
+}
+
+// PollEvents handles the pending window events without waiting for more,
+// and reports whether the window is still open.
+func (w *Window) PollEvents() bool {
+	if !w.closed {
+		w.poll()
+	}
+	return !w.closed
+}
+
+// Closed reports whether the window has been closed.
+func (w *Window) Closed() bool {
+	return w.closed
+}
+
+// RawWindowHandle returns the native handle of the window, making Window a
+// [wgpu.WindowHandle].
+func (w *Window) RawWindowHandle() (wgpu.RawWindowHandle, error) {
+	if w.destroyed {
+		return wgpu.RawWindowHandle{}, errors.New("minwin: window is closed")
+	}
+	return w.rawHandle(), nil
+}
+
+// CreateSurface creates a surface presenting to the window.
+func (w *Window) CreateSurface(inst *wgpu.Instance) (*wgpu.Surface, error) {
+	return inst.CreateSurface(w)
+}
+
+// Close closes the window. Release surfaces created for it first.
+func (w *Window) Close() {
+	if !w.destroyed {
+		w.destroy()
+		w.destroyed = true
+	}
+	w.closed = true
+}
+
+// resized records a new drawable size and reports it to the OnResize
+// function.
+func (w *Window) resized(width, height uint32) {
+	if width == w.width && height == w.height {
+		return
+	}
+	w.width, w.height = width, height
+	if w.onResize != nil {
+		w.onResize(width, height)
+	}
+}
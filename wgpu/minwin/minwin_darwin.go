@@ -0,0 +1,250 @@
+//go:build darwin
+
+package minwin
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/go-webgpu/goffi/types"
+	"github.com/go-webgpu/webgpu/wgpu"
+)
+
+// NSWindow style and backing constants.
+const (
+	nsWindowStyleTitled         = 1 << 0
+	nsWindowStyleClosable       = 1 << 1
+	nsWindowStyleMiniaturizable = 1 << 2
+	nsWindowStyleResizable      = 1 << 3
+	nsBackingStoreBuffered      = 2
+	nsActivationPolicyRegular   = 0
+)
+
+type nsRect struct {
+	x, y, width, height float64
+}
+
+var nsRectType = &types.TypeDescriptor{
+	Kind: types.StructType,
+	Members: []*types.TypeDescriptor{
+		types.DoubleTypeDescriptor, types.DoubleTypeDescriptor,
+		types.DoubleTypeDescriptor, types.DoubleTypeDescriptor,
+	},
+}
+
+// objc calls into the Objective-C runtime. objc_msgSend is prepared once
+// per signature the backend uses.
+type objc struct {
+	getClass, registerName *cfunc
+	send                   [5]*cfunc // pointer-sized arguments, indexed by count after the selector
+	initWithRect           *cfunc    // (id, SEL, NSRect, NSUInteger, NSUInteger, BOOL) -> id
+	rect                   *cfunc    // (id, SEL) -> NSRect
+	double                 *cfunc    // (id, SEL) -> double
+}
+
+var (
+	objcOnce sync.Once
+	objcRT   *objc
+	objcErr  error
+)
+
+// loadObjC loads libobjc and AppKit on first use.
+func loadObjC() (*objc, error) {
+	objcOnce.Do(func() {
+		objcRT, objcErr = newObjC()
+	})
+	return objcRT, objcErr
+}
+
+func newObjC() (*objc, error) {
+	lib, err := openLibrary("/usr/lib/libobjc.A.dylib")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := openLibrary("/System/Library/Frameworks/AppKit.framework/AppKit"); err != nil {
+		return nil, err
+	}
+	rt := &objc{}
+	if rt.getClass, err = lib.fn("objc_getClass", 1); err != nil {
+		return nil, err
+	}
+	if rt.registerName, err = lib.fn("sel_registerName", 1); err != nil {
+		return nil, err
+	}
+	for n := range rt.send {
+		if rt.send[n], err = lib.fn("objc_msgSend", 2+n); err != nil {
+			return nil, err
+		}
+	}
+	ptr := types.PointerTypeDescriptor
+	if rt.initWithRect, err = lib.typedFn("objc_msgSend", ptr, ptr, ptr, nsRectType, ptr, ptr, ptr); err != nil {
+		return nil, err
+	}
+	// On amd64 a 32-byte struct is returned through memory, which
+	// objc_msgSend_stret handles; arm64 has no separate entry point.
+	rectSend := "objc_msgSend"
+	if runtime.GOARCH == "amd64" {
+		rectSend = "objc_msgSend_stret"
+	}
+	if rt.rect, err = lib.typedFn(rectSend, nsRectType, ptr, ptr); err != nil {
+		return nil, err
+	}
+	if rt.double, err = lib.typedFn("objc_msgSend", types.DoubleTypeDescriptor, ptr, ptr); err != nil {
+		return nil, err
+	}
+	return rt, nil
+}
+
+// class returns the class named name, or 0.
+func (rt *objc) class(name string) uintptr {
+	cname := cString(name)
+	c := rt.getClass.call(uintptr(unsafe.Pointer(&cname[0])))
+	runtime.KeepAlive(cname)
+	return c
+}
+
+// sel returns the selector named name.
+func (rt *objc) sel(name string) uintptr {
+	cname := cString(name)
+	s := rt.registerName.call(uintptr(unsafe.Pointer(&cname[0])))
+	runtime.KeepAlive(cname)
+	return s
+}
+
+// msg sends sel with pointer-sized arguments to obj.
+func (rt *objc) msg(obj uintptr, sel string, args ...uintptr) uintptr {
+	return rt.send[len(args)].call(append([]uintptr{obj, rt.sel(sel)}, args...)...)
+}
+
+// msgRect sends sel, which returns an NSRect, to obj.
+func (rt *objc) msgRect(obj uintptr, sel string) nsRect {
+	var r nsRect
+	s := rt.sel(sel)
+	rt.rect.callPtrs(unsafe.Pointer(&r), unsafe.Pointer(&obj), unsafe.Pointer(&s))
+	return r
+}
+
+// msgDouble sends sel, which returns a double, to obj.
+func (rt *objc) msgDouble(obj uintptr, sel string) float64 {
+	var d float64
+	s := rt.sel(sel)
+	rt.double.callPtrs(unsafe.Pointer(&d), unsafe.Pointer(&obj), unsafe.Pointer(&s))
+	return d
+}
+
+// nsString returns a retained NSString holding s.
+func (rt *objc) nsString(s string) uintptr {
+	cs := cString(s)
+	str := rt.msg(rt.msg(rt.class("NSString"), "alloc"), "initWithUTF8String:", uintptr(unsafe.Pointer(&cs[0])))
+	runtime.KeepAlive(cs)
+	return str
+}
+
+type native struct {
+	rt       *objc
+	app      uintptr // NSApplication
+	window   uintptr // NSWindow
+	view     uintptr // content NSView
+	mode     uintptr // NSDefaultRunLoopMode
+	past     uintptr // [NSDate distantPast], for polling without waiting
+	launched bool
+}
+
+func (w *Window) open(cfg Config) error {
+	rt, err := loadObjC()
+	if err != nil {
+		return err
+	}
+	app := rt.msg(rt.class("NSApplication"), "sharedApplication")
+	if app == 0 {
+		return errors.New("minwin: NSApplication unavailable")
+	}
+	rt.msg(app, "setActivationPolicy:", nsActivationPolicyRegular)
+
+	style := uintptr(nsWindowStyleTitled | nsWindowStyleClosable | nsWindowStyleMiniaturizable)
+	if !cfg.Fixed {
+		style |= nsWindowStyleResizable
+	}
+	// The content rect is in points; sizes reported later are in pixels.
+	frame := nsRect{width: float64(cfg.Width), height: float64(cfg.Height)}
+	window := rt.msg(rt.class("NSWindow"), "alloc")
+	sel := rt.sel("initWithContentRect:styleMask:backing:defer:")
+	backing, deferred := uintptr(nsBackingStoreBuffered), uintptr(0)
+	rt.initWithRect.callPtrs(unsafe.Pointer(&window),
+		unsafe.Pointer(&window), unsafe.Pointer(&sel), unsafe.Pointer(&frame),
+		unsafe.Pointer(&style), unsafe.Pointer(&backing), unsafe.Pointer(&deferred))
+	if window == 0 {
+		return errors.New("minwin: cannot create NSWindow")
+	}
+	// Closing only hides the window; Close releases it.
+	rt.msg(window, "setReleasedWhenClosed:", 0)
+	title := rt.nsString(cfg.Title)
+	rt.msg(window, "setTitle:", title)
+	rt.msg(title, "release")
+	rt.msg(window, "center")
+	rt.msg(window, "makeKeyAndOrderFront:", 0)
+
+	w.native = native{
+		rt:     rt,
+		app:    app,
+		window: window,
+		view:   rt.msg(window, "contentView"),
+		mode:   rt.nsString("kCFRunLoopDefaultMode"),
+		past:   rt.msg(rt.class("NSDate"), "distantPast"),
+	}
+	w.width, w.height = w.pixelSize()
+	return nil
+}
+
+// pixelSize returns the size of the content view in pixels.
+func (w *Window) pixelSize() (width, height uint32) {
+	frame := w.rt.msgRect(w.view, "frame")
+	scale := w.rt.msgDouble(w.window, "backingScaleFactor")
+	if scale <= 0 {
+		scale = 1
+	}
+	return uint32(frame.width*scale + 0.5), uint32(frame.height*scale + 0.5)
+}
+
+func (w *Window) poll() {
+	rt := w.rt
+	if !w.launched {
+		rt.msg(w.app, "finishLaunching")
+		rt.msg(w.app, "activateIgnoringOtherApps:", 1)
+		w.launched = true
+	}
+	pool := rt.msg(rt.msg(rt.class("NSAutoreleasePool"), "alloc"), "init")
+	for {
+		ev := rt.msg(w.app, "nextEventMatchingMask:untilDate:inMode:dequeue:", ^uintptr(0), w.past, w.mode, 1)
+		if ev == 0 {
+			break
+		}
+		rt.msg(w.app, "sendEvent:", ev)
+	}
+	rt.msg(w.app, "updateWindows")
+	rt.msg(pool, "drain")
+
+	// Without a window delegate, closing shows up as the window no longer
+	// being on screen while not minimized.
+	if rt.msg(w.window, "isVisible")&0xff == 0 && rt.msg(w.window, "isMiniaturized")&0xff == 0 {
+		w.closed = true
+		return
+	}
+	if rt.msg(w.window, "isMiniaturized")&0xff != 0 {
+		w.resized(0, 0)
+		return
+	}
+	w.resized(w.pixelSize())
+}
+
+func (w *Window) destroy() {
+	w.rt.msg(w.window, "close")
+	w.rt.msg(w.window, "release")
+	w.rt.msg(w.mode, "release")
+}
+
+func (w *Window) rawHandle() wgpu.RawWindowHandle {
+	return wgpu.RawWindowHandle{Kind: wgpu.WindowHandleAppKit, Window: w.view}
+}
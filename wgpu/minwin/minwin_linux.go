@@ -0,0 +1,172 @@
+//go:build linux && !android
+
+package minwin
+
+import (
+	"encoding/binary"
+	"errors"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/go-webgpu/webgpu/wgpu"
+)
+
+// Xlib event types and masks.
+const (
+	xConfigureNotify       = 22
+	xClientMessage         = 33
+	xStructureNotifyMask   = 1 << 17
+	xSizeHintsMinSize      = 1 << 4
+	xSizeHintsMaxSize      = 1 << 5
+	xEventSize             = 192 // sizeof(XEvent) on 64-bit
+	xConfigureWidthOffset  = 56  // XConfigureEvent.width
+	xConfigureHeightOffset = 60  // XConfigureEvent.height
+	xClientDataOffset      = 56  // XClientMessageEvent.data.l[0]
+)
+
+// xlib holds the Xlib functions used by the backend.
+type xlib struct {
+	openDisplay, closeDisplay, defaultScreen, rootWindow *cfunc
+	createSimpleWindow, destroyWindow, mapWindow         *cfunc
+	storeName, selectInput, setWMNormalHints             *cfunc
+	internAtom, setWMProtocols                           *cfunc
+	pending, nextEvent, flush                            *cfunc
+}
+
+var (
+	xlibOnce sync.Once
+	xlibFns  *xlib
+	xlibErr  error
+)
+
+// loadXlib opens libX11 on first use.
+func loadXlib() (*xlib, error) {
+	xlibOnce.Do(func() {
+		lib, err := openLibrary("libX11.so.6", "libX11.so")
+		if err != nil {
+			xlibErr = err
+			return
+		}
+		x := &xlib{}
+		for _, f := range []struct {
+			dst   **cfunc
+			name  string
+			nargs int
+		}{
+			{&x.openDisplay, "XOpenDisplay", 1},
+			{&x.closeDisplay, "XCloseDisplay", 1},
+			{&x.defaultScreen, "XDefaultScreen", 1},
+			{&x.rootWindow, "XRootWindow", 2},
+			{&x.createSimpleWindow, "XCreateSimpleWindow", 9},
+			{&x.destroyWindow, "XDestroyWindow", 2},
+			{&x.mapWindow, "XMapWindow", 2},
+			{&x.storeName, "XStoreName", 3},
+			{&x.selectInput, "XSelectInput", 3},
+			{&x.setWMNormalHints, "XSetWMNormalHints", 3},
+			{&x.internAtom, "XInternAtom", 3},
+			{&x.setWMProtocols, "XSetWMProtocols", 4},
+			{&x.pending, "XPending", 1},
+			{&x.nextEvent, "XNextEvent", 2},
+			{&x.flush, "XFlush", 1},
+		} {
+			if *f.dst, err = lib.fn(f.name, f.nargs); err != nil {
+				xlibErr = err
+				return
+			}
+		}
+		xlibFns = x
+	})
+	return xlibFns, xlibErr
+}
+
+// xSizeHints matches XSizeHints on 64-bit - 80 bytes.
+type xSizeHints struct {
+	flags                                    int64
+	x, y, width, height                      int32
+	minWidth, minHeight, maxWidth, maxHeight int32
+	widthInc, heightInc                      int32
+	minAspect, maxAspect                     [2]int32
+	baseWidth, baseHeight                    int32
+	winGravity                               int32
+}
+
+type native struct {
+	x        *xlib
+	display  uintptr
+	window   uintptr
+	wmDelete uintptr // WM_DELETE_WINDOW atom
+}
+
+func (w *Window) open(cfg Config) error {
+	x, err := loadXlib()
+	if err != nil {
+		return err
+	}
+	display := x.openDisplay.call(0)
+	if display == 0 {
+		return errors.New("minwin: cannot open the X display; on Wayland, XWayland must be running")
+	}
+	root := x.rootWindow.call(display, x.defaultScreen.call(display))
+	window := x.createSimpleWindow.call(display, root, 0, 0, uintptr(cfg.Width), uintptr(cfg.Height), 0, 0, 0)
+	if window == 0 {
+		x.closeDisplay.call(display)
+		return errors.New("minwin: XCreateSimpleWindow failed")
+	}
+	w.native = native{x: x, display: display, window: window}
+
+	title := cString(cfg.Title)
+	x.storeName.call(display, window, uintptr(unsafe.Pointer(&title[0])))
+	runtime.KeepAlive(title)
+	x.selectInput.call(display, window, xStructureNotifyMask)
+	if cfg.Fixed {
+		hints := xSizeHints{
+			flags:    xSizeHintsMinSize | xSizeHintsMaxSize,
+			minWidth: int32(cfg.Width), minHeight: int32(cfg.Height),
+			maxWidth: int32(cfg.Width), maxHeight: int32(cfg.Height),
+		}
+		x.setWMNormalHints.call(display, window, uintptr(unsafe.Pointer(&hints)))
+		runtime.KeepAlive(&hints)
+	}
+	// Ask the window manager for a message instead of killing the
+	// connection when the window is closed.
+	name := cString("WM_DELETE_WINDOW")
+	w.wmDelete = x.internAtom.call(display, uintptr(unsafe.Pointer(&name[0])), 0)
+	runtime.KeepAlive(name)
+	x.setWMProtocols.call(display, window, uintptr(unsafe.Pointer(&w.wmDelete)), 1)
+	x.mapWindow.call(display, window)
+	x.flush.call(display)
+	return nil
+}
+
+func (w *Window) poll() {
+	var ev [xEventSize]byte
+	for w.x.pending.call(w.display) != 0 {
+		w.x.nextEvent.call(w.display, uintptr(unsafe.Pointer(&ev[0])))
+		w.handleEvent(ev[:])
+	}
+}
+
+// handleEvent handles one XEvent.
+func (w *Window) handleEvent(ev []byte) {
+	switch int32(binary.NativeEndian.Uint32(ev)) {
+	case xConfigureNotify:
+		w.resized(
+			binary.NativeEndian.Uint32(ev[xConfigureWidthOffset:]),
+			binary.NativeEndian.Uint32(ev[xConfigureHeightOffset:]),
+		)
+	case xClientMessage:
+		if uintptr(binary.NativeEndian.Uint64(ev[xClientDataOffset:])) == w.wmDelete {
+			w.closed = true
+		}
+	}
+}
+
+func (w *Window) destroy() {
+	w.x.destroyWindow.call(w.display, w.window)
+	w.x.closeDisplay.call(w.display)
+}
+
+func (w *Window) rawHandle() wgpu.RawWindowHandle {
+	return wgpu.RawWindowHandle{Kind: wgpu.WindowHandleXlib, Display: w.display, Window: w.window}
+}
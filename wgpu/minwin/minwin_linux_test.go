@@ -0,0 +1,42 @@
+//go:build linux && !android
+
+package minwin
+
+import (
+	"encoding/binary"
+	"testing"
+	"unsafe"
+)
+
+func TestXSizeHintsSize(t *testing.T) {
+	if got := unsafe.Sizeof(xSizeHints{}); got != 80 {
+		t.Fatalf("sizeof(xSizeHints) = %d, want 80", got)
+	}
+}
+
+func TestHandleEvent(t *testing.T) {
+	w := &Window{width: 800, height: 600}
+	w.wmDelete = 0x123
+
+	ev := make([]byte, xEventSize)
+	binary.NativeEndian.PutUint32(ev, xConfigureNotify)
+	binary.NativeEndian.PutUint32(ev[xConfigureWidthOffset:], 640)
+	binary.NativeEndian.PutUint32(ev[xConfigureHeightOffset:], 480)
+	w.handleEvent(ev)
+	if width, height := w.Size(); width != 640 || height != 480 {
+		t.Errorf("Size() after ConfigureNotify = %dx%d, want 640x480", width, height)
+	}
+
+	ev = make([]byte, xEventSize)
+	binary.NativeEndian.PutUint32(ev, xClientMessage)
+	binary.NativeEndian.PutUint64(ev[xClientDataOffset:], 0x999)
+	w.handleEvent(ev)
+	if w.Closed() {
+		t.Fatal("unrelated client message closed the window")
+	}
+	binary.NativeEndian.PutUint64(ev[xClientDataOffset:], 0x123)
+	w.handleEvent(ev)
+	if !w.Closed() {
+		t.Error("WM_DELETE_WINDOW did not close the window")
+	}
+}
@@ -0,0 +1,23 @@
+//go:build !windows && !darwin && !(linux && !android)
+
+package minwin
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/go-webgpu/webgpu/wgpu"
+)
+
+type native struct{}
+
+func (w *Window) open(Config) error {
+	return fmt.Errorf("minwin: %s: %w", runtime.GOOS, errors.ErrUnsupported)
+}
+
+func (w *Window) poll() {}
+
+func (w *Window) destroy() {}
+
+func (w *Window) rawHandle() wgpu.RawWindowHandle { return wgpu.RawWindowHandle{} }
@@ -0,0 +1,25 @@
+package minwin
+
+import "testing"
+
+func TestNewRejectsEmpty(t *testing.T) {
+	if _, err := New(Config{Width: 0, Height: 480}); err == nil {
+		t.Error("New with zero width succeeded")
+	}
+}
+
+func TestResized(t *testing.T) {
+	w := &Window{width: 800, height: 600}
+	var calls [][2]uint32
+	w.OnResize(func(width, height uint32) { calls = append(calls, [2]uint32{width, height}) })
+
+	w.resized(800, 600) // unchanged
+	w.resized(1024, 768)
+	w.resized(0, 0) // minimized
+	if len(calls) != 2 || calls[0] != [2]uint32{1024, 768} || calls[1] != [2]uint32{0, 0} {
+		t.Fatalf("OnResize calls = %v", calls)
+	}
+	if width, height := w.Size(); width != 0 || height != 0 {
+		t.Errorf("Size() = %dx%d, want 0x0", width, height)
+	}
+}
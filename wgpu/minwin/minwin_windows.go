@@ -0,0 +1,201 @@
+//go:build windows
+
+package minwin
+
+import (
+	"fmt"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"github.com/go-webgpu/webgpu/wgpu"
+	"golang.org/x/sys/windows"
+)
+
+const (
+	csHRedraw          = 0x0002
+	csVRedraw          = 0x0001
+	wmSize             = 0x0005
+	wmClose            = 0x0010
+	idcArrow           = 32512
+	pmRemove           = 0x0001
+	swShowNormal       = 1
+	wsOverlappedWindow = 0x00CF0000
+	wsThickFrame       = 0x00040000
+	wsMaximizeBox      = 0x00010000
+	cwUseDefault       = 0x80000000
+	className          = "GoWebGPUMinwin"
+)
+
+var (
+	user32               = windows.NewLazySystemDLL("user32.dll")
+	kernel32             = windows.NewLazySystemDLL("kernel32.dll")
+	procRegisterClassExW = user32.NewProc("RegisterClassExW")
+	procCreateWindowExW  = user32.NewProc("CreateWindowExW")
+	procDestroyWindow    = user32.NewProc("DestroyWindow")
+	procShowWindow       = user32.NewProc("ShowWindow")
+	procAdjustWindowRect = user32.NewProc("AdjustWindowRect")
+	procGetClientRect    = user32.NewProc("GetClientRect")
+	procPeekMessageW     = user32.NewProc("PeekMessageW")
+	procTranslateMessage = user32.NewProc("TranslateMessage")
+	procDispatchMessageW = user32.NewProc("DispatchMessageW")
+	procDefWindowProcW   = user32.NewProc("DefWindowProcW")
+	procLoadCursorW      = user32.NewProc("LoadCursorW")
+	procGetModuleHandleW = kernel32.NewProc("GetModuleHandleW")
+)
+
+type wndClassEx struct {
+	size       uint32
+	style      uint32
+	wndProc    uintptr
+	clsExtra   int32
+	wndExtra   int32
+	instance   windows.Handle
+	icon       windows.Handle
+	cursor     windows.Handle
+	background windows.Handle
+	menuName   *uint16
+	className  *uint16
+	iconSm     windows.Handle
+}
+
+type msg struct {
+	hwnd    windows.HWND
+	message uint32
+	wParam  uintptr
+	lParam  uintptr
+	time    uint32
+	pt      struct{ x, y int32 }
+}
+
+type rect struct{ left, top, right, bottom int32 }
+
+type native struct {
+	hinstance windows.Handle
+	hwnd      windows.HWND
+}
+
+var (
+	registerOnce sync.Once
+	registerErr  error
+	hinstance    windows.Handle
+	// windowsByHWND maps window handles to their Window for the window procedure,
+	// which is shared by every window of the class.
+	windowsByHWND = map[windows.HWND]*Window{}
+)
+
+// register registers the window class on first use.
+func register() error {
+	registerOnce.Do(func() {
+		ret, _, _ := procGetModuleHandleW.Call(0)
+		hinstance = windows.Handle(ret)
+		name, err := windows.UTF16PtrFromString(className)
+		if err != nil {
+			registerErr = err
+			return
+		}
+		cursor, _, _ := procLoadCursorW.Call(0, idcArrow)
+		wc := wndClassEx{
+			style:     csHRedraw | csVRedraw,
+			wndProc:   syscall.NewCallback(wndProc),
+			instance:  hinstance,
+			cursor:    windows.Handle(cursor),
+			className: name,
+		}
+		wc.size = uint32(unsafe.Sizeof(wc))
+		// nolint:gosec // Required for Win32 FFI - passing struct to Windows API
+		if ret, _, err := procRegisterClassExW.Call(uintptr(unsafe.Pointer(&wc))); ret == 0 {
+			registerErr = fmt.Errorf("minwin: RegisterClassExW: %w", err)
+		}
+	})
+	return registerErr
+}
+
+func (w *Window) open(cfg Config) error {
+	if err := register(); err != nil {
+		return err
+	}
+	style := uint32(wsOverlappedWindow)
+	if cfg.Fixed {
+		style &^= wsThickFrame | wsMaximizeBox
+	}
+	// Grow the outer size so the client area is the requested size.
+	r := rect{right: int32(cfg.Width), bottom: int32(cfg.Height)}
+	// nolint:gosec // Required for Win32 FFI - passing struct to Windows API
+	procAdjustWindowRect.Call(uintptr(unsafe.Pointer(&r)), uintptr(style), 0) //nolint:errcheck
+
+	name, err := windows.UTF16PtrFromString(className)
+	if err != nil {
+		return err
+	}
+	title, err := windows.UTF16PtrFromString(cfg.Title)
+	if err != nil {
+		return err
+	}
+	// nolint:gosec // Required for Win32 FFI - passing string pointers to Windows API
+	hwnd, _, callErr := procCreateWindowExW.Call(
+		0,
+		uintptr(unsafe.Pointer(name)),
+		uintptr(unsafe.Pointer(title)),
+		uintptr(style),
+		cwUseDefault, cwUseDefault,
+		uintptr(r.right-r.left), uintptr(r.bottom-r.top),
+		0, 0, uintptr(hinstance), 0,
+	)
+	if hwnd == 0 {
+		return fmt.Errorf("minwin: CreateWindowExW: %w", callErr)
+	}
+	w.hinstance, w.hwnd = hinstance, windows.HWND(hwnd)
+	windowsByHWND[w.hwnd] = w
+
+	procShowWindow.Call(hwnd, swShowNormal) //nolint:errcheck
+	var client rect
+	// nolint:gosec // Required for Win32 FFI - passing struct to Windows API
+	if ret, _, _ := procGetClientRect.Call(hwnd, uintptr(unsafe.Pointer(&client))); ret != 0 {
+		w.width, w.height = uint32(client.right-client.left), uint32(client.bottom-client.top)
+	}
+	return nil
+}
+
+func (w *Window) poll() {
+	var m msg
+	for {
+		// Messages of every window of the thread are dispatched, so several
+		// windows can be polled in turn.
+		// nolint:gosec // Required for Win32 FFI - passing MSG struct to Windows API
+		ret, _, _ := procPeekMessageW.Call(uintptr(unsafe.Pointer(&m)), 0, 0, 0, pmRemove)
+		if ret == 0 {
+			return
+		}
+		// nolint:gosec // Required for Win32 FFI - passing MSG struct to Windows API
+		procTranslateMessage.Call(uintptr(unsafe.Pointer(&m))) //nolint:errcheck
+		// nolint:gosec // Required for Win32 FFI - passing MSG struct to Windows API
+		procDispatchMessageW.Call(uintptr(unsafe.Pointer(&m))) //nolint:errcheck
+	}
+}
+
+// wndProc is the window procedure of the class.
+func wndProc(hwnd windows.HWND, message uint32, wParam, lParam uintptr) uintptr {
+	if w, ok := windowsByHWND[hwnd]; ok {
+		switch message {
+		case wmSize:
+			w.resized(uint32(lParam&0xFFFF), uint32((lParam>>16)&0xFFFF))
+			return 0
+		case wmClose:
+			// Keep the window until Close, so surfaces can be released first.
+			w.closed = true
+			return 0
+		}
+	}
+	ret, _, _ := procDefWindowProcW.Call(uintptr(hwnd), uintptr(message), wParam, lParam)
+	return ret
+}
+
+func (w *Window) destroy() {
+	delete(windowsByHWND, w.hwnd)
+	procDestroyWindow.Call(uintptr(w.hwnd)) //nolint:errcheck
+}
+
+func (w *Window) rawHandle() wgpu.RawWindowHandle {
+	return wgpu.RawWindowHandle{Kind: wgpu.WindowHandleWin32, Display: uintptr(w.hinstance), Window: uintptr(w.hwnd)}
+}
@@ -0,0 +1,134 @@
+package wgpu
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// Strides of the argument records read by the multi-draw commands.
+var (
+	drawIndirectStride        = uint64(unsafe.Sizeof(DrawIndirectArgs{}))
+	drawIndexedIndirectStride = uint64(unsafe.Sizeof(DrawIndexedIndirectArgs{}))
+)
+
+// checkIndirectRange checks that count argument records of stride bytes
+// starting at offset fit in a buffer of size bytes. size is 0 when unknown.
+func checkIndirectRange(offset uint64, count uint32, stride, size uint64) error {
+	if offset%4 != 0 {
+		return fmt.Errorf("indirect offset %d is not a multiple of 4", offset)
+	}
+	if end := offset + uint64(count)*stride; size != 0 && end > size {
+		return fmt.Errorf("%d draws of %d bytes at offset %d end at byte %d, past the end of the %d-byte buffer",
+			count, stride, offset, end, size)
+	}
+	return nil
+}
+
+// MultiDrawIndirect issues count draws whose [DrawIndirectArgs] are packed
+// one after another in indirectBuffer starting at indirectOffset, the same as
+// count DrawIndirect calls at increasing offsets but in one command, which
+// is what GPU-driven renderers writing their draw lists from a compute
+// shader need. wgpu-native extension; it is available on every backend and
+// emulated by a loop where the native API has no multi-draw.
+//
+// If the draws do not fit in the buffer the call is skipped and
+// [CommandEncoder.Finish] returns the error.
+func (rpe *RenderPassEncoder) MultiDrawIndirect(indirectBuffer *Buffer, indirectOffset uint64, count uint32) {
+	mustInit()
+	if rpe == nil || rpe.handle == 0 || indirectBuffer == nil || indirectBuffer.handle == 0 || count == 0 {
+		return
+	}
+	if err := checkIndirectRange(indirectOffset, count, drawIndirectStride, indirectBuffer.Size()); err != nil {
+		rpe.encoder.recordErr("RenderPassEncoder.MultiDrawIndirect", err)
+		return
+	}
+	procRenderPassEncoderMultiDrawIndirect.Call( //nolint:errcheck
+		rpe.handle,
+		indirectBuffer.handle,
+		uintptr(indirectOffset),
+		uintptr(count),
+	)
+}
+
+// MultiDrawIndexedIndirect is the indexed form of
+// [RenderPassEncoder.MultiDrawIndirect]: indirectBuffer holds count
+// [DrawIndexedIndirectArgs] records and an index buffer must be set.
+func (rpe *RenderPassEncoder) MultiDrawIndexedIndirect(indirectBuffer *Buffer, indirectOffset uint64, count uint32) {
+	mustInit()
+	if rpe == nil || rpe.handle == 0 || indirectBuffer == nil || indirectBuffer.handle == 0 || count == 0 {
+		return
+	}
+	if err := checkIndirectRange(indirectOffset, count, drawIndexedIndirectStride, indirectBuffer.Size()); err != nil {
+		rpe.encoder.recordErr("RenderPassEncoder.MultiDrawIndexedIndirect", err)
+		return
+	}
+	procRenderPassEncoderMultiDrawIndexedIndirect.Call( //nolint:errcheck
+		rpe.handle,
+		indirectBuffer.handle,
+		uintptr(indirectOffset),
+		uintptr(count),
+	)
+}
+
+// checkDrawCount checks the count buffer range of a MultiDraw*Count call:
+// a 4-byte aligned uint32 within a buffer of size bytes (0 when unknown).
+func checkDrawCount(offset, size uint64) error {
+	if offset%4 != 0 {
+		return fmt.Errorf("count buffer offset %d is not a multiple of 4", offset)
+	}
+	if size != 0 && offset+4 > size {
+		return fmt.Errorf("count buffer offset %d is past the end of the %d-byte buffer", offset, size)
+	}
+	return nil
+}
+
+// multiDrawCount validates and records a MultiDraw*Count call.
+func (rpe *RenderPassEncoder) multiDrawCount(op string, proc Proc, stride uint64,
+	indirectBuffer *Buffer, indirectOffset uint64, countBuffer *Buffer, countBufferOffset uint64, maxCount uint32) {
+	mustInit()
+	if rpe == nil || rpe.handle == 0 || indirectBuffer == nil || indirectBuffer.handle == 0 ||
+		countBuffer == nil || countBuffer.handle == 0 || maxCount == 0 {
+		return
+	}
+	if enc := rpe.encoder; enc != nil && enc.device != nil &&
+		!enc.device.HasFeature(FeatureName(NativeFeatureMultiDrawIndirectCount)) {
+		enc.recordErr(op, fmt.Errorf("requires NativeFeatureMultiDrawIndirectCount"))
+		return
+	}
+	err := checkIndirectRange(indirectOffset, maxCount, stride, indirectBuffer.Size())
+	if err == nil {
+		err = checkDrawCount(countBufferOffset, countBuffer.Size())
+	}
+	if err != nil {
+		rpe.encoder.recordErr(op, err)
+		return
+	}
+	proc.Call( //nolint:errcheck
+		rpe.handle,
+		indirectBuffer.handle,
+		uintptr(indirectOffset),
+		countBuffer.handle,
+		uintptr(countBufferOffset),
+		uintptr(maxCount),
+	)
+}
+
+// MultiDrawIndirectCount is [RenderPassEncoder.MultiDrawIndirect] with the
+// number of draws read on the GPU from the uint32 at countBufferOffset in
+// countBuffer, clamped to maxCount. A culling compute shader can then write
+// both the surviving draws and their count. indirectBuffer must hold
+// maxCount records. Requires NativeFeatureMultiDrawIndirectCount; without it,
+// or if a buffer range is invalid, the call is skipped and
+// [CommandEncoder.Finish] returns the error.
+func (rpe *RenderPassEncoder) MultiDrawIndirectCount(indirectBuffer *Buffer, indirectOffset uint64, countBuffer *Buffer, countBufferOffset uint64, maxCount uint32) {
+	rpe.multiDrawCount("RenderPassEncoder.MultiDrawIndirectCount", procRenderPassEncoderMultiDrawIndirectCount,
+		drawIndirectStride, indirectBuffer, indirectOffset, countBuffer, countBufferOffset, maxCount)
+}
+
+// MultiDrawIndexedIndirectCount is the indexed form of
+// [RenderPassEncoder.MultiDrawIndirectCount], reading [DrawIndexedIndirectArgs]
+// records.
+func (rpe *RenderPassEncoder) MultiDrawIndexedIndirectCount(indirectBuffer *Buffer, indirectOffset uint64, countBuffer *Buffer, countBufferOffset uint64, maxCount uint32) {
+	rpe.multiDrawCount("RenderPassEncoder.MultiDrawIndexedIndirectCount", procRenderPassEncoderMultiDrawIndexedIndirectCount,
+		drawIndexedIndirectStride, indirectBuffer, indirectOffset, countBuffer, countBufferOffset, maxCount)
+}
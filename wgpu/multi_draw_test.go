@@ -0,0 +1,43 @@
+package wgpu
+
+import "testing"
+
+func TestCheckIndirectRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		offset  uint64
+		count   uint32
+		stride  uint64
+		size    uint64
+		wantErr bool
+	}{
+		{"exact fit", 0, 4, drawIndirectStride, 64, false},
+		{"indexed exact fit", 20, 3, drawIndexedIndirectStride, 80, false},
+		{"one past the end", 16, 4, drawIndirectStride, 64, true},
+		{"unaligned offset", 2, 1, drawIndirectStride, 64, true},
+		{"unknown size", 4096, 1000, drawIndirectStride, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkIndirectRange(tt.offset, tt.count, tt.stride, tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	if drawIndirectStride != 16 || drawIndexedIndirectStride != 20 {
+		t.Errorf("strides = %d, %d; want 16, 20", drawIndirectStride, drawIndexedIndirectStride)
+	}
+}
+
+func TestCheckDrawCount(t *testing.T) {
+	if err := checkDrawCount(12, 16); err != nil {
+		t.Errorf("last uint32: %v", err)
+	}
+	if err := checkDrawCount(16, 16); err == nil {
+		t.Error("offset at the end: expected an error")
+	}
+	if err := checkDrawCount(6, 0); err == nil {
+		t.Error("unaligned offset: expected an error")
+	}
+}
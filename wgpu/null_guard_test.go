@@ -39,6 +39,29 @@ func TestNullGuard_Device_Creation(t *testing.T) {
 		}
 	})
 
+	t.Run("CreateShaderModuleGLSL", func(t *testing.T) {
+		result, err := d.CreateShaderModuleGLSL(&GLSLShaderDescriptor{
+			Stage: gputypes.ShaderStageCompute,
+			Code:  glslProbeShader,
+		})
+		if result != nil || err == nil {
+			t.Error("expected nil result and non-nil error for nil device")
+		}
+	})
+
+	t.Run("SupportsGLSL", func(t *testing.T) {
+		if d.SupportsGLSL(nil) {
+			t.Error("expected false for nil device")
+		}
+	})
+
+	t.Run("ShaderModule.GetCompilationInfo", func(t *testing.T) {
+		var s *ShaderModule
+		if messages, err := s.GetCompilationInfo(); messages != nil || err == nil {
+			t.Error("expected nil messages and non-nil error for nil shader module")
+		}
+	})
+
 	t.Run("CreateSampler", func(t *testing.T) {
 		result, err := d.CreateSampler(&SamplerDescriptor{})
 		if result != nil || err == nil {
@@ -270,6 +293,22 @@ func TestNullGuard_ComputePassEncoder(t *testing.T) {
 		cpe.SetBindGroup(0, nil, nil) // should not panic
 	})
 
+	t.Run("SetBindGroups", func(t *testing.T) {
+		cpe.SetBindGroups(0, []*BindGroup{nil}, nil) // should not panic
+	})
+
+	t.Run("InsertDebugMarker", func(t *testing.T) {
+		cpe.InsertDebugMarker("test") // should not panic
+	})
+
+	t.Run("PushDebugGroup", func(t *testing.T) {
+		cpe.PushDebugGroup("test") // should not panic
+	})
+
+	t.Run("PopDebugGroup", func(t *testing.T) {
+		cpe.PopDebugGroup() // should not panic
+	})
+
 	t.Run("DispatchWorkgroups", func(t *testing.T) {
 		cpe.DispatchWorkgroups(1, 1, 1) // should not panic
 	})
@@ -295,6 +334,10 @@ func TestNullGuard_RenderPassEncoder(t *testing.T) {
 		rpe.SetBindGroup(0, nil, nil) // should not panic
 	})
 
+	t.Run("SetBindGroups", func(t *testing.T) {
+		rpe.SetBindGroups(0, []*BindGroup{nil}, nil) // should not panic
+	})
+
 	t.Run("SetVertexBuffer", func(t *testing.T) {
 		rpe.SetVertexBuffer(0, nil, 0, 0) // should not panic
 	})
@@ -352,7 +395,7 @@ func TestNullGuard_RenderPassEncoder(t *testing.T) {
 	})
 
 	t.Run("ExecuteBundles", func(t *testing.T) {
-		rpe.ExecuteBundles(nil) // should not panic
+		rpe.ExecuteBundles() // should not panic
 	})
 }
 
@@ -430,6 +473,13 @@ func TestNullGuard_Texture(t *testing.T) {
 			t.Error("expected nil result and non-nil error for nil texture")
 		}
 	})
+
+	t.Run("DefaultView", func(t *testing.T) {
+		result, err := tex.DefaultView()
+		if result != nil || err == nil {
+			t.Error("expected nil result and non-nil error for nil texture")
+		}
+	})
 }
 
 // TestNullGuard_QuerySet tests nil queryset guards.
@@ -487,8 +537,8 @@ func TestNullGuard_ComputePipeline(t *testing.T) {
 	var cp *ComputePipeline
 
 	t.Run("GetBindGroupLayout", func(t *testing.T) {
-		if cp.GetBindGroupLayout(0) != nil {
-			t.Error("expected nil for nil pipeline")
+		if layout, err := cp.GetBindGroupLayout(0); layout != nil || err == nil {
+			t.Error("expected nil and an error for nil pipeline")
 		}
 	})
 }
@@ -498,8 +548,8 @@ func TestNullGuard_RenderPipeline(t *testing.T) {
 	var rp *RenderPipeline
 
 	t.Run("GetBindGroupLayout", func(t *testing.T) {
-		if rp.GetBindGroupLayout(0) != nil {
-			t.Error("expected nil for nil pipeline")
+		if layout, err := rp.GetBindGroupLayout(0); layout != nil || err == nil {
+			t.Error("expected nil and an error for nil pipeline")
 		}
 	})
 }
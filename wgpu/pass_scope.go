@@ -0,0 +1,35 @@
+package wgpu
+
+// WithRenderPass begins a render pass with desc, calls fn with it, then ends
+// and releases the pass, also when fn returns early or panics. It returns
+// the error from BeginRenderPass or from fn. fn may end the pass itself.
+// Errors recorded while encoding are returned by [CommandEncoder.Finish] as
+// usual.
+func (enc *CommandEncoder) WithRenderPass(desc *RenderPassDescriptor, fn func(pass *RenderPassEncoder) error) error {
+	pass, err := enc.BeginRenderPass(desc)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if !pass.ended {
+			pass.End()
+		}
+		pass.Release()
+	}()
+	return fn(pass)
+}
+
+// WithComputePass is [CommandEncoder.WithRenderPass] for compute passes.
+func (enc *CommandEncoder) WithComputePass(desc *ComputePassDescriptor, fn func(pass *ComputePassEncoder) error) error {
+	pass, err := enc.BeginComputePass(desc)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if !pass.ended {
+			pass.End()
+		}
+		pass.Release()
+	}()
+	return fn(pass)
+}
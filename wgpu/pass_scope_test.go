@@ -0,0 +1,23 @@
+package wgpu
+
+import "testing"
+
+func TestWithPassBeginError(t *testing.T) {
+	var enc *CommandEncoder
+	called := false
+	if err := enc.WithRenderPass(&RenderPassDescriptor{}, func(*RenderPassEncoder) error {
+		called = true
+		return nil
+	}); err == nil {
+		t.Error("WithRenderPass on a nil encoder: expected an error")
+	}
+	if err := enc.WithComputePass(nil, func(*ComputePassEncoder) error {
+		called = true
+		return nil
+	}); err == nil {
+		t.Error("WithComputePass on a nil encoder: expected an error")
+	}
+	if called {
+		t.Error("fn was called although the pass could not begin")
+	}
+}
@@ -0,0 +1,67 @@
+package wgpu
+
+import "sync"
+
+// renderPassScratch holds the native descriptor of one render pass. The
+// structs are pooled: the encoder that begins a pass keeps its scratch
+// reachable until Finish or Release, then returns it, so steady render
+// loops stop allocating descriptor memory per pass.
+//
+// The Go wrappers themselves (CommandEncoder, RenderPassEncoder) are not
+// pooled. Callers may keep them after Release, and a recycled wrapper would
+// let such a stale reference act on an unrelated live encoder.
+type renderPassScratch struct {
+	desc         renderPassDescriptor
+	colors       []renderPassColorAttachment
+	depthStencil renderPassDepthStencilAttachment
+	timestamps   passTimestampWrites
+	maxDrawCount renderPassMaxDrawCount
+}
+
+// computePassScratch is renderPassScratch for compute passes.
+type computePassScratch struct {
+	desc       computePassDescriptorWire
+	timestamps passTimestampWrites
+}
+
+var (
+	renderPassScratchPool  = sync.Pool{New: func() any { return new(renderPassScratch) }}
+	computePassScratchPool = sync.Pool{New: func() any { return new(computePassScratch) }}
+)
+
+// renderScratch returns a render pass scratch with n zeroed color
+// attachments, owned by enc until releaseScratch.
+func (enc *CommandEncoder) renderScratch(n int) *renderPassScratch {
+	s := renderPassScratchPool.Get().(*renderPassScratch)
+	colors := s.colors[:0]
+	if cap(colors) < n {
+		colors = make([]renderPassColorAttachment, 0, n)
+	}
+	*s = renderPassScratch{colors: colors[:n]}
+	clear(s.colors)
+	enc.renderScratches = append(enc.renderScratches, s)
+	return s
+}
+
+// computeScratch returns a zeroed compute pass scratch owned by enc until
+// releaseScratch.
+func (enc *CommandEncoder) computeScratch() *computePassScratch {
+	s := computePassScratchPool.Get().(*computePassScratch)
+	*s = computePassScratch{}
+	enc.computeScratches = append(enc.computeScratches, s)
+	return s
+}
+
+// releaseScratch returns the scratch of every pass begun on enc to the pools.
+func (enc *CommandEncoder) releaseScratch() {
+	for i, s := range enc.renderScratches {
+		renderPassScratchPool.Put(s)
+		enc.renderScratches[i] = nil
+	}
+	enc.renderScratches = enc.renderScratches[:0]
+	for i, s := range enc.computeScratches {
+		computePassScratchPool.Put(s)
+		enc.computeScratches[i] = nil
+	}
+	enc.computeScratches = enc.computeScratches[:0]
+}
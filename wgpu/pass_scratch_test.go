@@ -0,0 +1,51 @@
+package wgpu
+
+import "testing"
+
+func TestRenderScratchZeroed(t *testing.T) {
+	enc := &CommandEncoder{}
+	s := enc.renderScratch(2)
+	s.colors[0].view = 1
+	s.desc.label = stringToStringView("pass")
+	s.maxDrawCount.maxDrawCount = 7
+	enc.releaseScratch()
+	if len(enc.renderScratches) != 0 {
+		t.Fatalf("%d render scratches after release, want 0", len(enc.renderScratches))
+	}
+
+	for range 4 {
+		s := enc.renderScratch(1)
+		if len(s.colors) != 1 || s.colors[0] != (renderPassColorAttachment{}) {
+			t.Errorf("colors = %+v, want one zero attachment", s.colors)
+		}
+		if s.desc != (renderPassDescriptor{}) || s.maxDrawCount != (renderPassMaxDrawCount{}) {
+			t.Error("reused scratch was not zeroed")
+		}
+		enc.releaseScratch()
+	}
+}
+
+func TestComputeScratchTracked(t *testing.T) {
+	enc := &CommandEncoder{}
+	a, b := enc.computeScratch(), enc.computeScratch()
+	if a == b {
+		t.Fatal("two live passes share a scratch")
+	}
+	if len(enc.computeScratches) != 2 {
+		t.Fatalf("%d compute scratches, want 2", len(enc.computeScratches))
+	}
+	backing := enc.computeScratches[:2]
+	enc.releaseScratch()
+	if backing[0] != nil || backing[1] != nil {
+		t.Error("releaseScratch left references in the backing array")
+	}
+}
+
+func BenchmarkRenderScratch(b *testing.B) {
+	enc := &CommandEncoder{}
+	b.ReportAllocs()
+	for b.Loop() {
+		enc.renderScratch(4)
+		enc.releaseScratch()
+	}
+}
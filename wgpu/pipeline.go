@@ -1,7 +1,12 @@
 package wgpu
 
 import (
+	"fmt"
+	"runtime"
+	"slices"
 	"unsafe"
+
+	"github.com/gogpu/gputypes"
 )
 
 // ProgrammableStageDescriptor describes a programmable shader stage.
@@ -18,6 +23,13 @@ type ProgrammableStageDescriptor struct {
 type PipelineLayoutDescriptor struct {
 	Label            string
 	BindGroupLayouts []*BindGroupLayout
+	// ImmediateSize is the number of bytes of immediate data (push
+	// constants) the pipelines can read, declared in WGSL as
+	// var<immediate>. wgpu-native v29 replaced per-stage push constant
+	// ranges with this single block shared by all stages. It must be a
+	// multiple of 4 and requires NativeFeatureImmediates and a device
+	// requested with Limits.MaxImmediateSize at least this large.
+	ImmediateSize uint32
 }
 
 // pipelineLayoutDescriptorWire is the FFI-compatible C-layout struct for wgpu-native.
@@ -47,6 +59,7 @@ type NativeLimits struct {
 // PipelineLayoutExtras provides wgpu-native specific pipeline layout extensions.
 // Chain via NextInChain in PipelineLayoutDescriptor with SType = STypePipelineLayoutExtras.
 // v29 BREAKING: pushConstantRangeCount/pushConstantRanges replaced by immediateDataSize.
+// [PipelineLayoutDescriptor.ImmediateSize] sets the same size without chaining.
 type PipelineLayoutExtras struct {
 	Chain             ChainedStruct // chain.SType must be STypePipelineLayoutExtras
 	ImmediateDataSize uint32        // bytes of immediate data for shaders (requires NativeFeatureImmediates)
@@ -55,10 +68,15 @@ type PipelineLayoutExtras struct {
 // ComputePipelineDescriptor describes a compute pipeline to create.
 // Layout is nil for auto layout.
 type ComputePipelineDescriptor struct {
-	Label      string
-	Layout     *PipelineLayout // nil for auto layout
-	Module     *ShaderModule
+	Label  string
+	Layout *PipelineLayout // nil for auto layout
+	Module *ShaderModule
+	// EntryPoint names the @compute function. Leave it empty when the
+	// module has exactly one compute entry point.
 	EntryPoint string
+	// Constants sets WGSL override declarations, such as a workgroup size,
+	// by name or numeric @id.
+	Constants map[string]float64
 }
 
 // computePipelineDescriptorWire is the FFI-compatible C-layout struct for wgpu-native.
@@ -74,6 +92,19 @@ type computePipelineDescriptorWire struct {
 // CreatePipelineLayout creates a pipeline layout.
 // Returns an error if the FFI call fails or the device/descriptor is nil.
 func (d *Device) CreatePipelineLayout(desc *PipelineLayoutDescriptor) (*PipelineLayout, error) {
+	return d.createPipelineLayout(desc, false)
+}
+
+// CreatePipelineLayoutE is CreatePipelineLayout that also returns the
+// validation error raised by wgpu-native, such as for more bind group
+// layouts than the device supports.
+func (d *Device) CreatePipelineLayoutE(desc *PipelineLayoutDescriptor) (*PipelineLayout, error) {
+	return d.createPipelineLayout(desc, true)
+}
+
+// createPipelineLayout creates a pipeline layout, inside an error scope when
+// capture is set.
+func (d *Device) createPipelineLayout(desc *PipelineLayoutDescriptor, capture bool) (*PipelineLayout, error) {
 	if err := checkInit(); err != nil {
 		return nil, err
 	}
@@ -83,6 +114,11 @@ func (d *Device) CreatePipelineLayout(desc *PipelineLayoutDescriptor) (*Pipeline
 	if desc == nil {
 		return nil, &WGPUError{Op: "CreatePipelineLayout", Message: "descriptor is nil"}
 	}
+	if desc.ImmediateSize > 0 {
+		if err := validateImmediateSize(desc.ImmediateSize, d.HasFeature, d.limits.MaxImmediateSize); err != nil {
+			return nil, &WGPUError{Op: "CreatePipelineLayout", Type: ErrorTypeValidation, Message: err.Error()}
+		}
+	}
 
 	// Convert []*BindGroupLayout → []uintptr handles
 	var layoutsPtr uintptr
@@ -101,19 +137,61 @@ func (d *Device) CreatePipelineLayout(desc *PipelineLayoutDescriptor) (*Pipeline
 		Label:                stringToStringView(desc.Label),
 		BindGroupLayoutCount: uintptr(len(desc.BindGroupLayouts)),
 		BindGroupLayouts:     layoutsPtr,
+		ImmediateSize:        desc.ImmediateSize,
 	}
 
-	handle, _, _ := procDeviceCreatePipelineLayout.Call(
-		d.handle,
-		uintptr(unsafe.Pointer(&wire)),
-	)
+	var handle uintptr
+	create := func() {
+		handle, _, _ = procDeviceCreatePipelineLayout.Call(
+			d.handle,
+			uintptr(unsafe.Pointer(&wire)),
+		)
+	}
+	var err error
+	if capture {
+		err = d.captureValidation("CreatePipelineLayout", create)
+	} else {
+		create()
+	}
 	if handle == 0 {
 		return nil, &WGPUError{Op: "CreatePipelineLayout", Message: "wgpu returned null handle"}
 	}
-	trackResource(handle, "PipelineLayout")
-	return &PipelineLayout{handle: handle}, nil
+	trackResource(handle, "PipelineLayout", desc.Label)
+	pl := &PipelineLayout{
+		handle:        handle,
+		groups:        slices.Clone(desc.BindGroupLayouts),
+		immediateSize: desc.ImmediateSize,
+	}
+	if err != nil {
+		// wgpu-native returns an invalid layout rather than null.
+		pl.Release()
+		return nil, err
+	}
+	if traceActive.Load() {
+		traceCall("Device.CreatePipelineLayout", nil, pl, desc)
+	}
+	return pl, nil
+}
+
+// validateImmediateSize checks an immediate data size against the device
+// features and its MaxImmediateSize limit.
+func validateImmediateSize(size uint32, hasFeature func(FeatureName) bool, limit uint32) error {
+	if !hasFeature(FeatureName(NativeFeatureImmediates)) {
+		return fmt.Errorf("immediate data requires NativeFeatureImmediates")
+	}
+	if size%4 != 0 {
+		return fmt.Errorf("ImmediateSize %d is not a multiple of 4", size)
+	}
+	if size > limit {
+		return fmt.Errorf("ImmediateSize %d exceeds the device limit MaxImmediateSize %d; request a larger limit in DeviceDescriptor.RequiredLimits", size, limit)
+	}
+	return nil
 }
 
+// ImmediateSize returns the number of bytes of immediate data declared by
+// the layout.
+func (pl *PipelineLayout) ImmediateSize() uint32 { return pl.immediateSize }
+
 // CreatePipelineLayoutSimple creates a pipeline layout with the given bind group layouts.
 // Returns an error if the FFI call fails or the device is nil.
 func (d *Device) CreatePipelineLayoutSimple(layouts []*BindGroupLayout) (*PipelineLayout, error) {
@@ -136,7 +214,23 @@ func (pl *PipelineLayout) Handle() uintptr { return pl.handle }
 
 // CreateComputePipeline creates a compute pipeline.
 // Returns an error if the FFI call fails or the device/descriptor is nil.
+//
+// For modules created from WGSL the entry point must exist and be a @compute
+// function, and every binding it uses must match desc.Layout.
 func (d *Device) CreateComputePipeline(desc *ComputePipelineDescriptor) (*ComputePipeline, error) {
+	return d.createComputePipeline(desc, false)
+}
+
+// CreateComputePipelineE is CreateComputePipeline that also returns the
+// validation error raised by wgpu-native, as a *[PipelineSourceError] when
+// it refers to lines of the module's WGSL source.
+func (d *Device) CreateComputePipelineE(desc *ComputePipelineDescriptor) (*ComputePipeline, error) {
+	return d.createComputePipeline(desc, true)
+}
+
+// createComputePipeline creates a compute pipeline, inside an error scope
+// when capture is set.
+func (d *Device) createComputePipeline(desc *ComputePipelineDescriptor, capture bool) (*ComputePipeline, error) {
 	if err := checkInit(); err != nil {
 		return nil, err
 	}
@@ -150,10 +244,24 @@ func (d *Device) CreateComputePipeline(desc *ComputePipelineDescriptor) (*Comput
 		return nil, &WGPUError{Op: "CreateComputePipeline", Message: "shader module is nil"}
 	}
 
+	ep, err := reflectEntryPoint(desc.Module, desc.EntryPoint, gputypes.ShaderStageCompute)
+	if err == nil {
+		err = checkLayoutCompatible(desc.Layout, ep)
+	}
+	if err != nil {
+		return nil, &WGPUError{Op: "CreateComputePipeline", Type: ErrorTypeValidation, Message: err.Error()}
+	}
+
 	entryPointBytes := []byte(desc.EntryPoint)
 
+	constants, err := toConstantsWire(desc.Constants)
+	if err != nil {
+		return nil, &WGPUError{Op: "CreateComputePipeline", Type: ErrorTypeValidation, Message: err.Error()}
+	}
 	compute := ProgrammableStageDescriptor{
-		Module: desc.Module.handle,
+		Module:        desc.Module.handle,
+		ConstantCount: constants.count(),
+		Constants:     constants.ptr(),
 	}
 	if len(entryPointBytes) > 0 {
 		compute.EntryPoint = StringView{
@@ -161,7 +269,7 @@ func (d *Device) CreateComputePipeline(desc *ComputePipelineDescriptor) (*Comput
 			Length: uintptr(len(entryPointBytes)),
 		}
 	} else {
-		compute.EntryPoint = EmptyStringView()
+		compute.EntryPoint = NullStringView()
 	}
 
 	var layoutHandle uintptr
@@ -175,15 +283,36 @@ func (d *Device) CreateComputePipeline(desc *ComputePipelineDescriptor) (*Comput
 		Compute: compute,
 	}
 
-	handle, _, _ := procDeviceCreateComputePipeline.Call(
-		d.handle,
-		uintptr(unsafe.Pointer(&wire)),
-	)
+	var handle uintptr
+	create := func() {
+		handle, _, _ = procDeviceCreateComputePipeline.Call(
+			d.handle,
+			uintptr(unsafe.Pointer(&wire)),
+		)
+	}
+	if capture {
+		err = d.captureValidation("CreateComputePipeline", create)
+	} else {
+		create()
+	}
+	runtime.KeepAlive(constants)
 	if handle == 0 {
 		return nil, &WGPUError{Op: "CreateComputePipeline", Message: "wgpu returned null handle"}
 	}
-	trackResource(handle, "ComputePipeline")
-	return &ComputePipeline{handle: handle}, nil
+	trackResource(handle, "ComputePipeline", desc.Label)
+	if err != nil {
+		// wgpu-native returns an invalid pipeline rather than null.
+		(&ComputePipeline{handle: handle}).Release()
+		return nil, annotatePipelineError(err, stageSource{"compute", desc.Module})
+	}
+	cp := &ComputePipeline{
+		handle:           handle,
+		bindGroupLayouts: newBindGroupLayoutCache(d, desc.Layout, ep),
+	}
+	if traceActive.Load() {
+		traceCall("Device.CreateComputePipeline", nil, cp, desc)
+	}
+	return cp, nil
 }
 
 // CreateComputePipelineSimple creates a compute pipeline with the given shader and entry point.
@@ -197,27 +326,10 @@ func (d *Device) CreateComputePipelineSimple(layout *PipelineLayout, shader *Sha
 	})
 }
 
-// GetBindGroupLayout returns the bind group layout at the given index.
-// Useful for auto-layout pipelines.
-func (cp *ComputePipeline) GetBindGroupLayout(groupIndex uint32) *BindGroupLayout {
-	mustInit()
-	if cp == nil || cp.handle == 0 {
-		return nil
-	}
-	handle, _, _ := procComputePipelineGetBindGroupLayout.Call(
-		cp.handle,
-		uintptr(groupIndex),
-	)
-	if handle == 0 {
-		return nil
-	}
-	trackResource(handle, "BindGroupLayout")
-	return &BindGroupLayout{handle: handle}
-}
-
 // Release releases the compute pipeline.
 func (cp *ComputePipeline) Release() {
 	if cp.handle != 0 {
+		cp.bindGroupLayouts.release()
 		untrackResource(cp.handle)
 		procComputePipelineRelease.Call(cp.handle) //nolint:errcheck
 		cp.handle = 0
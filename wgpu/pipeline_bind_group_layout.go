@@ -0,0 +1,255 @@
+package wgpu
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/gogpu/gputypes"
+)
+
+// bindGroupLayoutCache holds the bind group layouts handed out by a
+// pipeline's GetBindGroupLayout, so each index is fetched from wgpu-native
+// once and released exactly once, with the pipeline.
+type bindGroupLayoutCache struct {
+	device *Device
+	// layout is the explicit pipeline layout, nil for auto layouts.
+	layout *PipelineLayout
+	// groupCount is the number of bind groups in the pipeline layout, or 0
+	// when it is not known.
+	groupCount uint32
+	// implicit holds the entries of each group of an auto layout, derived
+	// from the reflected entry points; nil when they are not known.
+	implicit map[uint32][]BindGroupLayoutEntry
+
+	mu     sync.Mutex
+	cached map[uint32]*BindGroupLayout
+}
+
+// newBindGroupLayoutCache prepares the cache of a pipeline created with
+// layout, or with an auto layout derived from the given entry points when
+// layout is nil. Entry points that could not be reflected leave the group
+// count unknown.
+func newBindGroupLayoutCache(d *Device, layout *PipelineLayout, eps ...*ShaderEntryPoint) *bindGroupLayoutCache {
+	c := &bindGroupLayoutCache{device: d, layout: layout}
+	if layout != nil {
+		if layout.groups != nil {
+			c.groupCount = uint32(len(layout.groups))
+		}
+		return c
+	}
+	for _, ep := range eps {
+		if ep == nil {
+			return c
+		}
+	}
+	c.implicit = make(map[uint32][]BindGroupLayoutEntry)
+	for _, ep := range eps {
+		for _, b := range ep.Bindings {
+			c.groupCount = max(c.groupCount, b.Group+1)
+			c.addImplicit(b, ep.Stage)
+		}
+	}
+	for _, entries := range c.implicit {
+		slices.SortFunc(entries, func(a, b BindGroupLayoutEntry) int { return int(a.Binding) - int(b.Binding) })
+	}
+	return c
+}
+
+// addImplicit adds binding b, used by stage, to the auto layout entries. An
+// auto layout makes each binding visible to the stages of the pipeline that
+// use it, so a binding shared by several stages gets one entry.
+func (c *bindGroupLayoutCache) addImplicit(b ShaderBinding, stage gputypes.ShaderStage) {
+	entries := c.implicit[b.Group]
+	for i := range entries {
+		if entries[i].Binding == b.Entry.Binding {
+			entries[i].Visibility |= stage
+			return
+		}
+	}
+	e := b.Entry
+	e.Visibility = stage
+	c.implicit[b.Group] = append(entries, e)
+}
+
+// entries returns the layout entries of group index and whether they are
+// known: they are for explicit layouts made of layouts created by
+// [Device.CreateBindGroupLayout], and for auto layouts whose entry points
+// could be reflected.
+func (c *bindGroupLayoutCache) entries(index uint32) ([]BindGroupLayoutEntry, bool) {
+	if c == nil || c.groupCount == 0 || index >= c.groupCount {
+		return nil, false
+	}
+	if c.layout == nil {
+		return slices.Clone(c.implicit[index]), c.implicit != nil
+	}
+	src := c.layout.groups[index]
+	if src == nil || src.pipelineOwned {
+		return nil, false
+	}
+	return slices.Clone(src.entries), true
+}
+
+// get returns the cached layout for index, fetching it with fetch on first use.
+func (c *bindGroupLayoutCache) get(op string, index uint32, fetch func() uintptr) (*BindGroupLayout, error) {
+	if c == nil {
+		return nil, &WGPUError{Op: op, Message: "pipeline was not created by a Device"}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if bgl, ok := c.cached[index]; ok {
+		return bgl, nil
+	}
+	if c.groupCount > 0 && index >= c.groupCount {
+		return nil, &WGPUError{Op: op, Type: ErrorTypeValidation,
+			Message: fmt.Sprintf("group %d is out of range; the pipeline layout has %d bind groups", index, c.groupCount)}
+	}
+	if c.device == nil || c.device.handle == 0 {
+		return nil, &WGPUError{Op: op, Message: "device is nil or released"}
+	}
+	if limit := c.device.limits.MaxBindGroups; limit > 0 && index >= limit {
+		return nil, &WGPUError{Op: op, Type: ErrorTypeValidation,
+			Message: fmt.Sprintf("group %d exceeds the device limit MaxBindGroups %d", index, limit)}
+	}
+
+	var handle uintptr
+	err := c.device.captureValidation(op, func() { handle = fetch() })
+	if handle == 0 {
+		return nil, &WGPUError{Op: op, Message: "wgpu returned null handle"}
+	}
+	trackResource(handle, "BindGroupLayout", "")
+	if err != nil {
+		procBindGroupLayoutRelease.Call(handle) //nolint:errcheck
+		untrackResource(handle)
+		return nil, err
+	}
+
+	bgl := &BindGroupLayout{handle: handle, pipelineOwned: true}
+	if c.layout != nil && int(index) < len(c.layout.groups) {
+		// Keep the explicit layout's entries so CreateBindGroup can check
+		// binding arrays against it.
+		if src := c.layout.groups[index]; src != nil {
+			bgl.arrayCounts = src.arrayCounts
+			bgl.entries = src.entries
+		}
+	}
+	if c.cached == nil {
+		c.cached = make(map[uint32]*BindGroupLayout)
+	}
+	c.cached[index] = bgl
+	return bgl, nil
+}
+
+// release releases every cached layout.
+func (c *bindGroupLayoutCache) release() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, bgl := range c.cached {
+		bgl.pipelineOwned = false
+		bgl.Release()
+	}
+	c.cached = nil
+}
+
+// GetBindGroupLayout returns the layout of bind group groupIndex, which is
+// how bind groups are created for pipelines with an auto layout.
+//
+// The layout is owned by the pipeline: repeated calls return the same
+// *BindGroupLayout, its Release method does nothing, and it is released by
+// [ComputePipeline.Release]. Returns an error if groupIndex is not a group of
+// the pipeline layout.
+func (cp *ComputePipeline) GetBindGroupLayout(groupIndex uint32) (*BindGroupLayout, error) {
+	if err := checkInit(); err != nil {
+		return nil, err
+	}
+	if cp == nil || cp.handle == 0 {
+		return nil, &WGPUError{Op: "ComputePipeline.GetBindGroupLayout", Message: "pipeline is nil or released"}
+	}
+	bgl, err := cp.bindGroupLayouts.get("ComputePipeline.GetBindGroupLayout", groupIndex, func() uintptr {
+		handle, _, _ := procComputePipelineGetBindGroupLayout.Call(cp.handle, uintptr(groupIndex))
+		return handle
+	})
+	if err == nil && traceActive.Load() {
+		traceCall("ComputePipeline.GetBindGroupLayout", cp, bgl, groupIndex)
+	}
+	return bgl, err
+}
+
+// GetBindGroupLayout returns the layout of bind group groupIndex. It follows
+// the same ownership rules as [ComputePipeline.GetBindGroupLayout]; the
+// layout is released by [RenderPipeline.Release].
+func (rp *RenderPipeline) GetBindGroupLayout(groupIndex uint32) (*BindGroupLayout, error) {
+	if err := checkInit(); err != nil {
+		return nil, err
+	}
+	if rp == nil || rp.handle == 0 {
+		return nil, &WGPUError{Op: "RenderPipeline.GetBindGroupLayout", Message: "pipeline is nil or released"}
+	}
+	bgl, err := rp.bindGroupLayouts.get("RenderPipeline.GetBindGroupLayout", groupIndex, func() uintptr {
+		handle, _, _ := procRenderPipelineGetBindGroupLayout.Call(rp.handle, uintptr(groupIndex))
+		return handle
+	})
+	if err == nil && traceActive.Load() {
+		traceCall("RenderPipeline.GetBindGroupLayout", rp, bgl, groupIndex)
+	}
+	return bgl, err
+}
+
+// BindGroupCount returns the number of bind groups in the pipeline layout,
+// or 0 when it is not known: for auto layouts whose shaders were not created
+// from WGSL or could not be reflected, and for explicit layouts created
+// without their bind group layouts.
+func (cp *ComputePipeline) BindGroupCount() uint32 {
+	if cp == nil || cp.bindGroupLayouts == nil {
+		return 0
+	}
+	return cp.bindGroupLayouts.groupCount
+}
+
+// BindGroupLayoutEntries returns the entries of bind group groupIndex and
+// true, or false when they are not known. For pipelines created with an auto
+// layout the entries are derived from the WGSL source of the entry points,
+// with each binding visible to the stages that use it, which is what
+// wgpu-native derives; bind groups created with
+// [ComputePipeline.GetBindGroupLayout] must bind a resource to each of them.
+func (cp *ComputePipeline) BindGroupLayoutEntries(groupIndex uint32) ([]BindGroupLayoutEntry, bool) {
+	if cp == nil {
+		return nil, false
+	}
+	return cp.bindGroupLayouts.entries(groupIndex)
+}
+
+// BindGroupCount returns the number of bind groups in the pipeline layout,
+// or 0 when it is not known. See [ComputePipeline.BindGroupCount].
+func (rp *RenderPipeline) BindGroupCount() uint32 {
+	if rp == nil || rp.bindGroupLayouts == nil {
+		return 0
+	}
+	return rp.bindGroupLayouts.groupCount
+}
+
+// BindGroupLayoutEntries returns the entries of bind group groupIndex and
+// true, or false when they are not known. Bindings used by both the vertex
+// and fragment stage appear once, visible to both. See
+// [ComputePipeline.BindGroupLayoutEntries].
+func (rp *RenderPipeline) BindGroupLayoutEntries(groupIndex uint32) ([]BindGroupLayoutEntry, bool) {
+	if rp == nil {
+		return nil, false
+	}
+	return rp.bindGroupLayouts.entries(groupIndex)
+}
+
+// renderEntryPoints reflects the vertex and fragment entry points of desc,
+// returning nil for a stage that cannot be reflected.
+func renderEntryPoints(desc *RenderPipelineDescriptor) []*ShaderEntryPoint {
+	vs, _ := reflectEntryPoint(desc.Vertex.Module, desc.Vertex.EntryPoint, gputypes.ShaderStageVertex)
+	eps := []*ShaderEntryPoint{vs}
+	if desc.Fragment != nil {
+		fs, _ := reflectEntryPoint(desc.Fragment.Module, desc.Fragment.EntryPoint, gputypes.ShaderStageFragment)
+		eps = append(eps, fs)
+	}
+	return eps
+}
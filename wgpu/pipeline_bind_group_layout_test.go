@@ -0,0 +1,106 @@
+package wgpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestNewBindGroupLayoutCacheGroupCount(t *testing.T) {
+	eps, err := ReflectWGSLEntryPoints(reflectTestShader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := map[string]*ShaderEntryPoint{}
+	for i := range eps {
+		byName[eps[i].Name] = &eps[i]
+	}
+	explicit := &PipelineLayout{handle: 1, groups: []*BindGroupLayout{{handle: 2}, {handle: 3}}}
+
+	tests := []struct {
+		name   string
+		layout *PipelineLayout
+		eps    []*ShaderEntryPoint
+		want   uint32
+	}{
+		{"explicit layout", explicit, nil, 2},
+		{"layout not created here", &PipelineLayout{handle: 1}, nil, 0},
+		{"auto vertex only", nil, []*ShaderEntryPoint{byName["vs_main"]}, 1},
+		{"auto vertex and fragment", nil, []*ShaderEntryPoint{byName["vs_main"], byName["fs_main"]}, 3},
+		{"auto compute", nil, []*ShaderEntryPoint{byName["simulate"]}, 2},
+		{"unreflected stage", nil, []*ShaderEntryPoint{byName["vs_main"], nil}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newBindGroupLayoutCache(nil, tt.layout, tt.eps...).groupCount; got != tt.want {
+				t.Errorf("groupCount = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBindGroupLayoutCacheOutOfRange(t *testing.T) {
+	c := newBindGroupLayoutCache(nil, &PipelineLayout{handle: 1, groups: []*BindGroupLayout{{handle: 2}}})
+	if _, err := c.get("test", 1, func() uintptr { t.Fatal("fetch called"); return 0 }); err == nil {
+		t.Error("expected an error for group 1 of a one-group layout")
+	}
+	var nilCache *bindGroupLayoutCache
+	if _, err := nilCache.get("test", 0, nil); err == nil {
+		t.Error("expected an error from a nil cache")
+	}
+	nilCache.release() // must not panic
+}
+
+func TestPipelineOwnedLayoutReleaseIsNoOp(t *testing.T) {
+	bgl := &BindGroupLayout{handle: 42, pipelineOwned: true}
+	bgl.Release()
+	if bgl.handle != 42 {
+		t.Error("Release must not release a pipeline-owned layout")
+	}
+}
+
+func TestBindGroupLayoutCacheEntries(t *testing.T) {
+	eps, err := ReflectWGSLEntryPoints(reflectTestShader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := map[string]*ShaderEntryPoint{}
+	for i := range eps {
+		byName[eps[i].Name] = &eps[i]
+	}
+
+	rp := &RenderPipeline{bindGroupLayouts: newBindGroupLayoutCache(nil, nil, byName["vs_main"], byName["fs_main"])}
+	entries, ok := rp.BindGroupLayoutEntries(0)
+	if !ok || len(entries) != 3 {
+		t.Fatalf("group 0 = %d entries, known %v; want 3 known entries", len(entries), ok)
+	}
+	want := []gputypes.ShaderStage{
+		gputypes.ShaderStageVertex | gputypes.ShaderStageFragment, // globals
+		gputypes.ShaderStageFragment,                              // albedo
+		gputypes.ShaderStageFragment,                              // albedo_sampler
+	}
+	for i, e := range entries {
+		if e.Binding != uint32(i) || e.Visibility != want[i] {
+			t.Errorf("entry %d: binding %d visibility %v, want binding %d visibility %v", i, e.Binding, e.Visibility, i, want[i])
+		}
+	}
+	if _, ok := rp.BindGroupLayoutEntries(rp.BindGroupCount()); ok {
+		t.Error("group past the end reported as known")
+	}
+
+	unreflected := &ComputePipeline{bindGroupLayouts: newBindGroupLayoutCache(nil, nil, nil)}
+	if n := unreflected.BindGroupCount(); n != 0 {
+		t.Errorf("unreflected BindGroupCount = %d, want 0", n)
+	}
+
+	explicit := &ComputePipeline{bindGroupLayouts: newBindGroupLayoutCache(nil, &PipelineLayout{groups: []*BindGroupLayout{
+		{handle: 1, entries: []BindGroupLayoutEntry{{Binding: 4}}},
+		{handle: 2, pipelineOwned: true},
+	}})}
+	if e, ok := explicit.BindGroupLayoutEntries(0); !ok || len(e) != 1 || e[0].Binding != 4 {
+		t.Errorf("explicit group 0 = %v, %v", e, ok)
+	}
+	if _, ok := explicit.BindGroupLayoutEntries(1); ok {
+		t.Error("entries of a pipeline-owned layout reported as known")
+	}
+}
@@ -0,0 +1,129 @@
+package wgpu
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// PipelineCacheMap memoizes render pipelines by their state, so materials
+// that switch between a few blend, depth or format combinations reuse the
+// pipeline created the first time instead of compiling a new one per frame.
+//
+// Pipelines returned by the map are owned by it: do not release them, call
+// [PipelineCacheMap.Release] instead. The key uses the handles of the shader
+// modules and pipeline layout, so release the map's pipelines before
+// releasing the modules or layouts they were created from.
+//
+// A PipelineCacheMap is safe for concurrent use.
+type PipelineCacheMap struct {
+	device *Device
+
+	mu        sync.Mutex
+	pipelines map[string]*RenderPipeline
+}
+
+// NewPipelineCacheMap returns an empty cache creating pipelines on device.
+func NewPipelineCacheMap(device *Device) *PipelineCacheMap {
+	return &PipelineCacheMap{device: device, pipelines: make(map[string]*RenderPipeline)}
+}
+
+// GetOrCreate returns the cached pipeline for the state in desc, creating it
+// with [Device.CreateRenderPipeline] on first use. Labels are not part of the
+// key. Creation errors are returned and not cached.
+func (c *PipelineCacheMap) GetOrCreate(desc *RenderPipelineDescriptor) (*RenderPipeline, error) {
+	if desc == nil {
+		return nil, &WGPUError{Op: "PipelineCacheMap.GetOrCreate", Message: "descriptor is nil"}
+	}
+	key := renderPipelineKey(desc)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if p, ok := c.pipelines[key]; ok {
+		return p, nil
+	}
+	p, err := c.device.CreateRenderPipeline(desc)
+	if err != nil {
+		return nil, err
+	}
+	c.pipelines[key] = p
+	return p, nil
+}
+
+// Len returns the number of cached pipelines.
+func (c *PipelineCacheMap) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.pipelines)
+}
+
+// Release releases every cached pipeline and empties the cache. The cache
+// can be used again afterwards.
+func (c *PipelineCacheMap) Release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, p := range c.pipelines {
+		p.Release()
+		delete(c.pipelines, key)
+	}
+}
+
+// renderPipelineKey encodes every field of desc that affects the pipeline,
+// except the label, into a comparable string.
+func renderPipelineKey(desc *RenderPipelineDescriptor) string {
+	var b strings.Builder
+	handle := func(h uintptr) { fmt.Fprintf(&b, "%x|", h) }
+	constants := func(m map[string]float64) {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			fmt.Fprintf(&b, "%q=%v,", k, m[k])
+		}
+		b.WriteByte('|')
+	}
+
+	if desc.Layout != nil {
+		handle(desc.Layout.handle)
+	} else {
+		b.WriteString("auto|")
+	}
+
+	if desc.Vertex.Module != nil {
+		handle(desc.Vertex.Module.handle)
+	}
+	fmt.Fprintf(&b, "%q|", desc.Vertex.EntryPoint)
+	constants(desc.Vertex.Constants)
+	for _, buf := range desc.Vertex.Buffers {
+		fmt.Fprintf(&b, "vb%d,%d:", buf.ArrayStride, buf.StepMode)
+		for _, a := range buf.Attributes {
+			fmt.Fprintf(&b, "%d,%d,%d;", a.Format, a.Offset, a.ShaderLocation)
+		}
+		b.WriteByte('|')
+	}
+
+	fmt.Fprintf(&b, "prim%+v|ms%+v|", desc.Primitive, desc.Multisample)
+	if ds := desc.DepthStencil; ds != nil {
+		fmt.Fprintf(&b, "ds%+v|", *ds)
+	}
+
+	if f := desc.Fragment; f != nil {
+		b.WriteString("fs")
+		if f.Module != nil {
+			handle(f.Module.handle)
+		}
+		fmt.Fprintf(&b, "%q|", f.EntryPoint)
+		constants(f.Constants)
+		for _, t := range f.Targets {
+			fmt.Fprintf(&b, "ct%d,%d", t.Format, t.WriteMask)
+			if t.Blend != nil {
+				fmt.Fprintf(&b, ",%+v", *t.Blend)
+			}
+			b.WriteByte('|')
+		}
+	}
+	return b.String()
+}
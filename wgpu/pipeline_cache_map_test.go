@@ -0,0 +1,86 @@
+package wgpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestRenderPipelineKey(t *testing.T) {
+	shader := &ShaderModule{handle: 0x10}
+	base := func() *RenderPipelineDescriptor {
+		desc, err := NewRenderPipelineBuilder(nil).
+			VS(shader, "vs_main").
+			FS(shader, "fs_main").
+			ColorTarget(gputypes.TextureFormatBGRA8Unorm).
+			Depth(gputypes.TextureFormatDepth24Plus, gputypes.CompareFunctionLess).
+			VertexLayout(12, gputypes.VertexStepModeVertex,
+				VertexAttribute{Format: gputypes.VertexFormatFloat32x3}).
+			Descriptor()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return desc
+	}
+	alpha := BlendAlpha()
+	additive := BlendAdditive()
+
+	same := []func(*RenderPipelineDescriptor){
+		func(d *RenderPipelineDescriptor) { d.Label = "renamed" },
+		func(d *RenderPipelineDescriptor) {
+			// Attributes are compared by value, not by slice identity.
+			d.Vertex.Buffers[0].Attributes = []VertexAttribute{{Format: gputypes.VertexFormatFloat32x3}}
+		},
+	}
+	different := map[string]func(*RenderPipelineDescriptor){
+		"vertex module":   func(d *RenderPipelineDescriptor) { d.Vertex.Module = &ShaderModule{handle: 0x20} },
+		"entry point":     func(d *RenderPipelineDescriptor) { d.Fragment.EntryPoint = "fs_other" },
+		"target format":   func(d *RenderPipelineDescriptor) { d.Fragment.Targets[0].Format = gputypes.TextureFormatRGBA8Unorm },
+		"blend":           func(d *RenderPipelineDescriptor) { d.Fragment.Targets[0].Blend = &alpha },
+		"depth compare":   func(d *RenderPipelineDescriptor) { d.DepthStencil.DepthCompare = gputypes.CompareFunctionLessEqual },
+		"no depth":        func(d *RenderPipelineDescriptor) { d.DepthStencil = nil },
+		"cull mode":       func(d *RenderPipelineDescriptor) { d.Primitive.CullMode = gputypes.CullModeBack },
+		"sample count":    func(d *RenderPipelineDescriptor) { d.Multisample.Count = 4 },
+		"vertex stride":   func(d *RenderPipelineDescriptor) { d.Vertex.Buffers[0].ArrayStride = 16 },
+		"constants":       func(d *RenderPipelineDescriptor) { d.Vertex.Constants = map[string]float64{"scale": 2} },
+		"explicit layout": func(d *RenderPipelineDescriptor) { d.Layout = &PipelineLayout{handle: 0x30} },
+	}
+
+	want := renderPipelineKey(base())
+	for i, modify := range same {
+		d := base()
+		modify(d)
+		if got := renderPipelineKey(d); got != want {
+			t.Errorf("same[%d]: key changed:\n got %s\nwant %s", i, got, want)
+		}
+	}
+	seen := map[string]string{want: "base"}
+	for name, modify := range different {
+		d := base()
+		modify(d)
+		got := renderPipelineKey(d)
+		if prev, ok := seen[got]; ok {
+			t.Errorf("%s: key collides with %s", name, prev)
+		}
+		seen[got] = name
+	}
+
+	d := base()
+	d.Fragment.Targets[0].Blend = &alpha
+	withAlpha := renderPipelineKey(d)
+	d.Fragment.Targets[0].Blend = &additive
+	if renderPipelineKey(d) == withAlpha {
+		t.Error("different blend states must produce different keys")
+	}
+}
+
+func TestPipelineCacheMapNilDescriptor(t *testing.T) {
+	c := NewPipelineCacheMap(nil)
+	if _, err := c.GetOrCreate(nil); err == nil {
+		t.Error("expected an error for a nil descriptor")
+	}
+	if c.Len() != 0 {
+		t.Errorf("Len = %d, want 0", c.Len())
+	}
+	c.Release()
+}
@@ -0,0 +1,69 @@
+package wgpu
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"unsafe"
+)
+
+// constantEntryWire matches WGPUConstantEntry.
+// nextInChain(8)+key(16)+value(8) = 32 bytes.
+type constantEntryWire struct {
+	NextInChain uintptr
+	Key         StringView
+	Value       float64
+}
+
+// pipelineConstants is the wire form of a stage's override constants. The
+// entries point into keys, so both must stay reachable until the FFI call
+// returns.
+type pipelineConstants struct {
+	entries []constantEntryWire
+	keys    [][]byte
+}
+
+// count and ptr fill the constantCount and constants fields of a stage.
+func (c *pipelineConstants) count() uintptr { return uintptr(len(c.entries)) }
+
+func (c *pipelineConstants) ptr() uintptr {
+	if len(c.entries) == 0 {
+		return 0
+	}
+	return uintptr(unsafe.Pointer(&c.entries[0]))
+}
+
+// toConstantsWire converts the override constants of a pipeline stage,
+// keyed by WGSL override name or numeric @id, into wire entries in key order.
+// Values must be finite; they are converted to the override's type by
+// wgpu-native.
+func toConstantsWire(constants map[string]float64) (pipelineConstants, error) {
+	var c pipelineConstants
+	if len(constants) == 0 {
+		return c, nil
+	}
+	keys := make([]string, 0, len(constants))
+	for k := range constants {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	c.entries = make([]constantEntryWire, len(keys))
+	c.keys = make([][]byte, len(keys))
+	for i, k := range keys {
+		v := constants[k]
+		if k == "" {
+			return pipelineConstants{}, errors.New("override constant with empty name")
+		}
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return pipelineConstants{}, fmt.Errorf("override constant %q is not finite", k)
+		}
+		c.keys[i] = []byte(k)
+		c.entries[i] = constantEntryWire{
+			Key:   StringView{Data: uintptr(unsafe.Pointer(&c.keys[i][0])), Length: uintptr(len(k))},
+			Value: v,
+		}
+	}
+	return c, nil
+}
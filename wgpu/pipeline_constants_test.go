@@ -0,0 +1,44 @@
+package wgpu
+
+import (
+	"math"
+	"testing"
+	"unsafe"
+)
+
+func TestToConstantsWire(t *testing.T) {
+	c, err := toConstantsWire(map[string]float64{"workgroup_size": 64, "1300": 0.5, "use_fog": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.count() != 3 || c.ptr() != uintptr(unsafe.Pointer(&c.entries[0])) {
+		t.Fatalf("count = %d, ptr mismatch", c.count())
+	}
+	// Entries are sorted by key so the wire form is deterministic.
+	want := []struct {
+		key   string
+		value float64
+	}{{"1300", 0.5}, {"use_fog", 1}, {"workgroup_size", 64}}
+	for i, w := range want {
+		e := c.entries[i]
+		key := unsafe.String((*byte)(unsafe.Pointer(&c.keys[i][0])), e.Key.Length)
+		if key != w.key || e.Value != w.value || e.Key.Data != uintptr(unsafe.Pointer(&c.keys[i][0])) {
+			t.Errorf("entry %d = %q=%v, want %q=%v", i, key, e.Value, w.key, w.value)
+		}
+	}
+
+	empty, err := toConstantsWire(nil)
+	if err != nil || empty.count() != 0 || empty.ptr() != 0 {
+		t.Errorf("nil constants = %d entries, ptr %#x, err %v", empty.count(), empty.ptr(), err)
+	}
+
+	for name, constants := range map[string]map[string]float64{
+		"empty key": {"": 1},
+		"NaN":       {"x": math.NaN()},
+		"Inf":       {"x": math.Inf(1)},
+	} {
+		if _, err := toConstantsWire(constants); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
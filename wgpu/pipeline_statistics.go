@@ -0,0 +1,69 @@
+package wgpu
+
+import "fmt"
+
+// checkStatisticsQuery checks that querySet is a pipeline statistics query
+// set containing queryIndex.
+func checkStatisticsQuery(querySet *QuerySet, queryIndex uint32) error {
+	if querySet.typ != QueryTypePipelineStatistics {
+		return fmt.Errorf("query set is not a QueryTypePipelineStatistics set")
+	}
+	if queryIndex >= querySet.count {
+		return fmt.Errorf("query index %d is out of range for a query set of %d", queryIndex, querySet.count)
+	}
+	return nil
+}
+
+// BeginPipelineStatisticsQuery starts counting the statistics of querySet
+// into query queryIndex until [RenderPassEncoder.EndPipelineStatisticsQuery].
+// Queries cannot nest. wgpu-native extension; requires
+// NativeFeaturePipelineStatisticsQuery.
+//
+// If querySet is not a pipeline statistics set or queryIndex is out of range
+// the call is skipped and [CommandEncoder.Finish] returns the error.
+func (rpe *RenderPassEncoder) BeginPipelineStatisticsQuery(querySet *QuerySet, queryIndex uint32) {
+	mustInit()
+	if rpe == nil || rpe.handle == 0 || querySet == nil || querySet.handle == 0 {
+		return
+	}
+	if err := checkStatisticsQuery(querySet, queryIndex); err != nil {
+		rpe.encoder.recordErr("RenderPassEncoder.BeginPipelineStatisticsQuery", err)
+		return
+	}
+	procRenderPassEncoderBeginPipelineStatisticsQuery.Call(rpe.handle, querySet.handle, uintptr(queryIndex)) //nolint:errcheck
+}
+
+// EndPipelineStatisticsQuery ends the query begun by
+// [RenderPassEncoder.BeginPipelineStatisticsQuery].
+func (rpe *RenderPassEncoder) EndPipelineStatisticsQuery() {
+	mustInit()
+	if rpe == nil || rpe.handle == 0 {
+		return
+	}
+	procRenderPassEncoderEndPipelineStatisticsQuery.Call(rpe.handle) //nolint:errcheck
+}
+
+// BeginPipelineStatisticsQuery starts counting the statistics of querySet
+// into query queryIndex until [ComputePassEncoder.EndPipelineStatisticsQuery].
+// It follows the rules of [RenderPassEncoder.BeginPipelineStatisticsQuery].
+func (cpe *ComputePassEncoder) BeginPipelineStatisticsQuery(querySet *QuerySet, queryIndex uint32) {
+	mustInit()
+	if cpe == nil || cpe.handle == 0 || querySet == nil || querySet.handle == 0 {
+		return
+	}
+	if err := checkStatisticsQuery(querySet, queryIndex); err != nil {
+		cpe.encoder.recordErr("ComputePassEncoder.BeginPipelineStatisticsQuery", err)
+		return
+	}
+	procComputePassEncoderBeginPipelineStatisticsQuery.Call(cpe.handle, querySet.handle, uintptr(queryIndex)) //nolint:errcheck
+}
+
+// EndPipelineStatisticsQuery ends the query begun by
+// [ComputePassEncoder.BeginPipelineStatisticsQuery].
+func (cpe *ComputePassEncoder) EndPipelineStatisticsQuery() {
+	mustInit()
+	if cpe == nil || cpe.handle == 0 {
+		return
+	}
+	procComputePassEncoderEndPipelineStatisticsQuery.Call(cpe.handle) //nolint:errcheck
+}
@@ -0,0 +1,53 @@
+package wgpu
+
+import "testing"
+
+func TestValidateQuerySetDescriptor(t *testing.T) {
+	none := func(FeatureName) bool { return false }
+	statsFeature := func(f FeatureName) bool { return f == FeatureName(NativeFeaturePipelineStatisticsQuery) }
+	stats := []PipelineStatisticName{PipelineStatisticVertexShaderInvocations, PipelineStatisticFragmentShaderInvocations}
+	tests := []struct {
+		name       string
+		desc       QuerySetDescriptor
+		hasFeature func(FeatureName) bool
+		wantErr    bool
+	}{
+		{"timestamp", QuerySetDescriptor{Type: QueryTypeTimestamp, Count: 2}, none, false},
+		{"statistics", QuerySetDescriptor{Type: QueryTypePipelineStatistics, Count: 1, PipelineStatistics: stats}, statsFeature, false},
+		{"statistics without feature", QuerySetDescriptor{Type: QueryTypePipelineStatistics, Count: 1, PipelineStatistics: stats}, none, true},
+		{"statistics without counters", QuerySetDescriptor{Type: QueryTypePipelineStatistics, Count: 1}, statsFeature, true},
+		{"duplicate counter", QuerySetDescriptor{Type: QueryTypePipelineStatistics, Count: 1,
+			PipelineStatistics: []PipelineStatisticName{PipelineStatisticClipperInvocations, PipelineStatisticClipperInvocations}}, statsFeature, true},
+		{"unknown counter", QuerySetDescriptor{Type: QueryTypePipelineStatistics, Count: 1,
+			PipelineStatistics: []PipelineStatisticName{9}}, statsFeature, true},
+		{"counters on occlusion set", QuerySetDescriptor{Type: QueryTypeOcclusion, Count: 1, PipelineStatistics: stats}, statsFeature, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateQuerySetDescriptor(&tt.desc, tt.hasFeature)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckStatisticsQuery(t *testing.T) {
+	qs := &QuerySet{handle: 1, typ: QueryTypePipelineStatistics, count: 2,
+		statistics: []PipelineStatisticName{PipelineStatisticVertexShaderInvocations, PipelineStatisticComputeShaderInvocations}}
+	if err := checkStatisticsQuery(qs, 1); err != nil {
+		t.Errorf("index 1: %v", err)
+	}
+	if err := checkStatisticsQuery(qs, 2); err == nil {
+		t.Error("index 2: expected an out of range error")
+	}
+	if err := checkStatisticsQuery(&QuerySet{handle: 1, typ: QueryTypeTimestamp, count: 4}, 0); err == nil {
+		t.Error("timestamp set: expected an error")
+	}
+	if got := qs.ResultStride(); got != 16 {
+		t.Errorf("ResultStride = %d, want 16", got)
+	}
+	if got := (&QuerySet{typ: QueryTypeOcclusion}).ResultStride(); got != 8 {
+		t.Errorf("occlusion ResultStride = %d, want 8", got)
+	}
+}
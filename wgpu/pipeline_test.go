@@ -143,11 +143,10 @@ func TestComputePipelineGetBindGroupLayout(t *testing.T) {
 
 	// Get bind group layout
 	t.Log("Getting bind group layout from pipeline...")
-	layout := pipeline.GetBindGroupLayout(0)
-	if layout == nil {
-		t.Fatal("GetBindGroupLayout returned nil")
+	layout, err := pipeline.GetBindGroupLayout(0)
+	if err != nil {
+		t.Fatalf("GetBindGroupLayout failed: %v", err)
 	}
-	defer layout.Release()
 
 	t.Logf("Auto BindGroupLayout: handle=%#x", layout.Handle())
 }
@@ -0,0 +1,208 @@
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+)
+
+// reflectEntryPoint finds the entry point a pipeline stage will use in a
+// module created from WGSL. An empty name selects the only entry point of the
+// given stage. It returns nil without error when module has no WGSL source or
+// the reflection parser cannot read it; wgpu-native then validates alone.
+func reflectEntryPoint(module *ShaderModule, name string, stage gputypes.ShaderStage) (*ShaderEntryPoint, error) {
+	if module == nil || module.source == "" {
+		return nil, nil
+	}
+	eps, err := ReflectWGSLEntryPoints(module.source)
+	if err != nil {
+		return nil, nil //nolint:nilerr // the reflection parser is a subset of WGSL
+	}
+	var match []ShaderEntryPoint
+	for _, ep := range eps {
+		if name == "" && ep.Stage == stage || ep.Name == name {
+			match = append(match, ep)
+		}
+	}
+	switch {
+	case name == "" && len(match) == 0:
+		return nil, fmt.Errorf("shader module has no @%s entry point", stageName(stage))
+	case name == "" && len(match) > 1:
+		return nil, fmt.Errorf("shader module has %d @%s entry points; set the entry point name", len(match), stageName(stage))
+	case len(match) == 0:
+		return nil, fmt.Errorf("entry point %q not found in shader module", name)
+	case match[0].Stage != stage:
+		return nil, fmt.Errorf("entry point %q is a @%s function, not @%s", name, stageName(match[0].Stage), stageName(stage))
+	}
+	return &match[0], nil
+}
+
+// stageName returns the WGSL attribute name of a single shader stage.
+func stageName(stage gputypes.ShaderStage) string {
+	switch stage {
+	case gputypes.ShaderStageVertex:
+		return "vertex"
+	case gputypes.ShaderStageFragment:
+		return "fragment"
+	case gputypes.ShaderStageCompute:
+		return "compute"
+	}
+	return fmt.Sprintf("stage(%d)", uint32(stage))
+}
+
+// checkLayoutCompatible checks that every binding used by ep is declared by
+// layout with a compatible type and visible to ep's stage. Groups whose
+// layouts were not created by this package are skipped.
+func checkLayoutCompatible(layout *PipelineLayout, ep *ShaderEntryPoint) error {
+	if layout == nil || ep == nil || layout.groups == nil {
+		return nil
+	}
+	for _, b := range ep.Bindings {
+		if int(b.Group) >= len(layout.groups) {
+			return fmt.Errorf("%s uses @group(%d) but the pipeline layout has %d bind group layouts",
+				ep.Name, b.Group, len(layout.groups))
+		}
+		bgl := layout.groups[b.Group]
+		if bgl == nil || bgl.entries == nil {
+			continue
+		}
+		var entry *BindGroupLayoutEntry
+		for i := range bgl.entries {
+			if bgl.entries[i].Binding == b.Entry.Binding {
+				entry = &bgl.entries[i]
+				break
+			}
+		}
+		if entry == nil {
+			return fmt.Errorf("%s uses %s (@group(%d) @binding(%d)) which is missing from the bind group layout",
+				ep.Name, b.Name, b.Group, b.Entry.Binding)
+		}
+		if entry.Visibility&ep.Stage == 0 {
+			return fmt.Errorf("%s uses %s (@group(%d) @binding(%d)) which is not visible to the %s stage",
+				ep.Name, b.Name, b.Group, b.Entry.Binding, stageName(ep.Stage))
+		}
+		if err := arrayCountCompatible(entry.Count, &b); err != nil {
+			return fmt.Errorf("%s: %s (@group(%d) @binding(%d)): %w", ep.Name, b.Name, b.Group, b.Entry.Binding, err)
+		}
+		if err := bindingCompatible(entry, &b.Entry); err != nil {
+			return fmt.Errorf("%s: %s (@group(%d) @binding(%d)): %w", ep.Name, b.Name, b.Group, b.Entry.Binding, err)
+		}
+	}
+	return nil
+}
+
+// arrayCountCompatible checks the Count of a layout entry against a shader
+// binding: binding arrays need an array entry with at least as many elements
+// as a sized declaration, and single resources a non-array entry.
+func arrayCountCompatible(count uint32, b *ShaderBinding) error {
+	switch {
+	case b.Entry.Count == 0 && count > 0:
+		return fmt.Errorf("layout declares a binding array of %d but the shader declares a single resource", count)
+	case b.Entry.Count > 0 && count == 0:
+		return fmt.Errorf("shader declares a binding_array but the layout entry has no Count")
+	case !b.RuntimeSized && count < b.Entry.Count:
+		return fmt.Errorf("layout binding array of %d is smaller than the shader's %d", count, b.Entry.Count)
+	}
+	return nil
+}
+
+// bindingCompatible checks a layout entry against the entry reflected from
+// the shader declaration.
+func bindingCompatible(layout, shader *BindGroupLayoutEntry) error {
+	switch {
+	case shader.Buffer != nil:
+		if layout.Buffer == nil {
+			return fmt.Errorf("shader declares a buffer but the layout does not")
+		}
+		want := shader.Buffer.Type
+		got := layout.Buffer.Type
+		// A read-only storage declaration may bind a read-write storage entry.
+		if got != want && (want != gputypes.BufferBindingTypeReadOnlyStorage || got != gputypes.BufferBindingTypeStorage) {
+			return fmt.Errorf("layout buffer type %v does not match shader type %v", got, want)
+		}
+		if layout.Buffer.MinBindingSize != 0 && layout.Buffer.MinBindingSize < shader.Buffer.MinBindingSize {
+			return fmt.Errorf("layout MinBindingSize %d is smaller than the %d bytes the shader reads",
+				layout.Buffer.MinBindingSize, shader.Buffer.MinBindingSize)
+		}
+	case shader.Sampler != nil:
+		if layout.Sampler == nil {
+			return fmt.Errorf("shader declares a sampler but the layout does not")
+		}
+		comparison := shader.Sampler.Type == gputypes.SamplerBindingTypeComparison
+		if comparison != (layout.Sampler.Type == gputypes.SamplerBindingTypeComparison) {
+			return fmt.Errorf("layout sampler type %v does not match shader type %v", layout.Sampler.Type, shader.Sampler.Type)
+		}
+	case shader.Texture != nil:
+		if layout.Texture == nil {
+			return fmt.Errorf("shader declares a texture but the layout does not")
+		}
+		if layout.Texture.ViewDimension != shader.Texture.ViewDimension {
+			return fmt.Errorf("layout view dimension %v does not match shader dimension %v",
+				layout.Texture.ViewDimension, shader.Texture.ViewDimension)
+		}
+		if layout.Texture.Multisampled != shader.Texture.Multisampled {
+			return fmt.Errorf("layout and shader disagree on multisampling")
+		}
+	case shader.StorageTexture != nil:
+		if layout.StorageTexture == nil {
+			return fmt.Errorf("shader declares a storage texture but the layout does not")
+		}
+		if layout.StorageTexture.Format != shader.StorageTexture.Format ||
+			layout.StorageTexture.Access != shader.StorageTexture.Access ||
+			layout.StorageTexture.ViewDimension != shader.StorageTexture.ViewDimension {
+			return fmt.Errorf("layout storage texture does not match the shader's format, access or dimension")
+		}
+	}
+	return nil
+}
+
+// validateColorTargets checks the fragment targets of a render pipeline:
+// their count against maxAttachments (skipped when 0), and that each target
+// is a renderable color format with a valid write mask and blend state.
+// Targets with an undefined format are unused slots and are skipped.
+func validateColorTargets(targets []ColorTargetState, maxAttachments uint32, hasFeature func(FeatureName) bool) error {
+	if maxAttachments > 0 && uint32(len(targets)) > maxAttachments {
+		return fmt.Errorf("pipeline has %d color targets but the device limit MaxColorAttachments is %d",
+			len(targets), maxAttachments)
+	}
+	for i := range targets {
+		t := &targets[i]
+		if t.Format == gputypes.TextureFormatUndefined {
+			if t.Blend != nil {
+				return fmt.Errorf("color target %d: unused target has a blend state", i)
+			}
+			continue
+		}
+		if info, ok := FormatInfo(t.Format); ok {
+			switch {
+			case !info.HasColor:
+				return fmt.Errorf("color target %d: %v is not a color format", i, t.Format)
+			case !info.Renderable:
+				return fmt.Errorf("color target %d: format %v is not renderable", i, t.Format)
+			}
+		}
+		if t.WriteMask&^gputypes.ColorWriteMaskAll != 0 {
+			return fmt.Errorf("color target %d: write mask %#x has bits outside ColorWriteMaskAll", i, uint32(t.WriteMask))
+		}
+		if err := validateBlend(t, hasFeature); err != nil {
+			return fmt.Errorf("color target %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validatePrimitive checks primitive state rules that depend on the topology
+// or on optional features.
+func validatePrimitive(p *PrimitiveState, hasFeature func(FeatureName) bool) error {
+	if p.UnclippedDepth && !hasFeature(FeatureNameDepthClipControl) {
+		return fmt.Errorf("UnclippedDepth requires FeatureNameDepthClipControl")
+	}
+	if p.StripIndexFormat != gputypes.IndexFormatUndefined {
+		switch p.Topology {
+		case gputypes.PrimitiveTopologyLineStrip, gputypes.PrimitiveTopologyTriangleStrip:
+		default:
+			return fmt.Errorf("StripIndexFormat is only valid with strip topologies, not %v", p.Topology)
+		}
+	}
+	return nil
+}
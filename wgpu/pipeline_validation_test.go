@@ -0,0 +1,276 @@
+package wgpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+const computeValidationShader = `
+@group(0) @binding(0) var<uniform> params: vec4u;
+@group(0) @binding(1) var<storage, read> input: array<f32>;
+@group(0) @binding(2) var<storage, read_write> output: array<f32>;
+
+@compute @workgroup_size(64)
+fn main(@builtin(global_invocation_id) id: vec3u) {
+    output[id.x] = input[id.x] * f32(params.x);
+}
+
+@vertex
+fn vs() -> @builtin(position) vec4f {
+    return vec4f(0.0);
+}
+`
+
+func TestComputePipelineBuilderEntryPoint(t *testing.T) {
+	shader := &ShaderModule{handle: 1, source: computeValidationShader}
+	tests := []struct {
+		name    string
+		entry   string
+		wantErr bool
+	}{
+		{"named", "main", false},
+		{"only compute entry point", "", false},
+		{"missing", "mian", true},
+		{"wrong stage", "vs", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewComputePipelineBuilder(nil).Shader(shader, tt.entry).Descriptor()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Modules without WGSL source skip reflection.
+	if _, err := NewComputePipelineBuilder(nil).Shader(&ShaderModule{handle: 1}, "anything").Descriptor(); err != nil {
+		t.Errorf("non-WGSL module: %v", err)
+	}
+	if _, err := NewComputePipelineBuilder(nil).Descriptor(); err == nil {
+		t.Error("expected an error without a shader")
+	}
+}
+
+func TestCheckLayoutCompatible(t *testing.T) {
+	eps, err := ReflectWGSLEntryPoints(computeValidationShader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var main *ShaderEntryPoint
+	for i := range eps {
+		if eps[i].Name == "main" {
+			main = &eps[i]
+		}
+	}
+
+	compute := gputypes.ShaderStageCompute
+	buffer := func(binding uint32, typ gputypes.BufferBindingType, minSize uint64) BindGroupLayoutEntry {
+		return BindGroupLayoutEntry{
+			Binding:    binding,
+			Visibility: compute,
+			Buffer:     &BufferBindingLayout{Type: typ, MinBindingSize: minSize},
+		}
+	}
+	good := []BindGroupLayoutEntry{
+		buffer(0, gputypes.BufferBindingTypeUniform, 16),
+		buffer(1, gputypes.BufferBindingTypeReadOnlyStorage, 0),
+		buffer(2, gputypes.BufferBindingTypeStorage, 0),
+	}
+	layout := func(entries ...BindGroupLayoutEntry) *PipelineLayout {
+		return &PipelineLayout{handle: 1, groups: []*BindGroupLayout{{handle: 1, entries: entries}}}
+	}
+	hidden := buffer(0, gputypes.BufferBindingTypeUniform, 0)
+	hidden.Visibility = gputypes.ShaderStageFragment
+
+	tests := []struct {
+		name    string
+		layout  *PipelineLayout
+		wantErr bool
+	}{
+		{"matching", layout(good...), false},
+		{"read-only declaration on storage entry", layout(good[0], buffer(1, gputypes.BufferBindingTypeStorage, 0), good[2]), false},
+		{"unknown layout", &PipelineLayout{handle: 1}, false},
+		{"no groups", &PipelineLayout{handle: 1, groups: []*BindGroupLayout{}}, true},
+		{"missing binding", layout(good[0], good[1]), true},
+		{"not visible", layout(hidden, good[1], good[2]), true},
+		{"wrong buffer type", layout(good[0], good[1], buffer(2, gputypes.BufferBindingTypeReadOnlyStorage, 0)), true},
+		{"min size too small", layout(buffer(0, gputypes.BufferBindingTypeUniform, 8), good[1], good[2]), true},
+		{"sampler for buffer", layout(good[0], good[1], BindGroupLayoutEntry{Binding: 2, Visibility: compute, Sampler: &SamplerBindingLayout{}}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkLayoutCompatible(tt.layout, main)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestArrayCountCompatible(t *testing.T) {
+	bindings, err := ReflectWGSLBindings(`
+@group(0) @binding(0) var sized: binding_array<texture_2d<f32>, 8>;
+@group(0) @binding(1) var runtime: binding_array<texture_2d<f32>>;
+@group(0) @binding(2) var single: texture_2d<f32>;
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sized, runtime, single := &bindings[0], &bindings[1], &bindings[2]
+	if sized.RuntimeSized || !runtime.RuntimeSized || single.RuntimeSized {
+		t.Fatalf("RuntimeSized = %v %v %v, want false true false", sized.RuntimeSized, runtime.RuntimeSized, single.RuntimeSized)
+	}
+	tests := []struct {
+		name    string
+		count   uint32
+		binding *ShaderBinding
+		wantErr bool
+	}{
+		{"sized exact", 8, sized, false},
+		{"sized larger layout", 16, sized, false},
+		{"sized smaller layout", 4, sized, true},
+		{"runtime any count", 1000, runtime, false},
+		{"array without count", 0, runtime, true},
+		{"single with count", 4, single, true},
+		{"single", 0, single, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := arrayCountCompatible(tt.count, tt.binding)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateImmediateSize(t *testing.T) {
+	none := func(FeatureName) bool { return false }
+	immediates := func(f FeatureName) bool { return f == FeatureName(NativeFeatureImmediates) }
+	tests := []struct {
+		name       string
+		size       uint32
+		hasFeature func(FeatureName) bool
+		limit      uint32
+		wantErr    bool
+	}{
+		{"valid", 64, immediates, 128, false},
+		{"at limit", 128, immediates, 128, false},
+		{"without feature", 64, none, 128, true},
+		{"unaligned", 62, immediates, 128, true},
+		{"over limit", 256, immediates, 128, true},
+		{"limit not requested", 16, immediates, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateImmediateSize(tt.size, tt.hasFeature, tt.limit); (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReflectEntryPointDefault(t *testing.T) {
+	const twoVertex = `
+@vertex fn vs_a() -> @builtin(position) vec4f { return vec4f(0.0); }
+@vertex fn vs_b() -> @builtin(position) vec4f { return vec4f(1.0); }
+@fragment fn fs() -> @location(0) vec4f { return vec4f(1.0); }
+`
+	module := &ShaderModule{handle: 1, source: twoVertex}
+
+	ep, err := reflectEntryPoint(module, "", gputypes.ShaderStageFragment)
+	if err != nil || ep == nil || ep.Name != "fs" {
+		t.Errorf("sole fragment entry point: got %v, %v; want fs", ep, err)
+	}
+	if _, err := reflectEntryPoint(module, "", gputypes.ShaderStageVertex); err == nil {
+		t.Error("expected an error when two vertex entry points match an empty name")
+	}
+	if _, err := reflectEntryPoint(module, "", gputypes.ShaderStageCompute); err == nil {
+		t.Error("expected an error when no compute entry point exists")
+	}
+
+	if got := NullStringView(); got.Data != 0 || got.Length != strlen {
+		t.Errorf("NullStringView() = %#v, want {0, WGPU_STRLEN}", got)
+	}
+}
+
+func TestValidateColorTargets(t *testing.T) {
+	alpha := BlendAlpha()
+	target := func(format gputypes.TextureFormat) ColorTargetState {
+		return ColorTargetState{Format: format, WriteMask: gputypes.ColorWriteMaskAll}
+	}
+	gbuffer := []ColorTargetState{
+		target(gputypes.TextureFormatRGBA8Unorm),
+		target(gputypes.TextureFormatRGBA16Float),
+		target(gputypes.TextureFormatRG32Float),
+	}
+	noFeatures := func(FeatureName) bool { return false }
+
+	tests := []struct {
+		name    string
+		targets []ColorTargetState
+		max     uint32
+		wantErr bool
+	}{
+		{"gbuffer", gbuffer, 8, false},
+		{"unknown limit", gbuffer, 0, false},
+		{"too many targets", gbuffer, 2, true},
+		{"unused slot", []ColorTargetState{{}, target(gputypes.TextureFormatRGBA8Unorm)}, 8, false},
+		{"unused slot with blend", []ColorTargetState{{Blend: &alpha}}, 8, true},
+		{"depth format", []ColorTargetState{target(gputypes.TextureFormatDepth32Float)}, 8, true},
+		{"not renderable", []ColorTargetState{target(gputypes.TextureFormatRGBA8Snorm)}, 8, true},
+		{"bad write mask", []ColorTargetState{{Format: gputypes.TextureFormatRGBA8Unorm, WriteMask: 0x10}}, 8, true},
+		{"blend integer target", []ColorTargetState{
+			target(gputypes.TextureFormatRGBA8Unorm),
+			{Format: gputypes.TextureFormatR32Uint, WriteMask: gputypes.ColorWriteMaskAll, Blend: &alpha},
+		}, 8, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateColorTargets(tt.targets, tt.max, noFeatures)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidatePrimitive(t *testing.T) {
+	with := func(f FeatureName) func(FeatureName) bool {
+		return func(g FeatureName) bool { return g == f }
+	}
+	none := func(FeatureName) bool { return false }
+	tests := []struct {
+		name       string
+		state      PrimitiveState
+		hasFeature func(FeatureName) bool
+		wantErr    bool
+	}{
+		{"defaults", PrimitiveState{Topology: gputypes.PrimitiveTopologyTriangleList}, none, false},
+		{"unclipped without feature", PrimitiveState{UnclippedDepth: true}, none, true},
+		{"unclipped with feature", PrimitiveState{UnclippedDepth: true}, with(FeatureNameDepthClipControl), false},
+		{"strip index format", PrimitiveState{
+			Topology:         gputypes.PrimitiveTopologyTriangleStrip,
+			StripIndexFormat: gputypes.IndexFormatUint16,
+		}, none, false},
+		{"strip index format on a list", PrimitiveState{
+			Topology:         gputypes.PrimitiveTopologyTriangleList,
+			StripIndexFormat: gputypes.IndexFormatUint32,
+		}, none, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validatePrimitive(&tt.state, tt.hasFeature); (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	desc, err := NewRenderPipelineBuilder(nil).VS(&ShaderModule{handle: 1}, "vs").
+		Depth(gputypes.TextureFormatDepth32Float, gputypes.CompareFunctionLess).
+		UnclippedDepth(true).Descriptor()
+	if err != nil || !desc.Primitive.UnclippedDepth {
+		t.Errorf("builder UnclippedDepth: %v, %+v", err, desc)
+	}
+}
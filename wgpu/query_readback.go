@@ -0,0 +1,123 @@
+package wgpu
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/gogpu/gputypes"
+)
+
+// ResolveAndReadQueries resolves count queries of querySet starting at
+// first, copies the results to a staging buffer, submits the work on queue
+// and blocks until the values can be read back. Each query yields one value,
+// or one value per counter for pipeline statistics query sets, in the order
+// the counters were requested. Timestamps are in GPU ticks; convert them
+// with [Queue.TimestampDuration].
+//
+// The queries must have been written by command buffers submitted before
+// the call.
+func ResolveAndReadQueries(device *Device, queue *Queue, querySet *QuerySet, first, count uint32) ([]uint64, error) {
+	const op = "ResolveAndReadQueries"
+	if err := checkInit(); err != nil {
+		return nil, err
+	}
+	if device == nil || device.handle == 0 || queue == nil || queue.handle == 0 {
+		return nil, &WGPUError{Op: op, Message: "device or queue is nil or released"}
+	}
+	if querySet == nil || querySet.handle == 0 {
+		return nil, &WGPUError{Op: op, Message: "query set is nil or released"}
+	}
+	if err := checkQueryRange(querySet, first, count); err != nil {
+		return nil, &WGPUError{Op: op, Type: ErrorTypeValidation, Message: err.Error()}
+	}
+	if count == 0 {
+		return nil, nil
+	}
+	size := uint64(count) * querySet.ResultStride()
+
+	resolve, err := device.CreateBuffer(&BufferDescriptor{
+		Label: "query resolve",
+		Usage: gputypes.BufferUsageQueryResolve | gputypes.BufferUsageCopySrc,
+		Size:  size,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resolve.Release()
+	staging, err := device.CreateBuffer(&BufferDescriptor{
+		Label: "query readback",
+		Usage: gputypes.BufferUsageMapRead | gputypes.BufferUsageCopyDst,
+		Size:  size,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer staging.Release()
+
+	encoder, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer encoder.Release()
+	encoder.ResolveQuerySet(querySet, first, count, resolve, 0)
+	encoder.CopyBufferToBuffer(resolve, 0, staging, 0, size)
+	cmd, err := encoder.Finish()
+	if err != nil {
+		return nil, err
+	}
+	defer cmd.Release()
+	if _, err := queue.Submit(cmd); err != nil {
+		return nil, err
+	}
+
+	if err := staging.Map(context.Background(), MapModeRead, 0, size); err != nil {
+		return nil, err
+	}
+	defer staging.Unmap() //nolint:errcheck
+	rng, err := staging.MappedRange(0, size)
+	if err != nil {
+		return nil, err
+	}
+	return decodeQueryResults(rng.Bytes()), nil
+}
+
+// checkQueryRange reports whether first+count exceeds the queries of qs.
+// Query sets of unknown size are not checked.
+func checkQueryRange(qs *QuerySet, first, count uint32) error {
+	if qs.count != 0 && uint64(first)+uint64(count) > uint64(qs.count) {
+		return fmt.Errorf("queries [%d, %d) out of range, query set has %d", first, uint64(first)+uint64(count), qs.count)
+	}
+	return nil
+}
+
+// decodeQueryResults decodes little-endian uint64 query results.
+func decodeQueryResults(data []byte) []uint64 {
+	values := make([]uint64, len(data)/8)
+	for i := range values {
+		values[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	return values
+}
+
+// TimestampDuration converts the interval between two timestamp query
+// values into a duration, given the timestamp period in nanoseconds per
+// tick. It returns zero if end precedes begin, which some backends report
+// when the timestamps straddle a power-state change.
+func TimestampDuration(begin, end uint64, period float32) time.Duration {
+	if end < begin {
+		return 0
+	}
+	return time.Duration(float64(end-begin) * float64(period))
+}
+
+// TimestampDuration is [TimestampDuration] with the period reported by
+// [Queue.GetTimestampPeriod]. A missing period is treated as 1ns per tick.
+func (q *Queue) TimestampDuration(begin, end uint64) time.Duration {
+	period := q.GetTimestampPeriod()
+	if period <= 0 {
+		period = 1
+	}
+	return TimestampDuration(begin, end, period)
+}
@@ -0,0 +1,36 @@
+package wgpu
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDecodeQueryResults(t *testing.T) {
+	data := []byte{1, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0x80}
+	got := decodeQueryResults(data)
+	if len(got) != 2 || got[0] != 1 || got[1] != 0x8000000000000100 {
+		t.Errorf("decodeQueryResults = %#x", got)
+	}
+}
+
+func TestCheckQueryRange(t *testing.T) {
+	qs := &QuerySet{count: 4}
+	if err := checkQueryRange(qs, 2, 2); err != nil {
+		t.Errorf("last two queries: %v", err)
+	}
+	if err := checkQueryRange(qs, 3, 2); err == nil {
+		t.Error("past the end: expected an error")
+	}
+	if err := checkQueryRange(&QuerySet{}, 100, 1); err != nil {
+		t.Errorf("unknown size: %v", err)
+	}
+}
+
+func TestTimestampDuration(t *testing.T) {
+	if got := TimestampDuration(100, 1100, 1.5); got != 1500*time.Nanosecond {
+		t.Errorf("TimestampDuration = %v, want 1.5µs", got)
+	}
+	if got := TimestampDuration(1100, 100, 1); got != 0 {
+		t.Errorf("end before begin = %v, want 0", got)
+	}
+}
@@ -1,6 +1,11 @@
 package wgpu
 
-import "unsafe"
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"unsafe"
+)
 
 // querySetDescriptor is the native structure for QuerySet descriptor (32 bytes).
 type querySetDescriptor struct {
@@ -10,15 +15,67 @@ type querySetDescriptor struct {
 	count       uint32     // 4 bytes
 }
 
+// querySetDescriptorExtras is WGPUQuerySetDescriptorExtras (32 bytes),
+// chained for pipeline statistics query sets.
+type querySetDescriptorExtras struct {
+	chain                  ChainedStruct
+	pipelineStatistics     uintptr // *WGPUPipelineStatisticName
+	pipelineStatisticCount uintptr // size_t
+}
+
 // QuerySetDescriptor describes a QuerySet to create.
 type QuerySetDescriptor struct {
 	Label string
 	Type  QueryType
 	Count uint32
+	// PipelineStatistics lists the counters each query of a
+	// QueryTypePipelineStatistics set records; it must be empty for other
+	// types. Resolved queries hold one uint64 per counter, in this order.
+	PipelineStatistics []PipelineStatisticName
+}
+
+// validateQuerySetDescriptor checks the pipeline statistics of desc against
+// its type and the device features reported by hasFeature.
+func validateQuerySetDescriptor(desc *QuerySetDescriptor, hasFeature func(FeatureName) bool) error {
+	if desc.Type != QueryTypePipelineStatistics {
+		if len(desc.PipelineStatistics) > 0 {
+			return fmt.Errorf("PipelineStatistics is only used by QueryTypePipelineStatistics query sets")
+		}
+		return nil
+	}
+	if !hasFeature(FeatureName(NativeFeaturePipelineStatisticsQuery)) {
+		return fmt.Errorf("pipeline statistics queries require NativeFeaturePipelineStatisticsQuery")
+	}
+	if len(desc.PipelineStatistics) == 0 {
+		return fmt.Errorf("a pipeline statistics query set needs at least one PipelineStatistics counter")
+	}
+	seen := make(map[PipelineStatisticName]bool, len(desc.PipelineStatistics))
+	for _, name := range desc.PipelineStatistics {
+		if name > PipelineStatisticComputeShaderInvocations {
+			return fmt.Errorf("unknown pipeline statistic %d", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("pipeline statistic %d is listed more than once", name)
+		}
+		seen[name] = true
+	}
+	return nil
 }
 
 // CreateQuerySet creates a new QuerySet for GPU profiling/timestamps.
 func (d *Device) CreateQuerySet(desc *QuerySetDescriptor) (*QuerySet, error) {
+	return d.createQuerySet(desc, false)
+}
+
+// CreateQuerySetE is CreateQuerySet that also returns the validation and
+// out-of-memory errors raised by wgpu-native, retrying once when the
+// device's [OutOfMemoryHandler] asks to.
+func (d *Device) CreateQuerySetE(desc *QuerySetDescriptor) (*QuerySet, error) {
+	return d.createQuerySet(desc, true)
+}
+
+// createQuerySet creates a query set, inside error scopes when capture is set.
+func (d *Device) createQuerySet(desc *QuerySetDescriptor, capture bool) (*QuerySet, error) {
 	if err := checkInit(); err != nil {
 		return nil, err
 	}
@@ -29,22 +86,71 @@ func (d *Device) CreateQuerySet(desc *QuerySetDescriptor) (*QuerySet, error) {
 		return nil, &WGPUError{Op: "CreateQuerySet", Message: "descriptor is nil"}
 	}
 
+	if err := validateQuerySetDescriptor(desc, d.HasFeature); err != nil {
+		return nil, &WGPUError{Op: "CreateQuerySet", Type: ErrorTypeValidation, Message: err.Error()}
+	}
+
 	nativeDesc := querySetDescriptor{
 		nextInChain: 0,
 		label:       stringToStringView(desc.Label),
 		queryType:   desc.Type,
 		count:       desc.Count,
 	}
+	statistics := slices.Clone(desc.PipelineStatistics)
+	var extras querySetDescriptorExtras
+	if len(statistics) > 0 {
+		extras.chain.SType = uint32(STypeQuerySetDescriptorExtras)
+		extras.pipelineStatistics = uintptr(unsafe.Pointer(&statistics[0]))
+		extras.pipelineStatisticCount = uintptr(len(statistics))
+		nativeDesc.nextInChain = uintptr(unsafe.Pointer(&extras))
+	}
 
-	handle, _, _ := procDeviceCreateQuerySet.Call(
-		d.handle,
-		uintptr(unsafe.Pointer(&nativeDesc)),
-	)
+	create := func() uintptr {
+		h, _, _ := procDeviceCreateQuerySet.Call(
+			d.handle,
+			uintptr(unsafe.Pointer(&nativeDesc)),
+		)
+		return h
+	}
+	var handle uintptr
+	var err error
+	if capture {
+		handle, err = d.allocate("CreateQuerySet", create, func(h uintptr) { procQuerySetRelease.Call(h) }) //nolint:errcheck
+	} else {
+		handle = create()
+	}
+	runtime.KeepAlive(extras)
+	runtime.KeepAlive(statistics)
 	if handle == 0 {
 		return nil, &WGPUError{Op: "CreateQuerySet", Message: "wgpu returned null handle"}
 	}
-	trackResource(handle, "QuerySet")
-	return &QuerySet{handle: handle}, nil
+	trackResource(handle, "QuerySet", desc.Label)
+	qs := &QuerySet{handle: handle, typ: desc.Type, count: desc.Count, statistics: statistics}
+	if err != nil {
+		// wgpu-native returns an invalid query set rather than null.
+		qs.Release()
+		return nil, err
+	}
+	if traceActive.Load() {
+		traceCall("Device.CreateQuerySet", nil, qs, desc)
+	}
+	return qs, nil
+}
+
+// Type returns the type of queries in the set.
+func (qs *QuerySet) Type() QueryType { return qs.typ }
+
+// Count returns the number of queries in the set.
+func (qs *QuerySet) Count() uint32 { return qs.count }
+
+// ResultStride returns the number of bytes ResolveQuerySet writes per query:
+// 8 for timestamp and occlusion queries, and 8 per counter for pipeline
+// statistics queries.
+func (qs *QuerySet) ResultStride() uint64 {
+	if n := len(qs.statistics); n > 0 {
+		return 8 * uint64(n)
+	}
+	return 8
 }
 
 // Destroy destroys the QuerySet, making it invalid.
@@ -0,0 +1,29 @@
+package wgpu
+
+import "testing"
+
+func TestSubmitHandles(t *testing.T) {
+	a, b := &CommandBuffer{handle: 1}, &CommandBuffer{handle: 2}
+	tests := []struct {
+		name     string
+		commands []*CommandBuffer
+		wantErr  bool
+	}{
+		{"in order", []*CommandBuffer{a, b}, false},
+		{"nil", []*CommandBuffer{a, nil}, true},
+		{"released", []*CommandBuffer{{}}, true},
+		{"repeated", []*CommandBuffer{a, b, a}, true},
+		{"already submitted", []*CommandBuffer{{handle: 3, submitted: true}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handles, err := submitHandles(tt.commands)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (len(handles) != 2 || handles[0] != 1 || handles[1] != 2) {
+				t.Errorf("handles = %v, want [1 2]", handles)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package wgpu
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/go-webgpu/goffi/ffi"
+)
+
+// QueueWorkDoneStatus is the status passed to an OnSubmittedWorkDone callback.
+type QueueWorkDoneStatus uint32
+
+const (
+	// QueueWorkDoneStatusSuccess indicates the submitted work completed.
+	QueueWorkDoneStatusSuccess QueueWorkDoneStatus = 0x00000001
+	// QueueWorkDoneStatusCallbackCancelled indicates the callback was
+	// cancelled, for example because the device was released.
+	QueueWorkDoneStatusCallbackCancelled QueueWorkDoneStatus = 0x00000002
+	// QueueWorkDoneStatusError indicates the work could not complete.
+	QueueWorkDoneStatusError QueueWorkDoneStatus = 0x00000003
+)
+
+// queueWorkDoneCallbackInfo matches WGPUQueueWorkDoneCallbackInfo C struct.
+type queueWorkDoneCallbackInfo struct {
+	nextInChain uintptr // *ChainedStruct
+	mode        CallbackMode
+	callback    uintptr // function pointer
+	userdata1   uintptr
+	userdata2   uintptr
+}
+
+var (
+	// workDoneCallbacks maps the userdata of each pending OnSubmittedWorkDone
+	// call to its callback. Protected by workDoneCallbacksMu.
+	workDoneCallbacks   = make(map[uintptr]func(QueueWorkDoneStatus))
+	workDoneCallbacksMu sync.Mutex
+	workDoneCallbackID  uintptr
+
+	// workDoneCallbackPtr is the callback function pointer (created once).
+	workDoneCallbackPtr  uintptr
+	workDoneCallbackOnce sync.Once
+)
+
+// initWorkDoneCallback creates the platform-correct C callback function pointer.
+func initWorkDoneCallback() {
+	workDoneCallbackPtr = ffi.NewCallback(queueWorkDoneCallbackEntry)
+}
+
+// handleWorkDoneCallback runs the callback registered as userdata1 after the
+// platform callback entry drops the ABI-specific message argument.
+func handleWorkDoneCallback(status, userdata1 uintptr) uintptr {
+	workDoneCallbacksMu.Lock()
+	fn := workDoneCallbacks[userdata1]
+	delete(workDoneCallbacks, userdata1)
+	workDoneCallbacksMu.Unlock()
+	if fn != nil {
+		fn(QueueWorkDoneStatus(status))
+	}
+	return 0 // void return
+}
+
+// OnSubmittedWorkDone calls fn once all work submitted to the queue so far
+// has completed on the GPU. Like MapAsync, it does not drive the device: fn
+// runs during a later [Device.Poll] or [Instance.ProcessEvents] call, on the
+// goroutine making it. It runs with QueueWorkDoneStatusCallbackCancelled if
+// the device is released first.
+func (q *Queue) OnSubmittedWorkDone(fn func(QueueWorkDoneStatus)) error {
+	if err := checkInit(); err != nil {
+		return err
+	}
+	if q == nil || q.handle == 0 {
+		return &WGPUError{Op: "Queue.OnSubmittedWorkDone", Message: "queue is nil or released"}
+	}
+	if fn == nil {
+		return &WGPUError{Op: "Queue.OnSubmittedWorkDone", Message: "callback is nil"}
+	}
+	workDoneCallbackOnce.Do(initWorkDoneCallback)
+
+	workDoneCallbacksMu.Lock()
+	workDoneCallbackID++
+	id := workDoneCallbackID
+	workDoneCallbacks[id] = fn
+	workDoneCallbacksMu.Unlock()
+
+	info := queueWorkDoneCallbackInfo{
+		mode:      CallbackModeAllowSpontaneous,
+		callback:  workDoneCallbackPtr,
+		userdata1: id,
+	}
+	// nolint:errcheck // the WGPUFuture result is not used
+	procQueueOnSubmittedWorkDone.Call(q.handle, uintptr(unsafe.Pointer(&info)))
+	return nil
+}
@@ -42,6 +42,13 @@ type renderPassDescriptor struct {
 	timestampWrites        uintptr    // 8 bytes (nullable)
 }
 
+// renderPassMaxDrawCount is WGPURenderPassMaxDrawCount (24 bytes), chained
+// onto the render pass descriptor when MaxDrawCount is set.
+type renderPassMaxDrawCount struct {
+	chain        ChainedStruct
+	maxDrawCount uint64
+}
+
 // RenderPassColorAttachment describes a color attachment for a render pass.
 type RenderPassColorAttachment struct {
 	View          *TextureView
@@ -106,10 +113,16 @@ type RenderPassDescriptor struct {
 	ColorAttachments       []RenderPassColorAttachment
 	DepthStencilAttachment *RenderPassDepthStencilAttachment
 	TimestampWrites        *RenderPassTimestampWrites
+	// MaxDrawCount is an upper bound on the number of draw calls in the
+	// pass, used by some backends to size validation and profiling state.
+	// Zero leaves the WebGPU default of 50,000,000.
+	MaxDrawCount uint64
 }
 
 // BeginRenderPass begins a render pass.
-// Returns an error if the FFI call fails, encoder is nil, or desc has no color attachments.
+// A pass needs at least one color attachment or a depth/stencil attachment;
+// depth-only passes (shadow maps, depth prepasses) leave ColorAttachments empty.
+// Returns an error if the FFI call fails, encoder is nil, or desc has no attachments.
 func (enc *CommandEncoder) BeginRenderPass(desc *RenderPassDescriptor) (*RenderPassEncoder, error) {
 	if err := checkInit(); err != nil {
 		return nil, err
@@ -117,15 +130,30 @@ func (enc *CommandEncoder) BeginRenderPass(desc *RenderPassDescriptor) (*RenderP
 	if enc == nil || enc.handle == 0 {
 		return nil, &WGPUError{Op: "BeginRenderPass", Message: "encoder is nil or released"}
 	}
+	if err := enc.checkBegin(); err != nil {
+		return nil, &WGPUError{Op: "BeginRenderPass", Type: ErrorTypeValidation, Message: err.Error()}
+	}
 	if desc == nil {
 		return nil, &WGPUError{Op: "BeginRenderPass", Message: "descriptor is nil"}
 	}
-	if len(desc.ColorAttachments) == 0 {
-		return nil, &WGPUError{Op: "BeginRenderPass", Message: "no color attachments"}
+	if len(desc.ColorAttachments) == 0 && desc.DepthStencilAttachment == nil {
+		return nil, &WGPUError{Op: "BeginRenderPass", Message: "no color or depth/stencil attachments"}
+	}
+	if desc.DepthStencilAttachment != nil && desc.DepthStencilAttachment.View == nil {
+		return nil, &WGPUError{Op: "BeginRenderPass", Type: ErrorTypeValidation, Message: "depth/stencil attachment has no view"}
+	}
+	if debugBuild {
+		if err := checkRenderPassViews(desc); err != nil {
+			return nil, &WGPUError{Op: "BeginRenderPass", Type: ErrorTypeValidation, Message: err.Error()}
+		}
+	}
+	if err := validateAttachmentSampleCounts(desc); err != nil {
+		return nil, &WGPUError{Op: "BeginRenderPass", Type: ErrorTypeValidation, Message: err.Error()}
 	}
 
+	scratch := enc.renderScratch(len(desc.ColorAttachments))
+
 	// Build native color attachments
-	nativeColorAttachments := make([]renderPassColorAttachment, len(desc.ColorAttachments))
 	for i, ca := range desc.ColorAttachments {
 		var viewHandle uintptr
 		if ca.View != nil {
@@ -137,7 +165,7 @@ func (enc *CommandEncoder) BeginRenderPass(desc *RenderPassDescriptor) (*RenderP
 			resolveHandle = ca.ResolveTarget.handle
 		}
 
-		nativeColorAttachments[i] = renderPassColorAttachment{
+		scratch.colors[i] = renderPassColorAttachment{
 			nextInChain:   0,
 			view:          viewHandle,
 			depthSlice:    DepthSliceUndefined, // CRITICAL for 2D textures!
@@ -148,9 +176,14 @@ func (enc *CommandEncoder) BeginRenderPass(desc *RenderPassDescriptor) (*RenderP
 		}
 	}
 
+	nativeDesc := &scratch.desc
+	nativeDesc.label = stringToStringView(desc.Label)
+	nativeDesc.colorAttachmentCount = uintptr(len(scratch.colors))
+	if len(scratch.colors) > 0 {
+		nativeDesc.colorAttachments = uintptr(unsafe.Pointer(&scratch.colors[0]))
+	}
+
 	// Build depth/stencil attachment if present
-	var depthStencilPtr uintptr
-	var nativeDepthStencil renderPassDepthStencilAttachment
 	if desc.DepthStencilAttachment != nil {
 		depthRO := False
 		if desc.DepthStencilAttachment.DepthReadOnly {
@@ -161,7 +194,7 @@ func (enc *CommandEncoder) BeginRenderPass(desc *RenderPassDescriptor) (*RenderP
 			stencilRO = True
 		}
 
-		nativeDepthStencil = renderPassDepthStencilAttachment{
+		scratch.depthStencil = renderPassDepthStencilAttachment{
 			view:              desc.DepthStencilAttachment.View.handle,
 			depthLoadOp:       uint32(desc.DepthStencilAttachment.DepthLoadOp),
 			depthStoreOp:      uint32(desc.DepthStencilAttachment.DepthStoreOp),
@@ -172,41 +205,41 @@ func (enc *CommandEncoder) BeginRenderPass(desc *RenderPassDescriptor) (*RenderP
 			stencilClearValue: desc.DepthStencilAttachment.StencilClearValue,
 			stencilReadOnly:   stencilRO,
 		}
-		depthStencilPtr = uintptr(unsafe.Pointer(&nativeDepthStencil))
+		nativeDesc.depthStencilAttachment = uintptr(unsafe.Pointer(&scratch.depthStencil))
 	}
 
 	// Build timestamp writes if present (v29: passTimestampWrites with nextInChain)
-	var timestampWritesPtr uintptr
-	var nativeTimestampWrites passTimestampWrites
 	if desc.TimestampWrites != nil {
-		nativeTimestampWrites = passTimestampWrites{
+		scratch.timestamps = passTimestampWrites{
 			nextInChain:               0,
 			querySet:                  desc.TimestampWrites.QuerySet.handle,
 			beginningOfPassWriteIndex: desc.TimestampWrites.BeginningOfPassWriteIndex,
 			endOfPassWriteIndex:       desc.TimestampWrites.EndOfPassWriteIndex,
 		}
-		timestampWritesPtr = uintptr(unsafe.Pointer(&nativeTimestampWrites))
+		nativeDesc.timestampWrites = uintptr(unsafe.Pointer(&scratch.timestamps))
 	}
 
-	nativeDesc := renderPassDescriptor{
-		nextInChain:            0,
-		label:                  stringToStringView(desc.Label),
-		colorAttachmentCount:   uintptr(len(nativeColorAttachments)),
-		colorAttachments:       uintptr(unsafe.Pointer(&nativeColorAttachments[0])),
-		depthStencilAttachment: depthStencilPtr,
-		occlusionQuerySet:      0,
-		timestampWrites:        timestampWritesPtr,
+	if desc.MaxDrawCount != 0 {
+		scratch.maxDrawCount.chain.SType = uint32(STypeRenderPassMaxDrawCount)
+		scratch.maxDrawCount.maxDrawCount = desc.MaxDrawCount
+		nativeDesc.nextInChain = uintptr(unsafe.Pointer(&scratch.maxDrawCount))
 	}
 
+	enc.pins.add(desc)
 	handle, _, _ := procCommandEncoderBeginRenderPass.Call(
 		enc.handle,
-		uintptr(unsafe.Pointer(&nativeDesc)),
+		uintptr(unsafe.Pointer(nativeDesc)),
 	)
 	if handle == 0 {
 		return nil, &WGPUError{Op: "BeginRenderPass", Message: "wgpu returned null handle"}
 	}
-	trackResource(handle, "RenderPassEncoder")
-	return &RenderPassEncoder{handle: handle}, nil
+	trackResource(handle, "RenderPassEncoder", desc.Label)
+	enc.openPasses++
+	rpe := &RenderPassEncoder{handle: handle, encoder: enc, layout: renderPassLayout(desc)}
+	if traceActive.Load() {
+		traceCall("CommandEncoder.BeginRenderPass", enc, rpe, desc)
+	}
+	return rpe, nil
 }
 
 // SetPipeline sets the render pipeline for this pass.
@@ -215,15 +248,24 @@ func (rpe *RenderPassEncoder) SetPipeline(pipeline *RenderPipeline) {
 	if rpe == nil || rpe.handle == 0 || pipeline == nil || pipeline.handle == 0 {
 		return
 	}
+	if traceActive.Load() {
+		traceCall("RenderPassEncoder.SetPipeline", rpe, nil, pipeline)
+	}
 	procRenderPassEncoderSetPipeline.Call(rpe.handle, pipeline.handle) //nolint:errcheck
 }
 
 // SetBindGroup sets a bind group for this pass.
+// Dynamic offsets are checked with [BindGroup.ValidateDynamicOffsets]; on
+// failure the call is skipped and [CommandEncoder.Finish] returns the error.
 func (rpe *RenderPassEncoder) SetBindGroup(groupIndex uint32, group *BindGroup, dynamicOffsets []uint32) {
 	mustInit()
 	if rpe == nil || rpe.handle == 0 || group == nil || group.handle == 0 {
 		return
 	}
+	if err := group.ValidateDynamicOffsets(dynamicOffsets); err != nil {
+		rpe.encoder.recordErr("RenderPassEncoder.SetBindGroup", err)
+		return
+	}
 
 	var offsetsPtr uintptr
 	offsetCount := uintptr(0)
@@ -232,6 +274,9 @@ func (rpe *RenderPassEncoder) SetBindGroup(groupIndex uint32, group *BindGroup,
 		offsetCount = uintptr(len(dynamicOffsets))
 	}
 
+	if traceActive.Load() {
+		traceCall("RenderPassEncoder.SetBindGroup", rpe, nil, groupIndex, group, dynamicOffsets)
+	}
 	procRenderPassEncoderSetBindGroup.Call( //nolint:errcheck
 		rpe.handle,
 		uintptr(groupIndex),
@@ -241,12 +286,39 @@ func (rpe *RenderPassEncoder) SetBindGroup(groupIndex uint32, group *BindGroup,
 	)
 }
 
+// SetBindGroups sets groups at consecutive indices starting at first, with
+// offsets[i] as the dynamic offsets of groups[i]. offsets may be shorter than
+// groups, or nil, for groups without dynamic offsets; nil groups leave their
+// index unchanged. Each group is set as by [RenderPassEncoder.SetBindGroup].
+func (rpe *RenderPassEncoder) SetBindGroups(first uint32, groups []*BindGroup, offsets [][]uint32) {
+	if rpe == nil {
+		return
+	}
+	if err := checkBindGroupBatch(groups, offsets); err != nil {
+		rpe.encoder.recordErr("RenderPassEncoder.SetBindGroups", err)
+		return
+	}
+	for i, group := range groups {
+		if group == nil {
+			continue
+		}
+		var dyn []uint32
+		if i < len(offsets) {
+			dyn = offsets[i]
+		}
+		rpe.SetBindGroup(first+uint32(i), group, dyn)
+	}
+}
+
 // SetVertexBuffer sets a vertex buffer for this pass.
 func (rpe *RenderPassEncoder) SetVertexBuffer(slot uint32, buffer *Buffer, offset, size uint64) {
 	mustInit()
 	if rpe == nil || rpe.handle == 0 || buffer == nil || buffer.handle == 0 {
 		return
 	}
+	if traceActive.Load() {
+		traceCall("RenderPassEncoder.SetVertexBuffer", rpe, nil, slot, buffer, offset, size)
+	}
 	procRenderPassEncoderSetVertexBuffer.Call( //nolint:errcheck
 		rpe.handle,
 		uintptr(slot),
@@ -262,6 +334,9 @@ func (rpe *RenderPassEncoder) SetIndexBuffer(buffer *Buffer, format gputypes.Ind
 	if rpe == nil || rpe.handle == 0 || buffer == nil || buffer.handle == 0 {
 		return
 	}
+	if traceActive.Load() {
+		traceCall("RenderPassEncoder.SetIndexBuffer", rpe, nil, buffer, format, offset, size)
+	}
 	procRenderPassEncoderSetIndexBuffer.Call( //nolint:errcheck
 		rpe.handle,
 		buffer.handle,
@@ -277,6 +352,9 @@ func (rpe *RenderPassEncoder) Draw(vertexCount, instanceCount, firstVertex, firs
 	if rpe == nil || rpe.handle == 0 {
 		return
 	}
+	if traceActive.Load() {
+		traceCall("RenderPassEncoder.Draw", rpe, nil, vertexCount, instanceCount, firstVertex, firstInstance)
+	}
 	procRenderPassEncoderDraw.Call( //nolint:errcheck
 		rpe.handle,
 		uintptr(vertexCount),
@@ -292,6 +370,9 @@ func (rpe *RenderPassEncoder) DrawIndexed(indexCount, instanceCount, firstIndex
 	if rpe == nil || rpe.handle == 0 {
 		return
 	}
+	if traceActive.Load() {
+		traceCall("RenderPassEncoder.DrawIndexed", rpe, nil, indexCount, instanceCount, firstIndex, baseVertex, firstInstance)
+	}
 	procRenderPassEncoderDrawIndexed.Call( //nolint:errcheck
 		rpe.handle,
 		uintptr(indexCount),
@@ -308,11 +389,17 @@ func (rpe *RenderPassEncoder) DrawIndexed(indexCount, instanceCount, firstIndex
 //   - instanceCount (uint32)
 //   - firstVertex (uint32)
 //   - firstInstance (uint32)
+//
+// A non-zero firstInstance requires FeatureNameIndirectFirstInstance;
+// [Device.WriteDrawIndirect] checks this when filling the buffer.
 func (rpe *RenderPassEncoder) DrawIndirect(indirectBuffer *Buffer, indirectOffset uint64) {
 	mustInit()
 	if rpe == nil || rpe.handle == 0 || indirectBuffer == nil || indirectBuffer.handle == 0 {
 		return
 	}
+	if traceActive.Load() {
+		traceCall("RenderPassEncoder.DrawIndirect", rpe, nil, indirectBuffer, indirectOffset)
+	}
 	procRenderPassEncoderDrawIndirect.Call( //nolint:errcheck
 		rpe.handle,
 		indirectBuffer.handle,
@@ -327,11 +414,17 @@ func (rpe *RenderPassEncoder) DrawIndirect(indirectBuffer *Buffer, indirectOffse
 //   - firstIndex (uint32)
 //   - baseVertex (int32)
 //   - firstInstance (uint32)
+//
+// As for DrawIndirect, a non-zero firstInstance requires
+// FeatureNameIndirectFirstInstance.
 func (rpe *RenderPassEncoder) DrawIndexedIndirect(indirectBuffer *Buffer, indirectOffset uint64) {
 	mustInit()
 	if rpe == nil || rpe.handle == 0 || indirectBuffer == nil || indirectBuffer.handle == 0 {
 		return
 	}
+	if traceActive.Load() {
+		traceCall("RenderPassEncoder.DrawIndexedIndirect", rpe, nil, indirectBuffer, indirectOffset)
+	}
 	procRenderPassEncoderDrawIndexedIndirect.Call( //nolint:errcheck
 		rpe.handle,
 		indirectBuffer.handle,
@@ -348,6 +441,9 @@ func (rpe *RenderPassEncoder) SetViewport(x, y, width, height, minDepth, maxDept
 	if rpe == nil || rpe.handle == 0 {
 		return
 	}
+	if traceActive.Load() {
+		traceCall("RenderPassEncoder.SetViewport", rpe, nil, x, y, width, height, minDepth, maxDepth)
+	}
 	procRenderPassEncoderSetViewport.Call( //nolint:errcheck
 		rpe.handle,
 		uintptr(math.Float32bits(x)),
@@ -368,6 +464,9 @@ func (rpe *RenderPassEncoder) SetScissorRect(x, y, width, height uint32) {
 	if rpe == nil || rpe.handle == 0 {
 		return
 	}
+	if traceActive.Load() {
+		traceCall("RenderPassEncoder.SetScissorRect", rpe, nil, x, y, width, height)
+	}
 	procRenderPassEncoderSetScissorRect.Call( //nolint:errcheck
 		rpe.handle,
 		uintptr(x),
@@ -384,6 +483,9 @@ func (rpe *RenderPassEncoder) SetBlendConstant(color *Color) {
 	if rpe == nil || rpe.handle == 0 || color == nil {
 		return
 	}
+	if traceActive.Load() {
+		traceCall("RenderPassEncoder.SetBlendConstant", rpe, nil, color)
+	}
 	procRenderPassEncoderSetBlendConstant.Call( //nolint:errcheck
 		rpe.handle,
 		uintptr(unsafe.Pointer(color)),
@@ -396,6 +498,9 @@ func (rpe *RenderPassEncoder) SetStencilReference(reference uint32) {
 	if rpe == nil || rpe.handle == 0 {
 		return
 	}
+	if traceActive.Load() {
+		traceCall("RenderPassEncoder.SetStencilReference", rpe, nil, reference)
+	}
 	procRenderPassEncoderSetStencilReference.Call( //nolint:errcheck
 		rpe.handle,
 		uintptr(reference),
@@ -417,6 +522,9 @@ func (rpe *RenderPassEncoder) InsertDebugMarker(markerLabel string) {
 		Data:   uintptr(unsafe.Pointer(&labelBytes[0])),
 		Length: uintptr(len(labelBytes)),
 	}
+	if traceActive.Load() {
+		traceCall("RenderPassEncoder.InsertDebugMarker", rpe, nil, markerLabel)
+	}
 	procRenderPassEncoderInsertDebugMarker.Call( //nolint:errcheck
 		rpe.handle,
 		uintptr(unsafe.Pointer(&label)),
@@ -438,6 +546,9 @@ func (rpe *RenderPassEncoder) PushDebugGroup(groupLabel string) {
 		Data:   uintptr(unsafe.Pointer(&labelBytes[0])),
 		Length: uintptr(len(labelBytes)),
 	}
+	if traceActive.Load() {
+		traceCall("RenderPassEncoder.PushDebugGroup", rpe, nil, groupLabel)
+	}
 	procRenderPassEncoderPushDebugGroup.Call( //nolint:errcheck
 		rpe.handle,
 		uintptr(unsafe.Pointer(&label)),
@@ -451,16 +562,29 @@ func (rpe *RenderPassEncoder) PopDebugGroup() {
 	if rpe == nil || rpe.handle == 0 {
 		return
 	}
+	if traceActive.Load() {
+		traceCall("RenderPassEncoder.PopDebugGroup", rpe, nil)
+	}
 	procRenderPassEncoderPopDebugGroup.Call(rpe.handle) //nolint:errcheck
 }
 
-// End ends the render pass.
+// End ends the render pass. As for [ComputePassEncoder.End], a second
+// call is reported by [CommandEncoder.Finish].
 func (rpe *RenderPassEncoder) End() {
 	mustInit()
 	if rpe == nil || rpe.handle == 0 {
 		return
 	}
+	if rpe.ended {
+		rpe.encoder.recordErr("RenderPassEncoder.End", errPassEnded)
+		return
+	}
+	if traceActive.Load() {
+		traceCall("RenderPassEncoder.End", rpe, nil)
+	}
 	procRenderPassEncoderEnd.Call(rpe.handle) //nolint:errcheck
+	rpe.ended = true
+	rpe.encoder.passEnded()
 }
 
 // Release releases the render pass encoder.
@@ -1,6 +1,8 @@
 package wgpu
 
 import (
+	"fmt"
+	"strings"
 	"unsafe"
 
 	"github.com/gogpu/gputypes"
@@ -39,7 +41,24 @@ type renderBundleEncoderDescriptorWire struct {
 // CreateRenderBundleEncoder creates a render bundle encoder for pre-recording render commands.
 // Render bundles allow you to pre-record a sequence of render commands that can be replayed
 // multiple times, which is useful for static geometry.
+//
+// The descriptor must name at least one color or depth/stencil format; a
+// SampleCount of 0 means 1. Bundles recorded with it only execute in passes
+// with the same attachment formats and sample count, see
+// [RenderPassEncoder.ExecuteBundles].
 func (d *Device) CreateRenderBundleEncoder(desc *RenderBundleEncoderDescriptor) (*RenderBundleEncoder, error) {
+	return d.createRenderBundleEncoder(desc, false)
+}
+
+// CreateRenderBundleEncoderE is CreateRenderBundleEncoder that also returns
+// the validation error raised by wgpu-native.
+func (d *Device) CreateRenderBundleEncoderE(desc *RenderBundleEncoderDescriptor) (*RenderBundleEncoder, error) {
+	return d.createRenderBundleEncoder(desc, true)
+}
+
+// createRenderBundleEncoder creates a render bundle encoder, inside an error
+// scope when capture is set.
+func (d *Device) createRenderBundleEncoder(desc *RenderBundleEncoderDescriptor, capture bool) (*RenderBundleEncoder, error) {
 	if err := checkInit(); err != nil {
 		return nil, err
 	}
@@ -49,12 +68,16 @@ func (d *Device) CreateRenderBundleEncoder(desc *RenderBundleEncoderDescriptor)
 	if desc == nil {
 		return nil, &WGPUError{Op: "CreateRenderBundleEncoder", Message: "descriptor is nil"}
 	}
+	layout, err := validateBundleEncoderDescriptor(desc, d.limits.MaxColorAttachments)
+	if err != nil {
+		return nil, &WGPUError{Op: "CreateRenderBundleEncoder", Type: ErrorTypeValidation, Message: err.Error()}
+	}
 
 	wire := renderBundleEncoderDescriptorWire{
 		label:              stringToStringView(desc.Label),
 		colorFormatCount:   uintptr(len(desc.ColorFormats)),
 		depthStencilFormat: uint32(desc.DepthStencilFormat),
-		sampleCount:        desc.SampleCount,
+		sampleCount:        layout.sampleCount,
 		depthReadOnly:      boolToWGPU(desc.DepthReadOnly),
 		stencilReadOnly:    boolToWGPU(desc.StencilReadOnly),
 	}
@@ -69,15 +92,29 @@ func (d *Device) CreateRenderBundleEncoder(desc *RenderBundleEncoderDescriptor)
 		wire.colorFormats = uintptr(unsafe.Pointer(&convertedFormats[0]))
 	}
 
-	handle, _, _ := procDeviceCreateRenderBundleEncoder.Call(
-		d.handle,
-		uintptr(unsafe.Pointer(&wire)),
-	)
+	var handle uintptr
+	create := func() {
+		handle, _, _ = procDeviceCreateRenderBundleEncoder.Call(
+			d.handle,
+			uintptr(unsafe.Pointer(&wire)),
+		)
+	}
+	if capture {
+		err = d.captureValidation("CreateRenderBundleEncoder", create)
+	} else {
+		create()
+	}
 	if handle == 0 {
 		return nil, &WGPUError{Op: "CreateRenderBundleEncoder", Message: "wgpu returned null handle"}
 	}
-	trackResource(handle, "RenderBundleEncoder")
-	return &RenderBundleEncoder{handle: handle}, nil
+	trackResource(handle, "RenderBundleEncoder", desc.Label)
+	rbe := &RenderBundleEncoder{handle: handle, layout: layout}
+	if err != nil {
+		// wgpu-native returns an invalid encoder rather than null.
+		rbe.Release()
+		return nil, err
+	}
+	return rbe, nil
 }
 
 // CreateRenderBundleEncoderSimple creates a render bundle encoder with common settings.
@@ -100,11 +137,19 @@ func (rbe *RenderBundleEncoder) SetPipeline(pipeline *RenderPipeline) {
 }
 
 // SetBindGroup sets a bind group at the given index.
+// Dynamic offsets are checked with [BindGroup.ValidateDynamicOffsets]; on
+// failure the call is skipped and the error is reported by [RenderBundleEncoder.Err].
 func (rbe *RenderBundleEncoder) SetBindGroup(groupIndex uint32, group *BindGroup, dynamicOffsets []uint32) {
 	mustInit()
 	if rbe == nil || rbe.handle == 0 || group == nil || group.handle == 0 {
 		return
 	}
+	if err := group.ValidateDynamicOffsets(dynamicOffsets); err != nil {
+		if rbe.err == nil {
+			rbe.err = &WGPUError{Op: "RenderBundleEncoder.SetBindGroup", Type: ErrorTypeValidation, Message: err.Error()}
+		}
+		return
+	}
 	var offsetsPtr uintptr
 	if len(dynamicOffsets) > 0 {
 		offsetsPtr = uintptr(unsafe.Pointer(&dynamicOffsets[0]))
@@ -118,6 +163,32 @@ func (rbe *RenderBundleEncoder) SetBindGroup(groupIndex uint32, group *BindGroup
 	)
 }
 
+// SetBindGroups sets groups at consecutive indices starting at first, with
+// offsets[i] as the dynamic offsets of groups[i]. offsets may be shorter than
+// groups, or nil, for groups without dynamic offsets; nil groups leave their
+// index unchanged. Each group is set as by [RenderBundleEncoder.SetBindGroup].
+func (rbe *RenderBundleEncoder) SetBindGroups(first uint32, groups []*BindGroup, offsets [][]uint32) {
+	if rbe == nil {
+		return
+	}
+	if err := checkBindGroupBatch(groups, offsets); err != nil {
+		if rbe.err == nil {
+			rbe.err = &WGPUError{Op: "RenderBundleEncoder.SetBindGroups", Type: ErrorTypeValidation, Message: err.Error()}
+		}
+		return
+	}
+	for i, group := range groups {
+		if group == nil {
+			continue
+		}
+		var dyn []uint32
+		if i < len(offsets) {
+			dyn = offsets[i]
+		}
+		rbe.SetBindGroup(first+uint32(i), group, dyn)
+	}
+}
+
 // SetVertexBuffer sets a vertex buffer at the given slot.
 func (rbe *RenderBundleEncoder) SetVertexBuffer(slot uint32, buffer *Buffer, offset, size uint64) {
 	mustInit()
@@ -207,23 +278,36 @@ func (rbe *RenderBundleEncoder) DrawIndexedIndirect(indirectBuffer *Buffer, indi
 
 // Finish completes recording and returns the render bundle.
 // The optional desc parameter allows specifying a label; if omitted, nil is used.
+// Returns nil if the encoder recorded an error; see [RenderBundleEncoder.Err].
 func (rbe *RenderBundleEncoder) Finish(desc ...*RenderBundleDescriptor) *RenderBundle {
 	mustInit()
-	if rbe == nil || rbe.handle == 0 {
+	if rbe == nil || rbe.handle == 0 || rbe.err != nil {
 		return nil
 	}
 
 	var descPtr uintptr
+	var label string
 	if len(desc) > 0 && desc[0] != nil {
 		descPtr = uintptr(unsafe.Pointer(desc[0]))
+		label = strings.Clone(stringViewToString(desc[0].Label))
 	}
 
 	handle, _, _ := procRenderBundleEncoderFinish.Call(rbe.handle, descPtr)
 	if handle == 0 {
 		return nil
 	}
-	trackResource(handle, "RenderBundle")
-	return &RenderBundle{handle: handle}
+	trackResource(handle, "RenderBundle", label)
+	return &RenderBundle{handle: handle, layout: rbe.layout}
+}
+
+// Err returns the first error recorded on the Go side while encoding, such
+// as invalid dynamic offsets passed to SetBindGroup. Finish returns nil when
+// it is set.
+func (rbe *RenderBundleEncoder) Err() error {
+	if rbe == nil {
+		return nil
+	}
+	return rbe.err
 }
 
 // Release releases the render bundle encoder.
@@ -252,16 +336,21 @@ func (rb *RenderBundle) Handle() uintptr { return rb.handle }
 
 // ExecuteBundles executes pre-recorded render bundles in the render pass.
 // This is useful for replaying static geometry without re-recording commands.
-func (rpe *RenderPassEncoder) ExecuteBundles(bundles []*RenderBundle) {
+//
+// Each bundle must have been recorded for the attachment formats, sample
+// count and depth/stencil writability of the pass. If any bundle is nil,
+// released or incompatible, nothing is executed and the error is returned
+// by [CommandEncoder.Finish].
+func (rpe *RenderPassEncoder) ExecuteBundles(bundles ...*RenderBundle) {
 	mustInit()
 	if rpe == nil || rpe.handle == 0 || len(bundles) == 0 {
 		return
 	}
 
-	// Convert to handles
-	handles := make([]uintptr, len(bundles))
-	for i, b := range bundles {
-		handles[i] = b.handle
+	handles, err := bundleHandles(bundles, &rpe.layout)
+	if err != nil {
+		rpe.encoder.recordErr("RenderPassEncoder.ExecuteBundles", err)
+		return
 	}
 
 	procRenderPassEncoderExecuteBundles.Call( //nolint:errcheck
@@ -270,3 +359,19 @@ func (rpe *RenderPassEncoder) ExecuteBundles(bundles []*RenderBundle) {
 		uintptr(unsafe.Pointer(&handles[0])),
 	)
 }
+
+// bundleHandles returns the handles of bundles after checking that each can
+// execute in a pass with the given layout.
+func bundleHandles(bundles []*RenderBundle, pass *attachmentLayout) ([]uintptr, error) {
+	handles := make([]uintptr, len(bundles))
+	for i, b := range bundles {
+		if b == nil || b.handle == 0 {
+			return nil, fmt.Errorf("bundle %d is nil or released", i)
+		}
+		if err := checkBundleCompatible(&b.layout, pass); err != nil {
+			return nil, fmt.Errorf("bundle %d: %w", i, err)
+		}
+		handles[i] = b.handle
+	}
+	return handles, nil
+}
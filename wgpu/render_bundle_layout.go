@@ -0,0 +1,127 @@
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+)
+
+// attachmentLayout is the attachment state a render bundle is recorded for
+// and a render pass provides: bundles only execute in passes with an equal
+// layout. Unknown formats (zero) and sample counts (zero) are not compared.
+type attachmentLayout struct {
+	colorFormats       []gputypes.TextureFormat
+	depthStencilFormat gputypes.TextureFormat
+	sampleCount        uint32
+	depthReadOnly      bool
+	stencilReadOnly    bool
+}
+
+// validateBundleEncoderDescriptor checks desc against maxAttachments
+// (skipped when 0) and returns the layout of bundles recorded with it.
+// A sample count of 0 means 1.
+func validateBundleEncoderDescriptor(desc *RenderBundleEncoderDescriptor, maxAttachments uint32) (attachmentLayout, error) {
+	l := attachmentLayout{
+		colorFormats:       append([]gputypes.TextureFormat(nil), desc.ColorFormats...),
+		depthStencilFormat: desc.DepthStencilFormat,
+		sampleCount:        desc.SampleCount,
+		depthReadOnly:      desc.DepthReadOnly,
+		stencilReadOnly:    desc.StencilReadOnly,
+	}
+	if l.sampleCount == 0 {
+		l.sampleCount = 1
+	}
+	if !validMultisampleCount(l.sampleCount) {
+		return l, fmt.Errorf("invalid sample count %d", desc.SampleCount)
+	}
+	if maxAttachments > 0 && uint32(len(desc.ColorFormats)) > maxAttachments {
+		return l, fmt.Errorf("%d color formats but the device limit MaxColorAttachments is %d",
+			len(desc.ColorFormats), maxAttachments)
+	}
+	hasColor := false
+	for i, f := range desc.ColorFormats {
+		if f == gputypes.TextureFormatUndefined {
+			continue
+		}
+		hasColor = true
+		if info, ok := FormatInfo(f); ok {
+			switch {
+			case !info.HasColor:
+				return l, fmt.Errorf("color format %d: %v is not a color format", i, f)
+			case !info.Renderable:
+				return l, fmt.Errorf("color format %d: format %v is not renderable", i, f)
+			}
+		}
+	}
+	ds := desc.DepthStencilFormat
+	if ds == gputypes.TextureFormatUndefined {
+		if !hasColor {
+			return l, fmt.Errorf("no color or depth/stencil formats")
+		}
+		if desc.DepthReadOnly || desc.StencilReadOnly {
+			return l, fmt.Errorf("DepthReadOnly or StencilReadOnly set without a depth/stencil format")
+		}
+		return l, nil
+	}
+	if !ds.HasDepth() && !ds.HasStencil() {
+		return l, fmt.Errorf("depth/stencil format %v has no depth or stencil aspect", ds)
+	}
+	return l, nil
+}
+
+// renderPassLayout returns the attachment layout of a render pass begun
+// with desc, taking formats and sample counts from the attachment views.
+func renderPassLayout(desc *RenderPassDescriptor) attachmentLayout {
+	var l attachmentLayout
+	if len(desc.ColorAttachments) > 0 {
+		l.colorFormats = make([]gputypes.TextureFormat, len(desc.ColorAttachments))
+	}
+	for i, ca := range desc.ColorAttachments {
+		if ca.View != nil {
+			l.colorFormats[i] = ca.View.format
+			if l.sampleCount == 0 {
+				l.sampleCount = ca.View.sampleCount
+			}
+		}
+	}
+	if ds := desc.DepthStencilAttachment; ds != nil && ds.View != nil {
+		l.depthStencilFormat = ds.View.format
+		l.depthReadOnly = ds.DepthReadOnly
+		l.stencilReadOnly = ds.StencilReadOnly
+		if l.sampleCount == 0 {
+			l.sampleCount = ds.View.sampleCount
+		}
+	}
+	return l
+}
+
+// checkBundleCompatible reports why a bundle recorded for bundle cannot
+// execute in a pass with layout pass: the color formats, depth/stencil
+// format and sample count must match, and a bundle that writes depth or
+// stencil cannot run in a pass where that aspect is read-only. Read-only
+// flags for an aspect the format lacks are ignored.
+func checkBundleCompatible(bundle, pass *attachmentLayout) error {
+	if len(bundle.colorFormats) != len(pass.colorFormats) {
+		return fmt.Errorf("bundle has %d color formats, pass has %d color attachments",
+			len(bundle.colorFormats), len(pass.colorFormats))
+	}
+	for i, f := range bundle.colorFormats {
+		if p := pass.colorFormats[i]; p != 0 && f != p {
+			return fmt.Errorf("color attachment %d: bundle format %v, pass format %v", i, f, p)
+		}
+	}
+	if p := pass.depthStencilFormat; p != 0 && bundle.depthStencilFormat != p {
+		return fmt.Errorf("bundle depth/stencil format %v, pass format %v", bundle.depthStencilFormat, p)
+	}
+	if pass.sampleCount != 0 && bundle.sampleCount != pass.sampleCount {
+		return fmt.Errorf("bundle sample count %d, pass sample count %d", bundle.sampleCount, pass.sampleCount)
+	}
+	ds := bundle.depthStencilFormat
+	if pass.depthReadOnly && !bundle.depthReadOnly && ds.HasDepth() {
+		return fmt.Errorf("bundle writes depth but the pass depth attachment is read-only")
+	}
+	if pass.stencilReadOnly && !bundle.stencilReadOnly && ds.HasStencil() {
+		return fmt.Errorf("bundle writes stencil but the pass stencil attachment is read-only")
+	}
+	return nil
+}
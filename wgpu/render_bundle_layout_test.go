@@ -0,0 +1,98 @@
+package wgpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestValidateBundleEncoderDescriptor(t *testing.T) {
+	rgba := gputypes.TextureFormatRGBA8Unorm
+	depth := gputypes.TextureFormatDepth24Plus
+	tests := []struct {
+		name    string
+		desc    RenderBundleEncoderDescriptor
+		wantErr bool
+	}{
+		{"color only", RenderBundleEncoderDescriptor{ColorFormats: []gputypes.TextureFormat{rgba}}, false},
+		{"depth only read-only", RenderBundleEncoderDescriptor{DepthStencilFormat: depth, DepthReadOnly: true}, false},
+		{"msaa", RenderBundleEncoderDescriptor{ColorFormats: []gputypes.TextureFormat{rgba}, SampleCount: 4}, false},
+		{"no attachments", RenderBundleEncoderDescriptor{}, true},
+		{"only unused color slots", RenderBundleEncoderDescriptor{ColorFormats: []gputypes.TextureFormat{0}}, true},
+		{"bad sample count", RenderBundleEncoderDescriptor{ColorFormats: []gputypes.TextureFormat{rgba}, SampleCount: 3}, true},
+		{"too many colors", RenderBundleEncoderDescriptor{ColorFormats: make([]gputypes.TextureFormat, 9)}, true},
+		{"depth as color", RenderBundleEncoderDescriptor{ColorFormats: []gputypes.TextureFormat{depth}}, true},
+		{"color as depth", RenderBundleEncoderDescriptor{DepthStencilFormat: rgba}, true},
+		{"read-only without depth", RenderBundleEncoderDescriptor{ColorFormats: []gputypes.TextureFormat{rgba}, StencilReadOnly: true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validateBundleEncoderDescriptor(&tt.desc, 8)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	l, err := validateBundleEncoderDescriptor(&RenderBundleEncoderDescriptor{ColorFormats: []gputypes.TextureFormat{rgba}}, 0)
+	if err != nil || l.sampleCount != 1 {
+		t.Errorf("default sample count = %d, %v; want 1", l.sampleCount, err)
+	}
+}
+
+func TestCheckBundleCompatible(t *testing.T) {
+	rgba := gputypes.TextureFormatRGBA8Unorm
+	bgra := gputypes.TextureFormatBGRA8Unorm
+	d32 := gputypes.TextureFormatDepth32Float
+	colors := func(f ...gputypes.TextureFormat) []gputypes.TextureFormat { return f }
+	tests := []struct {
+		name    string
+		bundle  attachmentLayout
+		pass    attachmentLayout
+		wantErr bool
+	}{
+		{"match", attachmentLayout{colorFormats: colors(rgba), sampleCount: 1}, attachmentLayout{colorFormats: colors(rgba), sampleCount: 1}, false},
+		{"unknown pass format", attachmentLayout{colorFormats: colors(rgba), sampleCount: 1}, attachmentLayout{colorFormats: colors(0)}, false},
+		{"format mismatch", attachmentLayout{colorFormats: colors(rgba), sampleCount: 1}, attachmentLayout{colorFormats: colors(bgra), sampleCount: 1}, true},
+		{"attachment count", attachmentLayout{colorFormats: colors(rgba), sampleCount: 1}, attachmentLayout{colorFormats: colors(rgba, rgba)}, true},
+		{"sample count", attachmentLayout{colorFormats: colors(rgba), sampleCount: 1}, attachmentLayout{colorFormats: colors(rgba), sampleCount: 4}, true},
+		{"missing depth", attachmentLayout{colorFormats: colors(rgba), sampleCount: 1}, attachmentLayout{colorFormats: colors(rgba), depthStencilFormat: d32}, true},
+		{"writes read-only depth", attachmentLayout{depthStencilFormat: d32}, attachmentLayout{depthStencilFormat: d32, depthReadOnly: true}, true},
+		{"read-only bundle in read-only pass", attachmentLayout{depthStencilFormat: d32, depthReadOnly: true}, attachmentLayout{depthStencilFormat: d32, depthReadOnly: true}, false},
+		{"stencil read-only without stencil", attachmentLayout{depthStencilFormat: d32}, attachmentLayout{depthStencilFormat: d32, stencilReadOnly: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkBundleCompatible(&tt.bundle, &tt.pass)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBundleHandles(t *testing.T) {
+	pass := attachmentLayout{colorFormats: []gputypes.TextureFormat{gputypes.TextureFormatRGBA8Unorm}, sampleCount: 1}
+	ok := &RenderBundle{handle: 1, layout: pass}
+	other := &RenderBundle{handle: 2, layout: attachmentLayout{colorFormats: pass.colorFormats, sampleCount: 4}}
+	tests := []struct {
+		name    string
+		bundles []*RenderBundle
+		wantErr bool
+	}{
+		{"compatible", []*RenderBundle{ok, ok}, false},
+		{"nil bundle", []*RenderBundle{ok, nil}, true},
+		{"released bundle", []*RenderBundle{{layout: pass}}, true},
+		{"incompatible bundle", []*RenderBundle{ok, other}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handles, err := bundleHandles(tt.bundles, &pass)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && len(handles) != len(tt.bundles) {
+				t.Errorf("got %d handles, want %d", len(handles), len(tt.bundles))
+			}
+		})
+	}
+}
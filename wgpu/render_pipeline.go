@@ -1,6 +1,7 @@
 package wgpu
 
 import (
+	"runtime"
 	"unsafe"
 
 	"github.com/gogpu/gputypes"
@@ -11,12 +12,13 @@ type VertexAttribute struct {
 	Format         gputypes.VertexFormat
 	Offset         uint64
 	ShaderLocation uint32
-	_pad           [4]byte
 }
 
 // vertexAttributeWire is the FFI-compatible structure with converted Format.
-// Field order matches webgpu.h: format, offset, shaderLocation
+// v29: nextInChain added as FIRST field in WGPUVertexAttribute.
+// nextInChain(8)+format(4)+pad(4)+offset(8)+shaderLocation(4)+pad(4) = 32 bytes.
 type vertexAttributeWire struct {
+	NextInChain    uintptr
 	Format         uint32 // converted from gputypes.VertexFormat
 	_pad1          [4]byte
 	Offset         uint64
@@ -25,12 +27,12 @@ type vertexAttributeWire struct {
 }
 
 // VertexBufferLayout describes how vertex data is laid out in a buffer.
+// Attributes is copied into native memory layout when the pipeline is
+// created, so the slice may be reused afterwards.
 type VertexBufferLayout struct {
-	ArrayStride    uint64
-	StepMode       gputypes.VertexStepMode
-	_pad           [4]byte
-	AttributeCount uintptr
-	Attributes     *VertexAttribute
+	ArrayStride uint64
+	StepMode    gputypes.VertexStepMode
+	Attributes  []VertexAttribute
 }
 
 // vertexBufferLayoutWire is the FFI-compatible structure with converted StepMode.
@@ -131,16 +133,24 @@ type ColorTargetState struct {
 
 // VertexState describes the vertex stage of a render pipeline.
 type VertexState struct {
-	Module     *ShaderModule
+	Module *ShaderModule
+	// EntryPoint names the @vertex function. Leave it empty when the module
+	// has exactly one vertex entry point.
 	EntryPoint string
 	Buffers    []VertexBufferLayout
+	// Constants sets WGSL override declarations by name or numeric @id.
+	Constants map[string]float64
 }
 
 // FragmentState describes the fragment stage of a render pipeline.
 type FragmentState struct {
-	Module     *ShaderModule
+	Module *ShaderModule
+	// EntryPoint names the @fragment function. Leave it empty when the
+	// module has exactly one fragment entry point.
 	EntryPoint string
 	Targets    []ColorTargetState
+	// Constants sets WGSL override declarations by name or numeric @id.
+	Constants map[string]float64
 }
 
 // PrimitiveState describes how primitives are assembled.
@@ -149,6 +159,10 @@ type PrimitiveState struct {
 	StripIndexFormat gputypes.IndexFormat
 	FrontFace        gputypes.FrontFace
 	CullMode         gputypes.CullMode
+	// UnclippedDepth disables clipping of primitives against the near and
+	// far planes; fragment depth is clamped instead. Requires
+	// FeatureNameDepthClipControl.
+	UnclippedDepth bool
 }
 
 // MultisampleState describes multisampling.
@@ -180,6 +194,31 @@ type DepthStencilState struct {
 	DepthBiasClamp      float32
 }
 
+// NewDepthState returns depth-stencil state that tests depth against an
+// attachment of the given format, with the stencil test disabled: both faces
+// always pass and keep the stored value, and the stencil masks are all ones.
+func NewDepthState(format gputypes.TextureFormat, compare gputypes.CompareFunction, writeEnabled bool) *DepthStencilState {
+	return &DepthStencilState{
+		Format:            format,
+		DepthWriteEnabled: writeEnabled,
+		DepthCompare:      compare,
+		StencilFront:      defaultStencilFace(),
+		StencilBack:       defaultStencilFace(),
+		StencilReadMask:   0xFFFFFFFF,
+		StencilWriteMask:  0xFFFFFFFF,
+	}
+}
+
+// defaultStencilFace returns the WebGPU default stencil face state.
+func defaultStencilFace() StencilFaceState {
+	return StencilFaceState{
+		Compare:     gputypes.CompareFunctionAlways,
+		FailOp:      gputypes.StencilOperationKeep,
+		DepthFailOp: gputypes.StencilOperationKeep,
+		PassOp:      gputypes.StencilOperationKeep,
+	}
+}
+
 // depthStencilStateWire is the native FFI-compatible structure for depth/stencil state.
 // Uses uint32 for format (converted from gputypes).
 type depthStencilStateWire struct {
@@ -210,6 +249,19 @@ type RenderPipelineDescriptor struct {
 // CreateRenderPipeline creates a render pipeline.
 // Returns an error if the FFI call fails or the device/descriptor is nil.
 func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (*RenderPipeline, error) {
+	return d.createRenderPipeline(desc, false)
+}
+
+// CreateRenderPipelineE is CreateRenderPipeline that also returns the
+// validation error raised by wgpu-native, as a *[PipelineSourceError] when it
+// refers to lines of the shaders' WGSL source.
+func (d *Device) CreateRenderPipelineE(desc *RenderPipelineDescriptor) (*RenderPipeline, error) {
+	return d.createRenderPipeline(desc, true)
+}
+
+// createRenderPipeline creates a render pipeline, inside an error scope when
+// capture is set.
+func (d *Device) createRenderPipeline(desc *RenderPipelineDescriptor, capture bool) (*RenderPipeline, error) {
 	if err := checkInit(); err != nil {
 		return nil, err
 	}
@@ -219,6 +271,23 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (*RenderPi
 	if desc == nil {
 		return nil, &WGPUError{Op: "CreateRenderPipeline", Message: "descriptor is nil"}
 	}
+	if desc.Vertex.Module == nil {
+		return nil, &WGPUError{Op: "CreateRenderPipeline", Message: "vertex shader module is nil"}
+	}
+	if desc.Fragment != nil && desc.Fragment.Module == nil {
+		return nil, &WGPUError{Op: "CreateRenderPipeline", Message: "fragment shader module is nil"}
+	}
+	if err := validatePrimitive(&desc.Primitive, d.HasFeature); err != nil {
+		return nil, &WGPUError{Op: "CreateRenderPipeline", Type: ErrorTypeValidation, Message: err.Error()}
+	}
+	if err := validateDepthStencil(desc); err != nil {
+		return nil, &WGPUError{Op: "CreateRenderPipeline", Type: ErrorTypeValidation, Message: err.Error()}
+	}
+	if desc.Fragment != nil {
+		if err := validateColorTargets(desc.Fragment.Targets, d.limits.MaxColorAttachments, d.HasFeature); err != nil {
+			return nil, &WGPUError{Op: "CreateRenderPipeline", Type: ErrorTypeValidation, Message: err.Error()}
+		}
+	}
 
 	// Build vertex state
 	var entryPointBytes []byte
@@ -226,11 +295,15 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (*RenderPi
 		entryPointBytes = append([]byte(desc.Vertex.EntryPoint), 0)
 	}
 
+	vertexConstants, err := toConstantsWire(desc.Vertex.Constants)
+	if err != nil {
+		return nil, &WGPUError{Op: "CreateRenderPipeline", Type: ErrorTypeValidation, Message: "vertex: " + err.Error()}
+	}
 	nativeVertex := vertexState{
 		nextInChain:   0,
 		module:        desc.Vertex.Module.handle,
-		constantCount: 0,
-		constants:     0,
+		constantCount: vertexConstants.count(),
+		constants:     vertexConstants.ptr(),
 		bufferCount:   uintptr(len(desc.Vertex.Buffers)),
 	}
 
@@ -240,7 +313,7 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (*RenderPi
 			Length: uintptr(len(entryPointBytes) - 1),
 		}
 	} else {
-		nativeVertex.entryPoint = EmptyStringView()
+		nativeVertex.entryPoint = NullStringView()
 	}
 
 	// Convert vertex buffer layouts with StepMode and VertexFormat conversion
@@ -251,11 +324,10 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (*RenderPi
 		allNativeAttrs = make([][]vertexAttributeWire, len(desc.Vertex.Buffers))
 		for i, buf := range desc.Vertex.Buffers {
 			var attrsPtr uintptr
-			if buf.Attributes != nil && buf.AttributeCount > 0 {
+			if len(buf.Attributes) > 0 {
 				// Convert attributes with format conversion
-				attrs := unsafe.Slice(buf.Attributes, buf.AttributeCount)
-				allNativeAttrs[i] = make([]vertexAttributeWire, len(attrs))
-				for j, attr := range attrs {
+				allNativeAttrs[i] = make([]vertexAttributeWire, len(buf.Attributes))
+				for j, attr := range buf.Attributes {
 					allNativeAttrs[i][j] = vertexAttributeWire{
 						Format:         toWGPUVertexFormat(attr.Format),
 						Offset:         attr.Offset,
@@ -268,7 +340,7 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (*RenderPi
 				NextInChain:    0, // v29: required first field
 				StepMode:       toWGPUVertexStepMode(buf.StepMode),
 				ArrayStride:    buf.ArrayStride,
-				AttributeCount: buf.AttributeCount,
+				AttributeCount: uintptr(len(buf.Attributes)),
 				Attributes:     attrsPtr,
 			}
 		}
@@ -282,7 +354,7 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (*RenderPi
 		stripIndexFormat: desc.Primitive.StripIndexFormat,
 		frontFace:        desc.Primitive.FrontFace,
 		cullMode:         desc.Primitive.CullMode,
-		unclippedDepth:   False,
+		unclippedDepth:   boolToWGPU(desc.Primitive.UnclippedDepth),
 	}
 
 	// Build multisample state
@@ -320,8 +392,8 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (*RenderPi
 			format:              uint32(desc.DepthStencil.Format),
 			depthWriteEnabled:   depthWriteOpt,
 			depthCompare:        desc.DepthStencil.DepthCompare,
-			stencilFront:        desc.DepthStencil.StencilFront,
-			stencilBack:         desc.DepthStencil.StencilBack,
+			stencilFront:        stencilFaceOrDefault(desc.DepthStencil.StencilFront),
+			stencilBack:         stencilFaceOrDefault(desc.DepthStencil.StencilBack),
 			stencilReadMask:     desc.DepthStencil.StencilReadMask,
 			stencilWriteMask:    desc.DepthStencil.StencilWriteMask,
 			depthBias:           desc.DepthStencil.DepthBias,
@@ -336,17 +408,22 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (*RenderPi
 	var nativeFragment fragmentState
 	var nativeTargets []colorTargetStateWire
 	var fragEntryPointBytes []byte
+	var fragmentConstants pipelineConstants
 
 	if desc.Fragment != nil {
 		if desc.Fragment.EntryPoint != "" {
 			fragEntryPointBytes = append([]byte(desc.Fragment.EntryPoint), 0)
 		}
+		fragmentConstants, err = toConstantsWire(desc.Fragment.Constants)
+		if err != nil {
+			return nil, &WGPUError{Op: "CreateRenderPipeline", Type: ErrorTypeValidation, Message: "fragment: " + err.Error()}
+		}
 
 		nativeFragment = fragmentState{
 			nextInChain:   0,
 			module:        desc.Fragment.Module.handle,
-			constantCount: 0,
-			constants:     0,
+			constantCount: fragmentConstants.count(),
+			constants:     fragmentConstants.ptr(),
 			targetCount:   uintptr(len(desc.Fragment.Targets)),
 		}
 
@@ -356,7 +433,7 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (*RenderPi
 				Length: uintptr(len(fragEntryPointBytes) - 1),
 			}
 		} else {
-			nativeFragment.entryPoint = EmptyStringView()
+			nativeFragment.entryPoint = NullStringView()
 		}
 
 		// Build color targets with wire format (uint64 writeMask!)
@@ -388,7 +465,7 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (*RenderPi
 	// Build the full descriptor
 	nativeDesc := renderPipelineDescriptor{
 		nextInChain:  0,
-		label:        EmptyStringView(),
+		label:        stringToStringView(desc.Label),
 		layout:       layoutHandle,
 		vertex:       nativeVertex,
 		primitive:    nativePrimitive,
@@ -397,16 +474,44 @@ func (d *Device) CreateRenderPipeline(desc *RenderPipelineDescriptor) (*RenderPi
 		fragment:     fragmentPtr,
 	}
 
-	handle, _, _ := procDeviceCreateRenderPipeline.Call(
-		d.handle,
-		uintptr(unsafe.Pointer(&nativeDesc)),
-	)
+	var pins pinSet
+	pins.add(desc, entryPointBytes, nativeBuffers, allNativeAttrs, vertexConstants,
+		&nativeDepthStencil, &nativeFragment, nativeTargets, fragEntryPointBytes, fragmentConstants)
+	var handle uintptr
+	create := func() {
+		handle, _, _ = procDeviceCreateRenderPipeline.Call(
+			d.handle,
+			uintptr(unsafe.Pointer(&nativeDesc)),
+		)
+	}
+	if capture {
+		err = d.captureValidation("CreateRenderPipeline", create)
+	} else {
+		create()
+	}
+	runtime.KeepAlive(pins.objs)
 	if handle == 0 {
 		return nil, &WGPUError{Op: "CreateRenderPipeline", Message: "wgpu returned null handle"}
 	}
 
-	trackResource(handle, "RenderPipeline")
-	return &RenderPipeline{handle: handle}, nil
+	trackResource(handle, "RenderPipeline", desc.Label)
+	if err != nil {
+		// wgpu-native returns an invalid pipeline rather than null.
+		(&RenderPipeline{handle: handle}).Release()
+		stages := []stageSource{{"vertex", desc.Vertex.Module}}
+		if desc.Fragment != nil {
+			stages = append(stages, stageSource{"fragment", desc.Fragment.Module})
+		}
+		return nil, annotatePipelineError(err, stages...)
+	}
+	rp := &RenderPipeline{
+		handle:           handle,
+		bindGroupLayouts: newBindGroupLayoutCache(d, desc.Layout, renderEntryPoints(desc)...),
+	}
+	if traceActive.Load() {
+		traceCall("Device.CreateRenderPipeline", nil, rp, desc)
+	}
+	return rp, nil
 }
 
 // CreateRenderPipelineSimple creates a simple render pipeline with common defaults.
@@ -445,26 +550,10 @@ func (d *Device) CreateRenderPipelineSimple(
 	})
 }
 
-// GetBindGroupLayout returns the bind group layout for the given index.
-func (rp *RenderPipeline) GetBindGroupLayout(groupIndex uint32) *BindGroupLayout {
-	mustInit()
-	if rp == nil || rp.handle == 0 {
-		return nil
-	}
-	handle, _, _ := procRenderPipelineGetBindGroupLayout.Call(
-		rp.handle,
-		uintptr(groupIndex),
-	)
-	if handle == 0 {
-		return nil
-	}
-	trackResource(handle, "BindGroupLayout")
-	return &BindGroupLayout{handle: handle}
-}
-
 // Release releases the render pipeline.
 func (rp *RenderPipeline) Release() {
 	if rp.handle != 0 {
+		rp.bindGroupLayouts.release()
 		untrackResource(rp.handle)
 		procRenderPipelineRelease.Call(rp.handle) //nolint:errcheck
 		rp.handle = 0
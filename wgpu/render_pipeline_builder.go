@@ -0,0 +1,226 @@
+package wgpu
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gogpu/gputypes"
+)
+
+// RenderPipelineBuilder assembles a RenderPipelineDescriptor with the
+// defaults most pipelines want: triangle lists, counter-clockwise front
+// faces, no culling, one sample and color targets that write all channels.
+//
+//	pipeline, err := wgpu.NewRenderPipelineBuilder(device).
+//		VS(shader, "vs_main").
+//		FS(shader, "fs_main").
+//		ColorTarget(wgpu.TextureFormatBGRA8Unorm).
+//		Depth(wgpu.TextureFormatDepth24Plus, wgpu.CompareFunctionLess).
+//		VertexLayout(24, wgpu.VertexStepModeVertex,
+//			wgpu.VertexAttribute{Format: wgpu.VertexFormatFloat32x3, ShaderLocation: 0},
+//			wgpu.VertexAttribute{Format: wgpu.VertexFormatFloat32x3, Offset: 12, ShaderLocation: 1}).
+//		Build()
+//
+// Methods that modify a previous call, such as Blend, record an error when
+// that call is missing; Build reports the first recorded error.
+type RenderPipelineBuilder struct {
+	device *Device
+	desc   RenderPipelineDescriptor
+	err    error
+}
+
+// NewRenderPipelineBuilder returns a builder for a render pipeline on device.
+func NewRenderPipelineBuilder(device *Device) *RenderPipelineBuilder {
+	return &RenderPipelineBuilder{
+		device: device,
+		desc: RenderPipelineDescriptor{
+			Primitive: PrimitiveState{
+				Topology:  gputypes.PrimitiveTopologyTriangleList,
+				FrontFace: gputypes.FrontFaceCCW,
+				CullMode:  gputypes.CullModeNone,
+			},
+			Multisample: MultisampleState{
+				Count: 1,
+				Mask:  0xFFFFFFFF,
+			},
+		},
+	}
+}
+
+// setErr records err unless an earlier error is already recorded.
+func (b *RenderPipelineBuilder) setErr(format string, args ...any) {
+	if b.err == nil {
+		b.err = &WGPUError{Op: "RenderPipelineBuilder", Type: ErrorTypeValidation, Message: fmt.Sprintf(format, args...)}
+	}
+}
+
+// Label sets the pipeline label.
+func (b *RenderPipelineBuilder) Label(label string) *RenderPipelineBuilder {
+	b.desc.Label = label
+	return b
+}
+
+// Layout sets the pipeline layout. Without it the layout is derived from the
+// shaders.
+func (b *RenderPipelineBuilder) Layout(layout *PipelineLayout) *RenderPipelineBuilder {
+	b.desc.Layout = layout
+	return b
+}
+
+// VS sets the vertex shader module and entry point.
+func (b *RenderPipelineBuilder) VS(module *ShaderModule, entryPoint string) *RenderPipelineBuilder {
+	b.desc.Vertex.Module = module
+	b.desc.Vertex.EntryPoint = entryPoint
+	return b
+}
+
+// FS sets the fragment shader module and entry point.
+func (b *RenderPipelineBuilder) FS(module *ShaderModule, entryPoint string) *RenderPipelineBuilder {
+	b.fragment().Module = module
+	b.desc.Fragment.EntryPoint = entryPoint
+	return b
+}
+
+// fragment returns the fragment state, creating it on first use.
+func (b *RenderPipelineBuilder) fragment() *FragmentState {
+	if b.desc.Fragment == nil {
+		b.desc.Fragment = &FragmentState{}
+	}
+	return b.desc.Fragment
+}
+
+// ColorTarget appends a color target of the given format that writes all
+// channels without blending.
+func (b *RenderPipelineBuilder) ColorTarget(format gputypes.TextureFormat) *RenderPipelineBuilder {
+	f := b.fragment()
+	f.Targets = append(f.Targets, ColorTargetState{
+		Format:    format,
+		WriteMask: gputypes.ColorWriteMaskAll,
+	})
+	return b
+}
+
+// Blend sets the blend state of the most recently added color target.
+func (b *RenderPipelineBuilder) Blend(blend *BlendState) *RenderPipelineBuilder {
+	if b.desc.Fragment == nil || len(b.desc.Fragment.Targets) == 0 {
+		b.setErr("Blend called before ColorTarget")
+		return b
+	}
+	b.desc.Fragment.Targets[len(b.desc.Fragment.Targets)-1].Blend = blend
+	return b
+}
+
+// WriteMask sets the write mask of the most recently added color target.
+func (b *RenderPipelineBuilder) WriteMask(mask gputypes.ColorWriteMask) *RenderPipelineBuilder {
+	if b.desc.Fragment == nil || len(b.desc.Fragment.Targets) == 0 {
+		b.setErr("WriteMask called before ColorTarget")
+		return b
+	}
+	b.desc.Fragment.Targets[len(b.desc.Fragment.Targets)-1].WriteMask = mask
+	return b
+}
+
+// Depth enables depth testing against an attachment of the given format,
+// with depth writes on and the stencil test disabled.
+func (b *RenderPipelineBuilder) Depth(format gputypes.TextureFormat, compare gputypes.CompareFunction) *RenderPipelineBuilder {
+	b.desc.DepthStencil = NewDepthState(format, compare, true)
+	return b
+}
+
+// DepthWrite turns depth writes on or off; use it after Depth for passes that
+// test against but do not update the depth buffer.
+func (b *RenderPipelineBuilder) DepthWrite(enabled bool) *RenderPipelineBuilder {
+	if b.desc.DepthStencil == nil {
+		b.setErr("DepthWrite called before Depth")
+		return b
+	}
+	b.desc.DepthStencil.DepthWriteEnabled = enabled
+	return b
+}
+
+// DepthBias sets the constant and slope-scaled depth bias and its clamp.
+func (b *RenderPipelineBuilder) DepthBias(constant int32, slopeScale, clamp float32) *RenderPipelineBuilder {
+	if b.desc.DepthStencil == nil {
+		b.setErr("DepthBias called before Depth")
+		return b
+	}
+	b.desc.DepthStencil.DepthBias = constant
+	b.desc.DepthStencil.DepthBiasSlopeScale = slopeScale
+	b.desc.DepthStencil.DepthBiasClamp = clamp
+	return b
+}
+
+// VertexLayout appends a vertex buffer layout. Buffers are numbered in the
+// order they are added, matching the slot passed to SetVertexBuffer.
+func (b *RenderPipelineBuilder) VertexLayout(stride uint64, stepMode gputypes.VertexStepMode, attrs ...VertexAttribute) *RenderPipelineBuilder {
+	b.desc.Vertex.Buffers = append(b.desc.Vertex.Buffers, VertexBufferLayout{
+		ArrayStride: stride,
+		StepMode:    stepMode,
+		Attributes:  slices.Clone(attrs),
+	})
+	return b
+}
+
+// Topology sets the primitive topology. Strip topologies take their index
+// format from the index buffer bound at draw time.
+func (b *RenderPipelineBuilder) Topology(topology gputypes.PrimitiveTopology) *RenderPipelineBuilder {
+	b.desc.Primitive.Topology = topology
+	return b
+}
+
+// CullMode sets which faces are culled.
+func (b *RenderPipelineBuilder) CullMode(mode gputypes.CullMode) *RenderPipelineBuilder {
+	b.desc.Primitive.CullMode = mode
+	return b
+}
+
+// FrontFace sets the winding order of front faces.
+func (b *RenderPipelineBuilder) FrontFace(face gputypes.FrontFace) *RenderPipelineBuilder {
+	b.desc.Primitive.FrontFace = face
+	return b
+}
+
+// UnclippedDepth turns off near and far plane clipping, clamping fragment
+// depth instead, as shadow maps of geometry behind the light's near plane
+// need. The device must have FeatureNameDepthClipControl.
+func (b *RenderPipelineBuilder) UnclippedDepth(enabled bool) *RenderPipelineBuilder {
+	b.desc.Primitive.UnclippedDepth = enabled
+	return b
+}
+
+// SampleCount sets the number of samples per pixel for multisampled targets.
+func (b *RenderPipelineBuilder) SampleCount(count uint32) *RenderPipelineBuilder {
+	b.desc.Multisample.Count = count
+	return b
+}
+
+// Descriptor returns the descriptor assembled so far, or the first recorded
+// error. The descriptor shares state with the builder.
+func (b *RenderPipelineBuilder) Descriptor() (*RenderPipelineDescriptor, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.desc.Vertex.Module == nil {
+		return nil, &WGPUError{Op: "RenderPipelineBuilder", Type: ErrorTypeValidation, Message: "vertex shader not set; call VS"}
+	}
+	if f := b.desc.Fragment; f != nil {
+		if f.Module == nil {
+			return nil, &WGPUError{Op: "RenderPipelineBuilder", Type: ErrorTypeValidation, Message: "color targets require a fragment shader; call FS"}
+		}
+		if len(f.Targets) == 0 && b.desc.DepthStencil == nil {
+			return nil, &WGPUError{Op: "RenderPipelineBuilder", Type: ErrorTypeValidation, Message: "pipeline has no color target or depth attachment"}
+		}
+	}
+	return &b.desc, nil
+}
+
+// Build creates the render pipeline.
+// Returns the first error recorded while building, or any error from
+// [Device.CreateRenderPipelineE].
+func (b *RenderPipelineBuilder) Build() (*RenderPipeline, error) {
+	desc, err := b.Descriptor()
+	if err != nil {
+		return nil, err
+	}
+	return b.device.CreateRenderPipelineE(desc)
+}
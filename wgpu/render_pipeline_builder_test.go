@@ -0,0 +1,88 @@
+package wgpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestRenderPipelineBuilderDefaults(t *testing.T) {
+	shader := &ShaderModule{handle: 1}
+	desc, err := NewRenderPipelineBuilder(nil).
+		VS(shader, "vs_main").
+		FS(shader, "fs_main").
+		ColorTarget(gputypes.TextureFormatBGRA8Unorm).
+		Depth(gputypes.TextureFormatDepth24Plus, gputypes.CompareFunctionLess).
+		VertexLayout(24, gputypes.VertexStepModeVertex,
+			VertexAttribute{Format: gputypes.VertexFormatFloat32x3, ShaderLocation: 0},
+			VertexAttribute{Format: gputypes.VertexFormatFloat32x3, Offset: 12, ShaderLocation: 1}).
+		Descriptor()
+	if err != nil {
+		t.Fatalf("Descriptor: %v", err)
+	}
+
+	if desc.Primitive.Topology != gputypes.PrimitiveTopologyTriangleList ||
+		desc.Primitive.FrontFace != gputypes.FrontFaceCCW ||
+		desc.Primitive.CullMode != gputypes.CullModeNone {
+		t.Errorf("primitive = %+v, want triangle list, CCW, no culling", desc.Primitive)
+	}
+	if desc.Multisample.Count != 1 || desc.Multisample.Mask != 0xFFFFFFFF {
+		t.Errorf("multisample = %+v, want count 1 and full mask", desc.Multisample)
+	}
+	if got := desc.Fragment.Targets; len(got) != 1 || got[0].WriteMask != gputypes.ColorWriteMaskAll || got[0].Blend != nil {
+		t.Errorf("targets = %+v, want one opaque target writing all channels", got)
+	}
+	ds := desc.DepthStencil
+	if ds == nil || !ds.DepthWriteEnabled || ds.DepthCompare != gputypes.CompareFunctionLess ||
+		ds.StencilFront.Compare != gputypes.CompareFunctionAlways {
+		t.Errorf("depth stencil = %+v, want depth writes with Less and a pass-through stencil", ds)
+	}
+	buf := desc.Vertex.Buffers[0]
+	if buf.ArrayStride != 24 || len(buf.Attributes) != 2 {
+		t.Fatalf("vertex buffer = %+v", buf)
+	}
+	if attrs := buf.Attributes; attrs[1].Offset != 12 || attrs[1].ShaderLocation != 1 {
+		t.Errorf("attribute[1] = %+v", attrs[1])
+	}
+}
+
+func TestRenderPipelineBuilderErrors(t *testing.T) {
+	shader := &ShaderModule{handle: 1}
+	tests := []struct {
+		name string
+		b    *RenderPipelineBuilder
+	}{
+		{"no vertex shader", NewRenderPipelineBuilder(nil).FS(shader, "fs").ColorTarget(gputypes.TextureFormatRGBA8Unorm)},
+		{"targets without fragment shader", NewRenderPipelineBuilder(nil).VS(shader, "vs").ColorTarget(gputypes.TextureFormatRGBA8Unorm)},
+		{"blend before target", NewRenderPipelineBuilder(nil).VS(shader, "vs").FS(shader, "fs").Blend(&BlendState{})},
+		{"depth write before depth", NewRenderPipelineBuilder(nil).VS(shader, "vs").DepthWrite(false)},
+		{"fragment without outputs", NewRenderPipelineBuilder(nil).VS(shader, "vs").FS(shader, "fs")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.b.Descriptor(); err == nil {
+				t.Error("Descriptor() succeeded, want error")
+			}
+			if _, err := tt.b.Build(); err == nil {
+				t.Error("Build() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestRenderPipelineBuilderDepthOnly(t *testing.T) {
+	desc, err := NewRenderPipelineBuilder(nil).
+		VS(&ShaderModule{handle: 1}, "vs_main").
+		Depth(gputypes.TextureFormatDepth32Float, gputypes.CompareFunctionLessEqual).
+		DepthBias(2, 1.5, 0).
+		Descriptor()
+	if err != nil {
+		t.Fatalf("Descriptor: %v", err)
+	}
+	if desc.Fragment != nil {
+		t.Errorf("Fragment = %+v, want nil for a depth-only pipeline", desc.Fragment)
+	}
+	if desc.DepthStencil.DepthBias != 2 || desc.DepthStencil.DepthBiasSlopeScale != 1.5 {
+		t.Errorf("depth bias = %d/%v", desc.DepthStencil.DepthBias, desc.DepthStencil.DepthBiasSlopeScale)
+	}
+}
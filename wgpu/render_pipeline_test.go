@@ -199,11 +199,10 @@ fn fs_main() -> @location(0) vec4<f32> {
 	defer pipeline.Release()
 
 	t.Log("Getting bind group layout from render pipeline...")
-	layout := pipeline.GetBindGroupLayout(0)
-	if layout == nil {
-		t.Fatal("GetBindGroupLayout returned nil")
+	layout, err := pipeline.GetBindGroupLayout(0)
+	if err != nil {
+		t.Fatalf("GetBindGroupLayout failed: %v", err)
 	}
-	defer layout.Release()
 
 	if layout.Handle() == 0 {
 		t.Fatal("BindGroupLayout handle is zero")
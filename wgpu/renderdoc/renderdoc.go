@@ -0,0 +1,209 @@
+// Package renderdoc drives RenderDoc frame captures from Go through its
+// in-application API, without cgo.
+//
+// Load finds the RenderDoc library when the program was launched from
+// RenderDoc (or renderdoccmd), or loads it from the library path. Load it
+// before creating the wgpu instance, so RenderDoc can hook the graphics API:
+//
+//	rd, err := renderdoc.Load()
+//	if err != nil {
+//	    log.Printf("captures disabled: %v", err)
+//	}
+//	// ... create the instance and device ...
+//
+//	rd.StartCapture()
+//	// ... encode and submit the work to capture ...
+//	if err := rd.EndCapture(); err != nil { ... }
+//
+// Programs that present to a surface get frame boundaries from RenderDoc
+// itself, and TriggerCapture captures the next presented frame. Programs
+// that never present, such as those rendering into a wgpu.HeadlessSurface
+// or running compute work, call Frame once per frame instead.
+//
+// All methods are no-ops on a nil *API, so a failed Load can be ignored.
+// RenderDoc supports Windows and Linux; elsewhere Load returns
+// errors.ErrUnsupported.
+package renderdoc
+
+import (
+	"errors"
+	"sync"
+	"unsafe"
+)
+
+// ErrNotLoaded is returned by Load when the RenderDoc library cannot be
+// found.
+var ErrNotLoaded = errors.New("renderdoc: RenderDoc library not found")
+
+// apiVersion is eRENDERDOC_API_Version_1_1_2, the oldest version with every
+// function used here.
+const apiVersion = 10102
+
+// Indices of the functions used in the RENDERDOC_API_1_1_2 table.
+const (
+	fnGetAPIVersion              = 0
+	fnSetCaptureFilePathTemplate = 11
+	fnGetNumCaptures             = 13
+	fnGetCapture                 = 14
+	fnTriggerCapture             = 15
+	fnStartFrameCapture          = 19
+	fnIsFrameCapturing           = 20
+	fnEndFrameCapture            = 21
+	fnTriggerMultiFrameCapture   = 22
+	fnCount                      = 23
+)
+
+// API is the RenderDoc in-application API. It is safe for concurrent use.
+type API struct {
+	fns [fnCount]uintptr
+	// call calls a function of the table; replaced in tests.
+	call func(fn uintptr, args ...uintptr) uintptr
+
+	mu        sync.Mutex
+	pending   int  // frames still to capture through Frame
+	capturing bool // a capture was started by Frame
+}
+
+var (
+	loadOnce sync.Once
+	loaded   *API
+	loadErr  error
+)
+
+// Load returns the RenderDoc API, loading the library on first use.
+func Load() (*API, error) {
+	loadOnce.Do(func() {
+		loaded, loadErr = load()
+	})
+	return loaded, loadErr
+}
+
+// newAPI copies the function table at table.
+func newAPI(table unsafe.Pointer) *API {
+	a := &API{call: callFn}
+	for i := range a.fns {
+		a.fns[i] = *(*uintptr)(unsafe.Add(table, i*int(unsafe.Sizeof(uintptr(0)))))
+	}
+	return a
+}
+
+// Version returns the version of the loaded RenderDoc API.
+func (a *API) Version() (major, minor, patch int) {
+	if a == nil {
+		return 0, 0, 0
+	}
+	var v [3]int32
+	a.call(a.fns[fnGetAPIVersion],
+		uintptr(unsafe.Pointer(&v[0])), uintptr(unsafe.Pointer(&v[1])), uintptr(unsafe.Pointer(&v[2])))
+	return int(v[0]), int(v[1]), int(v[2])
+}
+
+// StartCapture starts capturing all work on any device until EndCapture.
+func (a *API) StartCapture() {
+	if a == nil {
+		return
+	}
+	// Null device and window pointers capture whatever is active.
+	a.call(a.fns[fnStartFrameCapture], 0, 0)
+}
+
+// EndCapture ends the capture started by StartCapture and writes it to
+// disk. It returns an error if RenderDoc could not capture the work.
+func (a *API) EndCapture() error {
+	if a == nil {
+		return nil
+	}
+	if uint32(a.call(a.fns[fnEndFrameCapture], 0, 0)) == 0 {
+		return errors.New("renderdoc: capture failed")
+	}
+	return nil
+}
+
+// IsCapturing reports whether a capture is in progress.
+func (a *API) IsCapturing() bool {
+	return a != nil && uint32(a.call(a.fns[fnIsFrameCapturing])) != 0
+}
+
+// TriggerCapture captures the next frame, as the capture key does: the next
+// presented frame, or the next frame marked with Frame.
+func (a *API) TriggerCapture() {
+	a.TriggerCaptures(1)
+}
+
+// TriggerCaptures captures the next n frames.
+func (a *API) TriggerCaptures(n int) {
+	if a == nil || n <= 0 {
+		return
+	}
+	if n == 1 {
+		a.call(a.fns[fnTriggerCapture])
+	} else {
+		a.call(a.fns[fnTriggerMultiFrameCapture], uintptr(n))
+	}
+	a.mu.Lock()
+	a.pending += n
+	a.mu.Unlock()
+}
+
+// Frame marks a frame boundary for programs that do not present to a
+// surface. It ends the capture of the previous frame, if any, and starts
+// capturing the next one while captures requested with TriggerCapture are
+// pending. The error is that of ending the previous capture.
+func (a *API) Frame() error {
+	if a == nil {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var err error
+	if a.capturing {
+		a.capturing = false
+		err = a.EndCapture()
+	}
+	if a.pending > 0 {
+		a.pending--
+		a.capturing = true
+		a.StartCapture()
+	}
+	return err
+}
+
+// SetCaptureFilePathTemplate sets the path prefix of capture files, for
+// example "captures/app" for captures/app_frame123.rdc.
+func (a *API) SetCaptureFilePathTemplate(template string) {
+	if a == nil {
+		return
+	}
+	s := append([]byte(template), 0)
+	a.call(a.fns[fnSetCaptureFilePathTemplate], uintptr(unsafe.Pointer(&s[0])))
+}
+
+// Capture is a capture written by RenderDoc.
+type Capture struct {
+	Path string
+	// Timestamp is the capture time in seconds since the Unix epoch.
+	Timestamp uint64
+}
+
+// Captures returns the captures made so far, oldest first.
+func (a *API) Captures() []Capture {
+	if a == nil {
+		return nil
+	}
+	n := int(uint32(a.call(a.fns[fnGetNumCaptures])))
+	captures := make([]Capture, 0, n)
+	for i := range n {
+		var pathLen uint32
+		if uint32(a.call(a.fns[fnGetCapture], uintptr(i), 0, uintptr(unsafe.Pointer(&pathLen)), 0)) == 0 || pathLen == 0 {
+			continue
+		}
+		path := make([]byte, pathLen)
+		var c Capture
+		a.call(a.fns[fnGetCapture], uintptr(i),
+			uintptr(unsafe.Pointer(&path[0])), uintptr(unsafe.Pointer(&pathLen)), uintptr(unsafe.Pointer(&c.Timestamp)))
+		// pathLen counts the terminating NUL.
+		c.Path = string(path[:max(int(pathLen)-1, 0)])
+		captures = append(captures, c)
+	}
+	return captures
+}
@@ -0,0 +1,57 @@
+//go:build linux && !android
+
+package renderdoc
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/go-webgpu/goffi/ffi"
+	"github.com/go-webgpu/goffi/types"
+)
+
+// maxArgs is the most arguments taken by a function of the API table.
+const maxArgs = 4
+
+// cifs holds a call interface for each argument count, all arguments and
+// results pointer-sized.
+var cifs [maxArgs + 1]types.CallInterface
+
+func load() (*API, error) {
+	// When launched from RenderDoc the library is already loaded, and
+	// dlopen returns it.
+	lib, err := ffi.LoadLibrary("librenderdoc.so")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotLoaded, err)
+	}
+	getAPI, err := ffi.GetSymbol(lib, "RENDERDOC_GetAPI")
+	if err != nil {
+		return nil, fmt.Errorf("renderdoc: RENDERDOC_GetAPI: %w", err)
+	}
+	for n := range cifs {
+		args := make([]*types.TypeDescriptor, n)
+		for i := range args {
+			args[i] = types.PointerTypeDescriptor
+		}
+		if err := ffi.PrepareCallInterface(&cifs[n], types.DefaultCall, types.PointerTypeDescriptor, args); err != nil {
+			return nil, fmt.Errorf("renderdoc: %w", err)
+		}
+	}
+	var table unsafe.Pointer
+	if uint32(callFn(uintptr(getAPI), apiVersion, uintptr(unsafe.Pointer(&table)))) != 1 || table == nil {
+		return nil, fmt.Errorf("renderdoc: library does not provide API version %d", apiVersion)
+	}
+	return newAPI(table), nil
+}
+
+// callFn calls the C function fn with pointer-sized arguments.
+func callFn(fn uintptr, args ...uintptr) uintptr {
+	ptrs := make([]unsafe.Pointer, len(args))
+	for i := range args {
+		ptrs[i] = unsafe.Pointer(&args[i])
+	}
+	var ret uintptr
+	// The call interfaces were validated in load, so the call cannot fail.
+	_, _ = ffi.CallFunction(&cifs[len(args)], *(*unsafe.Pointer)(unsafe.Pointer(&fn)), unsafe.Pointer(&ret), ptrs)
+	return ret
+}
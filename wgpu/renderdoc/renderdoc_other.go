@@ -0,0 +1,14 @@
+//go:build !windows && !(linux && !android)
+
+package renderdoc
+
+import "errors"
+
+func load() (*API, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// callFn is never reached: load fails, so no API exists to call through.
+func callFn(fn uintptr, args ...uintptr) uintptr {
+	panic("renderdoc: unsupported platform")
+}
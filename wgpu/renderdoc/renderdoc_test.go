@@ -0,0 +1,61 @@
+package renderdoc
+
+import (
+	"slices"
+	"testing"
+)
+
+// fakeAPI returns an API whose table entries are their own indices and
+// which records the functions called.
+func fakeAPI(calls *[]uintptr) *API {
+	a := &API{}
+	for i := range a.fns {
+		a.fns[i] = uintptr(i)
+	}
+	a.call = func(fn uintptr, args ...uintptr) uintptr {
+		*calls = append(*calls, fn)
+		return 1
+	}
+	return a
+}
+
+func TestFrame(t *testing.T) {
+	var calls []uintptr
+	a := fakeAPI(&calls)
+
+	if err := a.Frame(); err != nil || len(calls) != 0 {
+		t.Fatalf("Frame without a trigger: err %v, calls %v", err, calls)
+	}
+
+	a.TriggerCaptures(2)
+	for range 4 {
+		if err := a.Frame(); err != nil {
+			t.Fatalf("Frame: %v", err)
+		}
+	}
+	want := []uintptr{
+		fnTriggerMultiFrameCapture,
+		fnStartFrameCapture,
+		fnEndFrameCapture, fnStartFrameCapture,
+		fnEndFrameCapture,
+	}
+	if !slices.Equal(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestNilAPI(t *testing.T) {
+	var a *API
+	a.StartCapture()
+	a.TriggerCapture()
+	a.SetCaptureFilePathTemplate("x")
+	if err := a.EndCapture(); err != nil {
+		t.Errorf("EndCapture: %v", err)
+	}
+	if err := a.Frame(); err != nil {
+		t.Errorf("Frame: %v", err)
+	}
+	if a.IsCapturing() || a.Captures() != nil {
+		t.Error("nil API reports captures")
+	}
+}
@@ -0,0 +1,38 @@
+//go:build windows
+
+package renderdoc
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+func load() (*API, error) {
+	// When launched from RenderDoc the library is already loaded.
+	var lib windows.Handle
+	name, _ := windows.UTF16PtrFromString("renderdoc.dll")
+	err := windows.GetModuleHandleEx(0, name, &lib)
+	if err != nil || lib == 0 {
+		if lib, err = windows.LoadLibrary("renderdoc.dll"); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrNotLoaded, err)
+		}
+	}
+	getAPI, err := windows.GetProcAddress(lib, "RENDERDOC_GetAPI")
+	if err != nil {
+		return nil, fmt.Errorf("renderdoc: RENDERDOC_GetAPI: %w", err)
+	}
+	var table unsafe.Pointer
+	if uint32(callFn(getAPI, apiVersion, uintptr(unsafe.Pointer(&table)))) != 1 || table == nil {
+		return nil, fmt.Errorf("renderdoc: library does not provide API version %d", apiVersion)
+	}
+	return newAPI(table), nil
+}
+
+// callFn calls the C function fn with pointer-sized arguments.
+func callFn(fn uintptr, args ...uintptr) uintptr {
+	r, _, _ := syscall.SyscallN(fn, args...)
+	return r
+}
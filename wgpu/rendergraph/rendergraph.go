@@ -0,0 +1,280 @@
+// Package rendergraph is an optional frame-graph layer for multi-pass
+// rendering.
+//
+// Each frame, declare the textures a frame uses and the passes that read and
+// write them; [Graph.Execute] then orders the passes by their dependencies,
+// skips passes whose output nothing uses, takes transient textures from a
+// [wgpu.TexturePool] for just the span of passes that need them, and calls
+// each pass to record its commands:
+//
+//	g := rendergraph.New(pool)
+//	backbuffer := g.Import("backbuffer", surfaceView)
+//	hdr := g.Create("hdr", wgpu.TexturePoolKey{Width: w, Height: h,
+//	    Format: gputypes.TextureFormatRGBA16Float,
+//	    Usage:  gputypes.TextureUsageRenderAttachment | gputypes.TextureUsageTextureBinding})
+//
+//	g.AddPass("tonemap", []rendergraph.Resource{hdr}, []rendergraph.Resource{backbuffer},
+//	    func(ctx *rendergraph.PassContext) error { ... })
+//	g.AddPass("scene", nil, []rendergraph.Resource{hdr},
+//	    func(ctx *rendergraph.PassContext) error { ... })
+//
+//	err := g.Execute(encoder) // runs scene, then tonemap
+//	// Finish, Submit, then pool.EndFrame().
+//
+// Imported textures are the outputs of the graph: a pass runs only if it
+// writes an imported texture, or a texture read by a pass that runs.
+package rendergraph
+
+import (
+	"fmt"
+
+	"github.com/go-webgpu/webgpu/wgpu"
+)
+
+// Resource identifies a texture declared on a [Graph].
+type Resource struct{ id int }
+
+// PassFunc records the commands of a pass.
+type PassFunc func(ctx *PassContext) error
+
+// PassContext gives a running pass its encoder and textures.
+type PassContext struct {
+	// Encoder is the encoder passed to Graph.Execute.
+	Encoder *wgpu.CommandEncoder
+
+	graph *Graph
+}
+
+// View returns the view of r, which the pass must have declared.
+func (c *PassContext) View(r Resource) *wgpu.TextureView {
+	return c.graph.resources[r.id].view
+}
+
+// Texture returns the texture of r; it is nil for imported views.
+func (c *PassContext) Texture(r Resource) *wgpu.Texture {
+	return c.graph.resources[r.id].texture
+}
+
+type resource struct {
+	name     string
+	imported bool
+	key      wgpu.TexturePoolKey // transient textures only
+	texture  *wgpu.Texture
+	view     *wgpu.TextureView
+}
+
+type pass struct {
+	name          string
+	reads, writes []Resource
+	run           PassFunc
+}
+
+// Graph collects the resources and passes of one frame. A Graph is not safe
+// for concurrent use.
+type Graph struct {
+	pool      *wgpu.TexturePool
+	resources []*resource
+	passes    []*pass
+}
+
+// New returns an empty graph taking transient textures from pool.
+func New(pool *wgpu.TexturePool) *Graph {
+	return &Graph{pool: pool}
+}
+
+// Import declares a texture owned by the caller, such as the surface's
+// current view. Imported textures are outputs of the graph.
+func (g *Graph) Import(name string, view *wgpu.TextureView) Resource {
+	g.resources = append(g.resources, &resource{name: name, imported: true, view: view})
+	return Resource{id: len(g.resources) - 1}
+}
+
+// Create declares a transient texture, acquired from the pool when the
+// first pass using it runs and handed back after the last one.
+func (g *Graph) Create(name string, key wgpu.TexturePoolKey) Resource {
+	g.resources = append(g.resources, &resource{name: name, key: key})
+	return Resource{id: len(g.resources) - 1}
+}
+
+// AddPass declares a pass that reads and writes the given resources. Passes
+// writing the same resource run in the order they were added.
+func (g *Graph) AddPass(name string, reads, writes []Resource, run PassFunc) {
+	g.passes = append(g.passes, &pass{name: name, reads: reads, writes: writes, run: run})
+}
+
+// Reset removes every resource and pass so the graph can describe the next
+// frame.
+func (g *Graph) Reset() {
+	g.resources = g.resources[:0]
+	g.passes = g.passes[:0]
+}
+
+// Execute runs the passes contributing to an imported resource in
+// dependency order, recording into enc. It stops at the first error. The
+// transient textures go back to the pool as soon as their last pass has
+// been recorded, so later passes may reuse their memory.
+func (g *Graph) Execute(enc *wgpu.CommandEncoder) error {
+	order, err := g.compile()
+	if err != nil {
+		return err
+	}
+	lastUse := make(map[int]int) // resource id -> index in order of its last pass
+	for i, p := range order {
+		for _, r := range g.uses(p) {
+			lastUse[r.id] = i
+		}
+	}
+	ctx := &PassContext{Encoder: enc, graph: g}
+	defer g.releaseTransients()
+	for i, p := range order {
+		for _, r := range g.uses(p) {
+			if err := g.acquire(r); err != nil {
+				return fmt.Errorf("rendergraph: pass %q: %w", g.passes[p].name, err)
+			}
+		}
+		if err := g.passes[p].run(ctx); err != nil {
+			return fmt.Errorf("rendergraph: pass %q: %w", g.passes[p].name, err)
+		}
+		for _, r := range g.uses(p) {
+			if lastUse[r.id] == i {
+				g.release(r)
+			}
+		}
+	}
+	return nil
+}
+
+// uses returns the resources pass p reads or writes.
+func (g *Graph) uses(p int) []Resource {
+	ps := g.passes[p]
+	return append(append([]Resource(nil), ps.reads...), ps.writes...)
+}
+
+// acquire gives a transient resource a pooled texture if it has none.
+func (g *Graph) acquire(r Resource) error {
+	res := g.resources[r.id]
+	if res.imported || res.texture != nil {
+		return nil
+	}
+	tex, err := g.pool.Acquire(res.key)
+	if err != nil {
+		return fmt.Errorf("texture %q: %w", res.name, err)
+	}
+	view, err := tex.DefaultView()
+	if err != nil {
+		g.pool.Release(tex)
+		return fmt.Errorf("texture %q: %w", res.name, err)
+	}
+	res.texture, res.view = tex, view
+	return nil
+}
+
+// release hands a transient resource's texture back to the pool.
+func (g *Graph) release(r Resource) {
+	res := g.resources[r.id]
+	if res.imported || res.texture == nil {
+		return
+	}
+	g.pool.Release(res.texture)
+	res.texture, res.view = nil, nil
+}
+
+// releaseTransients hands back textures still held after an error.
+func (g *Graph) releaseTransients() {
+	for id := range g.resources {
+		g.release(Resource{id: id})
+	}
+}
+
+// compile returns the indices of the passes to run, in execution order.
+// A pass depends on the passes that write a resource it reads, and on
+// earlier-added passes writing a resource it also writes. Passes not
+// contributing to an imported resource are dropped.
+func (g *Graph) compile() ([]int, error) {
+	for _, p := range g.passes {
+		for _, r := range append(append([]Resource(nil), p.reads...), p.writes...) {
+			if r.id < 0 || r.id >= len(g.resources) {
+				return nil, fmt.Errorf("rendergraph: pass %q uses an undeclared resource", p.name)
+			}
+		}
+	}
+	deps := make([][]int, len(g.passes))
+	for i, p := range g.passes {
+		for j, q := range g.passes {
+			if i == j {
+				continue
+			}
+			if (overlaps(p.reads, q.writes) && !overlaps(p.writes, q.writes)) ||
+				(j < i && overlaps(p.writes, q.writes)) {
+				deps[i] = append(deps[i], j)
+			}
+		}
+	}
+
+	// Keep the passes reachable from the writers of imported resources.
+	live := make([]bool, len(g.passes))
+	var mark func(int)
+	mark = func(i int) {
+		if live[i] {
+			return
+		}
+		live[i] = true
+		for _, j := range deps[i] {
+			mark(j)
+		}
+	}
+	for i, p := range g.passes {
+		for _, r := range p.writes {
+			if g.resources[r.id].imported {
+				mark(i)
+			}
+		}
+	}
+
+	// Depth-first topological sort; ties keep the order passes were added.
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(g.passes))
+	var order []int
+	var visit func(int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("rendergraph: dependency cycle through pass %q", g.passes[i].name)
+		}
+		state[i] = visiting
+		for _, j := range deps[i] {
+			if err := visit(j); err != nil {
+				return err
+			}
+		}
+		state[i] = done
+		order = append(order, i)
+		return nil
+	}
+	for i := range g.passes {
+		if live[i] {
+			if err := visit(i); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return order, nil
+}
+
+// overlaps reports whether a and b share a resource.
+func overlaps(a, b []Resource) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
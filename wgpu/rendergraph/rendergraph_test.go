@@ -0,0 +1,99 @@
+package rendergraph
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-webgpu/webgpu/wgpu"
+)
+
+func noop(*PassContext) error { return nil }
+
+func names(g *Graph, order []int) []string {
+	out := make([]string, len(order))
+	for i, p := range order {
+		out[i] = g.passes[p].name
+	}
+	return out
+}
+
+func TestCompileOrdersAndCulls(t *testing.T) {
+	g := New(nil)
+	out := g.Import("backbuffer", nil)
+	hdr := g.Create("hdr", wgpu.TexturePoolKey{})
+	depth := g.Create("depth", wgpu.TexturePoolKey{})
+	unused := g.Create("debug", wgpu.TexturePoolKey{})
+
+	g.AddPass("tonemap", []Resource{hdr}, []Resource{out}, noop)
+	g.AddPass("debug view", []Resource{depth}, []Resource{unused}, noop)
+	g.AddPass("scene", []Resource{depth}, []Resource{hdr}, noop)
+	g.AddPass("prepass", nil, []Resource{depth}, noop)
+	g.AddPass("overlay", nil, []Resource{out}, noop)
+
+	order, err := g.compile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"prepass", "scene", "tonemap", "overlay"}
+	if got := names(g, order); !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
+
+func TestCompileReadModifyWrite(t *testing.T) {
+	g := New(nil)
+	out := g.Import("backbuffer", nil)
+	g.AddPass("opaque", nil, []Resource{out}, noop)
+	g.AddPass("transparent", []Resource{out}, []Resource{out}, noop)
+
+	order, err := g.compile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := names(g, order); !reflect.DeepEqual(got, []string{"opaque", "transparent"}) {
+		t.Errorf("order = %v", got)
+	}
+}
+
+func TestCompileCycle(t *testing.T) {
+	g := New(nil)
+	a := g.Import("a", nil)
+	b := g.Create("b", wgpu.TexturePoolKey{})
+	g.AddPass("p", []Resource{a}, []Resource{b}, noop)
+	g.AddPass("q", []Resource{b}, []Resource{a}, noop)
+	if _, err := g.compile(); err == nil {
+		t.Error("expected a cycle error")
+	}
+}
+
+func TestCompileUndeclaredResource(t *testing.T) {
+	g := New(nil)
+	out := g.Import("out", nil)
+	g.AddPass("p", []Resource{{id: 7}}, []Resource{out}, noop)
+	if _, err := g.compile(); err == nil {
+		t.Error("expected an error for an undeclared resource")
+	}
+}
+
+func TestExecuteImportedOnly(t *testing.T) {
+	g := New(nil)
+	out := g.Import("backbuffer", nil)
+	var ran []string
+	record := func(name string) PassFunc {
+		return func(ctx *PassContext) error {
+			if ctx.Texture(out) != nil {
+				t.Error("imported view has a texture")
+			}
+			ran = append(ran, name)
+			return nil
+		}
+	}
+	g.AddPass("clear", nil, []Resource{out}, record("clear"))
+	g.AddPass("draw", []Resource{out}, []Resource{out}, record("draw"))
+	if err := g.Execute(nil); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ran, []string{"clear", "draw"}) {
+		t.Errorf("ran = %v", ran)
+	}
+}
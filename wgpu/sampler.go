@@ -1,6 +1,7 @@
 package wgpu
 
 import (
+	"fmt"
 	"unsafe"
 
 	"github.com/gogpu/gputypes"
@@ -48,6 +49,17 @@ type samplerDescriptorWire struct {
 
 // CreateSampler creates a sampler with the specified descriptor.
 func (d *Device) CreateSampler(desc *SamplerDescriptor) (*Sampler, error) {
+	return d.createSampler(desc, false)
+}
+
+// CreateSamplerE is CreateSampler that also returns the validation error
+// raised by wgpu-native.
+func (d *Device) CreateSamplerE(desc *SamplerDescriptor) (*Sampler, error) {
+	return d.createSampler(desc, true)
+}
+
+// createSampler creates a sampler, inside an error scope when capture is set.
+func (d *Device) createSampler(desc *SamplerDescriptor, capture bool) (*Sampler, error) {
 	if err := checkInit(); err != nil {
 		return nil, err
 	}
@@ -58,6 +70,10 @@ func (d *Device) CreateSampler(desc *SamplerDescriptor) (*Sampler, error) {
 		return nil, &WGPUError{Op: "CreateSampler", Message: "descriptor is nil"}
 	}
 
+	if err := desc.validate(); err != nil {
+		return nil, &WGPUError{Op: "CreateSampler", Type: ErrorTypeValidation, Message: err.Error()}
+	}
+
 	// wgpu-native requires Anisotropy >= 1
 	anisotropy := desc.Anisotropy
 	if anisotropy == 0 {
@@ -78,15 +94,94 @@ func (d *Device) CreateSampler(desc *SamplerDescriptor) (*Sampler, error) {
 		MaxAnisotropy: anisotropy,
 	}
 
-	handle, _, _ := procDeviceCreateSampler.Call(
-		d.handle,
-		uintptr(unsafe.Pointer(&wire)),
-	)
+	var handle uintptr
+	create := func() {
+		handle, _, _ = procDeviceCreateSampler.Call(
+			d.handle,
+			uintptr(unsafe.Pointer(&wire)),
+		)
+	}
+	var err error
+	if capture {
+		err = d.captureValidation("CreateSampler", create)
+	} else {
+		create()
+	}
 	if handle == 0 {
 		return nil, &WGPUError{Op: "CreateSampler", Message: "wgpu returned null handle"}
 	}
-	trackResource(handle, "Sampler")
-	return &Sampler{handle: handle}, nil
+	trackResource(handle, "Sampler", desc.Label)
+	s := &Sampler{handle: handle}
+	if err != nil {
+		// wgpu-native returns an invalid sampler rather than null.
+		s.Release()
+		return nil, err
+	}
+	if traceActive.Load() {
+		traceCall("Device.CreateSampler", nil, s, desc)
+	}
+	return s, nil
+}
+
+// validate applies the WebGPU sampler rules that wgpu-native would otherwise
+// report asynchronously through the uncaptured-error callback.
+func (desc *SamplerDescriptor) validate() error {
+	if desc.LodMinClamp < 0 || desc.LodMaxClamp < desc.LodMinClamp {
+		return fmt.Errorf("invalid LOD clamp [%g, %g]", desc.LodMinClamp, desc.LodMaxClamp)
+	}
+	if desc.Anisotropy > 1 && (desc.MagFilter != gputypes.FilterModeLinear ||
+		desc.MinFilter != gputypes.FilterModeLinear ||
+		desc.MipmapFilter != gputypes.MipmapFilterModeLinear) {
+		return fmt.Errorf("anisotropy %d requires linear mag, min and mipmap filters", desc.Anisotropy)
+	}
+	return nil
+}
+
+// DefaultSamplerDescriptor returns the WebGPU default sampler: clamp-to-edge
+// addressing, nearest filtering and a LOD range of [0, 32]. Unlike the zero
+// SamplerDescriptor, it samples every mip level.
+func DefaultSamplerDescriptor() SamplerDescriptor {
+	return SamplerDescriptor{
+		AddressModeU: gputypes.AddressModeClampToEdge,
+		AddressModeV: gputypes.AddressModeClampToEdge,
+		AddressModeW: gputypes.AddressModeClampToEdge,
+		MagFilter:    gputypes.FilterModeNearest,
+		MinFilter:    gputypes.FilterModeNearest,
+		MipmapFilter: gputypes.MipmapFilterModeNearest,
+		LodMaxClamp:  32.0,
+		Anisotropy:   1,
+	}
+}
+
+// CreateAnisotropicSampler creates a repeating, trilinear sampler with the
+// given maximum anisotropy (typically 4, 8 or 16; values above 16 are
+// clamped by the implementation).
+func (d *Device) CreateAnisotropicSampler(maxAnisotropy uint16) (*Sampler, error) {
+	return d.CreateSampler(&SamplerDescriptor{
+		AddressModeU: gputypes.AddressModeRepeat,
+		AddressModeV: gputypes.AddressModeRepeat,
+		AddressModeW: gputypes.AddressModeRepeat,
+		MagFilter:    gputypes.FilterModeLinear,
+		MinFilter:    gputypes.FilterModeLinear,
+		MipmapFilter: gputypes.MipmapFilterModeLinear,
+		LodMaxClamp:  32.0,
+		Anisotropy:   maxAnisotropy,
+	})
+}
+
+// CreateComparisonSampler creates a sampler_comparison with linear filtering,
+// so textureSampleCompare returns a 2x2 percentage-closer filtered result.
+func (d *Device) CreateComparisonSampler(compare gputypes.CompareFunction) (*Sampler, error) {
+	return d.CreateSampler(&SamplerDescriptor{
+		AddressModeU: gputypes.AddressModeClampToEdge,
+		AddressModeV: gputypes.AddressModeClampToEdge,
+		AddressModeW: gputypes.AddressModeClampToEdge,
+		MagFilter:    gputypes.FilterModeLinear,
+		MinFilter:    gputypes.FilterModeLinear,
+		MipmapFilter: gputypes.MipmapFilterModeNearest,
+		LodMaxClamp:  32.0,
+		Compare:      compare,
+	})
 }
 
 // CreateLinearSampler creates a sampler with linear filtering.
@@ -123,6 +218,7 @@ func (s *Sampler) Release() {
 		untrackResource(s.handle)
 		procSamplerRelease.Call(s.handle) //nolint:errcheck
 		s.handle = 0
+		s.released.set()
 	}
 }
 
@@ -0,0 +1,66 @@
+package wgpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestSamplerDescriptorValidate(t *testing.T) {
+	aniso := DefaultSamplerDescriptor()
+	aniso.MagFilter = gputypes.FilterModeLinear
+	aniso.MinFilter = gputypes.FilterModeLinear
+	aniso.MipmapFilter = gputypes.MipmapFilterModeLinear
+	aniso.Anisotropy = 16
+
+	anisoNearest := DefaultSamplerDescriptor()
+	anisoNearest.Anisotropy = 8
+
+	badLod := DefaultSamplerDescriptor()
+	badLod.LodMinClamp = 4
+	badLod.LodMaxClamp = 2
+
+	negLod := DefaultSamplerDescriptor()
+	negLod.LodMinClamp = -1
+
+	tests := []struct {
+		name    string
+		desc    SamplerDescriptor
+		wantErr bool
+	}{
+		{"zero value", SamplerDescriptor{}, false},
+		{"default", DefaultSamplerDescriptor(), false},
+		{"anisotropic linear", aniso, false},
+		{"anisotropic nearest", anisoNearest, true},
+		{"lod min above max", badLod, true},
+		{"negative lod min", negLod, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.desc.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestABISamplerEnumValues(t *testing.T) {
+	// Sampler enums are passed straight through; make sure the values agree
+	// with webgpu.h v29.
+	tests := []struct {
+		name     string
+		got      uint32
+		expected uint32
+	}{
+		{"AddressModeClampToEdge", uint32(gputypes.AddressModeClampToEdge), 0x00000001},
+		{"AddressModeRepeat", uint32(gputypes.AddressModeRepeat), 0x00000002},
+		{"AddressModeMirrorRepeat", uint32(gputypes.AddressModeMirrorRepeat), 0x00000003},
+		{"FilterModeLinear", uint32(gputypes.FilterModeLinear), 0x00000002},
+		{"MipmapFilterModeLinear", uint32(gputypes.MipmapFilterModeLinear), 0x00000002},
+		{"CompareFunctionLess", uint32(gputypes.CompareFunctionLess), 0x00000002},
+		{"CompareFunctionLessEqual", uint32(gputypes.CompareFunctionLessEqual), 0x00000004},
+		{"CompareFunctionAlways", uint32(gputypes.CompareFunctionAlways), 0x00000008},
+	}
+	runEnumTests(t, tests)
+}
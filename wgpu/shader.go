@@ -1,6 +1,7 @@
 package wgpu
 
 import (
+	"strings"
 	"unsafe"
 )
 
@@ -17,8 +18,26 @@ type ShaderSourceWGSL struct {
 }
 
 // CreateShaderModuleWGSL creates a shader module from WGSL source code.
-// Returns an error if the FFI call fails or the device is nil.
+// Returns an error if the FFI call fails or the device is nil. Compile
+// errors go to the device's error scopes like other validation errors; use
+// [Device.CreateShaderModuleWGSLE] to have them returned. Sources with
+// enable directives such as "enable f16;" need the matching feature on the
+// device; see [WGSLRequiredFeatures].
 func (d *Device) CreateShaderModuleWGSL(code string) (*ShaderModule, error) {
+	return d.createShaderModuleWGSL("", code, false)
+}
+
+// CreateShaderModuleWGSLE is CreateShaderModuleWGSL that also fetches the
+// compilation info: if the source does not compile, the error is a
+// *[ShaderCompilationError] carrying the compiler's messages with their line
+// and column. See [ShaderModule.GetCompilationInfo] for its cost.
+func (d *Device) CreateShaderModuleWGSLE(code string) (*ShaderModule, error) {
+	return d.createShaderModuleWGSL("", code, true)
+}
+
+// createShaderModuleWGSL is CreateShaderModuleWGSL with a label, checking
+// the compilation info when check is set.
+func (d *Device) createShaderModuleWGSL(label, code string, check bool) (*ShaderModule, error) {
 	if err := checkInit(); err != nil {
 		return nil, err
 	}
@@ -28,6 +47,9 @@ func (d *Device) CreateShaderModuleWGSL(code string) (*ShaderModule, error) {
 	if code == "" {
 		return nil, &WGPUError{Op: "CreateShaderModuleWGSL", Message: "shader source is empty"}
 	}
+	if err := checkShaderFeatures(code, d.HasFeature); err != nil {
+		return nil, &WGPUError{Op: "CreateShaderModuleWGSL", Type: ErrorTypeValidation, Message: err.Error()}
+	}
 
 	// Create WGSL source with embedded string data
 	codeBytes := []byte(code)
@@ -45,7 +67,7 @@ func (d *Device) CreateShaderModuleWGSL(code string) (*ShaderModule, error) {
 
 	desc := ShaderModuleDescriptor{
 		NextInChain: uintptr(unsafe.Pointer(&wgslSource)),
-		Label:       EmptyStringView(),
+		Label:       stringToStringView(label),
 	}
 
 	handle, _, _ := procDeviceCreateShaderModule.Call(
@@ -55,8 +77,16 @@ func (d *Device) CreateShaderModuleWGSL(code string) (*ShaderModule, error) {
 	if handle == 0 {
 		return nil, &WGPUError{Op: "CreateShaderModuleWGSL", Message: "wgpu returned null handle"}
 	}
-	trackResource(handle, "ShaderModule")
-	return &ShaderModule{handle: handle}, nil
+	trackResource(handle, "ShaderModule", label)
+	module := &ShaderModule{handle: handle, device: d, source: code}
+	var err error
+	if check {
+		module, err = checkCompilation("CreateShaderModuleWGSL", module)
+	}
+	if err == nil && traceActive.Load() {
+		traceCall("Device.CreateShaderModuleWGSL", nil, module, code)
+	}
+	return module, err
 }
 
 // CreateShaderModule creates a shader module from a descriptor.
@@ -79,8 +109,8 @@ func (d *Device) CreateShaderModule(desc *ShaderModuleDescriptor) (*ShaderModule
 	if handle == 0 {
 		return nil, &WGPUError{Op: "CreateShaderModule", Message: "wgpu returned null handle"}
 	}
-	trackResource(handle, "ShaderModule")
-	return &ShaderModule{handle: handle}, nil
+	trackResource(handle, "ShaderModule", strings.Clone(stringViewToString(desc.Label)))
+	return &ShaderModule{handle: handle, device: d}, nil
 }
 
 // CreateShaderModuleFromDescriptor creates a shader module from a Go-idiomatic ShaderDescriptor.
@@ -108,7 +138,7 @@ func (d *Device) createShaderModuleFromDesc(desc *ShaderDescriptor) (*ShaderModu
 		return nil, &WGPUError{Op: "CreateShaderModule", Message: "descriptor is nil"}
 	}
 	if desc.WGSL != "" {
-		return d.CreateShaderModuleWGSL(desc.WGSL)
+		return d.createShaderModuleWGSL(desc.Label, desc.WGSL, false)
 	}
 	if len(desc.SPIRV) > 0 {
 		return d.CreateShaderModuleSPIRV(desc.Label, desc.SPIRV)
@@ -119,6 +149,18 @@ func (d *Device) createShaderModuleFromDesc(desc *ShaderDescriptor) (*ShaderModu
 // CreateShaderModuleSPIRV creates a shader module from SPIR-V bytecode.
 // Returns an error if the FFI call fails, the device is nil, or the bytecode is empty.
 func (d *Device) CreateShaderModuleSPIRV(label string, spirv []uint32) (*ShaderModule, error) {
+	return d.createShaderModuleSPIRV(label, spirv, false)
+}
+
+// CreateShaderModuleSPIRVE is CreateShaderModuleSPIRV that also returns the
+// validation error raised by wgpu-native for bytecode it rejects.
+func (d *Device) CreateShaderModuleSPIRVE(label string, spirv []uint32) (*ShaderModule, error) {
+	return d.createShaderModuleSPIRV(label, spirv, true)
+}
+
+// createShaderModuleSPIRV creates a SPIR-V module, inside an error scope when
+// capture is set.
+func (d *Device) createShaderModuleSPIRV(label string, spirv []uint32, capture bool) (*ShaderModule, error) {
 	if err := checkInit(); err != nil {
 		return nil, err
 	}
@@ -148,15 +190,33 @@ func (d *Device) CreateShaderModuleSPIRV(label string, spirv []uint32) (*ShaderM
 		Label:       stringToStringView(label),
 	}
 
-	handle, _, _ := procDeviceCreateShaderModule.Call(
-		d.handle,
-		uintptr(unsafe.Pointer(&desc)),
-	)
+	var handle uintptr
+	create := func() {
+		handle, _, _ = procDeviceCreateShaderModule.Call(
+			d.handle,
+			uintptr(unsafe.Pointer(&desc)),
+		)
+	}
+	var err error
+	if capture {
+		err = d.captureValidation("CreateShaderModuleSPIRV", create)
+	} else {
+		create()
+	}
 	if handle == 0 {
 		return nil, &WGPUError{Op: "CreateShaderModuleSPIRV", Message: "wgpu returned null handle"}
 	}
-	trackResource(handle, "ShaderModule")
-	return &ShaderModule{handle: handle}, nil
+	trackResource(handle, "ShaderModule", label)
+	module := &ShaderModule{handle: handle, device: d}
+	if err != nil {
+		// Invalid SPIR-V yields an invalid module rather than null.
+		module.Release()
+		return nil, err
+	}
+	if traceActive.Load() {
+		traceCall("Device.CreateShaderModuleSPIRV", nil, module, label, spirv)
+	}
+	return module, nil
 }
 
 // Release releases the shader module resources.
@@ -0,0 +1,264 @@
+package wgpu
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/go-webgpu/goffi/ffi"
+)
+
+// CompilationMessageType is the severity of a shader compilation message.
+type CompilationMessageType uint32
+
+const (
+	// CompilationMessageTypeError is a compilation error; the module is unusable.
+	CompilationMessageTypeError CompilationMessageType = 0x00000001
+	// CompilationMessageTypeWarning is a warning.
+	CompilationMessageTypeWarning CompilationMessageType = 0x00000002
+	// CompilationMessageTypeInfo is an informational message.
+	CompilationMessageTypeInfo CompilationMessageType = 0x00000003
+)
+
+// String returns the lower-case severity name used in compiler output.
+func (t CompilationMessageType) String() string {
+	switch t {
+	case CompilationMessageTypeError:
+		return "error"
+	case CompilationMessageTypeWarning:
+		return "warning"
+	case CompilationMessageTypeInfo:
+		return "info"
+	}
+	return fmt.Sprintf("CompilationMessageType(%d)", uint32(t))
+}
+
+// CompilationInfoRequestStatus is the status passed to the compilation info callback.
+type CompilationInfoRequestStatus uint32
+
+const (
+	// CompilationInfoRequestStatusSuccess indicates the messages are available.
+	CompilationInfoRequestStatusSuccess CompilationInfoRequestStatus = 0x00000001
+	// CompilationInfoRequestStatusCallbackCancelled indicates the request was cancelled.
+	CompilationInfoRequestStatusCallbackCancelled CompilationInfoRequestStatus = 0x00000002
+)
+
+// CompilationMessage is one diagnostic produced while compiling a shader.
+// LineNum and LinePos are 1-based and zero when the message has no source
+// location; Offset and Length locate the span in bytes.
+type CompilationMessage struct {
+	Message string
+	Type    CompilationMessageType
+	LineNum uint64
+	LinePos uint64
+	Offset  uint64
+	Length  uint64
+}
+
+// String formats the message as "line:col: severity: text".
+func (m CompilationMessage) String() string {
+	if m.LineNum == 0 {
+		return fmt.Sprintf("%s: %s", m.Type, m.Message)
+	}
+	return fmt.Sprintf("%d:%d: %s: %s", m.LineNum, m.LinePos, m.Type, m.Message)
+}
+
+// ShaderCompilationError is returned by [Device.CreateShaderModuleWGSLE] and
+// [Device.CreateShaderModuleGLSLE] when the source fails to compile. Messages holds every diagnostic, including
+// warnings reported alongside the errors.
+type ShaderCompilationError struct {
+	Op       string
+	Messages []CompilationMessage
+}
+
+// Error lists the error messages, one per line.
+func (e *ShaderCompilationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "wgpu: %s: shader compilation failed", e.Op)
+	for _, m := range e.Messages {
+		if m.Type == CompilationMessageTypeError {
+			b.WriteString("\n\t")
+			b.WriteString(m.String())
+		}
+	}
+	return b.String()
+}
+
+// compilationMessageWire matches WGPUCompilationMessage.
+// nextInChain(8)+message(16)+type(4)+pad(4)+lineNum(8)+linePos(8)+offset(8)+length(8) = 64 bytes.
+type compilationMessageWire struct {
+	NextInChain uintptr
+	Message     StringView
+	Type        uint32
+	_pad        [4]byte
+	LineNum     uint64
+	LinePos     uint64
+	Offset      uint64
+	Length      uint64
+}
+
+// compilationInfoWire matches WGPUCompilationInfo.
+type compilationInfoWire struct {
+	NextInChain  uintptr
+	MessageCount uintptr // size_t
+	Messages     uintptr // *compilationMessageWire
+}
+
+// compilationInfoCallbackInfo matches WGPUCompilationInfoCallbackInfo.
+type compilationInfoCallbackInfo struct {
+	nextInChain uintptr
+	mode        CallbackMode
+	callback    uintptr
+	userdata1   uintptr
+	userdata2   uintptr
+}
+
+// compilationInfoRequest holds the result of a GetCompilationInfo call.
+type compilationInfoRequest struct {
+	done     chan struct{}
+	status   CompilationInfoRequestStatus
+	messages []CompilationMessage
+}
+
+// compilationInfoTimeout bounds how long GetCompilationInfo waits for
+// wgpu-native to deliver the diagnostics.
+var compilationInfoTimeout = 5 * time.Second
+
+var (
+	// compilationInfoRequests is the registry of pending requests, keyed by userdata1.
+	compilationInfoRequests   = make(map[uintptr]*compilationInfoRequest)
+	compilationInfoRequestsMu sync.Mutex
+	compilationInfoRequestID  uintptr
+
+	compilationInfoCallbackPtr  uintptr
+	compilationInfoCallbackOnce sync.Once
+)
+
+// compilationInfoCallbackEntry is the C callback. Its arguments are all
+// pointer-sized on every supported ABI, so unlike the callbacks that receive
+// a WGPUStringView it needs no per-platform entry.
+func compilationInfoCallbackEntry(status, info, userdata1, _ uintptr) uintptr {
+	compilationInfoRequestsMu.Lock()
+	req, ok := compilationInfoRequests[userdata1]
+	if ok {
+		delete(compilationInfoRequests, userdata1)
+	}
+	compilationInfoRequestsMu.Unlock()
+
+	if ok && req != nil {
+		req.status = CompilationInfoRequestStatus(status)
+		if info != 0 {
+			// The messages are only valid during the callback; copy them out.
+			req.messages = readCompilationInfo((*compilationInfoWire)(ptrFromUintptr(info)))
+		}
+		close(req.done)
+	}
+	return 0
+}
+
+// readCompilationInfo converts a C WGPUCompilationInfo into Go messages.
+func readCompilationInfo(info *compilationInfoWire) []CompilationMessage {
+	if info.MessageCount == 0 || info.Messages == 0 {
+		return nil
+	}
+	wire := unsafe.Slice((*compilationMessageWire)(ptrFromUintptr(info.Messages)), info.MessageCount)
+	messages := make([]CompilationMessage, len(wire))
+	for i := range wire {
+		messages[i] = CompilationMessage{
+			Message: stringViewToString(wire[i].Message),
+			Type:    CompilationMessageType(wire[i].Type),
+			LineNum: wire[i].LineNum,
+			LinePos: wire[i].LinePos,
+			Offset:  wire[i].Offset,
+			Length:  wire[i].Length,
+		}
+	}
+	return messages
+}
+
+// GetCompilationInfo returns the diagnostics produced when the module was
+// compiled. It blocks, polling the device, until wgpu-native delivers them,
+// and returns an error if they do not arrive within five seconds or the
+// loaded library does not export wgpuShaderModuleGetCompilationInfo. Some
+// wgpu-native releases leave the call unimplemented and abort the process,
+// which is why the shader module constructors only fetch the diagnostics in
+// their E variants.
+func (s *ShaderModule) GetCompilationInfo() ([]CompilationMessage, error) {
+	if err := checkInit(); err != nil {
+		return nil, err
+	}
+	if s == nil || s.handle == 0 {
+		return nil, &WGPUError{Op: "ShaderModule.GetCompilationInfo", Message: "shader module is nil or released"}
+	}
+	compilationInfoCallbackOnce.Do(func() {
+		compilationInfoCallbackPtr = ffi.NewCallback(compilationInfoCallbackEntry)
+	})
+
+	req := &compilationInfoRequest{done: make(chan struct{})}
+	compilationInfoRequestsMu.Lock()
+	compilationInfoRequestID++
+	id := compilationInfoRequestID
+	compilationInfoRequests[id] = req
+	compilationInfoRequestsMu.Unlock()
+
+	callbackInfo := compilationInfoCallbackInfo{
+		mode:      CallbackModeAllowSpontaneous,
+		callback:  compilationInfoCallbackPtr,
+		userdata1: id,
+	}
+	forget := func() {
+		compilationInfoRequestsMu.Lock()
+		delete(compilationInfoRequests, id)
+		compilationInfoRequestsMu.Unlock()
+	}
+	if _, _, err := procShaderModuleGetCompilationInfo.Call(
+		s.handle,
+		uintptr(unsafe.Pointer(&callbackInfo)),
+	); err != nil {
+		forget()
+		return nil, &WGPUError{Op: "ShaderModule.GetCompilationInfo", Message: err.Error()}
+	}
+
+	deadline := time.Now().Add(compilationInfoTimeout)
+	for {
+		select {
+		case <-req.done:
+			if req.status != CompilationInfoRequestStatusSuccess {
+				return nil, &WGPUError{Op: "ShaderModule.GetCompilationInfo",
+					Message: fmt.Sprintf("request failed with status %d", req.status)}
+			}
+			return req.messages, nil
+		default:
+			if time.Now().After(deadline) {
+				// A late callback finds no request and is ignored.
+				forget()
+				return nil, &WGPUError{Op: "ShaderModule.GetCompilationInfo",
+					Message: fmt.Sprintf("no compilation info after %v", compilationInfoTimeout)}
+			}
+			if s.device != nil {
+				s.device.Poll(false)
+			}
+			runtime.Gosched()
+		}
+	}
+}
+
+// checkCompilation releases module and returns a *ShaderCompilationError if
+// its compilation info contains errors. It backs the E shader constructors.
+// Failing to fetch the info is not treated as a compilation failure.
+func checkCompilation(op string, module *ShaderModule) (*ShaderModule, error) {
+	messages, err := module.GetCompilationInfo()
+	if err != nil {
+		return module, nil //nolint:nilerr // diagnostics are best effort
+	}
+	for _, m := range messages {
+		if m.Type == CompilationMessageTypeError {
+			module.Release()
+			return nil, &ShaderCompilationError{Op: op, Messages: messages}
+		}
+	}
+	return module, nil
+}
@@ -0,0 +1,75 @@
+package wgpu
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestCompilationInfoCallbackCopiesMessages(t *testing.T) {
+	text := []byte("unknown identifier `colr`")
+	messages := []compilationMessageWire{
+		{
+			Message: StringView{Data: uintptr(unsafe.Pointer(&text[0])), Length: uintptr(len(text))},
+			Type:    uint32(CompilationMessageTypeError),
+			LineNum: 12,
+			LinePos: 5,
+			Offset:  240,
+			Length:  4,
+		},
+		{Type: uint32(CompilationMessageTypeWarning)},
+	}
+	info := compilationInfoWire{
+		MessageCount: uintptr(len(messages)),
+		Messages:     uintptr(unsafe.Pointer(&messages[0])),
+	}
+
+	const id = uintptr(501)
+	req := &compilationInfoRequest{done: make(chan struct{})}
+	compilationInfoRequestsMu.Lock()
+	compilationInfoRequests[id] = req
+	compilationInfoRequestsMu.Unlock()
+
+	compilationInfoCallbackEntry(uintptr(CompilationInfoRequestStatusSuccess), uintptr(unsafe.Pointer(&info)), id, 0)
+
+	select {
+	case <-req.done:
+	default:
+		t.Fatal("callback did not complete the request")
+	}
+	if req.status != CompilationInfoRequestStatusSuccess || len(req.messages) != 2 {
+		t.Fatalf("status = %d, %d messages", req.status, len(req.messages))
+	}
+	want := CompilationMessage{Message: string(text), Type: CompilationMessageTypeError, LineNum: 12, LinePos: 5, Offset: 240, Length: 4}
+	if req.messages[0] != want {
+		t.Errorf("message = %+v, want %+v", req.messages[0], want)
+	}
+	if req.messages[1].Type != CompilationMessageTypeWarning {
+		t.Errorf("second message type = %s, want warning", req.messages[1].Type)
+	}
+}
+
+func TestShaderCompilationError(t *testing.T) {
+	var err error = &ShaderCompilationError{
+		Op: "CreateShaderModuleWGSL",
+		Messages: []CompilationMessage{
+			{Message: "unused variable", Type: CompilationMessageTypeWarning, LineNum: 3, LinePos: 9},
+			{Message: "expected ';'", Type: CompilationMessageTypeError, LineNum: 7, LinePos: 14},
+		},
+	}
+	got := err.Error()
+	if !strings.Contains(got, "7:14: error: expected ';'") {
+		t.Errorf("Error() = %q, want the error location and text", got)
+	}
+	if strings.Contains(got, "unused variable") {
+		t.Errorf("Error() = %q, should list errors only", got)
+	}
+	var compileErr *ShaderCompilationError
+	if !errors.As(err, &compileErr) || len(compileErr.Messages) != 2 {
+		t.Errorf("errors.As did not expose all messages")
+	}
+	if s := (CompilationMessage{Message: "internal", Type: CompilationMessageTypeInfo}).String(); s != "info: internal" {
+		t.Errorf("String() without location = %q", s)
+	}
+}
@@ -0,0 +1,58 @@
+package wgpu
+
+import "fmt"
+
+// wgslExtensions maps WGSL enable-extensions to the features that allow them.
+var wgslExtensions = map[string]struct {
+	feature FeatureName
+	name    string
+}{
+	"f16":                  {FeatureNameShaderF16, "FeatureNameShaderF16"},
+	"subgroups":            {FeatureNameSubgroups, "FeatureNameSubgroups"},
+	"clip_distances":       {FeatureNameClipDistances, "FeatureNameClipDistances"},
+	"dual_source_blending": {FeatureNameDualSourceBlending, "FeatureNameDualSourceBlending"},
+	"primitive_index":      {FeatureNamePrimitiveIndex, "FeatureNamePrimitiveIndex"},
+}
+
+// WGSLRequiredFeatures returns the device features that the enable
+// directives of a WGSL module depend on, such as FeatureNameShaderF16 for
+// "enable f16;", in the order they are enabled. Unknown extensions are
+// ignored.
+func WGSLRequiredFeatures(source string) []FeatureName {
+	var features []FeatureName
+	for _, ext := range wgslEnables(source) {
+		if e, ok := wgslExtensions[ext]; ok {
+			features = append(features, e.feature)
+		}
+	}
+	return features
+}
+
+// wgslEnables returns the extension names listed by enable directives.
+func wgslEnables(source string) []string {
+	toks := wgslTokenize(source)
+	var exts []string
+	for i := 0; i < len(toks); i++ {
+		if toks[i] != "enable" {
+			continue
+		}
+		for i++; i < len(toks) && toks[i] != ";"; i++ {
+			if toks[i] != "," {
+				exts = append(exts, toks[i])
+			}
+		}
+	}
+	return exts
+}
+
+// checkShaderFeatures reports the first extension enabled by source whose
+// feature is not enabled on the device. naga's own error for this case does
+// not name the feature to request.
+func checkShaderFeatures(source string, hasFeature func(FeatureName) bool) error {
+	for _, ext := range wgslEnables(source) {
+		if e, ok := wgslExtensions[ext]; ok && !hasFeature(e.feature) {
+			return fmt.Errorf("shader enables %s, which requires %s in DeviceDescriptor.RequiredFeatures", ext, e.name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package wgpu
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWGSLRequiredFeatures(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   []FeatureName
+	}{
+		{"none", "@compute @workgroup_size(1) fn main() {}", nil},
+		{"f16", "enable f16;\nfn f() -> f16 { return 1.0h; }", []FeatureName{FeatureNameShaderF16}},
+		{"list", "enable subgroups, f16;", []FeatureName{FeatureNameSubgroups, FeatureNameShaderF16}},
+		{"separate directives", "enable f16;\nenable clip_distances;",
+			[]FeatureName{FeatureNameShaderF16, FeatureNameClipDistances}},
+		{"commented out", "// enable f16;\n/* enable subgroups; */", nil},
+		{"unknown extension", "enable chromium_experimental_foo;", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WGSLRequiredFeatures(tt.source); !slices.Equal(got, tt.want) {
+				t.Errorf("WGSLRequiredFeatures = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckShaderFeatures(t *testing.T) {
+	const src = "enable f16, subgroups;"
+	onlyF16 := func(f FeatureName) bool { return f == FeatureNameShaderF16 }
+	both := func(f FeatureName) bool { return f == FeatureNameShaderF16 || f == FeatureNameSubgroups }
+
+	if err := checkShaderFeatures(src, onlyF16); err == nil {
+		t.Error("expected an error when subgroups is not enabled")
+	}
+	if err := checkShaderFeatures(src, both); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
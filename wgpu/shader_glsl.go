@@ -0,0 +1,161 @@
+package wgpu
+
+import (
+	"runtime"
+	"unsafe"
+
+	"github.com/gogpu/gputypes"
+)
+
+// ShaderDefine is a preprocessor macro passed to the GLSL front end, as if
+// the source began with "#define Name Value".
+type ShaderDefine struct {
+	Name  string
+	Value string
+}
+
+// GLSLShaderDescriptor describes a shader module compiled from GLSL.
+//
+// GLSL is a wgpu-native extension: one module holds a single stage whose
+// entry point is "main". Use [Device.SupportsGLSL] to check that the loaded
+// library was built with the GLSL front end.
+type GLSLShaderDescriptor struct {
+	Label string
+	// Stage is exactly one of ShaderStageVertex, ShaderStageFragment or
+	// ShaderStageCompute.
+	Stage   gputypes.ShaderStage
+	Code    string
+	Defines []ShaderDefine
+}
+
+// shaderDefineWire matches WGPUShaderDefine in wgpu.h.
+// name(16)+value(16) = 32 bytes.
+type shaderDefineWire struct {
+	Name  StringView
+	Value StringView
+}
+
+// shaderSourceGLSLWire matches WGPUShaderSourceGLSL in wgpu.h.
+// chain(16)+stage(8)+code(16)+defineCount(4)+pad(4)+defines(8) = 56 bytes.
+type shaderSourceGLSLWire struct {
+	Chain       ChainedStruct
+	Stage       uint64 // WGPUShaderStage = WGPUFlags = uint64
+	Code        StringView
+	DefineCount uint32
+	_pad        [4]byte
+	Defines     uintptr // *shaderDefineWire
+}
+
+// CreateShaderModuleGLSL creates a shader module from GLSL source through
+// the wgpu-native GLSL front end.
+// Returns an error if the device is nil, the stage is not a single stage,
+// the source is empty, or the FFI call fails. Compile errors go to the
+// device's error scopes; [Device.CreateShaderModuleGLSLE] returns them.
+func (d *Device) CreateShaderModuleGLSL(desc *GLSLShaderDescriptor) (*ShaderModule, error) {
+	return d.createShaderModuleGLSL(desc, false)
+}
+
+// CreateShaderModuleGLSLE is CreateShaderModuleGLSL that reports compile
+// errors as a *[ShaderCompilationError], at the cost of fetching the
+// compilation info.
+func (d *Device) CreateShaderModuleGLSLE(desc *GLSLShaderDescriptor) (*ShaderModule, error) {
+	return d.createShaderModuleGLSL(desc, true)
+}
+
+// createShaderModuleGLSL creates a GLSL module, checking the compilation
+// info when check is set.
+func (d *Device) createShaderModuleGLSL(desc *GLSLShaderDescriptor, check bool) (*ShaderModule, error) {
+	if err := checkInit(); err != nil {
+		return nil, err
+	}
+	if d == nil || d.handle == 0 {
+		return nil, &WGPUError{Op: "CreateShaderModuleGLSL", Message: "device is nil or released"}
+	}
+	if desc == nil {
+		return nil, &WGPUError{Op: "CreateShaderModuleGLSL", Message: "descriptor is nil"}
+	}
+	switch desc.Stage {
+	case gputypes.ShaderStageVertex, gputypes.ShaderStageFragment, gputypes.ShaderStageCompute:
+	default:
+		return nil, &WGPUError{Op: "CreateShaderModuleGLSL", Type: ErrorTypeValidation,
+			Message: "stage must be exactly one of vertex, fragment or compute"}
+	}
+	if desc.Code == "" {
+		return nil, &WGPUError{Op: "CreateShaderModuleGLSL", Message: "shader source is empty"}
+	}
+
+	// Keep the backing bytes of every StringView reachable until the call returns.
+	var strs [][]byte
+	view := func(s string) StringView {
+		if s == "" {
+			return EmptyStringView()
+		}
+		b := []byte(s)
+		strs = append(strs, b)
+		return StringView{Data: uintptr(unsafe.Pointer(&b[0])), Length: uintptr(len(b))}
+	}
+
+	source := shaderSourceGLSLWire{
+		Chain: ChainedStruct{SType: uint32(STypeShaderSourceGLSL)},
+		Stage: uint64(desc.Stage),
+		Code:  view(desc.Code),
+	}
+	var defines []shaderDefineWire
+	if len(desc.Defines) > 0 {
+		defines = make([]shaderDefineWire, len(desc.Defines))
+		for i, def := range desc.Defines {
+			defines[i] = shaderDefineWire{Name: view(def.Name), Value: view(def.Value)}
+		}
+		source.DefineCount = uint32(len(defines))
+		source.Defines = uintptr(unsafe.Pointer(&defines[0]))
+	}
+
+	wire := ShaderModuleDescriptor{
+		NextInChain: uintptr(unsafe.Pointer(&source)),
+		Label:       view(desc.Label),
+	}
+	handle, _, _ := procDeviceCreateShaderModule.Call(
+		d.handle,
+		uintptr(unsafe.Pointer(&wire)),
+	)
+	runtime.KeepAlive(strs)
+	runtime.KeepAlive(defines)
+	runtime.KeepAlive(&source)
+	if handle == 0 {
+		return nil, &WGPUError{Op: "CreateShaderModuleGLSL", Message: "wgpu returned null handle"}
+	}
+	trackResource(handle, "ShaderModule", desc.Label)
+	module := &ShaderModule{handle: handle, device: d}
+	var err error
+	if check {
+		module, err = checkCompilation("CreateShaderModuleGLSL", module)
+	}
+	if err == nil && traceActive.Load() {
+		traceCall("Device.CreateShaderModuleGLSL", nil, module, desc)
+	}
+	return module, err
+}
+
+// glslProbeShader is the smallest GLSL module the front end accepts.
+const glslProbeShader = "#version 450\nlayout(local_size_x = 1) in;\nvoid main() {}\n"
+
+// SupportsGLSL reports whether the loaded wgpu-native library can compile
+// GLSL. The front end is a build-time option of wgpu-native and there is no
+// query for it, so SupportsGLSL compiles a trivial compute shader inside a
+// validation error scope; instance is needed to pop that scope.
+func (d *Device) SupportsGLSL(instance *Instance) bool {
+	if checkInit() != nil || d == nil || d.handle == 0 || instance == nil {
+		return false
+	}
+	d.PushErrorScope(ErrorFilterValidation)
+	module, err := d.CreateShaderModuleGLSL(&GLSLShaderDescriptor{
+		Label: "glsl probe",
+		Stage: gputypes.ShaderStageCompute,
+		Code:  glslProbeShader,
+	})
+	errType, _, popErr := d.PopErrorScopeAsync(instance)
+	if module != nil {
+		module.Release()
+	}
+	return err == nil && popErr == nil && errType == ErrorTypeNoError
+}
@@ -0,0 +1,182 @@
+package wgpu
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"slices"
+	"sync"
+)
+
+// ShaderLibrary loads WGSL shader modules by name from an [fs.FS] and shares
+// one module between files whose source, after preprocessing, is identical.
+// It records the entry points of every module it loads, and [ShaderLibrary.Reload]
+// recreates a module when its file changes, which is what a hot reload
+// watcher needs.
+//
+//	lib := wgpu.NewShaderLibrary(device, os.DirFS("shaders"))
+//	defer lib.Release()
+//	module, err := lib.Load("sprite.wgsl")
+//
+// Modules returned by the library are owned by it: do not release them.
+// A ShaderLibrary is safe for concurrent use.
+type ShaderLibrary struct {
+	fsys   fs.FS
+	create func(source string) (*ShaderModule, error)
+
+	mu         sync.Mutex
+	preprocess func(name, source string) (string, error)
+	byName     map[string]*libraryModule
+	byHash     map[[sha256.Size]byte]*libraryModule
+}
+
+// libraryModule is a module shared by every name whose source hashes to hash.
+type libraryModule struct {
+	hash        [sha256.Size]byte
+	module      *ShaderModule
+	entryPoints []ShaderEntryPoint
+	refs        int
+}
+
+// NewShaderLibrary returns an empty library that reads files from fsys and
+// creates modules on device.
+func NewShaderLibrary(device *Device, fsys fs.FS) *ShaderLibrary {
+	return &ShaderLibrary{
+		fsys:   fsys,
+		create: device.CreateShaderModuleWGSL,
+		byName: make(map[string]*libraryModule),
+		byHash: make(map[[sha256.Size]byte]*libraryModule),
+	}
+}
+
+// SetPreprocessor sets a function applied to each file's source before it is
+// hashed and compiled, for example to resolve includes or defines. It applies
+// to files loaded or reloaded afterwards.
+func (l *ShaderLibrary) SetPreprocessor(preprocess func(name, source string) (string, error)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.preprocess = preprocess
+}
+
+// Load returns the module for the named file, reading and compiling it on
+// first use. Files with the same processed source share one module.
+func (l *ShaderLibrary) Load(name string) (*ShaderModule, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if m, ok := l.byName[name]; ok {
+		return m.module, nil
+	}
+	m, err := l.load(name)
+	if err != nil {
+		return nil, err
+	}
+	l.byName[name] = m
+	return m.module, nil
+}
+
+// load reads, preprocesses and hashes name, and returns the shared module for
+// its source with an extra reference, creating it if needed.
+func (l *ShaderLibrary) load(name string) (*libraryModule, error) {
+	data, err := fs.ReadFile(l.fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: ShaderLibrary: %w", err)
+	}
+	source := string(data)
+	if l.preprocess != nil {
+		if source, err = l.preprocess(name, source); err != nil {
+			return nil, fmt.Errorf("wgpu: ShaderLibrary: preprocess %s: %w", name, err)
+		}
+	}
+
+	hash := sha256.Sum256([]byte(source))
+	if m, ok := l.byHash[hash]; ok {
+		m.refs++
+		return m, nil
+	}
+	module, err := l.create(source)
+	if err != nil {
+		return nil, fmt.Errorf("wgpu: ShaderLibrary: %s: %w", name, err)
+	}
+	// Reflection covers a subset of WGSL; modules it cannot read simply
+	// report no entry points.
+	entryPoints, _ := ReflectWGSLEntryPoints(source)
+	m := &libraryModule{hash: hash, module: module, entryPoints: entryPoints, refs: 1}
+	l.byHash[hash] = m
+	return m, nil
+}
+
+// unref drops a reference to m, releasing its module with the last one.
+func (l *ShaderLibrary) unref(m *libraryModule) {
+	m.refs--
+	if m.refs == 0 {
+		delete(l.byHash, m.hash)
+		m.module.Release()
+	}
+}
+
+// Module returns the module loaded for name, or nil if it is not loaded.
+func (l *ShaderLibrary) Module(name string) *ShaderModule {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if m, ok := l.byName[name]; ok {
+		return m.module
+	}
+	return nil
+}
+
+// EntryPoints returns the entry points found in the module loaded for name,
+// or nil if it is not loaded.
+func (l *ShaderLibrary) EntryPoints(name string) []ShaderEntryPoint {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if m, ok := l.byName[name]; ok {
+		return slices.Clone(m.entryPoints)
+	}
+	return nil
+}
+
+// Names returns the names of the loaded files in sorted order.
+func (l *ShaderLibrary) Names() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	names := make([]string, 0, len(l.byName))
+	for name := range l.byName {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Reload reads name again and, if its processed source changed, replaces its
+// module and reports true. Pipelines created from the old module keep
+// working but must be recreated to use the new one. On error the previous
+// module stays in place.
+func (l *ShaderLibrary) Reload(name string) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	old, ok := l.byName[name]
+	if !ok {
+		return false, fmt.Errorf("wgpu: ShaderLibrary: %s is not loaded", name)
+	}
+	m, err := l.load(name)
+	if err != nil {
+		return false, err
+	}
+	if m == old {
+		m.refs--
+		return false, nil
+	}
+	l.byName[name] = m
+	l.unref(old)
+	return true, nil
+}
+
+// Release releases every module and empties the library.
+func (l *ShaderLibrary) Release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for name, m := range l.byName {
+		l.unref(m)
+		delete(l.byName, name)
+	}
+}
@@ -0,0 +1,137 @@
+package wgpu
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// newTestShaderLibrary returns a library whose modules are fakes, counting
+// how many were created.
+func newTestShaderLibrary(fsys fstest.MapFS, created *int) *ShaderLibrary {
+	lib := NewShaderLibrary(nil, fsys)
+	lib.create = func(source string) (*ShaderModule, error) {
+		if strings.Contains(source, "syntax error") {
+			return nil, errors.New("compilation failed")
+		}
+		*created++
+		return &ShaderModule{source: source}, nil
+	}
+	return lib
+}
+
+func TestShaderLibraryDeduplicates(t *testing.T) {
+	const src = "@vertex fn vs() -> @builtin(position) vec4f { return vec4f(0.0); }\n" +
+		"@fragment fn fs() -> @location(0) vec4f { return vec4f(1.0); }\n"
+	fsys := fstest.MapFS{
+		"a.wgsl": {Data: []byte(src)},
+		"b.wgsl": {Data: []byte(src)},
+		"c.wgsl": {Data: []byte("@compute @workgroup_size(1) fn main() {}\n")},
+	}
+	var created int
+	lib := newTestShaderLibrary(fsys, &created)
+
+	a, err := lib.Load("a.wgsl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := lib.Load("b.wgsl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lib.Load("c.wgsl"); err != nil {
+		t.Fatal(err)
+	}
+	if a != b {
+		t.Error("files with identical source should share a module")
+	}
+	if created != 2 {
+		t.Errorf("created %d modules, want 2", created)
+	}
+	if again, _ := lib.Load("a.wgsl"); again != a {
+		t.Error("loading a file twice should return the same module")
+	}
+
+	eps := lib.EntryPoints("a.wgsl")
+	if len(eps) != 2 || eps[0].Name != "fs" || eps[1].Name != "vs" {
+		t.Errorf("EntryPoints = %+v", eps)
+	}
+	if got := lib.Names(); strings.Join(got, ",") != "a.wgsl,b.wgsl,c.wgsl" {
+		t.Errorf("Names = %v", got)
+	}
+	if lib.Module("missing.wgsl") != nil || lib.EntryPoints("missing.wgsl") != nil {
+		t.Error("unloaded names should have no module or entry points")
+	}
+	if _, err := lib.Load("missing.wgsl"); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+
+	lib.Release()
+	if len(lib.Names()) != 0 || len(lib.byHash) != 0 {
+		t.Error("Release should empty the library")
+	}
+}
+
+func TestShaderLibraryReload(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.wgsl": {Data: []byte("@compute @workgroup_size(1) fn main() {}\n")},
+		"b.wgsl": {Data: []byte("@compute @workgroup_size(1) fn main() {}\n")},
+	}
+	var created int
+	lib := newTestShaderLibrary(fsys, &created)
+	a, _ := lib.Load("a.wgsl")
+	lib.Load("b.wgsl") //nolint:errcheck
+
+	if changed, err := lib.Reload("a.wgsl"); err != nil || changed {
+		t.Errorf("unchanged file: changed = %v, err = %v", changed, err)
+	}
+
+	fsys["a.wgsl"] = &fstest.MapFile{Data: []byte("@compute @workgroup_size(64) fn main() {}\n")}
+	changed, err := lib.Reload("a.wgsl")
+	if err != nil || !changed {
+		t.Fatalf("edited file: changed = %v, err = %v", changed, err)
+	}
+	if lib.Module("a.wgsl") == a {
+		t.Error("Reload should replace the module of an edited file")
+	}
+	if lib.Module("b.wgsl") != a {
+		t.Error("Reload must not affect other files sharing the old module")
+	}
+
+	fsys["a.wgsl"] = &fstest.MapFile{Data: []byte("syntax error")}
+	before := lib.Module("a.wgsl")
+	if _, err := lib.Reload("a.wgsl"); err == nil {
+		t.Error("expected an error for source that fails to compile")
+	}
+	if lib.Module("a.wgsl") != before {
+		t.Error("a failed reload should keep the previous module")
+	}
+	if _, err := lib.Reload("missing.wgsl"); err == nil {
+		t.Error("expected an error reloading an unloaded name")
+	}
+}
+
+func TestShaderLibraryPreprocessor(t *testing.T) {
+	fsys := fstest.MapFS{
+		"common.wgsl": {Data: []byte("const SCALE = 2.0;\n")},
+		"main.wgsl":   {Data: []byte("//#include common.wgsl\n@compute @workgroup_size(1) fn main() {}\n")},
+	}
+	var created int
+	lib := newTestShaderLibrary(fsys, &created)
+	lib.SetPreprocessor(func(name, source string) (string, error) {
+		return strings.ReplaceAll(source, "//#include common.wgsl\n", string(fsys["common.wgsl"].Data)), nil
+	})
+	module, err := lib.Load("main.wgsl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(module.Source(), "const SCALE") {
+		t.Errorf("module source was not preprocessed: %q", module.Source())
+	}
+
+	lib.SetPreprocessor(func(name, source string) (string, error) { return "", errors.New("bad include") })
+	if _, err := lib.Load("common.wgsl"); err == nil || !strings.Contains(err.Error(), "bad include") {
+		t.Errorf("preprocessor error = %v", err)
+	}
+}
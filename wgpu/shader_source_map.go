@@ -0,0 +1,156 @@
+package wgpu
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Source returns the WGSL source the module was created from, or "" for
+// modules created from SPIR-V or GLSL and after [ShaderModule.DiscardSource].
+func (s *ShaderModule) Source() string { return s.source }
+
+// DiscardSource drops the WGSL source the module keeps after creation.
+// Pipelines created from the module afterwards skip the Go-side entry point
+// and layout checks, and their errors carry no source excerpts.
+func (s *ShaderModule) DiscardSource() { s.source = "" }
+
+// SourceExcerpt is a line of WGSL that a pipeline error refers to.
+type SourceExcerpt struct {
+	// Stage names the pipeline stages whose module contains the line, joined
+	// with "+" when several stages share a module.
+	Stage string
+	// Line is the 1-based line number.
+	Line int
+	// Text is the source line without its trailing newline.
+	Text string
+	// Reason is the part of the error message that matched the line.
+	Reason string
+}
+
+// String formats the excerpt as "stage:line: text  // reason".
+func (e SourceExcerpt) String() string {
+	return fmt.Sprintf("%s:%d: %s  // %s", e.Stage, e.Line, strings.TrimSpace(e.Text), e.Reason)
+}
+
+// PipelineSourceError is returned when pipeline creation fails validation
+// and the message refers to declarations found in the WGSL source of the
+// pipeline's shader modules. Err is the error reported by wgpu-native, so
+// errors.Is and errors.As work as for any other *[WGPUError].
+type PipelineSourceError struct {
+	Err      *WGPUError
+	Excerpts []SourceExcerpt
+}
+
+// Error returns the wgpu-native message followed by the source excerpts.
+func (e *PipelineSourceError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Err.Error())
+	for _, x := range e.Excerpts {
+		b.WriteString("\n\t")
+		b.WriteString(x.String())
+	}
+	return b.String()
+}
+
+// Unwrap returns the underlying *WGPUError.
+func (e *PipelineSourceError) Unwrap() error { return e.Err }
+
+// stageSource is a pipeline stage's module, searched by annotatePipelineError.
+type stageSource struct {
+	stage  string
+	module *ShaderModule
+}
+
+var (
+	// Spans in naga diagnostics, e.g. "┌─ wgsl:12:5".
+	sourceSpanRe = regexp.MustCompile(`\bwgsl:(\d+):(\d+)`)
+	// Bindings, written by naga as "ResourceBinding { group: 0, binding: 1 }".
+	resourceBindingRe = regexp.MustCompile(`group:\s*(\d+),\s*binding:\s*(\d+)`)
+	// Entry points, e.g. "Unable to find entry point 'main'".
+	entryPointRe = regexp.MustCompile(`(?i)entry point ['"]?([A-Za-z_][A-Za-z0-9_]*)`)
+)
+
+// annotatePipelineError wraps a validation error from pipeline creation in a
+// *PipelineSourceError when its message can be resolved to lines of the
+// stages' WGSL sources, and returns err unchanged otherwise.
+func annotatePipelineError(err error, stages ...stageSource) error {
+	wgpuErr, ok := err.(*WGPUError)
+	if !ok || wgpuErr.Type != ErrorTypeValidation {
+		return err
+	}
+	// A module shared by several stages is searched once, under all their names.
+	var modules []*ShaderModule
+	names := map[*ShaderModule][]string{}
+	for _, st := range stages {
+		if st.module == nil || st.module.source == "" {
+			continue
+		}
+		if _, ok := names[st.module]; !ok {
+			modules = append(modules, st.module)
+		}
+		names[st.module] = append(names[st.module], st.stage)
+	}
+	var excerpts []SourceExcerpt
+	for _, m := range modules {
+		stage := strings.Join(names[m], "+")
+		excerpts = append(excerpts, resolveSourceExcerpts(wgpuErr.Message, stage, m.source)...)
+	}
+	if len(excerpts) == 0 {
+		return err
+	}
+	return &PipelineSourceError{Err: wgpuErr, Excerpts: excerpts}
+}
+
+// resolveSourceExcerpts finds the lines of source that message refers to:
+// explicit line:column spans, @group/@binding declarations and entry point
+// functions. The result is ordered by line without duplicates.
+func resolveSourceExcerpts(message, stage, source string) []SourceExcerpt {
+	lines := strings.Split(source, "\n")
+	found := map[int]string{}
+	add := func(line int, reason string) {
+		if line < 1 || line > len(lines) {
+			return
+		}
+		if _, ok := found[line]; !ok {
+			found[line] = reason
+		}
+	}
+
+	for _, m := range sourceSpanRe.FindAllStringSubmatch(message, -1) {
+		line, _ := strconv.Atoi(m[1])
+		add(line, m[0])
+	}
+	for _, m := range resourceBindingRe.FindAllStringSubmatch(message, -1) {
+		decl := regexp.MustCompile(`@group\(\s*` + m[1] + `\s*\)\s*@binding\(\s*` + m[2] + `\s*\)|@binding\(\s*` + m[2] + `\s*\)\s*@group\(\s*` + m[1] + `\s*\)`)
+		add(findLine(lines, decl), fmt.Sprintf("@group(%s) @binding(%s)", m[1], m[2]))
+	}
+	for _, m := range entryPointRe.FindAllStringSubmatch(message, -1) {
+		fn := regexp.MustCompile(`\bfn\s+` + m[1] + `\s*\(`)
+		add(findLine(lines, fn), "entry point "+m[1])
+	}
+
+	excerpts := make([]SourceExcerpt, 0, len(found))
+	for line, reason := range found {
+		excerpts = append(excerpts, SourceExcerpt{
+			Stage:  stage,
+			Line:   line,
+			Text:   strings.TrimRight(lines[line-1], "\r"),
+			Reason: reason,
+		})
+	}
+	slices.SortFunc(excerpts, func(a, b SourceExcerpt) int { return a.Line - b.Line })
+	return excerpts
+}
+
+// findLine returns the 1-based number of the first line matching re, or 0.
+func findLine(lines []string, re *regexp.Regexp) int {
+	for i, l := range lines {
+		if re.MatchString(l) {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,106 @@
+package wgpu
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const sourceMapShader = `struct Params { scale: f32 }
+@group(0) @binding(0) var<uniform> params: Params;
+@binding(1) @group(0) var tex: texture_2d<f32>;
+
+@vertex
+fn vs_main() -> @builtin(position) vec4f {
+    return vec4f(params.scale);
+}
+
+@fragment
+fn fs_main() -> @location(0) vec4f {
+    return vec4f(1.0);
+}
+`
+
+func TestResolveSourceExcerpts(t *testing.T) {
+	tests := []struct {
+		name      string
+		message   string
+		wantLines []int
+	}{
+		{
+			"resource binding",
+			"Shader global ResourceBinding { group: 0, binding: 0 } is not available in the pipeline layout",
+			[]int{2},
+		},
+		{
+			"binding declared group last",
+			"ResourceBinding { group: 0, binding: 1 } type mismatch",
+			[]int{3},
+		},
+		{"entry point", "Unable to find entry point 'fs_main'", []int{11}},
+		{"naga span", "error: invalid type\n  ┌─ wgsl:7:12", []int{7}},
+		{
+			"several, deduplicated and ordered",
+			"Entry point vs_main at Vertex is invalid: wgsl:6:1 ResourceBinding { group: 0, binding: 0 }",
+			[]int{2, 6},
+		},
+		{"unknown binding", "ResourceBinding { group: 3, binding: 0 }", nil},
+		{"line out of range", "wgsl:99:1", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveSourceExcerpts(tt.message, "vertex", sourceMapShader)
+			var lines []int
+			for _, e := range got {
+				lines = append(lines, e.Line)
+				if e.Stage != "vertex" || e.Text == "" || e.Reason == "" {
+					t.Errorf("incomplete excerpt %+v", e)
+				}
+			}
+			if len(lines) != len(tt.wantLines) {
+				t.Fatalf("lines = %v, want %v", lines, tt.wantLines)
+			}
+			for i := range lines {
+				if lines[i] != tt.wantLines[i] {
+					t.Fatalf("lines = %v, want %v", lines, tt.wantLines)
+				}
+			}
+		})
+	}
+}
+
+func TestAnnotatePipelineError(t *testing.T) {
+	module := &ShaderModule{handle: 1, source: sourceMapShader}
+	validation := &WGPUError{Op: "CreateRenderPipeline", Type: ErrorTypeValidation,
+		Message: "Unable to find entry point 'fs_main'"}
+
+	err := annotatePipelineError(validation, stageSource{"vertex", module}, stageSource{"fragment", module})
+	var srcErr *PipelineSourceError
+	if !errors.As(err, &srcErr) {
+		t.Fatalf("err = %T, want *PipelineSourceError", err)
+	}
+	if len(srcErr.Excerpts) != 1 || srcErr.Excerpts[0].Stage != "vertex+fragment" {
+		t.Errorf("excerpts = %+v", srcErr.Excerpts)
+	}
+	if !errors.Is(err, ErrValidation) {
+		t.Error("annotated error should still match ErrValidation")
+	}
+	if msg := err.Error(); !strings.Contains(msg, "fn fs_main()") || !strings.HasPrefix(msg, validation.Error()) {
+		t.Errorf("Error() = %q", msg)
+	}
+
+	// Errors that cannot be resolved, or come from modules without source,
+	// are returned unchanged.
+	unresolved := &WGPUError{Op: "CreateRenderPipeline", Type: ErrorTypeValidation, Message: "invalid topology"}
+	if got := annotatePipelineError(unresolved, stageSource{"vertex", module}); got != error(unresolved) {
+		t.Errorf("unresolved error was wrapped: %v", got)
+	}
+	if got := annotatePipelineError(validation, stageSource{"vertex", &ShaderModule{handle: 2}}); got != error(validation) {
+		t.Errorf("error without source was wrapped: %v", got)
+	}
+
+	module.DiscardSource()
+	if module.Source() != "" {
+		t.Error("DiscardSource should clear the source")
+	}
+}
@@ -92,3 +92,43 @@ func TestCreateShaderModuleVertex(t *testing.T) {
 
 	t.Logf("Vertex/Fragment ShaderModule created: handle=%#x", shader.Handle())
 }
+
+func TestCreateShaderModuleLabel(t *testing.T) {
+	inst, err := CreateInstance(nil)
+	if err != nil {
+		t.Fatalf("CreateInstance failed: %v", err)
+	}
+	defer inst.Release()
+
+	adapter, err := inst.RequestAdapter(nil)
+	if err != nil {
+		t.Fatalf("RequestAdapter failed: %v", err)
+	}
+	defer adapter.Release()
+
+	device, err := adapter.RequestDevice(nil)
+	if err != nil {
+		t.Fatalf("RequestDevice failed: %v", err)
+	}
+	defer device.Release()
+
+	SetDebugMode(true)
+	defer SetDebugMode(false)
+	defer ResetLeakTracker()
+
+	shader, err := device.CreateShaderModuleFromDesc(&ShaderDescriptor{Label: "doubler", WGSL: testComputeShader})
+	if err != nil {
+		t.Fatalf("CreateShaderModuleFromDesc: %v", err)
+	}
+	defer shader.Release()
+
+	for _, r := range LiveResources() {
+		if r.Handle == shader.Handle() {
+			if r.Label != "doubler" {
+				t.Errorf("shader module label = %q, want %q", r.Label, "doubler")
+			}
+			return
+		}
+	}
+	t.Error("shader module is not tracked")
+}
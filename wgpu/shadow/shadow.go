@@ -0,0 +1,158 @@
+// Package shadow provides building blocks for depth-map shadows sampled with
+// percentage-closer filtering (PCF).
+//
+// A shadow [Map] owns a depth texture, its view and a comparison sampler.
+// Render the scene from the light into it with a depth-only pipeline built
+// from [PipelineDescriptor], then bind it in the main pass using
+// [LayoutEntries] and [Map.BindGroupEntries]:
+//
+//	sm, _ := shadow.New(device, 2048, gputypes.TextureFormatDepth32Float)
+//	defer sm.Release()
+//
+//	pipeline, _ := device.CreateRenderPipeline(shadow.PipelineDescriptor(
+//	    layout, module, "vs_shadow", vertexBuffers, sm.Format, shadow.DefaultBias))
+//
+//	pass, _ := encoder.BeginRenderPass(sm.PassDescriptor())
+//	pass.SetPipeline(pipeline)
+//	// ... draw casters ...
+//	pass.End()
+//
+// In WGSL, declare the bindings as texture_depth_2d and sampler_comparison
+// and sample with textureSampleCompare (see [WGSLSamplePCF]).
+package shadow
+
+import (
+	"github.com/go-webgpu/webgpu/wgpu"
+	"github.com/gogpu/gputypes"
+)
+
+// Bias is the rasterizer depth bias applied while rendering the shadow map
+// to fight shadow acne.
+type Bias = wgpu.DepthBias
+
+// DefaultBias is a reasonable starting point for Depth32Float shadow maps.
+var DefaultBias = Bias{Constant: 2, SlopeScale: 2.0, Clamp: 0}
+
+// Map is a square depth texture with a comparison sampler for PCF lookups.
+type Map struct {
+	Texture *wgpu.Texture
+	View    *wgpu.TextureView
+	Sampler *wgpu.Sampler
+	Format  gputypes.TextureFormat
+	Size    uint32
+}
+
+// New creates a size x size shadow map in a depth format (usually
+// Depth32Float or Depth16Unorm). The sampler compares with LessEqual.
+func New(device *wgpu.Device, size uint32, format gputypes.TextureFormat) (*Map, error) {
+	tex, err := device.CreateTexture(&wgpu.TextureDescriptor{
+		Label:         "shadow map",
+		Usage:         gputypes.TextureUsageRenderAttachment | gputypes.TextureUsageTextureBinding,
+		Dimension:     gputypes.TextureDimension2D,
+		Size:          gputypes.Extent3D{Width: size, Height: size, DepthOrArrayLayers: 1},
+		Format:        format,
+		MipLevelCount: 1,
+		SampleCount:   1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	view, err := tex.CreateView(nil)
+	if err != nil {
+		tex.Release()
+		return nil, err
+	}
+	sampler, err := device.CreateComparisonSampler(gputypes.CompareFunctionLessEqual)
+	if err != nil {
+		view.Release()
+		tex.Release()
+		return nil, err
+	}
+	return &Map{Texture: tex, View: view, Sampler: sampler, Format: format, Size: size}, nil
+}
+
+// Release releases the sampler, view and texture.
+func (m *Map) Release() {
+	if m.Sampler != nil {
+		m.Sampler.Release()
+		m.Sampler = nil
+	}
+	if m.View != nil {
+		m.View.Release()
+		m.View = nil
+	}
+	if m.Texture != nil {
+		m.Texture.Release()
+		m.Texture = nil
+	}
+}
+
+// PassDescriptor returns a depth-only render pass that clears the shadow map
+// to 1.0 and stores the result.
+func (m *Map) PassDescriptor() *wgpu.RenderPassDescriptor {
+	return &wgpu.RenderPassDescriptor{
+		Label: "shadow pass",
+		DepthStencilAttachment: &wgpu.RenderPassDepthStencilAttachment{
+			View:            m.View,
+			DepthLoadOp:     gputypes.LoadOpClear,
+			DepthStoreOp:    gputypes.StoreOpStore,
+			DepthClearValue: 1.0,
+		},
+	}
+}
+
+// BindGroupEntries returns the entries binding the shadow map and its
+// comparison sampler, matching [LayoutEntries].
+func (m *Map) BindGroupEntries(textureBinding, samplerBinding uint32) []wgpu.BindGroupEntry {
+	return []wgpu.BindGroupEntry{
+		wgpu.TextureBindingEntry(textureBinding, m.View),
+		wgpu.SamplerBindingEntry(samplerBinding, m.Sampler),
+	}
+}
+
+// LayoutEntries returns bind group layout entries for a texture_depth_2d and a
+// sampler_comparison visible to the given stages.
+func LayoutEntries(textureBinding, samplerBinding uint32, visibility gputypes.ShaderStage) []wgpu.BindGroupLayoutEntry {
+	return []wgpu.BindGroupLayoutEntry{
+		{
+			Binding:    textureBinding,
+			Visibility: visibility,
+			Texture: &wgpu.TextureBindingLayout{
+				SampleType:    gputypes.TextureSampleTypeDepth,
+				ViewDimension: gputypes.TextureViewDimension2D,
+			},
+		},
+		{
+			Binding:    samplerBinding,
+			Visibility: visibility,
+			Sampler:    &wgpu.SamplerBindingLayout{Type: gputypes.SamplerBindingTypeComparison},
+		},
+	}
+}
+
+// PipelineDescriptor returns a depth-only render pipeline descriptor for
+// rendering shadow casters: no fragment stage, back-face culling, depth
+// writes with Less comparison, and the given bias. It is
+// [wgpu.DepthOnlyPipelineDescriptor] with a shadow-specific label.
+func PipelineDescriptor(layout *wgpu.PipelineLayout, module *wgpu.ShaderModule, entryPoint string,
+	buffers []wgpu.VertexBufferLayout, format gputypes.TextureFormat, bias Bias) *wgpu.RenderPipelineDescriptor {
+	desc := wgpu.DepthOnlyPipelineDescriptor(layout, module, entryPoint, buffers, format, bias)
+	desc.Label = "shadow pipeline"
+	return desc
+}
+
+// WGSLSamplePCF is a WGSL helper that performs 3x3 PCF on a shadow map.
+// It expects light-space NDC coordinates in pos (xy in [-1, 1], z in [0, 1]).
+const WGSLSamplePCF = `
+fn shadow_pcf(map: texture_depth_2d, cmp: sampler_comparison, pos: vec3<f32>) -> f32 {
+    let uv = pos.xy * vec2<f32>(0.5, -0.5) + vec2<f32>(0.5, 0.5);
+    let texel = 1.0 / vec2<f32>(textureDimensions(map));
+    var lit = 0.0;
+    for (var y = -1; y <= 1; y++) {
+        for (var x = -1; x <= 1; x++) {
+            lit += textureSampleCompare(map, cmp, uv + vec2<f32>(f32(x), f32(y)) * texel, pos.z);
+        }
+    }
+    return lit / 9.0;
+}
+`
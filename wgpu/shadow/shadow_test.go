@@ -0,0 +1,53 @@
+package shadow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestLayoutEntries(t *testing.T) {
+	entries := LayoutEntries(1, 2, gputypes.ShaderStageFragment)
+	if len(entries) != 2 {
+		t.Fatalf("len = %d, want 2", len(entries))
+	}
+	tex, smp := entries[0], entries[1]
+	if tex.Binding != 1 || tex.Texture == nil || tex.Texture.SampleType != gputypes.TextureSampleTypeDepth {
+		t.Errorf("texture entry = %+v", tex)
+	}
+	if smp.Binding != 2 || smp.Sampler == nil || smp.Sampler.Type != gputypes.SamplerBindingTypeComparison {
+		t.Errorf("sampler entry = %+v", smp)
+	}
+}
+
+func TestPipelineDescriptorIsDepthOnly(t *testing.T) {
+	desc := PipelineDescriptor(nil, nil, "vs", nil, gputypes.TextureFormatDepth32Float, DefaultBias)
+	if desc.Fragment != nil {
+		t.Error("shadow pipeline must not have a fragment stage")
+	}
+	ds := desc.DepthStencil
+	if ds == nil || ds.Format != gputypes.TextureFormatDepth32Float || !ds.DepthWriteEnabled {
+		t.Fatalf("depth state = %+v", ds)
+	}
+	if ds.DepthBias != DefaultBias.Constant || ds.DepthBiasSlopeScale != DefaultBias.SlopeScale {
+		t.Errorf("bias not applied: %+v", ds)
+	}
+}
+
+func TestPassDescriptorIsDepthOnly(t *testing.T) {
+	m := &Map{}
+	desc := m.PassDescriptor()
+	if len(desc.ColorAttachments) != 0 || desc.DepthStencilAttachment == nil {
+		t.Fatalf("pass = %+v", desc)
+	}
+	if desc.DepthStencilAttachment.DepthClearValue != 1.0 {
+		t.Errorf("clear value = %v, want 1", desc.DepthStencilAttachment.DepthClearValue)
+	}
+}
+
+func TestWGSLSamplePCF(t *testing.T) {
+	if !strings.Contains(WGSLSamplePCF, "textureSampleCompare") {
+		t.Error("PCF snippet does not use textureSampleCompare")
+	}
+}
@@ -0,0 +1,50 @@
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+)
+
+// Visibility presets for BindGroupLayoutEntry.Visibility.
+const (
+	VisibilityVertex         = gputypes.ShaderStageVertex
+	VisibilityFragment       = gputypes.ShaderStageFragment
+	VisibilityCompute        = gputypes.ShaderStageCompute
+	VisibilityVertexFragment = gputypes.ShaderStageVertex | gputypes.ShaderStageFragment
+	VisibilityAll            = gputypes.ShaderStageVertex | gputypes.ShaderStageFragment | gputypes.ShaderStageCompute
+)
+
+// StorageEntry returns a layout entry for a read-write storage buffer,
+// declared in WGSL as var<storage, read_write>. Writable storage buffers are
+// not visible to the vertex stage unless the device has
+// NativeFeatureVertexWritableStorage.
+func StorageEntry(binding uint32, visibility gputypes.ShaderStage) BindGroupLayoutEntry {
+	return BindGroupLayoutEntry{
+		Binding:    binding,
+		Visibility: visibility,
+		Buffer:     &BufferBindingLayout{Type: gputypes.BufferBindingTypeStorage},
+	}
+}
+
+// StorageROEntry returns a layout entry for a read-only storage buffer,
+// declared in WGSL as var<storage> or var<storage, read>. Unlike
+// [StorageEntry] it may be visible to every stage.
+func StorageROEntry(binding uint32, visibility gputypes.ShaderStage) BindGroupLayoutEntry {
+	return BindGroupLayoutEntry{
+		Binding:    binding,
+		Visibility: visibility,
+		Buffer:     &BufferBindingLayout{Type: gputypes.BufferBindingTypeReadOnlyStorage},
+	}
+}
+
+// validateBufferLayout checks the buffer part of e: WebGPU does not let
+// vertex shaders write storage buffers unless the device reported by
+// hasFeature enables NativeFeatureVertexWritableStorage.
+func validateBufferLayout(e *BindGroupLayoutEntry, hasFeature func(FeatureName) bool) error {
+	if e.Buffer.Type == gputypes.BufferBindingTypeStorage && e.Visibility&gputypes.ShaderStageVertex != 0 &&
+		!hasFeature(FeatureName(NativeFeatureVertexWritableStorage)) {
+		return fmt.Errorf("binding %d: read-write storage buffers are not visible to the vertex stage; use BufferBindingTypeReadOnlyStorage", e.Binding)
+	}
+	return nil
+}
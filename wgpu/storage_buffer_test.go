@@ -0,0 +1,37 @@
+package wgpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestValidateBufferLayout(t *testing.T) {
+	none := func(FeatureName) bool { return false }
+	vertexWritable := func(f FeatureName) bool { return f == FeatureName(NativeFeatureVertexWritableStorage) }
+	tests := []struct {
+		name       string
+		entry      BindGroupLayoutEntry
+		hasFeature func(FeatureName) bool
+		wantErr    bool
+	}{
+		{"read-only everywhere", StorageROEntry(0, VisibilityAll), none, false},
+		{"read-write in compute", StorageEntry(0, VisibilityCompute), none, false},
+		{"read-write in fragment", StorageEntry(0, VisibilityFragment), none, false},
+		{"read-write in vertex", StorageEntry(0, VisibilityVertexFragment), none, true},
+		{"read-write in vertex with feature", StorageEntry(0, VisibilityVertex), vertexWritable, false},
+		{"uniform in vertex", UniformEntry[Mat4](0, VisibilityVertex), none, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBufferLayout(&tt.entry, tt.hasFeature)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if e := StorageROEntry(3, VisibilityFragment); e.Binding != 3 || e.Buffer.Type != gputypes.BufferBindingTypeReadOnlyStorage {
+		t.Errorf("StorageROEntry = %+v", e)
+	}
+}
@@ -0,0 +1,74 @@
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+)
+
+// StorageTextureBindingEntry creates a BindGroupEntry for a storage texture
+// view. The view must cover a single mip level.
+func StorageTextureBindingEntry(binding uint32, textureView *TextureView) BindGroupEntry {
+	return BindGroupEntry{
+		Binding:     binding,
+		TextureView: textureView,
+	}
+}
+
+// StorageTextureLayoutEntry returns a BindGroupLayoutEntry for a 2D storage
+// texture (texture_storage_2d<format, access>) with the given access mode.
+func StorageTextureLayoutEntry(binding uint32, visibility gputypes.ShaderStage, access gputypes.StorageTextureAccess, format gputypes.TextureFormat) BindGroupLayoutEntry {
+	return BindGroupLayoutEntry{
+		Binding:    binding,
+		Visibility: visibility,
+		StorageTexture: &StorageTextureBindingLayout{
+			Access:        access,
+			Format:        format,
+			ViewDimension: gputypes.TextureViewDimension2D,
+		},
+	}
+}
+
+// readWriteStorageFormats are the formats core WebGPU allows as read-write
+// storage textures.
+var readWriteStorageFormats = map[gputypes.TextureFormat]bool{
+	gputypes.TextureFormatR32Float: true,
+	gputypes.TextureFormatR32Uint:  true,
+	gputypes.TextureFormatR32Sint:  true,
+}
+
+// validateStorageTextureLayout checks the storage texture part of e against
+// the WebGPU rules, relaxed by the device features reported by hasFeature.
+func validateStorageTextureLayout(e *BindGroupLayoutEntry, hasFeature func(FeatureName) bool) error {
+	st := e.StorageTexture
+	if st.Format == gputypes.TextureFormatUndefined {
+		return fmt.Errorf("binding %d: storage texture format is required", e.Binding)
+	}
+	adapterSpecific := func() bool {
+		return hasFeature(FeatureName(NativeFeatureTextureAdapterSpecificFormatFeatures))
+	}
+
+	info, _ := FormatInfo(st.Format)
+	if !info.StorageBinding {
+		switch {
+		case st.Format == gputypes.TextureFormatBGRA8Unorm && hasFeature(FeatureNameBGRA8UnormStorage):
+		case hasFeature(FeatureNameTextureFormatsTier1) || adapterSpecific():
+		default:
+			return fmt.Errorf("binding %d: format %s cannot be used as a storage texture", e.Binding, st.Format)
+		}
+	}
+
+	access := st.Access
+	if access == gputypes.StorageTextureAccessUndefined {
+		access = gputypes.StorageTextureAccessWriteOnly
+	}
+	if access == gputypes.StorageTextureAccessReadWrite && !readWriteStorageFormats[st.Format] &&
+		!hasFeature(FeatureNameTextureFormatsTier2) && !adapterSpecific() {
+		return fmt.Errorf("binding %d: format %s cannot be used as a read-write storage texture", e.Binding, st.Format)
+	}
+	if access != gputypes.StorageTextureAccessReadOnly && e.Visibility&gputypes.ShaderStageVertex != 0 &&
+		!hasFeature(FeatureName(NativeFeatureVertexWritableStorage)) {
+		return fmt.Errorf("binding %d: writable storage textures are not visible to the vertex stage", e.Binding)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package wgpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestValidateStorageTextureLayout(t *testing.T) {
+	none := func(FeatureName) bool { return false }
+	only := func(want FeatureName) func(FeatureName) bool {
+		return func(f FeatureName) bool { return f == want }
+	}
+	tests := []struct {
+		name       string
+		entry      BindGroupLayoutEntry
+		hasFeature func(FeatureName) bool
+		wantErr    bool
+	}{
+		{"write-only rgba8", StorageTextureLayoutEntry(0, gputypes.ShaderStageCompute,
+			gputypes.StorageTextureAccessWriteOnly, gputypes.TextureFormatRGBA8Unorm), none, false},
+		{"read-write r32float", StorageTextureLayoutEntry(0, gputypes.ShaderStageCompute,
+			gputypes.StorageTextureAccessReadWrite, gputypes.TextureFormatR32Float), none, false},
+		{"read-write rgba8 without tier2", StorageTextureLayoutEntry(0, gputypes.ShaderStageCompute,
+			gputypes.StorageTextureAccessReadWrite, gputypes.TextureFormatRGBA8Unorm), none, true},
+		{"read-write rgba8 with tier2", StorageTextureLayoutEntry(0, gputypes.ShaderStageCompute,
+			gputypes.StorageTextureAccessReadWrite, gputypes.TextureFormatRGBA8Unorm), only(FeatureNameTextureFormatsTier2), false},
+		{"srgb format", StorageTextureLayoutEntry(0, gputypes.ShaderStageCompute,
+			gputypes.StorageTextureAccessWriteOnly, gputypes.TextureFormatRGBA8UnormSrgb), none, true},
+		{"bgra8 without feature", StorageTextureLayoutEntry(0, gputypes.ShaderStageCompute,
+			gputypes.StorageTextureAccessWriteOnly, gputypes.TextureFormatBGRA8Unorm), none, true},
+		{"bgra8 with feature", StorageTextureLayoutEntry(0, gputypes.ShaderStageCompute,
+			gputypes.StorageTextureAccessWriteOnly, gputypes.TextureFormatBGRA8Unorm), only(FeatureNameBGRA8UnormStorage), false},
+		{"undefined format", StorageTextureLayoutEntry(0, gputypes.ShaderStageCompute,
+			gputypes.StorageTextureAccessReadOnly, gputypes.TextureFormatUndefined), none, true},
+		{"read-only in vertex", StorageTextureLayoutEntry(0, gputypes.ShaderStageVertex,
+			gputypes.StorageTextureAccessReadOnly, gputypes.TextureFormatR32Float), none, false},
+		{"default access in vertex", StorageTextureLayoutEntry(0, gputypes.ShaderStageVertex,
+			gputypes.StorageTextureAccessUndefined, gputypes.TextureFormatR32Float), none, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateStorageTextureLayout(&tt.entry, tt.hasFeature)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStorageTextureLayoutEntryWire(t *testing.T) {
+	e := StorageTextureLayoutEntry(3, gputypes.ShaderStageCompute,
+		gputypes.StorageTextureAccessUndefined, gputypes.TextureFormatRGBA16Float)
+	wire := e.toWire()
+	// Undefined access must not become the BindingNotUsed sentinel.
+	if wire.StorageTexture.Access != 2 {
+		t.Errorf("access = %d, want 2 (WGPUStorageTextureAccess_WriteOnly)", wire.StorageTexture.Access)
+	}
+	if wire.StorageTexture.Format != uint32(gputypes.TextureFormatRGBA16Float) || wire.Binding != 3 {
+		t.Errorf("wire = %+v", wire)
+	}
+}
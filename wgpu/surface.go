@@ -1,6 +1,10 @@
 package wgpu
 
 import (
+	"cmp"
+	"fmt"
+	"runtime"
+	"slices"
 	"unsafe"
 
 	"github.com/gogpu/gputypes"
@@ -62,6 +66,37 @@ type SurfaceConfiguration struct {
 	Height      uint32
 	AlphaMode   gputypes.CompositeAlphaMode
 	PresentMode gputypes.PresentMode
+	// ViewFormats lists additional formats that views of the surface textures
+	// may use. Only the sRGB counterpart of Format is allowed, so an
+	// RGBA8Unorm/BGRA8Unorm surface can be rendered through an sRGB view.
+	ViewFormats []gputypes.TextureFormat
+	// ColorSpace and ToneMapping describe how the compositor interprets the
+	// surface contents. Leaving both zero keeps the platform default (sRGB,
+	// standard range). For HDR output use an RGBA16Float format with
+	// ToneMappingModeExtended, so values above 1.0 reach the display; see
+	// [SurfaceCapabilities.HDRFormat]. Backends without color management
+	// ignore these fields.
+	ColorSpace  PredefinedColorSpace
+	ToneMapping ToneMappingMode
+	// Capturable adds CopySrc to Usage, so frames can be read back with
+	// [CaptureFrame] for screenshots and golden-image tests.
+	Capturable bool
+}
+
+// usage returns Usage with CopySrc added for Capturable configurations.
+func (c *SurfaceConfiguration) usage() gputypes.TextureUsage {
+	if c.Capturable {
+		return c.Usage | gputypes.TextureUsageCopySrc
+	}
+	return c.Usage
+}
+
+// surfaceColorManagement matches WGPUSurfaceColorManagement, chained on the
+// surface configuration - 24 bytes.
+type surfaceColorManagement struct {
+	chain           ChainedStruct // 16 bytes
+	colorSpace      uint32        // WGPUPredefinedColorSpace
+	toneMappingMode uint32        // WGPUToneMappingMode
 }
 
 // SurfaceTexture holds the result of GetCurrentTexture.
@@ -72,6 +107,8 @@ type SurfaceTexture struct {
 
 // SurfaceCapabilities describes the capabilities of a surface for presentation.
 // Returned by Surface.GetCapabilities() to query supported formats, present modes, etc.
+// Formats, PresentModes and AlphaModes are in the adapter's order of
+// preference; the first entry is the usual choice.
 type SurfaceCapabilities struct {
 	Usages       gputypes.TextureUsage
 	Formats      []gputypes.TextureFormat
@@ -89,6 +126,13 @@ var (
 	// Applications should skip rendering for the current frame and try again when unoccluded.
 	// New in wgpu-native v29.
 	ErrSurfaceOccluded = &WGPUError{Op: "Surface.GetCurrentTexture", Message: "surface occluded (window minimized or covered)"}
+	// ErrSurfaceSuboptimal is the error of the SuccessSuboptimal status. GetCurrentTexture
+	// reports that status through its suboptimal flag instead, since the texture is usable.
+	ErrSurfaceSuboptimal = &WGPUError{Op: "Surface.GetCurrentTexture", Message: "surface suboptimal, reconfigure soon"}
+	// ErrSurfaceFailed is returned for the Error status, which in v29 covers an
+	// unconfigured surface, out-of-memory and device loss alike. It is not recoverable
+	// by reconfiguring.
+	ErrSurfaceFailed = &WGPUError{Op: "Surface.GetCurrentTexture", Message: "failed to get surface texture"}
 	// ErrSurfaceOutOfMemory is kept for backward compatibility.
 	// Deprecated: In v29 this is reported as generic Error status.
 	ErrSurfaceOutOfMemory = &WGPUError{Op: "Surface.GetCurrentTexture", Message: "out of memory"}
@@ -100,14 +144,26 @@ var (
 // Configure configures the surface for rendering.
 // The device argument specifies which logical device to use for the surface.
 // If config.Device is also set (deprecated usage), it takes precedence over the device arg.
-// Returns nil on success. Errors are surfaced through the Device uncaptured-error callback
-// in this FFI implementation; the error return matches the gogpu/wgpu API signature.
 // This replaces the deprecated SwapChain API.
 // Enum values are converted from gputypes to wgpu-native values before FFI call.
+//
+// The configuration is validated on the Go side first, because wgpu-native
+// aborts the process on an invalid configuration: zero dimensions, sizes
+// above the device's MaxTextureDimension2D, a missing format or usage, and
+// incompatible ViewFormats are validation errors. Once
+// [Surface.GetCapabilities] has been called, the format, usage, present
+// mode and alpha mode are also checked against the capabilities it returned.
+// The returned error comes only from this Go-side validation:
+// wgpuSurfaceConfigure has no result, so a configuration that passes here
+// but that wgpu-native still rejects is not reported through the return
+// value and ends the process as described above.
 func (s *Surface) Configure(device *Device, config *SurfaceConfiguration) error {
 	mustInit()
-	if s == nil || s.handle == 0 || config == nil {
-		return nil
+	if s == nil || s.handle == 0 {
+		return &WGPUError{Op: "Surface.Configure", Message: "surface is nil or released"}
+	}
+	if config == nil {
+		return &WGPUError{Op: "Surface.Configure", Type: ErrorTypeValidation, Message: "configuration is nil"}
 	}
 
 	// config.Device takes precedence (backward compat) over the device argument.
@@ -116,18 +172,36 @@ func (s *Surface) Configure(device *Device, config *SurfaceConfiguration) error
 		dev = config.Device
 	}
 	if dev == nil || dev.handle == 0 {
-		return nil
+		return &WGPUError{Op: "Surface.Configure", Message: "device is nil or released"}
+	}
+	s.mu.Lock()
+	caps := s.caps
+	s.mu.Unlock()
+	if err := validateSurfaceConfiguration(config, dev.limits.MaxTextureDimension2D, caps); err != nil {
+		return &WGPUError{Op: "Surface.Configure", Type: ErrorTypeValidation, Message: err.Error()}
+	}
+	viewFormats, viewFormatCount, viewFormatsPtr := viewFormatsWire(config.ViewFormats)
+
+	var colorManagement surfaceColorManagement
+	var nextInChain uintptr
+	if config.ColorSpace != 0 || config.ToneMapping != 0 {
+		colorManagement = surfaceColorManagement{
+			chain:           ChainedStruct{SType: uint32(STypeSurfaceColorManagement)},
+			colorSpace:      uint32(cmp.Or(config.ColorSpace, PredefinedColorSpaceSRGB)),
+			toneMappingMode: uint32(cmp.Or(config.ToneMapping, ToneMappingModeStandard)),
+		}
+		nextInChain = uintptr(unsafe.Pointer(&colorManagement))
 	}
 
 	nativeConfig := surfaceConfigurationWire{
-		nextInChain:     0,
+		nextInChain:     nextInChain,
 		device:          dev.handle,
 		format:          uint32(config.Format),
-		usage:           uint64(config.Usage),
+		usage:           uint64(config.usage()),
 		width:           config.Width,
 		height:          config.Height,
-		viewFormatCount: 0,
-		viewFormats:     0,
+		viewFormatCount: viewFormatCount,
+		viewFormats:     viewFormatsPtr,
 		alphaMode:       uint32(config.AlphaMode),
 		presentMode:     uint32(config.PresentMode),
 	}
@@ -136,6 +210,47 @@ func (s *Surface) Configure(device *Device, config *SurfaceConfiguration) error
 		s.handle,
 		uintptr(unsafe.Pointer(&nativeConfig)),
 	)
+	runtime.KeepAlive(viewFormats)
+	runtime.KeepAlive(&colorManagement)
+	s.configured(config, dev)
+	return nil
+}
+
+// validateSurfaceConfiguration checks config against the device's
+// maxDimension (skipped when 0) and, when caps is non-nil, the surface
+// capabilities.
+func validateSurfaceConfiguration(config *SurfaceConfiguration, maxDimension uint32, caps *SurfaceCapabilities) error {
+	if config.Width == 0 || config.Height == 0 {
+		return fmt.Errorf("size %dx%d must be non-zero", config.Width, config.Height)
+	}
+	if maxDimension != 0 && (config.Width > maxDimension || config.Height > maxDimension) {
+		return fmt.Errorf("size %dx%d exceeds the device limit MaxTextureDimension2D %d", config.Width, config.Height, maxDimension)
+	}
+	if config.Format == gputypes.TextureFormatUndefined {
+		return fmt.Errorf("format is undefined")
+	}
+	if config.usage() == 0 {
+		return fmt.Errorf("usage is empty")
+	}
+	if err := validateViewFormats(config.Format, config.ViewFormats); err != nil {
+		return err
+	}
+	if config.ToneMapping == ToneMappingModeExtended && config.Format != gputypes.TextureFormatRGBA16Float {
+		return fmt.Errorf("extended tone mapping requires format %v, got %v", gputypes.TextureFormatRGBA16Float, config.Format)
+	}
+	if caps == nil {
+		return nil
+	}
+	switch {
+	case !caps.SupportsFormat(config.Format):
+		return fmt.Errorf("format %v is not supported by the surface (supported: %v)", config.Format, caps.Formats)
+	case !caps.SupportsUsage(config.usage()):
+		return fmt.Errorf("usage %v is not supported by the surface (supported: %v)", config.usage(), caps.Usages)
+	case config.PresentMode != gputypes.PresentModeUndefined && !caps.SupportsPresentMode(config.PresentMode):
+		return fmt.Errorf("present mode %v is not supported by the surface (supported: %v)", config.PresentMode, caps.PresentModes)
+	case config.AlphaMode != gputypes.CompositeAlphaModeAuto && !caps.SupportsAlphaMode(config.AlphaMode):
+		return fmt.Errorf("alpha mode %v is not supported by the surface (supported: %v)", config.AlphaMode, caps.AlphaModes)
+	}
 	return nil
 }
 
@@ -152,11 +267,21 @@ func (s *Surface) Unconfigure() {
 		return
 	}
 	procSurfaceUnconfigure.Call(s.handle) //nolint:errcheck
+	s.unconfigured()
 }
 
 // GetCurrentTexture gets the current texture to render to.
 // Returns the texture, a suboptimal flag (true if the surface needs reconfiguration
 // but is still usable this frame), and any error. This matches the gogpu/wgpu API.
+//
+// Errors are the sentinel of the status, see [SurfaceGetCurrentTextureStatus.Err].
+// The SurfaceTexture is returned with every status, so its Status can be
+// inspected on error too; its Texture has a zero handle when the surface
+// provided none.
+//
+// The surface must be configured, and a texture acquired by an earlier call
+// must be presented, or released without presenting, first; otherwise a
+// validation error is returned rather than wgpu-native aborting.
 func (s *Surface) GetCurrentTexture() (*SurfaceTexture, bool, error) {
 	if err := checkInit(); err != nil {
 		return nil, false, err
@@ -165,6 +290,12 @@ func (s *Surface) GetCurrentTexture() (*SurfaceTexture, bool, error) {
 		return nil, false, &WGPUError{Op: "Surface.GetCurrentTexture", Message: "surface is nil or released"}
 	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkAcquire(); err != nil {
+		return nil, false, &WGPUError{Op: "Surface.GetCurrentTexture", Type: ErrorTypeValidation, Message: err.Error()}
+	}
+
 	var surfTex surfaceTexture
 
 	procSurfaceGetCurrentTexture.Call( //nolint:errcheck
@@ -177,38 +308,45 @@ func (s *Surface) GetCurrentTexture() (*SurfaceTexture, bool, error) {
 		Status:  surfTex.status,
 	}
 
-	switch surfTex.status {
-	case SurfaceGetCurrentTextureStatusSuccessOptimal:
-		return result, false, nil
-	case SurfaceGetCurrentTextureStatusSuccessSuboptimal:
+	err := surfTex.status.Err()
+	if err == nil || err == ErrSurfaceSuboptimal {
+		result.Texture.surface, result.Texture.surfaceFrame = s, s.acquire()
+		if traceActive.Load() {
+			traceCall("Surface.GetCurrentTexture", nil, result.Texture, TextureDescriptor{
+				Usage:  s.config.usage(),
+				Size:   gputypes.Extent3D{Width: s.config.Width, Height: s.config.Height, DepthOrArrayLayers: 1},
+				Format: s.config.Format,
+			})
+		}
+	}
+	if err == ErrSurfaceSuboptimal {
 		// Surface still usable but caller should reconfigure soon.
+		if !s.suboptimalLogged {
+			s.suboptimalLogged = true
+			logger().Warn("wgpu: surface is suboptimal; reconfigure it",
+				"width", s.config.Width, "height", s.config.Height, "format", s.config.Format)
+		}
 		return result, true, nil
-	case SurfaceGetCurrentTextureStatusOutdated:
-		return result, false, ErrSurfaceNeedsReconfigure
-	case SurfaceGetCurrentTextureStatusLost:
-		return nil, false, ErrSurfaceLost
-	case SurfaceGetCurrentTextureStatusTimeout:
-		return nil, false, ErrSurfaceTimeout
-	case NativeSurfaceGetCurrentTextureStatusOccluded:
-		// wgpu-native v29: window is occluded/minimized (Metal backend only).
-		// No texture is returned; caller should skip this frame and try again.
-		return nil, false, ErrSurfaceOccluded
-	default:
-		// v29: SurfaceGetCurrentTextureStatusError (0x06) covers all error cases
-		// including former OutOfMemory (0x06) and DeviceLost (0x07).
-		return nil, false, &WGPUError{Op: "Surface.GetCurrentTexture", Message: "failed to get surface texture"}
 	}
+	return result, false, err
 }
 
 // Present presents the current frame to the surface.
 // The texture argument is accepted for API compatibility with gogpu/wgpu but
 // is unused in the FFI implementation (wgpuSurfacePresent takes no texture arg).
-// Returns nil on success.
+// Returns nil on success, and a validation error instead of presenting when
+// no texture has been acquired since the last Present, which wgpu-native
+// would abort on. To present several surfaces, see [Present].
 func (s *Surface) Present(texture ...*SurfaceTexture) error {
 	mustInit()
 	if s == nil || s.handle == 0 {
 		return nil
 	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.present(); err != nil {
+		return &WGPUError{Op: "Surface.Present", Type: ErrorTypeValidation, Message: err.Error()}
+	}
 	procSurfacePresent.Call(s.handle) //nolint:errcheck
 	return nil
 }
@@ -216,6 +354,7 @@ func (s *Surface) Present(texture ...*SurfaceTexture) error {
 // Release releases the surface.
 func (s *Surface) Release() {
 	if s.handle != 0 {
+		s.unconfigured()
 		untrackResource(s.handle)
 		procSurfaceRelease.Call(s.handle) //nolint:errcheck
 		s.handle = 0
@@ -228,6 +367,7 @@ func (s *Surface) Handle() uintptr { return s.handle }
 // GetCapabilities queries the surface capabilities for the given adapter.
 // This determines which texture formats, present modes, and alpha modes are supported.
 // The caller must provide a valid adapter that will be used with this surface.
+// The result is remembered and later configurations are validated against it.
 func (s *Surface) GetCapabilities(adapter *Adapter) (*SurfaceCapabilities, error) {
 	if err := checkInit(); err != nil {
 		return nil, err
@@ -242,13 +382,27 @@ func (s *Surface) GetCapabilities(adapter *Adapter) (*SurfaceCapabilities, error
 
 	// Call wgpuSurfaceGetCapabilities
 	var wire surfaceCapabilitiesWire
-	procSurfaceGetCapabilities.Call( //nolint:errcheck
+	status, _, _ := procSurfaceGetCapabilities.Call(
 		s.handle,
 		adapter.handle,
 		uintptr(unsafe.Pointer(&wire)),
 	)
+	if WGPUStatus(status) != WGPUStatusSuccess {
+		return nil, &WGPUError{Op: "Surface.GetCapabilities", Message: "surface is not supported by the adapter"}
+	}
+	caps := capabilitiesFromWire(&wire)
+
+	// Free C memory allocated by wgpu-native
+	procSurfaceCapabilitiesFreeMembers.Call(uintptr(unsafe.Pointer(&wire))) //nolint:errcheck
 
-	// Convert wire struct to Go struct
+	s.mu.Lock()
+	s.caps = caps
+	s.mu.Unlock()
+	return caps, nil
+}
+
+// capabilitiesFromWire copies the arrays of wire into Go memory.
+func capabilitiesFromWire(wire *surfaceCapabilitiesWire) *SurfaceCapabilities {
 	caps := &SurfaceCapabilities{
 		Usages: gputypes.TextureUsage(wire.usages),
 	}
@@ -279,9 +433,101 @@ func (s *Surface) GetCapabilities(adapter *Adapter) (*SurfaceCapabilities, error
 			caps.AlphaModes[i] = gputypes.CompositeAlphaMode(am)
 		}
 	}
+	return caps
+}
 
-	// Free C memory allocated by wgpu-native
-	procSurfaceCapabilitiesFreeMembers.Call(uintptr(unsafe.Pointer(&wire))) //nolint:errcheck
+// SupportsFormat reports whether the surface can be configured with format.
+func (c *SurfaceCapabilities) SupportsFormat(format gputypes.TextureFormat) bool {
+	return slices.Contains(c.Formats, format)
+}
 
-	return caps, nil
+// SupportsPresentMode reports whether the surface can present with mode.
+func (c *SurfaceCapabilities) SupportsPresentMode(mode gputypes.PresentMode) bool {
+	return slices.Contains(c.PresentModes, mode)
+}
+
+// SupportsAlphaMode reports whether the surface can composite with mode.
+func (c *SurfaceCapabilities) SupportsAlphaMode(mode gputypes.CompositeAlphaMode) bool {
+	return slices.Contains(c.AlphaModes, mode)
+}
+
+// HDRFormat returns the surface format best suited to HDR or wide-gamut
+// output: RGBA16Float, which can carry values above 1.0 with
+// ToneMappingModeExtended, else RGB10A2Unorm for 10-bit standard-range
+// output. ok is false if the surface supports neither.
+func (c *SurfaceCapabilities) HDRFormat() (format gputypes.TextureFormat, ok bool) {
+	for _, f := range []gputypes.TextureFormat{gputypes.TextureFormatRGBA16Float, gputypes.TextureFormatRGB10A2Unorm} {
+		if c.SupportsFormat(f) {
+			return f, true
+		}
+	}
+	return gputypes.TextureFormatUndefined, false
+}
+
+// TransparentAlphaMode returns the alpha mode for a window with per-pixel
+// transparency: Premultiplied, which pairs with [BlendPremultiplied] and a
+// clear color of zero alpha, else Unpremultiplied. ok is false if the
+// surface only composites opaquely, as HWND surfaces on Windows do; there,
+// create the surface with [Instance.CreateSurfaceFromSwapChainPanel].
+func (c *SurfaceCapabilities) TransparentAlphaMode() (mode gputypes.CompositeAlphaMode, ok bool) {
+	for _, m := range []gputypes.CompositeAlphaMode{gputypes.CompositeAlphaModePremultiplied, gputypes.CompositeAlphaModeUnpremultiplied} {
+		if c.SupportsAlphaMode(m) {
+			return m, true
+		}
+	}
+	return gputypes.CompositeAlphaModeAuto, false
+}
+
+// SupportsUsage reports whether surface textures can have every usage in
+// usage.
+func (c *SurfaceCapabilities) SupportsUsage(usage gputypes.TextureUsage) bool {
+	return c.Usages&usage == usage
+}
+
+// PreferredFormat returns the format to configure the surface with on this
+// adapter: the adapter's first-listed format, in its linear (non-sRGB)
+// variant when the surface supports both. This matches the browser's
+// navigator.gpu.getPreferredCanvasFormat; to have the hardware encode
+// linear shader output to sRGB, use [Surface.PreferredSrgbFormat], or keep
+// this format and list its sRGB counterpart in
+// [SurfaceConfiguration.ViewFormats] to render through an sRGB view.
+func (s *Surface) PreferredFormat(adapter *Adapter) (gputypes.TextureFormat, error) {
+	return s.preferredFormat("Surface.PreferredFormat", adapter, false)
+}
+
+// PreferredSrgbFormat is [Surface.PreferredFormat] in the sRGB variant when
+// the surface supports it, for shaders that output linear color.
+func (s *Surface) PreferredSrgbFormat(adapter *Adapter) (gputypes.TextureFormat, error) {
+	return s.preferredFormat("Surface.PreferredSrgbFormat", adapter, true)
+}
+
+func (s *Surface) preferredFormat(op string, adapter *Adapter, srgb bool) (gputypes.TextureFormat, error) {
+	caps, err := s.GetCapabilities(adapter)
+	if err != nil {
+		return gputypes.TextureFormatUndefined, err
+	}
+	format, ok := caps.preferredFormat(srgb)
+	if !ok {
+		return gputypes.TextureFormatUndefined, &WGPUError{Op: op, Message: "surface reports no supported formats"}
+	}
+	if format.IsSrgb() != srgb {
+		logger().Warn("wgpu: surface has no format of the requested color encoding; using the preferred one",
+			"op", op, "srgb", srgb, "format", format)
+	}
+	return format, nil
+}
+
+// preferredFormat returns the first format of c, switched to its sRGB or
+// linear counterpart as srgb asks when c supports the counterpart.
+func (c *SurfaceCapabilities) preferredFormat(srgb bool) (gputypes.TextureFormat, bool) {
+	if len(c.Formats) == 0 {
+		return gputypes.TextureFormatUndefined, false
+	}
+	format := c.Formats[0]
+	if format.IsSrgb() != srgb {
+		if pair, ok := TextureFormatSrgbCounterpart(format); ok && c.SupportsFormat(pair) {
+			return pair, true
+		}
+	}
+	return format, true
 }
@@ -23,7 +23,7 @@ func (inst *Instance) CreateSurfaceFromAndroidNativeWindow(window uintptr) (*Sur
 	}
 	desc := surfaceDescriptor{
 		nextInChain: uintptr(unsafe.Pointer(&source)),
-		label:       EmptyStringView(),
+		label:       stringToStringView("Android surface"),
 	}
 
 	handle, _, _ := procInstanceCreateSurface.Call(
@@ -34,6 +34,6 @@ func (inst *Instance) CreateSurfaceFromAndroidNativeWindow(window uintptr) (*Sur
 		return nil, &WGPUError{Op: "CreateSurface", Message: "failed to create surface"}
 	}
 
-	trackResource(handle, "Surface")
+	trackResource(handle, "Surface", "Android surface")
 	return &Surface{handle: handle}, nil
 }
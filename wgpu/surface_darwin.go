@@ -3,6 +3,9 @@
 package wgpu
 
 import (
+	"fmt"
+	"runtime"
+	"sync"
 	"unsafe"
 )
 
@@ -21,6 +24,9 @@ func (inst *Instance) CreateSurfaceFromMetalLayer(layer uintptr) (*Surface, erro
 	if inst == nil || inst.handle == 0 {
 		return nil, &WGPUError{Op: "CreateSurface", Message: "instance is nil or released"}
 	}
+	if layer == 0 {
+		return nil, &WGPUError{Op: "CreateSurface", Type: ErrorTypeValidation, Message: "metal layer is nil"}
+	}
 
 	// Build WGPUSurfaceSourceMetalLayer
 	source := surfaceSourceMetalLayer{
@@ -34,7 +40,7 @@ func (inst *Instance) CreateSurfaceFromMetalLayer(layer uintptr) (*Surface, erro
 	// Build WGPUSurfaceDescriptor with source chained
 	desc := surfaceDescriptor{
 		nextInChain: uintptr(unsafe.Pointer(&source)),
-		label:       EmptyStringView(),
+		label:       stringToStringView("Metal layer surface"),
 	}
 
 	handle, _, _ := procInstanceCreateSurface.Call(
@@ -45,6 +51,131 @@ func (inst *Instance) CreateSurfaceFromMetalLayer(layer uintptr) (*Surface, erro
 		return nil, &WGPUError{Op: "CreateSurface", Message: "failed to create surface"}
 	}
 
-	trackResource(handle, "Surface")
+	trackResource(handle, "Surface", "Metal layer surface")
 	return &Surface{handle: handle}, nil
 }
+
+// CreateSurfaceFromNSView creates a surface for an NSView, making the view
+// layer-hosting with a new CAMetalLayer unless its layer already is one.
+// view is an NSView pointer, such as the one returned by GLFW's
+// glfwGetCocoaView.
+//
+// AppKit requires this to be called on the main thread.
+func (inst *Instance) CreateSurfaceFromNSView(view uintptr) (*Surface, error) {
+	if err := checkInit(); err != nil {
+		return nil, err
+	}
+	if inst == nil || inst.handle == 0 {
+		return nil, &WGPUError{Op: "CreateSurface", Message: "instance is nil or released"}
+	}
+	if view == 0 {
+		return nil, &WGPUError{Op: "CreateSurface", Type: ErrorTypeValidation, Message: "NSView is nil"}
+	}
+	layer, err := metalLayerForView(view)
+	if err != nil {
+		return nil, &WGPUError{Op: "CreateSurface", Message: err.Error()}
+	}
+	return inst.CreateSurfaceFromMetalLayer(layer)
+}
+
+// CreateSurfaceFromNSWindow is [Instance.CreateSurfaceFromNSView] for the
+// content view of an NSWindow, such as the one returned by GLFW's
+// glfwGetCocoaWindow.
+//
+// AppKit requires this to be called on the main thread.
+func (inst *Instance) CreateSurfaceFromNSWindow(window uintptr) (*Surface, error) {
+	if err := checkInit(); err != nil {
+		return nil, err
+	}
+	if window == 0 {
+		return nil, &WGPUError{Op: "CreateSurface", Type: ErrorTypeValidation, Message: "NSWindow is nil"}
+	}
+	rt, err := loadObjC()
+	if err != nil {
+		return nil, &WGPUError{Op: "CreateSurface", Message: err.Error()}
+	}
+	view := rt.send(window, "contentView")
+	if view == 0 {
+		return nil, &WGPUError{Op: "CreateSurface", Type: ErrorTypeValidation, Message: "NSWindow has no content view"}
+	}
+	return inst.CreateSurfaceFromNSView(view)
+}
+
+// metalLayerForView returns the CAMetalLayer backing view, attaching a new
+// one if the view has no layer or a layer of another class.
+func metalLayerForView(view uintptr) (uintptr, error) {
+	rt, err := loadObjC()
+	if err != nil {
+		return 0, err
+	}
+	class := rt.class("CAMetalLayer")
+	if class == 0 {
+		return 0, fmt.Errorf("CAMetalLayer class not found")
+	}
+	if layer := rt.send(view, "layer"); layer != 0 && rt.send(layer, "isKindOfClass:", class)&0xff != 0 {
+		return layer, nil
+	}
+	// [CAMetalLayer layer] is autoreleased; setLayer: retains it. Setting
+	// the layer before wantsLayer makes the view layer-hosting, so AppKit
+	// leaves the layer to us instead of replacing it.
+	layer := rt.send(class, "layer")
+	if layer == 0 {
+		return 0, fmt.Errorf("failed to create CAMetalLayer")
+	}
+	rt.send(view, "setLayer:", layer)
+	rt.send(view, "setWantsLayer:", 1)
+	return layer, nil
+}
+
+// objcRuntime calls into the Objective-C runtime. objc_msgSend has a
+// separate Proc per argument count because each Proc prepares its call
+// interface for the argument count of its first call.
+type objcRuntime struct {
+	getClass     Proc
+	registerName Proc
+	msgSend      [2]Proc // indexed by argument count after the selector
+}
+
+var (
+	objcOnce sync.Once
+	objcRT   *objcRuntime
+	objcErr  error
+)
+
+// loadObjC loads libobjc and QuartzCore, which defines CAMetalLayer.
+func loadObjC() (*objcRuntime, error) {
+	objcOnce.Do(func() {
+		lib, err := loadLibrary("/usr/lib/libobjc.A.dylib")
+		if err != nil {
+			objcErr = fmt.Errorf("load Objective-C runtime: %w", err)
+			return
+		}
+		if _, err := loadLibrary("/System/Library/Frameworks/QuartzCore.framework/QuartzCore"); err != nil {
+			objcErr = fmt.Errorf("load QuartzCore: %w", err)
+			return
+		}
+		objcRT = &objcRuntime{
+			getClass:     lib.NewProc("objc_getClass"),
+			registerName: lib.NewProc("sel_registerName"),
+			msgSend:      [2]Proc{lib.NewProc("objc_msgSend"), lib.NewProc("objc_msgSend")},
+		}
+	})
+	return objcRT, objcErr
+}
+
+// class returns the class named name, or 0.
+func (rt *objcRuntime) class(name string) uintptr {
+	cname := append([]byte(name), 0)
+	c, _, _ := rt.getClass.Call(uintptr(unsafe.Pointer(&cname[0])))
+	runtime.KeepAlive(cname)
+	return c
+}
+
+// send sends the message sel with at most one pointer-sized argument to obj.
+func (rt *objcRuntime) send(obj uintptr, sel string, args ...uintptr) uintptr {
+	csel := append([]byte(sel), 0)
+	s, _, _ := rt.registerName.Call(uintptr(unsafe.Pointer(&csel[0])))
+	runtime.KeepAlive(csel)
+	r, _, _ := rt.msgSend[len(args)].Call(append([]uintptr{obj, s}, args...)...)
+	return r
+}
@@ -0,0 +1,9 @@
+//go:build darwin
+
+package wgpu
+
+var (
+	_ func(*Instance, uintptr) (*Surface, error) = (*Instance).CreateSurfaceFromMetalLayer
+	_ func(*Instance, uintptr) (*Surface, error) = (*Instance).CreateSurfaceFromNSView
+	_ func(*Instance, uintptr) (*Surface, error) = (*Instance).CreateSurfaceFromNSWindow
+)
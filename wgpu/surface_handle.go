@@ -0,0 +1,111 @@
+package wgpu
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// WindowHandleKind identifies the windowing system of a [RawWindowHandle].
+type WindowHandleKind uint32
+
+const (
+	// WindowHandleUnknown is the zero kind; it is never supported.
+	WindowHandleUnknown WindowHandleKind = iota
+	// WindowHandleWin32 is a Windows window. Display is the HINSTANCE
+	// (may be 0), Window the HWND.
+	WindowHandleWin32
+	// WindowHandleXlib is an X11 window. Display is the Xlib Display*,
+	// Window the Window XID.
+	WindowHandleXlib
+	// WindowHandleXCB is an X11 window. Display is the xcb_connection_t*,
+	// Window the xcb_window_t.
+	WindowHandleXCB
+	// WindowHandleWayland is a Wayland surface. Display is the wl_display*,
+	// Window the wl_surface*.
+	WindowHandleWayland
+	// WindowHandleAppKit is a macOS view. Window is the NSView*; a
+	// CAMetalLayer is attached to it as by [Instance.CreateSurfaceFromNSView].
+	WindowHandleAppKit
+	// WindowHandleMetalLayer is a CAMetalLayer*, held in Window.
+	WindowHandleMetalLayer
+	// WindowHandleAndroid is an Android window. Window is the ANativeWindow*.
+	WindowHandleAndroid
+	// WindowHandleSwapChainPanel is a WinUI SwapChainPanel. Window is its
+	// ISwapChainPanelNative*.
+	WindowHandleSwapChainPanel
+)
+
+// String returns the name of the windowing system.
+func (k WindowHandleKind) String() string {
+	switch k {
+	case WindowHandleUnknown:
+		return "unknown"
+	case WindowHandleWin32:
+		return "Win32"
+	case WindowHandleXlib:
+		return "Xlib"
+	case WindowHandleXCB:
+		return "XCB"
+	case WindowHandleWayland:
+		return "Wayland"
+	case WindowHandleAppKit:
+		return "AppKit"
+	case WindowHandleMetalLayer:
+		return "Metal layer"
+	case WindowHandleAndroid:
+		return "Android"
+	case WindowHandleSwapChainPanel:
+		return "SwapChainPanel"
+	}
+	return fmt.Sprintf("WindowHandleKind(%d)", uint32(k))
+}
+
+// RawWindowHandle is a platform window, in the spirit of Rust's
+// raw-window-handle crate: a kind plus the display connection and window
+// pointers or IDs that kind needs. It implements [WindowHandle] itself.
+type RawWindowHandle struct {
+	Kind    WindowHandleKind
+	Display uintptr
+	Window  uintptr
+}
+
+// RawWindowHandle returns h.
+func (h RawWindowHandle) RawWindowHandle() (RawWindowHandle, error) { return h, nil }
+
+// WindowHandle is implemented by windows that a surface can be created for.
+// Windowing libraries implement it once instead of calling the
+// platform-specific CreateSurfaceFrom* constructors.
+type WindowHandle interface {
+	// RawWindowHandle returns the native window. It may fail, for example
+	// when the window has not been realized yet.
+	RawWindowHandle() (RawWindowHandle, error)
+}
+
+// CreateSurface creates a surface for handle, dispatching on its kind to
+// the matching CreateSurfaceFrom* constructor. Kinds the current platform
+// does not support are reported as validation errors.
+func (inst *Instance) CreateSurface(handle WindowHandle) (*Surface, error) {
+	if err := checkInit(); err != nil {
+		return nil, err
+	}
+	if inst == nil || inst.handle == 0 {
+		return nil, &WGPUError{Op: "CreateSurface", Message: "instance is nil or released"}
+	}
+	if handle == nil {
+		return nil, &WGPUError{Op: "CreateSurface", Type: ErrorTypeValidation, Message: "window handle is nil"}
+	}
+	h, err := handle.RawWindowHandle()
+	if err != nil {
+		return nil, &WGPUError{Op: "CreateSurface", Message: fmt.Sprintf("window handle: %v", err)}
+	}
+	return inst.createSurfaceFromHandle(h)
+}
+
+// unsupportedWindowHandle is returned for kinds the platform cannot present to.
+func unsupportedWindowHandle(h RawWindowHandle) error {
+	return &WGPUError{
+		Op:      "CreateSurface",
+		Type:    ErrorTypeValidation,
+		Message: fmt.Sprintf("%v windows are not supported on %s", h.Kind, runtime.GOOS),
+	}
+}
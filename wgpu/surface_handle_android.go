@@ -0,0 +1,11 @@
+//go:build android
+
+package wgpu
+
+func (inst *Instance) createSurfaceFromHandle(h RawWindowHandle) (*Surface, error) {
+	switch h.Kind {
+	case WindowHandleAndroid:
+		return inst.CreateSurfaceFromAndroidNativeWindow(h.Window)
+	}
+	return nil, unsupportedWindowHandle(h)
+}
@@ -0,0 +1,13 @@
+//go:build darwin
+
+package wgpu
+
+func (inst *Instance) createSurfaceFromHandle(h RawWindowHandle) (*Surface, error) {
+	switch h.Kind {
+	case WindowHandleAppKit:
+		return inst.CreateSurfaceFromNSView(h.Window)
+	case WindowHandleMetalLayer:
+		return inst.CreateSurfaceFromMetalLayer(h.Window)
+	}
+	return nil, unsupportedWindowHandle(h)
+}
@@ -0,0 +1,27 @@
+//go:build linux && !android
+
+package wgpu
+
+import (
+	"fmt"
+	"math"
+)
+
+func (inst *Instance) createSurfaceFromHandle(h RawWindowHandle) (*Surface, error) {
+	switch h.Kind {
+	case WindowHandleXlib:
+		return inst.CreateSurfaceFromXlibWindow(h.Display, uint64(h.Window))
+	case WindowHandleXCB:
+		if h.Window > math.MaxUint32 {
+			return nil, &WGPUError{
+				Op:      "CreateSurface",
+				Type:    ErrorTypeValidation,
+				Message: fmt.Sprintf("XCB window %#x does not fit in xcb_window_t", h.Window),
+			}
+		}
+		return inst.CreateSurfaceFromXCBWindow(h.Display, uint32(h.Window))
+	case WindowHandleWayland:
+		return inst.CreateSurfaceFromWaylandSurface(h.Display, h.Window)
+	}
+	return nil, unsupportedWindowHandle(h)
+}
@@ -0,0 +1,45 @@
+package wgpu
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// glfwWindow stands in for a windowing library's window type.
+type glfwWindow struct{ display, window uintptr }
+
+func (w *glfwWindow) RawWindowHandle() (RawWindowHandle, error) {
+	if w.window == 0 {
+		return RawWindowHandle{}, errors.New("window not realized")
+	}
+	return RawWindowHandle{Kind: WindowHandleXlib, Display: w.display, Window: w.window}, nil
+}
+
+var (
+	_ WindowHandle = RawWindowHandle{}
+	_ WindowHandle = (*glfwWindow)(nil)
+)
+
+func TestWindowHandleKindString(t *testing.T) {
+	if got := WindowHandleXCB.String(); got != "XCB" {
+		t.Errorf("WindowHandleXCB = %q, want XCB", got)
+	}
+	if got := WindowHandleSwapChainPanel.String(); got != "SwapChainPanel" {
+		t.Errorf("WindowHandleSwapChainPanel = %q, want SwapChainPanel", got)
+	}
+	if got := WindowHandleKind(99).String(); got != "WindowHandleKind(99)" {
+		t.Errorf("unknown kind = %q", got)
+	}
+}
+
+func TestUnsupportedWindowHandle(t *testing.T) {
+	err := unsupportedWindowHandle(RawWindowHandle{Kind: WindowHandleAndroid})
+	var wgpuErr *WGPUError
+	if !errors.As(err, &wgpuErr) || wgpuErr.Type != ErrorTypeValidation {
+		t.Fatalf("err = %v, want a validation WGPUError", err)
+	}
+	if !strings.Contains(err.Error(), "Android") {
+		t.Errorf("err = %v, want it to name the window kind", err)
+	}
+}
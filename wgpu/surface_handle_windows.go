@@ -0,0 +1,13 @@
+//go:build windows
+
+package wgpu
+
+func (inst *Instance) createSurfaceFromHandle(h RawWindowHandle) (*Surface, error) {
+	switch h.Kind {
+	case WindowHandleWin32:
+		return inst.CreateSurfaceFromWindowsHWND(h.Display, h.Window)
+	case WindowHandleSwapChainPanel:
+		return inst.CreateSurfaceFromSwapChainPanel(h.Window)
+	}
+	return nil, unsupportedWindowHandle(h)
+}
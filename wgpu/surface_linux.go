@@ -6,13 +6,6 @@ import (
 	"unsafe"
 )
 
-// surfaceSourceXlibWindow is the native structure for X11 via Xlib surface creation - 32 bytes.
-type surfaceSourceXlibWindow struct {
-	chain   ChainedStruct // 16 bytes: next (8) + sType (4) + padding (4)
-	display uintptr       // 8 bytes - Display*
-	window  uint64        // 8 bytes - Window (XID on 64-bit)
-}
-
 // surfaceSourceWaylandSurface is the native structure for Wayland surface creation - 32 bytes.
 type surfaceSourceWaylandSurface struct {
 	chain   ChainedStruct // 16 bytes: next (8) + sType (4) + padding (4)
@@ -22,7 +15,10 @@ type surfaceSourceWaylandSurface struct {
 
 // CreateSurfaceFromXlibWindow creates a surface from an X11 Xlib window.
 // display is the X11 Display pointer.
-// window is the X11 Window ID (XID).
+// window is the X11 Window ID (XID), e.g. from GLFW's glfwGetX11Window or
+// SDL's SDL_PROP_WINDOW_X11_WINDOW_NUMBER property.
+// The caller must keep the display open until the returned Surface is
+// released.
 func (inst *Instance) CreateSurfaceFromXlibWindow(display uintptr, window uint64) (*Surface, error) {
 	if err := checkInit(); err != nil {
 		return nil, err
@@ -31,20 +27,52 @@ func (inst *Instance) CreateSurfaceFromXlibWindow(display uintptr, window uint64
 		return nil, &WGPUError{Op: "CreateSurface", Message: "instance is nil or released"}
 	}
 
-	// Build WGPUSurfaceSourceXlibWindow
-	source := surfaceSourceXlibWindow{
-		chain: ChainedStruct{
-			Next:  0,
-			SType: uint32(STypeSurfaceSourceXlibWindow),
-		},
-		display: display,
-		window:  window,
+	source, err := newSurfaceSourceXlibWindow(display, window)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build WGPUSurfaceDescriptor with source chained
+	desc := surfaceDescriptor{
+		nextInChain: uintptr(unsafe.Pointer(&source)),
+		label:       stringToStringView("Xlib surface"),
+	}
+
+	handle, _, _ := procInstanceCreateSurface.Call(
+		inst.handle,
+		uintptr(unsafe.Pointer(&desc)),
+	)
+	if handle == 0 {
+		return nil, &WGPUError{Op: "CreateSurface", Message: "failed to create surface"}
+	}
+
+	trackResource(handle, "Surface", "Xlib surface")
+	return &Surface{handle: handle}, nil
+}
+
+// CreateSurfaceFromXCBWindow creates a surface from an X11 window managed
+// through XCB.
+// connection is the xcb_connection_t pointer.
+// window is the xcb_window_t ID.
+// The caller must keep the connection open until the returned Surface is
+// released.
+func (inst *Instance) CreateSurfaceFromXCBWindow(connection uintptr, window uint32) (*Surface, error) {
+	if err := checkInit(); err != nil {
+		return nil, err
+	}
+	if inst == nil || inst.handle == 0 {
+		return nil, &WGPUError{Op: "CreateSurface", Message: "instance is nil or released"}
+	}
+
+	source, err := newSurfaceSourceXCBWindow(connection, window)
+	if err != nil {
+		return nil, err
 	}
 
 	// Build WGPUSurfaceDescriptor with source chained
 	desc := surfaceDescriptor{
 		nextInChain: uintptr(unsafe.Pointer(&source)),
-		label:       EmptyStringView(),
+		label:       stringToStringView("XCB surface"),
 	}
 
 	handle, _, _ := procInstanceCreateSurface.Call(
@@ -55,7 +83,7 @@ func (inst *Instance) CreateSurfaceFromXlibWindow(display uintptr, window uint64
 		return nil, &WGPUError{Op: "CreateSurface", Message: "failed to create surface"}
 	}
 
-	trackResource(handle, "Surface")
+	trackResource(handle, "Surface", "XCB surface")
 	return &Surface{handle: handle}, nil
 }
 
@@ -83,7 +111,7 @@ func (inst *Instance) CreateSurfaceFromWaylandSurface(display, surface uintptr)
 	// Build WGPUSurfaceDescriptor with source chained
 	desc := surfaceDescriptor{
 		nextInChain: uintptr(unsafe.Pointer(&source)),
-		label:       EmptyStringView(),
+		label:       stringToStringView("Wayland surface"),
 	}
 
 	handle, _, _ := procInstanceCreateSurface.Call(
@@ -94,6 +122,6 @@ func (inst *Instance) CreateSurfaceFromWaylandSurface(display, surface uintptr)
 		return nil, &WGPUError{Op: "CreateSurface", Message: "failed to create surface"}
 	}
 
-	trackResource(handle, "Surface")
+	trackResource(handle, "Surface", "Wayland surface")
 	return &Surface{handle: handle}, nil
 }
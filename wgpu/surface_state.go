@@ -0,0 +1,100 @@
+package wgpu
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Configuration returns the configuration of the last successful
+// [Surface.Configure], with the device it was configured for. ok is false
+// while the surface is unconfigured. Editors driving one device across
+// several windows can use it to tell which surfaces need reconfiguring.
+func (s *Surface) Configuration() (config SurfaceConfiguration, device *Device, ok bool) {
+	if s == nil {
+		return SurfaceConfiguration{}, nil, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.config == nil {
+		return SurfaceConfiguration{}, nil, false
+	}
+	config = *s.config
+	config.ViewFormats = slices.Clone(config.ViewFormats)
+	return config, s.device, true
+}
+
+// Present presents every surface that has an acquired texture, typically
+// all windows of an application after one queue submission. Each surface
+// is presented even if another fails; the errors are joined. Surfaces
+// without an acquired texture are reported as errors too.
+func Present(surfaces ...*Surface) error {
+	var errs []error
+	for i, s := range surfaces {
+		if s == nil || s.handle == 0 {
+			errs = append(errs, &WGPUError{Op: "Present", Message: fmt.Sprintf("surface %d is nil or released", i)})
+			continue
+		}
+		if err := s.Present(); err != nil {
+			errs = append(errs, fmt.Errorf("surface %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// configured records a successful configuration.
+func (s *Surface) configured(config *SurfaceConfiguration, device *Device) {
+	c := *config
+	c.Device = nil
+	c.ViewFormats = slices.Clone(config.ViewFormats)
+	s.mu.Lock()
+	s.config, s.device = &c, device
+	s.suboptimalLogged = false
+	s.mu.Unlock()
+}
+
+// unconfigured forgets the configuration and any acquired texture.
+func (s *Surface) unconfigured() {
+	s.mu.Lock()
+	s.config, s.device = nil, nil
+	s.acquired = false
+	s.mu.Unlock()
+}
+
+// checkAcquire reports why a texture cannot be acquired. s.mu must be held.
+func (s *Surface) checkAcquire() error {
+	switch {
+	case s.config == nil:
+		return errors.New("surface is not configured")
+	case s.acquired:
+		return errors.New("a texture is already acquired; present or release it first")
+	}
+	return nil
+}
+
+// acquire records an acquired texture and returns its acquisition number.
+// s.mu must be held.
+func (s *Surface) acquire() uint64 {
+	s.frame++
+	s.acquired = true
+	return s.frame
+}
+
+// present ends the current acquisition. s.mu must be held.
+func (s *Surface) present() error {
+	if !s.acquired {
+		return errors.New("no texture acquired since the last present")
+	}
+	s.acquired = false
+	return nil
+}
+
+// discard ends acquisition frame when its texture is released unpresented;
+// wgpu-native then discards the texture.
+func (s *Surface) discard(frame uint64) {
+	s.mu.Lock()
+	if s.acquired && s.frame == frame {
+		s.acquired = false
+	}
+	s.mu.Unlock()
+}
@@ -0,0 +1,70 @@
+package wgpu
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestSurfaceAcquireState(t *testing.T) {
+	s := &Surface{handle: 1} // fake handle
+	if err := s.checkAcquire(); err == nil {
+		t.Fatal("acquire allowed on an unconfigured surface")
+	}
+
+	dev := &Device{handle: 2}
+	s.configured(&SurfaceConfiguration{
+		Device:      dev,
+		Format:      gputypes.TextureFormatBGRA8Unorm,
+		Width:       640,
+		Height:      480,
+		ViewFormats: []gputypes.TextureFormat{gputypes.TextureFormatBGRA8UnormSrgb},
+	}, dev)
+	config, device, ok := s.Configuration()
+	if !ok || device != dev || config.Width != 640 || config.Device != nil || len(config.ViewFormats) != 1 {
+		t.Fatalf("Configuration() = %+v, %v, %v", config, device, ok)
+	}
+
+	if err := s.checkAcquire(); err != nil {
+		t.Fatalf("checkAcquire: %v", err)
+	}
+	if err := s.present(); err == nil {
+		t.Fatal("present allowed without an acquired texture")
+	}
+	first := s.acquire()
+	if err := s.checkAcquire(); err == nil {
+		t.Fatal("second acquire allowed before present")
+	}
+	if err := s.present(); err != nil {
+		t.Fatalf("present: %v", err)
+	}
+
+	// Releasing a presented texture must not end a later acquisition.
+	second := s.acquire()
+	s.discard(first)
+	if !s.acquired {
+		t.Fatal("stale discard ended the current acquisition")
+	}
+	s.discard(second)
+	if err := s.checkAcquire(); err != nil {
+		t.Fatalf("checkAcquire after discard: %v", err)
+	}
+
+	s.unconfigured()
+	if _, _, ok := s.Configuration(); ok {
+		t.Error("Configuration() ok after unconfigure")
+	}
+}
+
+func TestPresentSurfacesNil(t *testing.T) {
+	err := Present(nil, &Surface{})
+	if err == nil {
+		t.Fatal("Present(nil, released) = nil")
+	}
+	for _, want := range []string{"surface 0", "surface 1"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+}
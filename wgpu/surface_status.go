@@ -0,0 +1,72 @@
+package wgpu
+
+import "fmt"
+
+// String returns the status name as in the WebGPU header.
+func (s SurfaceGetCurrentTextureStatus) String() string {
+	switch s {
+	case SurfaceGetCurrentTextureStatusSuccessOptimal:
+		return "SuccessOptimal"
+	case SurfaceGetCurrentTextureStatusSuccessSuboptimal:
+		return "SuccessSuboptimal"
+	case SurfaceGetCurrentTextureStatusTimeout:
+		return "Timeout"
+	case SurfaceGetCurrentTextureStatusOutdated:
+		return "Outdated"
+	case SurfaceGetCurrentTextureStatusLost:
+		return "Lost"
+	case SurfaceGetCurrentTextureStatusError:
+		return "Error"
+	case NativeSurfaceGetCurrentTextureStatusOccluded:
+		return "Occluded"
+	}
+	return fmt.Sprintf("SurfaceGetCurrentTextureStatus(%#x)", uint32(s))
+}
+
+// Err returns the sentinel error of the status:
+//
+//   - SuccessOptimal: nil
+//   - SuccessSuboptimal: [ErrSurfaceSuboptimal]; render, then reconfigure
+//   - Outdated: [ErrSurfaceNeedsReconfigure]; reconfigure and retry
+//   - Lost: [ErrSurfaceLost]; reconfigure, or recreate the surface
+//   - Timeout: [ErrSurfaceTimeout]; skip the frame
+//   - Occluded: [ErrSurfaceOccluded]; skip the frame
+//   - Error and unknown statuses: [ErrSurfaceFailed]; fatal for the surface
+func (s SurfaceGetCurrentTextureStatus) Err() error {
+	switch s {
+	case SurfaceGetCurrentTextureStatusSuccessOptimal:
+		return nil
+	case SurfaceGetCurrentTextureStatusSuccessSuboptimal:
+		return ErrSurfaceSuboptimal
+	case SurfaceGetCurrentTextureStatusOutdated:
+		return ErrSurfaceNeedsReconfigure
+	case SurfaceGetCurrentTextureStatusLost:
+		return ErrSurfaceLost
+	case SurfaceGetCurrentTextureStatusTimeout:
+		return ErrSurfaceTimeout
+	case NativeSurfaceGetCurrentTextureStatusOccluded:
+		// wgpu-native v29: window is occluded/minimized (Metal backend only).
+		return ErrSurfaceOccluded
+	}
+	// v29: SurfaceGetCurrentTextureStatusError (0x06) covers all error cases
+	// including former OutOfMemory (0x06) and DeviceLost (0x07).
+	return ErrSurfaceFailed
+}
+
+// NeedsReconfigure reports whether the surface should be configured again:
+// the status is SuccessSuboptimal, Outdated or Lost.
+func (s SurfaceGetCurrentTextureStatus) NeedsReconfigure() bool {
+	switch s {
+	case SurfaceGetCurrentTextureStatusSuccessSuboptimal,
+		SurfaceGetCurrentTextureStatusOutdated,
+		SurfaceGetCurrentTextureStatusLost:
+		return true
+	}
+	return false
+}
+
+// IsFatal reports whether the status is one reconfiguring cannot recover
+// from: Error or an unknown status.
+func (s SurfaceGetCurrentTextureStatus) IsFatal() bool {
+	return s.Err() == ErrSurfaceFailed
+}
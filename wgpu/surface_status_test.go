@@ -0,0 +1,34 @@
+package wgpu
+
+import "testing"
+
+func TestSurfaceGetCurrentTextureStatus(t *testing.T) {
+	tests := []struct {
+		status      SurfaceGetCurrentTextureStatus
+		err         error
+		reconfigure bool
+		fatal       bool
+	}{
+		{SurfaceGetCurrentTextureStatusSuccessOptimal, nil, false, false},
+		{SurfaceGetCurrentTextureStatusSuccessSuboptimal, ErrSurfaceSuboptimal, true, false},
+		{SurfaceGetCurrentTextureStatusTimeout, ErrSurfaceTimeout, false, false},
+		{SurfaceGetCurrentTextureStatusOutdated, ErrSurfaceNeedsReconfigure, true, false},
+		{SurfaceGetCurrentTextureStatusLost, ErrSurfaceLost, true, false},
+		{SurfaceGetCurrentTextureStatusError, ErrSurfaceFailed, false, true},
+		{NativeSurfaceGetCurrentTextureStatusOccluded, ErrSurfaceOccluded, false, false},
+		{SurfaceGetCurrentTextureStatus(0x42), ErrSurfaceFailed, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			if err := tt.status.Err(); err != tt.err {
+				t.Errorf("Err() = %v, want %v", err, tt.err)
+			}
+			if got := tt.status.NeedsReconfigure(); got != tt.reconfigure {
+				t.Errorf("NeedsReconfigure() = %v, want %v", got, tt.reconfigure)
+			}
+			if got := tt.status.IsFatal(); got != tt.fatal {
+				t.Errorf("IsFatal() = %v, want %v", got, tt.fatal)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package wgpu
+
+// surfaceSourceSwapChainPanel matches WGPUSurfaceSourceSwapChainPanel in the
+// wgpu-native v29 header - 24 bytes. It is host-buildable so ordinary CI can
+// verify the layout without a Windows toolchain.
+type surfaceSourceSwapChainPanel struct {
+	chain       ChainedStruct // 16 bytes: next (8) + sType (4) + padding (4)
+	panelNative uintptr       // 8 bytes - ISwapChainPanelNative*
+}
+
+func newSurfaceSourceSwapChainPanel(panelNative uintptr) (surfaceSourceSwapChainPanel, error) {
+	if panelNative == 0 {
+		return surfaceSourceSwapChainPanel{}, &WGPUError{
+			Op:      "CreateSurface",
+			Message: "SwapChainPanel is nil",
+		}
+	}
+
+	return surfaceSourceSwapChainPanel{
+		chain: ChainedStruct{
+			Next:  0,
+			SType: uint32(STypeSurfaceSourceSwapChainPanel),
+		},
+		panelNative: panelNative,
+	}, nil
+}
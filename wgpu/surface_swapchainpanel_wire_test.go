@@ -0,0 +1,32 @@
+package wgpu
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestABISurfaceSourceSwapChainPanel(t *testing.T) {
+	source, err := newSurfaceSourceSwapChainPanel(0x1234)
+	if err != nil {
+		t.Fatalf("newSurfaceSourceSwapChainPanel: %v", err)
+	}
+
+	if got := unsafe.Sizeof(source); got != 24 {
+		t.Fatalf("sizeof(surfaceSourceSwapChainPanel) = %d, want 24", got)
+	}
+	if got := unsafe.Offsetof(source.panelNative); got != 16 {
+		t.Fatalf("offsetof(panelNative) = %d, want 16", got)
+	}
+	if source.chain.SType != uint32(STypeSurfaceSourceSwapChainPanel) {
+		t.Fatalf("chain.SType = %#x, want %#x", source.chain.SType, uint32(STypeSurfaceSourceSwapChainPanel))
+	}
+	if source.panelNative != 0x1234 {
+		t.Fatalf("panelNative = %#x", source.panelNative)
+	}
+}
+
+func TestSurfaceSourceSwapChainPanelRejectsZero(t *testing.T) {
+	if _, err := newSurfaceSourceSwapChainPanel(0); err == nil {
+		t.Error("nil panel accepted")
+	}
+}
@@ -1,7 +1,11 @@
 package wgpu
 
 import (
+	"runtime"
 	"testing"
+	"unsafe"
+
+	"github.com/gogpu/gputypes"
 )
 
 // TestSurfaceGetCapabilities_NilSurface tests nil safety for surface.
@@ -37,3 +41,175 @@ func TestSurfaceGetCapabilities_NilAdapter(t *testing.T) {
 
 // Note: Full integration testing of GetCapabilities requires a real window surface,
 // which is tested in the examples (e.g., examples/triangle).
+
+func TestCapabilitiesFromWire(t *testing.T) {
+	formats := []uint32{uint32(gputypes.TextureFormatBGRA8UnormSrgb), uint32(gputypes.TextureFormatBGRA8Unorm)}
+	modes := []uint32{uint32(gputypes.PresentModeFifo), uint32(gputypes.PresentModeMailbox)}
+	alpha := []uint32{uint32(gputypes.CompositeAlphaModeOpaque)}
+	wire := surfaceCapabilitiesWire{
+		usages:           uint64(gputypes.TextureUsageRenderAttachment | gputypes.TextureUsageCopyDst),
+		formatCount:      uintptr(len(formats)),
+		formats:          uintptr(unsafe.Pointer(&formats[0])),
+		presentModeCount: uintptr(len(modes)),
+		presentModes:     uintptr(unsafe.Pointer(&modes[0])),
+		alphaModeCount:   uintptr(len(alpha)),
+		alphaModes:       uintptr(unsafe.Pointer(&alpha[0])),
+	}
+	caps := capabilitiesFromWire(&wire)
+	runtime.KeepAlive(formats)
+	runtime.KeepAlive(modes)
+	runtime.KeepAlive(alpha)
+
+	if len(caps.Formats) != 2 || caps.Formats[0] != gputypes.TextureFormatBGRA8UnormSrgb {
+		t.Errorf("Formats = %v", caps.Formats)
+	}
+	if !caps.SupportsFormat(gputypes.TextureFormatBGRA8Unorm) || caps.SupportsFormat(gputypes.TextureFormatRGBA16Float) {
+		t.Error("SupportsFormat disagrees with Formats")
+	}
+	if !caps.SupportsPresentMode(gputypes.PresentModeMailbox) || caps.SupportsPresentMode(gputypes.PresentModeImmediate) {
+		t.Error("SupportsPresentMode disagrees with PresentModes")
+	}
+	if !caps.SupportsAlphaMode(gputypes.CompositeAlphaModeOpaque) || caps.SupportsAlphaMode(gputypes.CompositeAlphaModePremultiplied) {
+		t.Error("SupportsAlphaMode disagrees with AlphaModes")
+	}
+	if !caps.SupportsUsage(gputypes.TextureUsageRenderAttachment) ||
+		caps.SupportsUsage(gputypes.TextureUsageRenderAttachment|gputypes.TextureUsageStorageBinding) {
+		t.Error("SupportsUsage disagrees with Usages")
+	}
+}
+
+func TestCapabilitiesFromWireEmpty(t *testing.T) {
+	caps := capabilitiesFromWire(&surfaceCapabilitiesWire{})
+	if caps.Formats != nil || caps.PresentModes != nil || caps.AlphaModes != nil {
+		t.Errorf("caps = %+v, want empty", caps)
+	}
+}
+
+func TestSurfaceCapabilitiesPreferredFormat(t *testing.T) {
+	bgra := gputypes.TextureFormatBGRA8Unorm
+	bgraSrgb := gputypes.TextureFormatBGRA8UnormSrgb
+	rgb10 := gputypes.TextureFormatRGB10A2Unorm
+	tests := []struct {
+		name    string
+		formats []gputypes.TextureFormat
+		srgb    bool
+		want    gputypes.TextureFormat
+		wantOK  bool
+	}{
+		{"linear first, want linear", []gputypes.TextureFormat{bgra, bgraSrgb}, false, bgra, true},
+		{"sRGB first, want linear", []gputypes.TextureFormat{bgraSrgb, bgra}, false, bgra, true},
+		{"linear first, want sRGB", []gputypes.TextureFormat{bgra, bgraSrgb}, true, bgraSrgb, true},
+		{"counterpart unsupported", []gputypes.TextureFormat{bgraSrgb}, false, bgraSrgb, true},
+		{"no counterpart", []gputypes.TextureFormat{rgb10, bgra}, true, rgb10, true},
+		{"empty", nil, false, gputypes.TextureFormatUndefined, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			caps := &SurfaceCapabilities{Formats: tt.formats}
+			got, ok := caps.preferredFormat(tt.srgb)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("preferredFormat(%v) = %v, %v; want %v, %v", tt.srgb, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestValidateSurfaceConfiguration(t *testing.T) {
+	bgra := gputypes.TextureFormatBGRA8Unorm
+	base := SurfaceConfiguration{
+		Format:      bgra,
+		Usage:       gputypes.TextureUsageRenderAttachment,
+		Width:       800,
+		Height:      600,
+		PresentMode: gputypes.PresentModeFifo,
+	}
+	caps := &SurfaceCapabilities{
+		Usages:       gputypes.TextureUsageRenderAttachment | gputypes.TextureUsageCopySrc,
+		Formats:      []gputypes.TextureFormat{bgra, gputypes.TextureFormatBGRA8UnormSrgb},
+		PresentModes: []gputypes.PresentMode{gputypes.PresentModeFifo},
+		AlphaModes:   []gputypes.CompositeAlphaMode{gputypes.CompositeAlphaModeOpaque},
+	}
+	tests := []struct {
+		name    string
+		modify  func(c *SurfaceConfiguration)
+		caps    *SurfaceCapabilities
+		wantErr bool
+	}{
+		{"valid", func(*SurfaceConfiguration) {}, caps, false},
+		{"valid without capabilities", func(c *SurfaceConfiguration) { c.Format = gputypes.TextureFormatRGBA16Float }, nil, false},
+		{"zero width", func(c *SurfaceConfiguration) { c.Width = 0 }, nil, true},
+		{"over the device limit", func(c *SurfaceConfiguration) { c.Height = 16384 }, nil, true},
+		{"undefined format", func(c *SurfaceConfiguration) { c.Format = 0 }, nil, true},
+		{"no usage", func(c *SurfaceConfiguration) { c.Usage = 0 }, nil, true},
+		{"bad view format", func(c *SurfaceConfiguration) {
+			c.ViewFormats = []gputypes.TextureFormat{gputypes.TextureFormatRGBA8Unorm}
+		}, nil, true},
+		{"unsupported format", func(c *SurfaceConfiguration) { c.Format = gputypes.TextureFormatRGBA16Float }, caps, true},
+		{"unsupported usage", func(c *SurfaceConfiguration) { c.Usage |= gputypes.TextureUsageStorageBinding }, caps, true},
+		{"unsupported present mode", func(c *SurfaceConfiguration) { c.PresentMode = gputypes.PresentModeMailbox }, caps, true},
+		{"default present mode", func(c *SurfaceConfiguration) { c.PresentMode = gputypes.PresentModeUndefined }, caps, false},
+		{"unsupported alpha mode", func(c *SurfaceConfiguration) { c.AlphaMode = gputypes.CompositeAlphaModePremultiplied }, caps, true},
+		{"auto alpha mode", func(c *SurfaceConfiguration) { c.AlphaMode = gputypes.CompositeAlphaModeAuto }, caps, false},
+		{"extended tone mapping on 8-bit", func(c *SurfaceConfiguration) { c.ToneMapping = ToneMappingModeExtended }, nil, true},
+		{"extended tone mapping on float16", func(c *SurfaceConfiguration) {
+			c.Format = gputypes.TextureFormatRGBA16Float
+			c.ToneMapping = ToneMappingModeExtended
+		}, nil, false},
+		{"display P3", func(c *SurfaceConfiguration) { c.ColorSpace = PredefinedColorSpaceDisplayP3 }, caps, false},
+		{"capturable", func(c *SurfaceConfiguration) { c.Capturable = true }, caps, false},
+		{"capturable unsupported", func(c *SurfaceConfiguration) { c.Capturable = true }, &SurfaceCapabilities{
+			Usages:  gputypes.TextureUsageRenderAttachment,
+			Formats: caps.Formats,
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := base
+			tt.modify(&config)
+			err := validateSurfaceConfiguration(&config, 8192, tt.caps)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSurfaceCapabilitiesHDRFormat(t *testing.T) {
+	tests := []struct {
+		formats []gputypes.TextureFormat
+		want    gputypes.TextureFormat
+		wantOK  bool
+	}{
+		{[]gputypes.TextureFormat{gputypes.TextureFormatBGRA8Unorm, gputypes.TextureFormatRGB10A2Unorm, gputypes.TextureFormatRGBA16Float},
+			gputypes.TextureFormatRGBA16Float, true},
+		{[]gputypes.TextureFormat{gputypes.TextureFormatBGRA8Unorm, gputypes.TextureFormatRGB10A2Unorm}, gputypes.TextureFormatRGB10A2Unorm, true},
+		{[]gputypes.TextureFormat{gputypes.TextureFormatBGRA8Unorm}, gputypes.TextureFormatUndefined, false},
+	}
+	for _, tt := range tests {
+		caps := &SurfaceCapabilities{Formats: tt.formats}
+		if got, ok := caps.HDRFormat(); got != tt.want || ok != tt.wantOK {
+			t.Errorf("HDRFormat(%v) = %v, %v; want %v, %v", tt.formats, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestSurfaceCapabilitiesTransparentAlphaMode(t *testing.T) {
+	opaque := gputypes.CompositeAlphaModeOpaque
+	pre := gputypes.CompositeAlphaModePremultiplied
+	post := gputypes.CompositeAlphaModeUnpremultiplied
+	tests := []struct {
+		modes  []gputypes.CompositeAlphaMode
+		want   gputypes.CompositeAlphaMode
+		wantOK bool
+	}{
+		{[]gputypes.CompositeAlphaMode{opaque, post, pre}, pre, true},
+		{[]gputypes.CompositeAlphaMode{opaque, post}, post, true},
+		{[]gputypes.CompositeAlphaMode{opaque}, gputypes.CompositeAlphaModeAuto, false},
+	}
+	for _, tt := range tests {
+		caps := &SurfaceCapabilities{AlphaModes: tt.modes}
+		if got, ok := caps.TransparentAlphaMode(); got != tt.want || ok != tt.wantOK {
+			t.Errorf("TransparentAlphaMode(%v) = %v, %v; want %v, %v", tt.modes, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -37,7 +37,7 @@ func (inst *Instance) CreateSurfaceFromWindowsHWND(hinstance, hwnd uintptr) (*Su
 	// Build WGPUSurfaceDescriptor with source chained
 	desc := surfaceDescriptor{
 		nextInChain: uintptr(unsafe.Pointer(&source)),
-		label:       EmptyStringView(),
+		label:       stringToStringView("HWND surface"),
 	}
 
 	handle, _, _ := procInstanceCreateSurface.Call(
@@ -48,6 +48,44 @@ func (inst *Instance) CreateSurfaceFromWindowsHWND(hinstance, hwnd uintptr) (*Su
 		return nil, &WGPUError{Op: "CreateSurface", Message: "failed to create surface"}
 	}
 
-	trackResource(handle, "Surface")
+	trackResource(handle, "Surface", "HWND surface")
+	return &Surface{handle: handle}, nil
+}
+
+// CreateSurfaceFromSwapChainPanel creates a surface from a WinUI
+// SwapChainPanel. panelNative is the panel's ISwapChainPanelNative
+// interface pointer.
+//
+// The swapchain of such a surface is created for DirectComposition rather
+// than for an HWND, which makes it the way to per-pixel transparency on
+// Windows: it requires the DX12 backend and offers
+// CompositeAlphaModePremultiplied, whereas HWND surfaces only composite
+// opaquely. See [SurfaceCapabilities.TransparentAlphaMode].
+func (inst *Instance) CreateSurfaceFromSwapChainPanel(panelNative uintptr) (*Surface, error) {
+	if err := checkInit(); err != nil {
+		return nil, err
+	}
+	if inst == nil || inst.handle == 0 {
+		return nil, &WGPUError{Op: "CreateSurface", Message: "instance is nil or released"}
+	}
+	source, err := newSurfaceSourceSwapChainPanel(panelNative)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := surfaceDescriptor{
+		nextInChain: uintptr(unsafe.Pointer(&source)),
+		label:       stringToStringView("SwapChainPanel surface"),
+	}
+
+	handle, _, _ := procInstanceCreateSurface.Call(
+		inst.handle,
+		uintptr(unsafe.Pointer(&desc)),
+	)
+	if handle == 0 {
+		return nil, &WGPUError{Op: "CreateSurface", Message: "failed to create surface"}
+	}
+
+	trackResource(handle, "Surface", "SwapChainPanel surface")
 	return &Surface{handle: handle}, nil
 }
@@ -0,0 +1,55 @@
+package wgpu
+
+// surfaceSourceXlibWindow matches WGPUSurfaceSourceXlibWindow in the WebGPU
+// native v29 header - 32 bytes. It is host-buildable so ordinary CI can
+// verify the X11 ABI layout.
+type surfaceSourceXlibWindow struct {
+	chain   ChainedStruct // 16 bytes: next (8) + sType (4) + padding (4)
+	display uintptr       // 8 bytes - Display*
+	window  uint64        // 8 bytes - Window (XID on 64-bit)
+}
+
+func newSurfaceSourceXlibWindow(display uintptr, window uint64) (surfaceSourceXlibWindow, error) {
+	if display == 0 || window == 0 {
+		return surfaceSourceXlibWindow{}, &WGPUError{
+			Op:      "CreateSurface",
+			Message: "Xlib display or window is nil",
+		}
+	}
+
+	return surfaceSourceXlibWindow{
+		chain: ChainedStruct{
+			Next:  0,
+			SType: uint32(STypeSurfaceSourceXlibWindow),
+		},
+		display: display,
+		window:  window,
+	}, nil
+}
+
+// surfaceSourceXCBWindow matches WGPUSurfaceSourceXCBWindow in the WebGPU
+// native v29 header - 32 bytes.
+type surfaceSourceXCBWindow struct {
+	chain      ChainedStruct // 16 bytes: next (8) + sType (4) + padding (4)
+	connection uintptr       // 8 bytes - xcb_connection_t*
+	window     uint32        // 4 bytes - xcb_window_t
+	_          [4]byte       // padding
+}
+
+func newSurfaceSourceXCBWindow(connection uintptr, window uint32) (surfaceSourceXCBWindow, error) {
+	if connection == 0 || window == 0 {
+		return surfaceSourceXCBWindow{}, &WGPUError{
+			Op:      "CreateSurface",
+			Message: "XCB connection or window is nil",
+		}
+	}
+
+	return surfaceSourceXCBWindow{
+		chain: ChainedStruct{
+			Next:  0,
+			SType: uint32(STypeSurfaceSourceXCBWindow),
+		},
+		connection: connection,
+		window:     window,
+	}, nil
+}
@@ -0,0 +1,70 @@
+package wgpu
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestABISurfaceSourceXlibWindow(t *testing.T) {
+	source, err := newSurfaceSourceXlibWindow(0x1234, 0x5600001)
+	if err != nil {
+		t.Fatalf("newSurfaceSourceXlibWindow: %v", err)
+	}
+
+	if got := unsafe.Sizeof(source); got != 32 {
+		t.Fatalf("sizeof(surfaceSourceXlibWindow) = %d, want 32", got)
+	}
+	if got := unsafe.Offsetof(source.display); got != 16 {
+		t.Fatalf("offsetof(display) = %d, want 16", got)
+	}
+	if got := unsafe.Offsetof(source.window); got != 24 {
+		t.Fatalf("offsetof(window) = %d, want 24", got)
+	}
+	if source.chain.SType != uint32(STypeSurfaceSourceXlibWindow) {
+		t.Fatalf("chain.SType = %#x, want %#x", source.chain.SType, uint32(STypeSurfaceSourceXlibWindow))
+	}
+	if source.display != 0x1234 || source.window != 0x5600001 {
+		t.Fatalf("display, window = %#x, %#x", source.display, source.window)
+	}
+}
+
+func TestSurfaceSourceXlibWindowRejectsZero(t *testing.T) {
+	if _, err := newSurfaceSourceXlibWindow(0, 1); err == nil {
+		t.Error("nil display accepted")
+	}
+	if _, err := newSurfaceSourceXlibWindow(1, 0); err == nil {
+		t.Error("zero window accepted")
+	}
+}
+
+func TestABISurfaceSourceXCBWindow(t *testing.T) {
+	source, err := newSurfaceSourceXCBWindow(0x1234, 0x3e00002)
+	if err != nil {
+		t.Fatalf("newSurfaceSourceXCBWindow: %v", err)
+	}
+
+	if got := unsafe.Sizeof(source); got != 32 {
+		t.Fatalf("sizeof(surfaceSourceXCBWindow) = %d, want 32", got)
+	}
+	if got := unsafe.Offsetof(source.connection); got != 16 {
+		t.Fatalf("offsetof(connection) = %d, want 16", got)
+	}
+	if got := unsafe.Offsetof(source.window); got != 24 {
+		t.Fatalf("offsetof(window) = %d, want 24", got)
+	}
+	if source.chain.SType != uint32(STypeSurfaceSourceXCBWindow) {
+		t.Fatalf("chain.SType = %#x, want %#x", source.chain.SType, uint32(STypeSurfaceSourceXCBWindow))
+	}
+	if source.connection != 0x1234 || source.window != 0x3e00002 {
+		t.Fatalf("connection, window = %#x, %#x", source.connection, source.window)
+	}
+}
+
+func TestSurfaceSourceXCBWindowRejectsZero(t *testing.T) {
+	if _, err := newSurfaceSourceXCBWindow(0, 1); err == nil {
+		t.Error("nil connection accepted")
+	}
+	if _, err := newSurfaceSourceXCBWindow(1, 0); err == nil {
+		t.Error("zero window accepted")
+	}
+}
@@ -0,0 +1,406 @@
+package wgpu
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gogpu/gputypes"
+)
+
+// SwapchainOptions configures a [SwapchainManager]. Zero fields select the
+// defaults noted on each.
+type SwapchainOptions struct {
+	// Format of the surface textures; defaults to [Surface.PreferredFormat].
+	Format gputypes.TextureFormat
+	// Usage of the surface textures; defaults to RenderAttachment.
+	Usage gputypes.TextureUsage
+	// PresentMode defaults to Fifo.
+	PresentMode gputypes.PresentMode
+	// AlphaMode defaults to Auto.
+	AlphaMode gputypes.CompositeAlphaMode
+	// Transparent selects [SurfaceCapabilities.TransparentAlphaMode] when
+	// AlphaMode is Auto, and fails if the surface cannot composite with
+	// transparency.
+	Transparent bool
+	// ViewFormats may list the sRGB counterpart of Format.
+	ViewFormats []gputypes.TextureFormat
+	// ColorSpace and ToneMapping are passed to the surface configuration;
+	// see [SurfaceConfiguration].
+	ColorSpace  PredefinedColorSpace
+	ToneMapping ToneMappingMode
+	// DepthFormat, if set, adds a depth attachment that follows the surface
+	// size and sample count.
+	DepthFormat gputypes.TextureFormat
+	// SampleCount above 1 renders into a multisampled color target that is
+	// resolved into the surface texture.
+	SampleCount uint32
+	// ResizeDebounce delays applying a resize until no further resize has
+	// been requested for this long, so dragging a window edge does not
+	// reallocate every frame. Zero applies resizes on the next frame.
+	ResizeDebounce time.Duration
+}
+
+// SwapchainFrame is a surface texture acquired by
+// [SwapchainManager.AcquireFrame], with the attachments of the frame. It is
+// valid until [SwapchainManager.Present].
+type SwapchainFrame struct {
+	// Texture is the surface texture.
+	Texture *Texture
+	// View is the view of Texture.
+	View *TextureView
+	// Width and Height are the size of every attachment of the frame.
+	Width, Height uint32
+	// Suboptimal is set when the surface still presents but no longer
+	// matches the window; the manager reconfigures after Present.
+	Suboptimal bool
+
+	msaa      *TextureView
+	depthView *TextureView
+}
+
+// ColorAttachment returns an attachment that clears the frame to clear and
+// stores into the surface texture, through the multisampled target when
+// the manager has one.
+func (f *SwapchainFrame) ColorAttachment(clear Color) RenderPassColorAttachment {
+	if f.msaa != nil {
+		return RenderPassColorAttachment{
+			View:          f.msaa,
+			ResolveTarget: f.View,
+			LoadOp:        gputypes.LoadOpClear,
+			StoreOp:       gputypes.StoreOpDiscard,
+			ClearValue:    clear,
+		}
+	}
+	return RenderPassColorAttachment{
+		View:       f.View,
+		LoadOp:     gputypes.LoadOpClear,
+		StoreOp:    gputypes.StoreOpStore,
+		ClearValue: clear,
+	}
+}
+
+// DepthStencilAttachment returns an attachment clearing the depth texture to
+// depth and discarding it after the pass, or nil without a DepthFormat.
+func (f *SwapchainFrame) DepthStencilAttachment(depth float32) *RenderPassDepthStencilAttachment {
+	if f.depthView == nil {
+		return nil
+	}
+	return &RenderPassDepthStencilAttachment{
+		View:            f.depthView,
+		DepthLoadOp:     gputypes.LoadOpClear,
+		DepthStoreOp:    gputypes.StoreOpDiscard,
+		DepthClearValue: depth,
+	}
+}
+
+// PassDescriptor returns a render pass over the frame's color and depth
+// attachments.
+func (f *SwapchainFrame) PassDescriptor(clear Color) *RenderPassDescriptor {
+	return &RenderPassDescriptor{
+		ColorAttachments:       []RenderPassColorAttachment{f.ColorAttachment(clear)},
+		DepthStencilAttachment: f.DepthStencilAttachment(1),
+	}
+}
+
+// SwapchainManager configures a surface and keeps it usable: it applies
+// (debounced) window resizes, reconfigures when the surface reports
+// Outdated, Lost or Suboptimal, and recreates the depth and multisampled
+// attachments to match. A SwapchainManager is not safe for concurrent use;
+// call it from the render loop.
+//
+//	sc, _ := wgpu.NewSwapchainManager(surface, adapter, device, w, h, nil)
+//	defer sc.Release()
+//	for running {
+//	    frame, err := sc.AcquireFrame()
+//	    if errors.Is(err, wgpu.ErrSurfaceOccluded) || errors.Is(err, wgpu.ErrSurfaceTimeout) {
+//	        continue // minimized or busy: skip this frame
+//	    }
+//	    // ... record a pass with frame.PassDescriptor(clear), submit ...
+//	    sc.Present()
+//	}
+//
+// Forward window size changes with [SwapchainManager.Resize].
+type SwapchainManager struct {
+	surface *Surface
+	device  *Device
+	opts    SwapchainOptions
+	config  SurfaceConfiguration
+
+	resize        resizeState
+	width, height uint32 // size to configure; zero while minimized
+	stale         bool   // reconfigure before the next acquire
+
+	msaa      *MultisampleTarget
+	depth     *Texture
+	depthView *TextureView
+	frame     *SwapchainFrame
+}
+
+// NewSwapchainManager configures surface for device at width x height. The
+// adapter is used to query the surface capabilities; opts may be nil.
+func NewSwapchainManager(surface *Surface, adapter *Adapter, device *Device, width, height uint32, opts *SwapchainOptions) (*SwapchainManager, error) {
+	m := &SwapchainManager{surface: surface, device: device}
+	if opts != nil {
+		m.opts = *opts
+	}
+	if m.opts.Format == gputypes.TextureFormatUndefined {
+		format, err := surface.PreferredFormat(adapter)
+		if err != nil {
+			return nil, err
+		}
+		m.opts.Format = format
+	} else if _, err := surface.GetCapabilities(adapter); err != nil {
+		return nil, err
+	}
+	if m.opts.Transparent && m.opts.AlphaMode == gputypes.CompositeAlphaModeAuto {
+		mode, ok := surface.caps.TransparentAlphaMode()
+		if !ok {
+			return nil, &WGPUError{Op: "NewSwapchainManager", Type: ErrorTypeValidation,
+				Message: fmt.Sprintf("surface has no transparent alpha mode (supported: %v)", surface.caps.AlphaModes)}
+		}
+		m.opts.AlphaMode = mode
+	}
+	if m.opts.Usage == 0 {
+		m.opts.Usage = gputypes.TextureUsageRenderAttachment
+	}
+	if m.opts.PresentMode == gputypes.PresentModeUndefined {
+		m.opts.PresentMode = gputypes.PresentModeFifo
+	}
+	if m.opts.SampleCount == 0 {
+		m.opts.SampleCount = 1
+	}
+	m.config = SurfaceConfiguration{
+		Format:      m.opts.Format,
+		Usage:       m.opts.Usage,
+		AlphaMode:   m.opts.AlphaMode,
+		PresentMode: m.opts.PresentMode,
+		ViewFormats: m.opts.ViewFormats,
+		ColorSpace:  m.opts.ColorSpace,
+		ToneMapping: m.opts.ToneMapping,
+	}
+	m.width, m.height = width, height
+	if err := m.configure(width, height); err != nil {
+		m.Release()
+		return nil, err
+	}
+	return m, nil
+}
+
+// Format returns the format of the surface textures, for pipeline color
+// targets.
+func (m *SwapchainManager) Format() gputypes.TextureFormat { return m.config.Format }
+
+// SampleCount returns the sample count pipelines must use.
+func (m *SwapchainManager) SampleCount() uint32 { return m.opts.SampleCount }
+
+// Size returns the configured surface size.
+func (m *SwapchainManager) Size() (width, height uint32) { return m.config.Width, m.config.Height }
+
+// Resize requests a new surface size, applied by a later AcquireFrame once
+// the debounce interval has passed. A zero size (minimized window) pauses
+// rendering: AcquireFrame returns [ErrSurfaceOccluded] until a non-zero
+// size is set.
+func (m *SwapchainManager) Resize(width, height uint32) {
+	m.resize.request(width, height, time.Now().Add(m.opts.ResizeDebounce))
+}
+
+// AcquireFrame returns the next frame to render into. Errors matching
+// [ErrSurfaceOccluded] or [ErrSurfaceTimeout] mean the frame should be
+// skipped; other errors are fatal for the surface. A frame acquired and not
+// presented is presented first.
+func (m *SwapchainManager) AcquireFrame() (*SwapchainFrame, error) {
+	if m.frame != nil {
+		if err := m.Present(); err != nil {
+			return nil, err
+		}
+	}
+	if w, h, ok := m.resize.due(time.Now()); ok {
+		m.width, m.height = w, h
+	}
+	if m.width == 0 || m.height == 0 {
+		return nil, ErrSurfaceOccluded
+	}
+	if m.stale || m.width != m.config.Width || m.height != m.config.Height {
+		if err := m.configure(m.width, m.height); err != nil {
+			return nil, err
+		}
+	}
+
+	st, suboptimal, err := m.surface.GetCurrentTexture()
+	if err == ErrSurfaceNeedsReconfigure || err == ErrSurfaceLost {
+		// The window changed under us: apply any pending size right away
+		// and try once more. A pending 0x0 size means the window was
+		// minimized meanwhile; keep it so later acquires pause too.
+		releaseSurfaceTexture(st)
+		if w, h, ok := m.resize.take(); ok {
+			m.width, m.height = w, h
+		}
+		if m.width == 0 || m.height == 0 {
+			m.stale = true
+			return nil, ErrSurfaceOccluded
+		}
+		if err := m.configure(m.width, m.height); err != nil {
+			return nil, err
+		}
+		st, suboptimal, err = m.surface.GetCurrentTexture()
+	}
+	if err != nil {
+		releaseSurfaceTexture(st)
+		return nil, err
+	}
+
+	view, err := st.Texture.DefaultView()
+	if err != nil {
+		releaseSurfaceTexture(st)
+		return nil, err
+	}
+	m.frame = &SwapchainFrame{
+		Texture:    st.Texture,
+		View:       view,
+		Width:      m.config.Width,
+		Height:     m.config.Height,
+		Suboptimal: suboptimal,
+		depthView:  m.depthView,
+	}
+	if m.msaa != nil {
+		m.frame.msaa = m.msaa.View()
+	}
+	return m.frame, nil
+}
+
+// Present presents the frame returned by the last AcquireFrame and
+// releases it. It does nothing if no frame is outstanding.
+func (m *SwapchainManager) Present() error {
+	f := m.frame
+	if f == nil {
+		return nil
+	}
+	m.frame = nil
+	err := m.surface.Present()
+	f.Texture.Release()
+	if f.Suboptimal {
+		m.stale = true
+	}
+	return err
+}
+
+// Release releases the attachments and unconfigures the surface. The
+// surface itself stays owned by the caller.
+func (m *SwapchainManager) Release() {
+	if m.frame != nil {
+		m.frame.Texture.Release()
+		m.frame = nil
+	}
+	m.releaseAttachments()
+	if m.config.Width != 0 {
+		m.surface.Unconfigure()
+		m.config.Width, m.config.Height = 0, 0
+	}
+}
+
+// configure configures the surface at width x height and recreates the
+// attachments to match.
+func (m *SwapchainManager) configure(width, height uint32) error {
+	config := m.config
+	config.Width, config.Height = width, height
+	if err := m.surface.Configure(m.device, &config); err != nil {
+		return err
+	}
+	m.config = config
+	m.stale = false
+	return m.createAttachments()
+}
+
+func (m *SwapchainManager) createAttachments() error {
+	width, height := m.config.Width, m.config.Height
+	if m.opts.SampleCount > 1 {
+		if m.msaa == nil {
+			msaa, err := m.device.NewMultisampleTarget(width, height, m.config.Format, m.opts.SampleCount)
+			if err != nil {
+				return err
+			}
+			m.msaa = msaa
+		} else if err := m.msaa.Resize(width, height); err != nil {
+			return err
+		}
+	}
+	if m.opts.DepthFormat == gputypes.TextureFormatUndefined {
+		return nil
+	}
+	depth, err := m.device.CreateTexture(&TextureDescriptor{
+		Label:         "swapchain depth",
+		Usage:         gputypes.TextureUsageRenderAttachment,
+		Dimension:     gputypes.TextureDimension2D,
+		Size:          gputypes.Extent3D{Width: width, Height: height, DepthOrArrayLayers: 1},
+		Format:        m.opts.DepthFormat,
+		MipLevelCount: 1,
+		SampleCount:   m.opts.SampleCount,
+	})
+	if err != nil {
+		return err
+	}
+	view, err := depth.CreateView(nil)
+	if err != nil {
+		depth.Release()
+		return err
+	}
+	m.releaseDepth()
+	m.depth, m.depthView = depth, view
+	return nil
+}
+
+func (m *SwapchainManager) releaseDepth() {
+	if m.depthView != nil {
+		m.depthView.Release()
+		m.depthView = nil
+	}
+	if m.depth != nil {
+		m.depth.Release()
+		m.depth = nil
+	}
+}
+
+func (m *SwapchainManager) releaseAttachments() {
+	m.releaseDepth()
+	if m.msaa != nil {
+		m.msaa.Release()
+		m.msaa = nil
+	}
+}
+
+// releaseSurfaceTexture releases the texture of a surface texture that will
+// not be rendered to. st may be nil.
+func releaseSurfaceTexture(st *SurfaceTexture) {
+	if st != nil && st.Texture != nil {
+		st.Texture.Release()
+	}
+}
+
+// resizeState debounces resize requests: the latest size becomes due once
+// no newer request has arrived before its deadline.
+type resizeState struct {
+	pending       bool
+	width, height uint32
+	deadline      time.Time
+}
+
+func (r *resizeState) request(width, height uint32, deadline time.Time) {
+	r.pending, r.width, r.height, r.deadline = true, width, height, deadline
+}
+
+// due returns the requested size if its deadline has passed, clearing it.
+func (r *resizeState) due(now time.Time) (width, height uint32, ok bool) {
+	if !r.pending || now.Before(r.deadline) {
+		return 0, 0, false
+	}
+	return r.take()
+}
+
+// take returns the requested size regardless of its deadline, clearing it.
+func (r *resizeState) take() (width, height uint32, ok bool) {
+	if !r.pending {
+		return 0, 0, false
+	}
+	r.pending = false
+	return r.width, r.height, true
+}
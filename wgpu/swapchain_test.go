@@ -0,0 +1,61 @@
+package wgpu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestResizeStateDebounce(t *testing.T) {
+	t0 := time.Unix(0, 0)
+	var r resizeState
+	if _, _, ok := r.due(t0); ok {
+		t.Fatal("due without a request")
+	}
+
+	r.request(800, 600, t0.Add(100*time.Millisecond))
+	r.request(810, 610, t0.Add(150*time.Millisecond)) // dragging: deadline moves
+	if _, _, ok := r.due(t0.Add(120 * time.Millisecond)); ok {
+		t.Fatal("due before the latest deadline")
+	}
+	w, h, ok := r.due(t0.Add(150 * time.Millisecond))
+	if !ok || w != 810 || h != 610 {
+		t.Fatalf("due = %d, %d, %v; want 810, 610, true", w, h, ok)
+	}
+	if _, _, ok := r.due(t0.Add(time.Second)); ok {
+		t.Fatal("request was not cleared")
+	}
+
+	r.request(1024, 768, t0.Add(time.Hour))
+	if w, h, ok := r.take(); !ok || w != 1024 || h != 768 {
+		t.Fatalf("take = %d, %d, %v; want 1024, 768, true", w, h, ok)
+	}
+}
+
+func TestSwapchainFrameAttachments(t *testing.T) {
+	surfaceView := &TextureView{handle: 1}
+	msaaView := &TextureView{handle: 2}
+	depthView := &TextureView{handle: 3}
+	clear := Color{R: 0.1, A: 1}
+
+	plain := &SwapchainFrame{View: surfaceView}
+	ca := plain.ColorAttachment(clear)
+	if ca.View != surfaceView || ca.ResolveTarget != nil || ca.StoreOp != gputypes.StoreOpStore {
+		t.Errorf("single-sampled attachment = %+v", ca)
+	}
+	if plain.DepthStencilAttachment(1) != nil {
+		t.Error("depth attachment without a depth format")
+	}
+
+	msaa := &SwapchainFrame{View: surfaceView, msaa: msaaView, depthView: depthView}
+	ca = msaa.ColorAttachment(clear)
+	if ca.View != msaaView || ca.ResolveTarget != surfaceView || ca.ClearValue != clear {
+		t.Errorf("multisampled attachment = %+v", ca)
+	}
+	desc := msaa.PassDescriptor(clear)
+	if desc.DepthStencilAttachment == nil || desc.DepthStencilAttachment.View != depthView ||
+		desc.DepthStencilAttachment.DepthClearValue != 1 {
+		t.Errorf("depth attachment = %+v", desc.DepthStencilAttachment)
+	}
+}
@@ -0,0 +1,230 @@
+package texload
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+)
+
+const (
+	ddsMagic        = "DDS "
+	ddsHeaderSize   = 124
+	ddsDX10Size     = 20
+	ddsPixelFormat  = 4 + 72 // DDS_PIXELFORMAT offset within the file
+	ddsFourCCFlag   = 0x4
+	ddsRGBFlag      = 0x40
+	ddsLuminance    = 0x20000
+	ddsCaps2Cubemap = 0x200
+	ddsCaps2Volume  = 0x200000
+	ddsMiscCube     = 0x4 // D3D11_RESOURCE_MISC_TEXTURECUBE
+	ddsDimension3D  = 4   // D3D10_RESOURCE_DIMENSION_TEXTURE3D
+)
+
+// ddsFourCCFormats maps legacy FourCC codes to WebGPU formats.
+var ddsFourCCFormats = map[string]gputypes.TextureFormat{
+	"DXT1": gputypes.TextureFormatBC1RGBAUnorm,
+	"DXT2": gputypes.TextureFormatBC2RGBAUnorm,
+	"DXT3": gputypes.TextureFormatBC2RGBAUnorm,
+	"DXT4": gputypes.TextureFormatBC3RGBAUnorm,
+	"DXT5": gputypes.TextureFormatBC3RGBAUnorm,
+	"ATI1": gputypes.TextureFormatBC4RUnorm,
+	"BC4U": gputypes.TextureFormatBC4RUnorm,
+	"BC4S": gputypes.TextureFormatBC4RSnorm,
+	"ATI2": gputypes.TextureFormatBC5RGUnorm,
+	"BC5U": gputypes.TextureFormatBC5RGUnorm,
+	"BC5S": gputypes.TextureFormatBC5RGSnorm,
+}
+
+// dxgiFormats maps DXGI_FORMAT values from the DX10 header to WebGPU formats.
+var dxgiFormats = map[uint32]gputypes.TextureFormat{
+	2:  gputypes.TextureFormatRGBA32Float,
+	3:  gputypes.TextureFormatRGBA32Uint,
+	4:  gputypes.TextureFormatRGBA32Sint,
+	10: gputypes.TextureFormatRGBA16Float,
+	11: gputypes.TextureFormatRGBA16Unorm,
+	12: gputypes.TextureFormatRGBA16Uint,
+	13: gputypes.TextureFormatRGBA16Snorm,
+	14: gputypes.TextureFormatRGBA16Sint,
+	16: gputypes.TextureFormatRG32Float,
+	17: gputypes.TextureFormatRG32Uint,
+	18: gputypes.TextureFormatRG32Sint,
+	24: gputypes.TextureFormatRGB10A2Unorm,
+	25: gputypes.TextureFormatRGB10A2Uint,
+	26: gputypes.TextureFormatRG11B10Ufloat,
+	28: gputypes.TextureFormatRGBA8Unorm,
+	29: gputypes.TextureFormatRGBA8UnormSrgb,
+	30: gputypes.TextureFormatRGBA8Uint,
+	31: gputypes.TextureFormatRGBA8Snorm,
+	32: gputypes.TextureFormatRGBA8Sint,
+	34: gputypes.TextureFormatRG16Float,
+	35: gputypes.TextureFormatRG16Unorm,
+	36: gputypes.TextureFormatRG16Uint,
+	37: gputypes.TextureFormatRG16Snorm,
+	38: gputypes.TextureFormatRG16Sint,
+	40: gputypes.TextureFormatDepth32Float,
+	41: gputypes.TextureFormatR32Float,
+	42: gputypes.TextureFormatR32Uint,
+	43: gputypes.TextureFormatR32Sint,
+	49: gputypes.TextureFormatRG8Unorm,
+	50: gputypes.TextureFormatRG8Uint,
+	51: gputypes.TextureFormatRG8Snorm,
+	52: gputypes.TextureFormatRG8Sint,
+	54: gputypes.TextureFormatR16Float,
+	55: gputypes.TextureFormatDepth16Unorm,
+	56: gputypes.TextureFormatR16Unorm,
+	57: gputypes.TextureFormatR16Uint,
+	58: gputypes.TextureFormatR16Snorm,
+	59: gputypes.TextureFormatR16Sint,
+	61: gputypes.TextureFormatR8Unorm,
+	62: gputypes.TextureFormatR8Uint,
+	63: gputypes.TextureFormatR8Snorm,
+	64: gputypes.TextureFormatR8Sint,
+	67: gputypes.TextureFormatRGB9E5Ufloat,
+	71: gputypes.TextureFormatBC1RGBAUnorm,
+	72: gputypes.TextureFormatBC1RGBAUnormSrgb,
+	74: gputypes.TextureFormatBC2RGBAUnorm,
+	75: gputypes.TextureFormatBC2RGBAUnormSrgb,
+	77: gputypes.TextureFormatBC3RGBAUnorm,
+	78: gputypes.TextureFormatBC3RGBAUnormSrgb,
+	80: gputypes.TextureFormatBC4RUnorm,
+	81: gputypes.TextureFormatBC4RSnorm,
+	83: gputypes.TextureFormatBC5RGUnorm,
+	84: gputypes.TextureFormatBC5RGSnorm,
+	87: gputypes.TextureFormatBGRA8Unorm,
+	91: gputypes.TextureFormatBGRA8UnormSrgb,
+	95: gputypes.TextureFormatBC6HRGBUfloat,
+	96: gputypes.TextureFormatBC6HRGBFloat,
+	98: gputypes.TextureFormatBC7RGBAUnorm,
+	99: gputypes.TextureFormatBC7RGBAUnormSrgb,
+}
+
+// ParseDDS parses a DirectDraw Surface file, with or without the DX10
+// extension header. Legacy uncompressed files are accepted for 32-bit
+// RGBA/BGRA and 8-bit luminance layouts.
+func ParseDDS(data []byte) (*Image, error) {
+	if len(data) < 4+ddsHeaderSize || string(data[:4]) != ddsMagic {
+		return nil, errors.New("texload: not a DDS file")
+	}
+	le := binary.LittleEndian
+	hdr := data[4:]
+	if le.Uint32(hdr) != ddsHeaderSize {
+		return nil, errors.New("texload: DDS header size mismatch")
+	}
+	height := le.Uint32(hdr[8:])
+	width := le.Uint32(hdr[12:])
+	depth := max(le.Uint32(hdr[20:]), 1)
+	mipCount := max(le.Uint32(hdr[24:]), 1)
+	caps2 := le.Uint32(hdr[108:])
+	if width == 0 || height == 0 {
+		return nil, errors.New("texload: DDS image has zero size")
+	}
+	if mipCount > 32 {
+		return nil, fmt.Errorf("texload: DDS mip count %d is invalid", mipCount)
+	}
+
+	format, layers, faces, volume, offset, err := parseDDSFormat(data, caps2)
+	if err != nil {
+		return nil, err
+	}
+	if !volume {
+		depth = 1
+	}
+
+	img := &Image{
+		Format: format,
+		Width:  width,
+		Height: height,
+		Depth:  depth,
+		Layers: layers,
+		Faces:  faces,
+		Levels: make([]Level, mipCount),
+	}
+	slices := int(layers * faces)
+	for i := range img.Levels {
+		img.Levels[i] = Level{
+			Width:  mipExtent(width, i),
+			Height: mipExtent(height, i),
+			Depth:  mipExtent(depth, i),
+			Slices: make([][]byte, slices),
+		}
+	}
+
+	// DDS stores every mip level of one layer/face before the next.
+	for s := 0; s < slices; s++ {
+		for i := range img.Levels {
+			level := &img.Levels[i]
+			size, err := levelSize(format, level.Width, level.Height, level.Depth)
+			if err != nil {
+				return nil, err
+			}
+			if size > len(data)-offset {
+				return nil, fmt.Errorf("texload: DDS data truncated at layer %d level %d", s, i)
+			}
+			level.Slices[s] = data[offset : offset+size]
+			offset += size
+		}
+	}
+	return img, nil
+}
+
+// parseDDSFormat decodes the pixel format and, if present, the DX10 header.
+// It returns the payload offset.
+func parseDDSFormat(data []byte, caps2 uint32) (format gputypes.TextureFormat, layers, faces uint32, volume bool, offset int, err error) {
+	le := binary.LittleEndian
+	pf := data[ddsPixelFormat:]
+	flags := le.Uint32(pf[4:])
+	fourCC := string(pf[8:12])
+	offset = 4 + ddsHeaderSize
+	layers, faces = 1, 1
+	volume = caps2&ddsCaps2Volume != 0
+	if caps2&ddsCaps2Cubemap != 0 {
+		faces = 6
+	}
+
+	switch {
+	case flags&ddsFourCCFlag != 0 && fourCC == "DX10":
+		if len(data) < offset+ddsDX10Size {
+			return 0, 0, 0, false, 0, errors.New("texload: DDS DX10 header truncated")
+		}
+		dx10 := data[offset:]
+		dxgi := le.Uint32(dx10)
+		var ok bool
+		if format, ok = dxgiFormats[dxgi]; !ok {
+			return 0, 0, 0, false, 0, fmt.Errorf("texload: DXGI format %d has no WebGPU equivalent", dxgi)
+		}
+		volume = le.Uint32(dx10[4:]) == ddsDimension3D
+		if le.Uint32(dx10[8:])&ddsMiscCube != 0 {
+			faces = 6
+		}
+		layers = max(le.Uint32(dx10[12:]), 1)
+		if layers > maxArrayLayers {
+			return 0, 0, 0, false, 0, fmt.Errorf("texload: DDS array size %d is too large", layers)
+		}
+		offset += ddsDX10Size
+	case flags&ddsFourCCFlag != 0:
+		var ok bool
+		if format, ok = ddsFourCCFormats[fourCC]; !ok {
+			return 0, 0, 0, false, 0, fmt.Errorf("texload: DDS FourCC %q is not supported", fourCC)
+		}
+	case flags&ddsRGBFlag != 0 && le.Uint32(pf[12:]) == 32:
+		switch rMask := le.Uint32(pf[16:]); rMask {
+		case 0x000000FF:
+			format = gputypes.TextureFormatRGBA8Unorm
+		case 0x00FF0000:
+			format = gputypes.TextureFormatBGRA8Unorm
+		default:
+			return 0, 0, 0, false, 0, fmt.Errorf("texload: DDS RGB mask %#x is not supported", rMask)
+		}
+	case flags&ddsLuminance != 0 && le.Uint32(pf[12:]) == 8:
+		format = gputypes.TextureFormatR8Unorm
+	default:
+		return 0, 0, 0, false, 0, errors.New("texload: DDS pixel format is not supported")
+	}
+
+	if volume && (layers > 1 || faces > 1) {
+		return 0, 0, 0, false, 0, errors.New("texload: DDS volume textures cannot be arrays or cube maps")
+	}
+	return format, layers, faces, volume, offset, nil
+}
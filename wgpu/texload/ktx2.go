@@ -0,0 +1,172 @@
+package texload
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+)
+
+// ktx2Identifier is the 12-byte file identifier «KTX 20»\r\n\x1A\n.
+var ktx2Identifier = [12]byte{0xAB, 'K', 'T', 'X', ' ', '2', '0', 0xBB, '\r', '\n', 0x1A, '\n'}
+
+const (
+	// ktx2HeaderSize covers the identifier, the nine header fields and the
+	// DFD/KVD/SGD index; the level index follows immediately.
+	ktx2HeaderSize     = 80
+	ktx2LevelIndexSize = 24
+)
+
+// vkFormats maps the VkFormat values KTX2 uses to WebGPU texture formats.
+// Formats with no WebGPU equivalent (24-bit RGB, packed 16-bit, ...) are absent.
+var vkFormats = map[uint32]gputypes.TextureFormat{
+	9:   gputypes.TextureFormatR8Unorm,
+	10:  gputypes.TextureFormatR8Snorm,
+	13:  gputypes.TextureFormatR8Uint,
+	14:  gputypes.TextureFormatR8Sint,
+	16:  gputypes.TextureFormatRG8Unorm,
+	17:  gputypes.TextureFormatRG8Snorm,
+	20:  gputypes.TextureFormatRG8Uint,
+	21:  gputypes.TextureFormatRG8Sint,
+	37:  gputypes.TextureFormatRGBA8Unorm,
+	38:  gputypes.TextureFormatRGBA8Snorm,
+	41:  gputypes.TextureFormatRGBA8Uint,
+	42:  gputypes.TextureFormatRGBA8Sint,
+	43:  gputypes.TextureFormatRGBA8UnormSrgb,
+	44:  gputypes.TextureFormatBGRA8Unorm,
+	50:  gputypes.TextureFormatBGRA8UnormSrgb,
+	64:  gputypes.TextureFormatRGB10A2Unorm,
+	68:  gputypes.TextureFormatRGB10A2Uint,
+	70:  gputypes.TextureFormatR16Unorm,
+	71:  gputypes.TextureFormatR16Snorm,
+	74:  gputypes.TextureFormatR16Uint,
+	75:  gputypes.TextureFormatR16Sint,
+	76:  gputypes.TextureFormatR16Float,
+	77:  gputypes.TextureFormatRG16Unorm,
+	78:  gputypes.TextureFormatRG16Snorm,
+	81:  gputypes.TextureFormatRG16Uint,
+	82:  gputypes.TextureFormatRG16Sint,
+	83:  gputypes.TextureFormatRG16Float,
+	91:  gputypes.TextureFormatRGBA16Unorm,
+	92:  gputypes.TextureFormatRGBA16Snorm,
+	95:  gputypes.TextureFormatRGBA16Uint,
+	96:  gputypes.TextureFormatRGBA16Sint,
+	97:  gputypes.TextureFormatRGBA16Float,
+	98:  gputypes.TextureFormatR32Uint,
+	99:  gputypes.TextureFormatR32Sint,
+	100: gputypes.TextureFormatR32Float,
+	101: gputypes.TextureFormatRG32Uint,
+	102: gputypes.TextureFormatRG32Sint,
+	103: gputypes.TextureFormatRG32Float,
+	107: gputypes.TextureFormatRGBA32Uint,
+	108: gputypes.TextureFormatRGBA32Sint,
+	109: gputypes.TextureFormatRGBA32Float,
+	122: gputypes.TextureFormatRG11B10Ufloat,
+	123: gputypes.TextureFormatRGB9E5Ufloat,
+	124: gputypes.TextureFormatDepth16Unorm,
+	126: gputypes.TextureFormatDepth32Float,
+	127: gputypes.TextureFormatStencil8,
+	// BC1 RGB and RGBA share a block layout; WebGPU only exposes RGBA.
+	131: gputypes.TextureFormatBC1RGBAUnorm,
+	132: gputypes.TextureFormatBC1RGBAUnormSrgb,
+	133: gputypes.TextureFormatBC1RGBAUnorm,
+	134: gputypes.TextureFormatBC1RGBAUnormSrgb,
+	135: gputypes.TextureFormatBC2RGBAUnorm,
+	136: gputypes.TextureFormatBC2RGBAUnormSrgb,
+	137: gputypes.TextureFormatBC3RGBAUnorm,
+	138: gputypes.TextureFormatBC3RGBAUnormSrgb,
+	139: gputypes.TextureFormatBC4RUnorm,
+	140: gputypes.TextureFormatBC4RSnorm,
+	141: gputypes.TextureFormatBC5RGUnorm,
+	142: gputypes.TextureFormatBC5RGSnorm,
+	143: gputypes.TextureFormatBC6HRGBUfloat,
+	144: gputypes.TextureFormatBC6HRGBFloat,
+	145: gputypes.TextureFormatBC7RGBAUnorm,
+	146: gputypes.TextureFormatBC7RGBAUnormSrgb,
+}
+
+func init() {
+	// VK_FORMAT_ETC2_R8G8B8_UNORM_BLOCK (147) through VK_FORMAT_ASTC_12x12_SRGB_BLOCK
+	// (184) follow the same order as the WebGPU enum.
+	for vk := uint32(147); vk <= 184; vk++ {
+		vkFormats[vk] = gputypes.TextureFormatETC2RGB8Unorm + gputypes.TextureFormat(vk-147)
+	}
+}
+
+// ParseKTX2 parses a KTX 2.0 container. Supercompressed files (BasisLZ,
+// Zstandard, ZLIB) are rejected; transcode them before loading.
+func ParseKTX2(data []byte) (*Image, error) {
+	if len(data) < ktx2HeaderSize || [12]byte(data[:12]) != ktx2Identifier {
+		return nil, errors.New("texload: not a KTX2 file")
+	}
+	le := binary.LittleEndian
+	vkFormat := le.Uint32(data[12:])
+	width := le.Uint32(data[20:])
+	height := max(le.Uint32(data[24:]), 1)
+	depth := max(le.Uint32(data[28:]), 1)
+	layers := max(le.Uint32(data[32:]), 1)
+	faces := le.Uint32(data[36:])
+	levelCount := max(le.Uint32(data[40:]), 1)
+	supercompression := le.Uint32(data[44:])
+
+	if supercompression != 0 {
+		return nil, fmt.Errorf("texload: KTX2 supercompression scheme %d is not supported", supercompression)
+	}
+	format, ok := vkFormats[vkFormat]
+	if !ok {
+		return nil, fmt.Errorf("texload: KTX2 VkFormat %d has no WebGPU equivalent", vkFormat)
+	}
+	if width == 0 {
+		return nil, errors.New("texload: KTX2 image has zero width")
+	}
+	if faces != 1 && faces != 6 {
+		return nil, fmt.Errorf("texload: KTX2 faceCount %d is invalid", faces)
+	}
+	if depth > 1 && (layers > 1 || faces > 1) {
+		return nil, errors.New("texload: KTX2 volume textures cannot be arrays or cube maps")
+	}
+	if layers > maxArrayLayers {
+		return nil, fmt.Errorf("texload: KTX2 layerCount %d is too large", layers)
+	}
+	if levelCount > 32 {
+		return nil, fmt.Errorf("texload: KTX2 levelCount %d is invalid", levelCount)
+	}
+	if ktx2HeaderSize+int(levelCount)*ktx2LevelIndexSize > len(data) {
+		return nil, errors.New("texload: KTX2 level index is truncated")
+	}
+
+	img := &Image{
+		Format: format,
+		Width:  width,
+		Height: height,
+		Depth:  depth,
+		Layers: layers,
+		Faces:  faces,
+		Levels: make([]Level, levelCount),
+	}
+	slices := int(layers * faces)
+	for i := range img.Levels {
+		entry := data[ktx2HeaderSize+i*ktx2LevelIndexSize:]
+		offset, length := le.Uint64(entry), le.Uint64(entry[8:])
+
+		lw, lh, ld := mipExtent(width, i), mipExtent(height, i), mipExtent(depth, i)
+		sliceSize, err := levelSize(format, lw, lh, ld)
+		if err != nil {
+			return nil, err
+		}
+		if length != uint64(sliceSize)*uint64(slices) || offset > uint64(len(data)) || length > uint64(len(data))-offset {
+			return nil, fmt.Errorf("texload: KTX2 level %d has invalid byte range", i)
+		}
+
+		// KTX2 stores each level as layer-major, then face, then z slice —
+		// the same order as WebGPU array layers.
+		level := Level{Width: lw, Height: lh, Depth: ld, Slices: make([][]byte, slices)}
+		for s := range level.Slices {
+			start := offset + uint64(s*sliceSize)
+			level.Slices[s] = data[start : start+uint64(sliceSize)]
+		}
+		img.Levels[i] = level
+	}
+	return img, nil
+}
@@ -0,0 +1,224 @@
+// Package texload loads KTX2 and DDS texture containers into wgpu textures.
+//
+// Both containers are parsed into a format-neutral [Image] holding every
+// mip level, array layer and cube face. [CreateTexture] uploads an Image
+// as-is, without decompressing or transcoding, so block-compressed payloads
+// require the matching device feature (see [wgpu.TextureFormatRequiredFeature]).
+//
+// Quick start:
+//
+//	tex, view, err := texload.LoadFile(device, "skybox.ktx2")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer tex.Release()
+//	defer view.Release()
+package texload
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-webgpu/webgpu/wgpu"
+	"github.com/gogpu/gputypes"
+)
+
+// ErrUnknownContainer is returned by [Decode] when the data is neither KTX2 nor DDS.
+var ErrUnknownContainer = errors.New("texload: unknown texture container")
+
+// Image is a decoded texture container.
+type Image struct {
+	Format gputypes.TextureFormat
+	// Width, Height and Depth describe mip level 0. Depth is 1 for
+	// non-volume textures.
+	Width, Height, Depth uint32
+	// Layers is the number of array layers (1 for non-array textures).
+	Layers uint32
+	// Faces is 6 for cube maps and 1 otherwise.
+	Faces uint32
+	// Levels holds one entry per mip level, largest first.
+	Levels []Level
+}
+
+// Level is a single mip level of an [Image].
+type Level struct {
+	Width, Height, Depth uint32
+	// Slices holds one tightly packed payload per texture array layer,
+	// indexed layer*Faces+face. Volume textures have a single slice that
+	// contains all Depth planes.
+	Slices [][]byte
+}
+
+// IsCube reports whether the image is a cube map or cube map array.
+func (img *Image) IsCube() bool { return img.Faces == 6 }
+
+// ArrayLayerCount returns the number of 2D texture array layers the image
+// occupies (Layers * Faces).
+func (img *Image) ArrayLayerCount() uint32 { return img.Layers * img.Faces }
+
+// ViewDimension returns the view dimension that samples the whole image.
+func (img *Image) ViewDimension() gputypes.TextureViewDimension {
+	switch {
+	case img.Depth > 1:
+		return gputypes.TextureViewDimension3D
+	case img.IsCube() && img.Layers > 1:
+		return gputypes.TextureViewDimensionCubeArray
+	case img.IsCube():
+		return gputypes.TextureViewDimensionCube
+	case img.Layers > 1:
+		return gputypes.TextureViewDimension2DArray
+	default:
+		return gputypes.TextureViewDimension2D
+	}
+}
+
+// Decode reads a KTX2 or DDS container from r, detected by its magic bytes.
+func Decode(r io.Reader) (*Image, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case bytes.HasPrefix(data, ktx2Identifier[:]):
+		return ParseKTX2(data)
+	case bytes.HasPrefix(data, []byte(ddsMagic)):
+		return ParseDDS(data)
+	default:
+		return nil, ErrUnknownContainer
+	}
+}
+
+// CreateTexture creates a texture for img on device and uploads every mip
+// level, layer and face. The texture has TextureBinding and CopyDst usage.
+func CreateTexture(device *wgpu.Device, img *Image, label string) (*wgpu.Texture, error) {
+	if img == nil || len(img.Levels) == 0 {
+		return nil, errors.New("texload: image has no levels")
+	}
+	dimension := gputypes.TextureDimension2D
+	depthOrLayers := img.ArrayLayerCount()
+	if img.Depth > 1 {
+		dimension = gputypes.TextureDimension3D
+		depthOrLayers = img.Depth
+	}
+
+	tex, err := device.CreateTexture(&wgpu.TextureDescriptor{
+		Label:         label,
+		Usage:         gputypes.TextureUsageTextureBinding | gputypes.TextureUsageCopyDst,
+		Dimension:     dimension,
+		Size:          gputypes.Extent3D{Width: img.Width, Height: img.Height, DepthOrArrayLayers: depthOrLayers},
+		Format:        img.Format,
+		MipLevelCount: uint32(len(img.Levels)),
+		SampleCount:   1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	queue := device.Queue()
+	if queue == nil {
+		tex.Release()
+		return nil, errors.New("texload: device queue unavailable")
+	}
+	defer queue.Release()
+
+	bw, bh := wgpu.TextureFormatBlockDimensions(img.Format)
+	blockSize := img.Format.BlockCopySize()
+	for mip, level := range img.Levels {
+		// Copies of compressed formats cover whole blocks, even for mip
+		// levels smaller than one block.
+		size := gputypes.Extent3D{
+			Width:              roundUp(level.Width, bw),
+			Height:             roundUp(level.Height, bh),
+			DepthOrArrayLayers: level.Depth,
+		}
+		layout := wgpu.ImageDataLayout{
+			BytesPerRow:  size.Width / bw * blockSize,
+			RowsPerImage: size.Height / bh,
+		}
+		for layer, data := range level.Slices {
+			dest := wgpu.ImageCopyTexture{
+				Texture:  tex,
+				MipLevel: uint32(mip),
+				Origin:   gputypes.Origin3D{Z: uint32(layer)},
+				Aspect:   wgpu.TextureAspectAll,
+			}
+			if err := queue.WriteTexture(&dest, data, &layout, &size); err != nil {
+				tex.Release()
+				return nil, err
+			}
+		}
+	}
+	return tex, nil
+}
+
+// CreateTextureView creates a view of tex covering every level and layer of img.
+func CreateTextureView(tex *wgpu.Texture, img *Image) (*wgpu.TextureView, error) {
+	layers := img.ArrayLayerCount()
+	if img.Depth > 1 {
+		layers = 1
+	}
+	return tex.CreateView(&wgpu.TextureViewDescriptor{
+		Format:          img.Format,
+		Dimension:       img.ViewDimension(),
+		MipLevelCount:   uint32(len(img.Levels)),
+		ArrayLayerCount: layers,
+		Aspect:          wgpu.TextureAspectAll,
+	})
+}
+
+// Load decodes a container from r and returns a ready-to-sample texture and view.
+func Load(device *wgpu.Device, r io.Reader, label string) (*wgpu.Texture, *wgpu.TextureView, error) {
+	img, err := Decode(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	tex, err := CreateTexture(device, img, label)
+	if err != nil {
+		return nil, nil, err
+	}
+	view, err := CreateTextureView(tex, img)
+	if err != nil {
+		tex.Release()
+		return nil, nil, err
+	}
+	return tex, view, nil
+}
+
+// LoadFile is like [Load] but reads from the named file, which is also used as the label.
+func LoadFile(device *wgpu.Device, path string) (*wgpu.Texture, *wgpu.TextureView, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	return Load(device, f, path)
+}
+
+// maxArrayLayers bounds the layer count accepted from file headers so a
+// corrupt header cannot trigger a huge allocation.
+const maxArrayLayers = 2048
+
+// levelSize returns the byte size of one tightly packed width x height x
+// depth image in format.
+func levelSize(format gputypes.TextureFormat, width, height, depth uint32) (int, error) {
+	blockSize := format.BlockCopySize()
+	if blockSize == 0 {
+		return 0, fmt.Errorf("texload: format %s has no defined copy size", format)
+	}
+	bw, bh := wgpu.TextureFormatBlockDimensions(format)
+	rowBytes := uint64(roundUp(width, bw)/bw) * uint64(blockSize)
+	rows := uint64(roundUp(height, bh) / bh)
+	return int(rowBytes * rows * uint64(depth)), nil
+}
+
+// mipExtent returns the size of dimension n at mip level.
+func mipExtent(n uint32, level int) uint32 {
+	return max(n>>uint(level), 1)
+}
+
+func roundUp(n, multiple uint32) uint32 {
+	return (n + multiple - 1) / multiple * multiple
+}
@@ -0,0 +1,216 @@
+package texload
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+// buildKTX2 assembles a minimal uncompressed KTX2 file. levels holds the
+// full payload of each mip level.
+func buildKTX2(vkFormat, width, height, layers, faces uint32, levels [][]byte) []byte {
+	le := binary.LittleEndian
+	hdr := make([]byte, ktx2HeaderSize+len(levels)*ktx2LevelIndexSize)
+	copy(hdr, ktx2Identifier[:])
+	le.PutUint32(hdr[12:], vkFormat)
+	le.PutUint32(hdr[16:], 1)
+	le.PutUint32(hdr[20:], width)
+	le.PutUint32(hdr[24:], height)
+	le.PutUint32(hdr[32:], layers)
+	le.PutUint32(hdr[36:], faces)
+	le.PutUint32(hdr[40:], uint32(len(levels)))
+
+	offset := uint64(len(hdr))
+	for i, lvl := range levels {
+		entry := hdr[ktx2HeaderSize+i*ktx2LevelIndexSize:]
+		le.PutUint64(entry, offset)
+		le.PutUint64(entry[8:], uint64(len(lvl)))
+		le.PutUint64(entry[16:], uint64(len(lvl)))
+		offset += uint64(len(lvl))
+	}
+	out := hdr
+	for _, lvl := range levels {
+		out = append(out, lvl...)
+	}
+	return out
+}
+
+// buildDDS assembles a DDS file. If dxgi is non-zero a DX10 header is written.
+func buildDDS(fourCC string, dxgi, width, height, mips, caps2, miscFlag, arraySize uint32, payload []byte) []byte {
+	le := binary.LittleEndian
+	out := make([]byte, 4+ddsHeaderSize)
+	copy(out, ddsMagic)
+	hdr := out[4:]
+	le.PutUint32(hdr, ddsHeaderSize)
+	le.PutUint32(hdr[8:], height)
+	le.PutUint32(hdr[12:], width)
+	le.PutUint32(hdr[24:], mips)
+	le.PutUint32(hdr[72:], 32)
+	le.PutUint32(hdr[76:], ddsFourCCFlag)
+	copy(hdr[80:84], fourCC)
+	le.PutUint32(hdr[108:], caps2)
+	if fourCC == "DX10" {
+		dx10 := make([]byte, ddsDX10Size)
+		le.PutUint32(dx10, dxgi)
+		le.PutUint32(dx10[4:], 3)
+		le.PutUint32(dx10[8:], miscFlag)
+		le.PutUint32(dx10[12:], arraySize)
+		out = append(out, dx10...)
+	}
+	return append(out, payload...)
+}
+
+func TestParseKTX2RGBA8Mips(t *testing.T) {
+	lvl0 := bytes.Repeat([]byte{1}, 4*4*4)
+	lvl1 := bytes.Repeat([]byte{2}, 2*2*4)
+	lvl2 := bytes.Repeat([]byte{3}, 1*1*4)
+	img, err := Decode(bytes.NewReader(buildKTX2(37, 4, 4, 0, 1, [][]byte{lvl0, lvl1, lvl2})))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if img.Format != gputypes.TextureFormatRGBA8Unorm || img.Width != 4 || img.Height != 4 || len(img.Levels) != 3 {
+		t.Fatalf("unexpected image %+v", img)
+	}
+	if got := img.Levels[2]; got.Width != 1 || got.Height != 1 || !bytes.Equal(got.Slices[0], lvl2) {
+		t.Errorf("level 2 = %+v", got)
+	}
+	if img.ViewDimension() != gputypes.TextureViewDimension2D {
+		t.Errorf("view dimension = %v, want 2D", img.ViewDimension())
+	}
+}
+
+func TestParseKTX2CubeBC7(t *testing.T) {
+	// 8x8 BC7 is 2x2 blocks of 16 bytes = 64 bytes per face.
+	face := func(b byte) []byte { return bytes.Repeat([]byte{b}, 64) }
+	var level []byte
+	for f := byte(0); f < 6; f++ {
+		level = append(level, face(f)...)
+	}
+	img, err := ParseKTX2(buildKTX2(145, 8, 8, 0, 6, [][]byte{level}))
+	if err != nil {
+		t.Fatalf("ParseKTX2: %v", err)
+	}
+	if img.Format != gputypes.TextureFormatBC7RGBAUnorm || !img.IsCube() || img.ArrayLayerCount() != 6 {
+		t.Fatalf("unexpected image %+v", img)
+	}
+	if img.ViewDimension() != gputypes.TextureViewDimensionCube {
+		t.Errorf("view dimension = %v, want Cube", img.ViewDimension())
+	}
+	if !bytes.Equal(img.Levels[0].Slices[5], face(5)) {
+		t.Error("face 5 payload mismatch")
+	}
+}
+
+func TestParseKTX2VkFormatRanges(t *testing.T) {
+	tests := []struct {
+		vk   uint32
+		want gputypes.TextureFormat
+	}{
+		{147, gputypes.TextureFormatETC2RGB8Unorm},
+		{156, gputypes.TextureFormatEACRG11Snorm},
+		{157, gputypes.TextureFormatASTC4x4Unorm},
+		{184, gputypes.TextureFormatASTC12x12UnormSrgb},
+	}
+	for _, tt := range tests {
+		if got := vkFormats[tt.vk]; got != tt.want {
+			t.Errorf("vkFormats[%d] = %v, want %v", tt.vk, got, tt.want)
+		}
+	}
+}
+
+func TestParseKTX2Errors(t *testing.T) {
+	good := buildKTX2(37, 2, 2, 0, 1, [][]byte{make([]byte, 16)})
+
+	supercompressed := bytes.Clone(good)
+	binary.LittleEndian.PutUint32(supercompressed[44:], 2)
+
+	unknownFormat := bytes.Clone(good)
+	binary.LittleEndian.PutUint32(unknownFormat[12:], 23) // R8G8B8_UNORM
+
+	tests := map[string][]byte{
+		"short":            good[:20],
+		"truncated level":  good[:len(good)-1],
+		"supercompressed":  supercompressed,
+		"unknown VkFormat": unknownFormat,
+		"bad face count":   buildKTX2(37, 2, 2, 0, 3, [][]byte{make([]byte, 48)}),
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseKTX2(data); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestParseDDSLegacyDXT1(t *testing.T) {
+	// 8x8 DXT1: level 0 = 4 blocks, level 1 (4x4) = 1 block, level 2 (2x2) = 1 block.
+	payload := make([]byte, (4+1+1)*8)
+	payload[len(payload)-1] = 0xEE
+	img, err := Decode(bytes.NewReader(buildDDS("DXT1", 0, 8, 8, 3, 0, 0, 0, payload)))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if img.Format != gputypes.TextureFormatBC1RGBAUnorm || len(img.Levels) != 3 {
+		t.Fatalf("unexpected image %+v", img)
+	}
+	last := img.Levels[2].Slices[0]
+	if len(last) != 8 || last[7] != 0xEE {
+		t.Errorf("level 2 payload = %v", last)
+	}
+}
+
+func TestParseDDSDX10Array(t *testing.T) {
+	// Two 2x2 RGBA8 layers with 2 mips each, stored layer-major.
+	var payload []byte
+	for layer := byte(0); layer < 2; layer++ {
+		payload = append(payload, bytes.Repeat([]byte{layer*10 + 0}, 16)...)
+		payload = append(payload, bytes.Repeat([]byte{layer*10 + 1}, 4)...)
+	}
+	img, err := ParseDDS(buildDDS("DX10", 28, 2, 2, 2, 0, 0, 2, payload))
+	if err != nil {
+		t.Fatalf("ParseDDS: %v", err)
+	}
+	if img.Layers != 2 || img.ViewDimension() != gputypes.TextureViewDimension2DArray {
+		t.Fatalf("unexpected image %+v", img)
+	}
+	if img.Levels[1].Slices[1][0] != 11 || img.Levels[0].Slices[1][0] != 10 {
+		t.Error("layer/level payloads out of order")
+	}
+}
+
+func TestParseDDSCube(t *testing.T) {
+	payload := make([]byte, 6*4)
+	img, err := ParseDDS(buildDDS("DX10", 28, 1, 1, 1, 0, ddsMiscCube, 1, payload))
+	if err != nil {
+		t.Fatalf("ParseDDS: %v", err)
+	}
+	if !img.IsCube() || len(img.Levels[0].Slices) != 6 {
+		t.Errorf("unexpected image %+v", img)
+	}
+}
+
+func TestParseDDSErrors(t *testing.T) {
+	tests := map[string][]byte{
+		"short":          []byte("DDS "),
+		"truncated data": buildDDS("DXT5", 0, 8, 8, 1, 0, 0, 0, make([]byte, 63)),
+		"unknown fourcc": buildDDS("XYZW", 0, 4, 4, 1, 0, 0, 0, make([]byte, 16)),
+		"unknown dxgi":   buildDDS("DX10", 1000, 4, 4, 1, 0, 0, 1, make([]byte, 64)),
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseDDS(data); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestDecodeUnknownContainer(t *testing.T) {
+	if _, err := Decode(bytes.NewReader([]byte("\x89PNG\r\n"))); !errors.Is(err, ErrUnknownContainer) {
+		t.Errorf("err = %v, want ErrUnknownContainer", err)
+	}
+}
@@ -1,6 +1,7 @@
 package wgpu
 
 import (
+	"runtime"
 	"unsafe"
 
 	"github.com/gogpu/gputypes"
@@ -24,7 +25,7 @@ type textureDescriptorWire struct {
 	NextInChain     uintptr
 	Label           StringView
 	Usage           uint64 // TextureUsage bitflags (uint64 in wgpu-native!)
-	Dimension       uint32 // TextureDimension (needs +1 shift)
+	Dimension       uint32 // TextureDimension (values match v29, passed directly)
 	Size            gputypes.Extent3D
 	Format          uint32 // TextureFormat (converted via map)
 	MipLevelCount   uint32
@@ -52,7 +53,7 @@ type textureViewDescriptorWire struct {
 	NextInChain     uintptr
 	Label           StringView
 	Format          uint32 // TextureFormat (converted)
-	Dimension       uint32 // TextureViewDimension (needs +1 shift)
+	Dimension       uint32 // TextureViewDimension (values match v29, passed directly)
 	BaseMipLevel    uint32
 	MipLevelCount   uint32
 	BaseArrayLayer  uint32
@@ -63,10 +64,24 @@ type textureViewDescriptorWire struct {
 }
 
 // CreateView creates a view into this texture.
-// Pass nil for default view parameters.
+// Pass nil for default view parameters. For TextureAspectDepthOnly and
+// TextureAspectStencilOnly views, a Format that is undefined or equal to the
+// texture format is replaced by the matching single-aspect format.
 // Enum values are converted from gputypes to wgpu-native values before FFI call.
 // Returns an error if the FFI call fails or the texture is nil.
 func (t *Texture) CreateView(desc *TextureViewDescriptor) (*TextureView, error) {
+	return t.createView(desc, false)
+}
+
+// CreateViewE is CreateView that also returns the validation error raised by
+// wgpu-native for a view the texture does not support. Views of surface
+// textures are created as by CreateView.
+func (t *Texture) CreateViewE(desc *TextureViewDescriptor) (*TextureView, error) {
+	return t.createView(desc, true)
+}
+
+// createView creates a view, inside an error scope when capture is set.
+func (t *Texture) createView(desc *TextureViewDescriptor, capture bool) (*TextureView, error) {
 	if err := checkInit(); err != nil {
 		return nil, err
 	}
@@ -74,12 +89,28 @@ func (t *Texture) CreateView(desc *TextureViewDescriptor) (*TextureView, error)
 		return nil, &WGPUError{Op: "CreateView", Message: "texture is nil or released"}
 	}
 
+	view := &TextureView{sampleCount: t.SampleCount(), format: t.Format(), texture: t}
+	var baseMip uint32
 	var descPtr uintptr
+	var label string
 	if desc != nil {
+		label = desc.Label
+		format := desc.Format
+		if desc.Aspect == TextureAspectDepthOnly || desc.Aspect == TextureAspectStencilOnly {
+			// Aspect views of combined depth-stencil textures need the
+			// single-aspect format; fill it in when left as the texture format.
+			resolved, err := aspectViewFormat(view.format, desc.Aspect)
+			if err != nil {
+				return nil, &WGPUError{Op: "CreateView", Type: ErrorTypeValidation, Message: err.Error()}
+			}
+			if format == gputypes.TextureFormatUndefined || format == view.format {
+				format = resolved
+			}
+		}
 		// Convert Go-idiomatic descriptor to FFI wire format
 		wireDesc := textureViewDescriptorWire{
 			Label:           stringToStringView(desc.Label),
-			Format:          uint32(desc.Format),
+			Format:          uint32(format),
 			Dimension:       uint32(desc.Dimension),
 			BaseMipLevel:    desc.BaseMipLevel,
 			MipLevelCount:   desc.MipLevelCount,
@@ -89,17 +120,64 @@ func (t *Texture) CreateView(desc *TextureViewDescriptor) (*TextureView, error)
 			Usage:           uint64(desc.Usage), // bitflags, uint64 in wgpu-native
 		}
 		descPtr = uintptr(unsafe.Pointer(&wireDesc))
+		if format != gputypes.TextureFormatUndefined {
+			view.format = format
+		}
+		baseMip = desc.BaseMipLevel
 	}
 
-	handle, _, _ := procTextureCreateView.Call(
-		t.handle,
-		descPtr,
-	)
+	var handle uintptr
+	create := func() {
+		handle, _, _ = procTextureCreateView.Call(
+			t.handle,
+			descPtr,
+		)
+	}
+	var err error
+	if capture && t.device != nil {
+		err = t.device.captureValidation("CreateView", create)
+	} else {
+		create()
+	}
 	if handle == 0 {
 		return nil, &WGPUError{Op: "CreateView", Message: "wgpu returned null handle"}
 	}
-	trackResource(handle, "TextureView")
-	return &TextureView{handle: handle}, nil
+	trackResource(handle, "TextureView", label)
+	view.handle = handle
+	if err != nil {
+		// wgpu-native returns an invalid view rather than null.
+		view.Release()
+		return nil, err
+	}
+	view.width = max(t.Width()>>baseMip, 1)
+	view.height = max(t.Height()>>baseMip, 1)
+	if traceActive.Load() {
+		traceCall("Texture.CreateView", t, view, desc)
+	}
+	return view, nil
+}
+
+// DefaultView returns a view of the whole texture, equivalent to
+// CreateView(nil). The view is created on the first call and reused
+// afterwards; it is owned by the texture and released by [Texture.Release],
+// so callers must not release it themselves.
+func (t *Texture) DefaultView() (*TextureView, error) {
+	if err := checkInit(); err != nil {
+		return nil, err
+	}
+	if t == nil || t.handle == 0 {
+		return nil, &WGPUError{Op: "DefaultView", Message: "texture is nil or released"}
+	}
+	t.viewMu.Lock()
+	defer t.viewMu.Unlock()
+	if t.defaultView == nil || t.defaultView.handle == 0 {
+		view, err := t.CreateView(nil)
+		if err != nil {
+			return nil, err
+		}
+		t.defaultView = view
+	}
+	return t.defaultView, nil
 }
 
 // Destroy destroys the texture.
@@ -107,15 +185,28 @@ func (t *Texture) Destroy() {
 	mustInit()
 	if t.handle != 0 {
 		procTextureDestroy.Call(t.handle) //nolint:errcheck
+		t.released.set()
 	}
 }
 
-// Release releases the texture reference.
+// Release releases the texture reference, along with the view returned by
+// DefaultView if one was created.
 func (t *Texture) Release() {
 	if t.handle != 0 {
+		t.viewMu.Lock()
+		if t.defaultView != nil {
+			t.defaultView.Release()
+			t.defaultView = nil
+		}
+		t.viewMu.Unlock()
 		untrackResource(t.handle)
 		procTextureRelease.Call(t.handle) //nolint:errcheck
 		t.handle = 0
+		t.released.set()
+		if t.surface != nil {
+			t.surface.discard(t.surfaceFrame)
+			t.surface = nil
+		}
 	}
 }
 
@@ -162,6 +253,16 @@ func (t *Texture) MipLevelCount() uint32 {
 	return uint32(result)
 }
 
+// Dimension returns the texture dimension (1D, 2D or 3D).
+func (t *Texture) Dimension() gputypes.TextureDimension {
+	mustInit()
+	if t == nil || t.handle == 0 {
+		return gputypes.TextureDimensionUndefined
+	}
+	result, _, _ := procTextureGetDimension.Call(t.handle)
+	return gputypes.TextureDimension(result)
+}
+
 // Format returns the texture format.
 // TextureFormat values match between gputypes v0.3.0 and wgpu-native v29 exactly.
 func (t *Texture) Format() gputypes.TextureFormat {
@@ -173,12 +274,33 @@ func (t *Texture) Format() gputypes.TextureFormat {
 	return gputypes.TextureFormat(result)
 }
 
+// SampleCount returns the number of samples per texel (1 for non-multisampled textures).
+func (t *Texture) SampleCount() uint32 {
+	mustInit()
+	if t == nil || t.handle == 0 {
+		return 0
+	}
+	result, _, _ := procTextureGetSampleCount.Call(t.handle)
+	return uint32(result)
+}
+
+// Usage returns the usage flags the texture was created with.
+func (t *Texture) Usage() gputypes.TextureUsage {
+	mustInit()
+	if t == nil || t.handle == 0 {
+		return gputypes.TextureUsageNone
+	}
+	result, _, _ := procTextureGetUsage.Call(t.handle)
+	return gputypes.TextureUsage(result)
+}
+
 // Release releases the texture view reference.
 func (tv *TextureView) Release() {
 	if tv.handle != 0 {
 		untrackResource(tv.handle)
 		procTextureViewRelease.Call(tv.handle) //nolint:errcheck
 		tv.handle = 0
+		tv.released.set()
 	}
 }
 
@@ -187,8 +309,22 @@ func (tv *TextureView) Handle() uintptr { return tv.handle }
 
 // CreateTexture creates a texture with the specified descriptor.
 // Enum values are converted from gputypes to wgpu-native values before FFI call.
-// Returns an error if the FFI call fails or the device/descriptor is nil.
+// Returns an error if the FFI call fails, the device/descriptor is nil or
+// the descriptor fails the Go-side format checks.
 func (d *Device) CreateTexture(desc *TextureDescriptor) (*Texture, error) {
+	return d.createTexture(desc, false)
+}
+
+// CreateTextureE is CreateTexture that also returns the validation error
+// raised by wgpu-native for a texture the device cannot create, and
+// out-of-memory errors after the device's [OutOfMemoryHandler] had a chance
+// to retry.
+func (d *Device) CreateTextureE(desc *TextureDescriptor) (*Texture, error) {
+	return d.createTexture(desc, true)
+}
+
+// createTexture creates a texture, inside error scopes when capture is set.
+func (d *Device) createTexture(desc *TextureDescriptor, capture bool) (*Texture, error) {
 	if err := checkInit(); err != nil {
 		return nil, err
 	}
@@ -198,6 +334,17 @@ func (d *Device) CreateTexture(desc *TextureDescriptor) (*Texture, error) {
 	if desc == nil {
 		return nil, &WGPUError{Op: "CreateTexture", Message: "descriptor is nil"}
 	}
+	if debugBuild {
+		if err := checkTextureDescriptor(desc); err != nil {
+			return nil, &WGPUError{Op: "CreateTexture", Type: ErrorTypeValidation, Message: err.Error()}
+		}
+	}
+	if err := validateTextureFormatFeatures(desc.Format, desc.Dimension, d.HasFeature); err != nil {
+		return nil, &WGPUError{Op: "CreateTexture", Type: ErrorTypeValidation, Message: err.Error()}
+	}
+	if err := validateViewFormats(desc.Format, desc.ViewFormats); err != nil {
+		return nil, &WGPUError{Op: "CreateTexture", Type: ErrorTypeValidation, Message: err.Error()}
+	}
 
 	// wgpu-native requires MipLevelCount >= 1 and SampleCount >= 1
 	mipLevelCount := desc.MipLevelCount
@@ -209,18 +356,7 @@ func (d *Device) CreateTexture(desc *TextureDescriptor) (*Texture, error) {
 		sampleCount = 1
 	}
 
-	// Convert []TextureFormat → []uint32 for FFI (values match, but wire struct needs uint32 pointer)
-	var viewFormatCount uintptr
-	var viewFormatsPtr uintptr
-	if len(desc.ViewFormats) > 0 {
-		// Convert to uint32 slice (gputypes values equal wgpu-native values)
-		wireFormats := make([]uint32, len(desc.ViewFormats))
-		for i, f := range desc.ViewFormats {
-			wireFormats[i] = uint32(f)
-		}
-		viewFormatCount = uintptr(len(wireFormats))
-		viewFormatsPtr = uintptr(unsafe.Pointer(&wireFormats[0]))
-	}
+	viewFormats, viewFormatCount, viewFormatsPtr := viewFormatsWire(desc.ViewFormats)
 
 	// Convert to wire format with wgpu-native enum values
 	wireDesc := textureDescriptorWire{
@@ -235,15 +371,35 @@ func (d *Device) CreateTexture(desc *TextureDescriptor) (*Texture, error) {
 		ViewFormats:     viewFormatsPtr,
 	}
 
-	handle, _, _ := procDeviceCreateTexture.Call(
-		d.handle,
-		uintptr(unsafe.Pointer(&wireDesc)),
-	)
+	create := func() uintptr {
+		h, _, _ := procDeviceCreateTexture.Call(
+			d.handle,
+			uintptr(unsafe.Pointer(&wireDesc)),
+		)
+		return h
+	}
+	var handle uintptr
+	var err error
+	if capture {
+		handle, err = d.allocate("CreateTexture", create, func(h uintptr) { procTextureRelease.Call(h) }) //nolint:errcheck
+	} else {
+		handle = create()
+	}
+	runtime.KeepAlive(viewFormats)
 	if handle == 0 {
 		return nil, &WGPUError{Op: "CreateTexture", Message: "wgpu returned null handle"}
 	}
-	trackResource(handle, "Texture")
-	return &Texture{handle: handle}, nil
+	trackResource(handle, "Texture", desc.Label)
+	tex := &Texture{handle: handle, device: d}
+	if err != nil {
+		// wgpu-native returns an invalid texture rather than null.
+		tex.Release()
+		return nil, err
+	}
+	if traceActive.Load() {
+		traceCall("Device.CreateTexture", nil, tex, desc)
+	}
+	return tex, nil
 }
 
 // TexelCopyTextureInfo describes a texture for WriteTexture (low-level wire type).
@@ -320,6 +476,14 @@ func (q *Queue) WriteTexture(dest *ImageCopyTexture, data []byte, layout *ImageD
 		BytesPerRow:  layout.BytesPerRow,
 		RowsPerImage: layout.RowsPerImage,
 	}
+	if debugBuild && dest.Texture != nil {
+		if err := checkCopyLayout(wireLayout, dest.Texture.Format(), *size, false); err != nil {
+			return &WGPUError{Op: "WriteTexture", Type: ErrorTypeValidation, Message: err.Error()}
+		}
+	}
+	if traceActive.Load() {
+		traceCall("Queue.WriteTexture", nil, nil, dest, data, layout, size)
+	}
 	procQueueWriteTexture.Call( //nolint:errcheck
 		q.handle,
 		uintptr(unsafe.Pointer(&wire)),
@@ -338,6 +502,11 @@ func (q *Queue) WriteTextureRaw(dest *TexelCopyTextureInfo, data []byte, layout
 	if q == nil || q.handle == 0 || dest == nil || layout == nil || size == nil || len(data) == 0 {
 		return nil
 	}
+	if debugBuild {
+		if err := checkCopyLayout(*layout, textureFormatOf(dest.Texture), *size, false); err != nil {
+			return &WGPUError{Op: "WriteTextureRaw", Type: ErrorTypeValidation, Message: err.Error()}
+		}
+	}
 	procQueueWriteTexture.Call( //nolint:errcheck
 		q.handle,
 		uintptr(unsafe.Pointer(dest)),
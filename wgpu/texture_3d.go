@@ -0,0 +1,42 @@
+package wgpu
+
+import "github.com/gogpu/gputypes"
+
+// CreateTexture3D creates a width x height x depth volume texture.
+// mipLevelCount 0 is treated as 1; each level halves all three dimensions.
+// TextureUsageTextureBinding and TextureUsageCopyDst are always included.
+func (d *Device) CreateTexture3D(label string, width, height, depth uint32, format gputypes.TextureFormat, mipLevelCount uint32, usage gputypes.TextureUsage) (*Texture, error) {
+	return d.CreateTexture(&TextureDescriptor{
+		Label:         label,
+		Usage:         usage | gputypes.TextureUsageTextureBinding | gputypes.TextureUsageCopyDst,
+		Dimension:     gputypes.TextureDimension3D,
+		Size:          gputypes.Extent3D{Width: width, Height: height, DepthOrArrayLayers: depth},
+		Format:        format,
+		MipLevelCount: mipLevelCount,
+		SampleCount:   1,
+	})
+}
+
+// Create3DView creates a TextureViewDimension3D view covering every mip level of t.
+func (t *Texture) Create3DView() (*TextureView, error) {
+	return t.CreateView(&TextureViewDescriptor{
+		Format:          t.Format(),
+		Dimension:       gputypes.TextureViewDimension3D,
+		MipLevelCount:   t.MipLevelCount(),
+		ArrayLayerCount: 1,
+		Aspect:          TextureAspectAll,
+	})
+}
+
+// Texture3DLayoutEntry returns a BindGroupLayoutEntry for a filterable
+// float texture_3d binding.
+func Texture3DLayoutEntry(binding uint32, visibility gputypes.ShaderStage) BindGroupLayoutEntry {
+	return BindGroupLayoutEntry{
+		Binding:    binding,
+		Visibility: visibility,
+		Texture: &TextureBindingLayout{
+			SampleType:    gputypes.TextureSampleTypeFloat,
+			ViewDimension: gputypes.TextureViewDimension3D,
+		},
+	}
+}
@@ -0,0 +1,96 @@
+package wgpu
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/gogpu/gputypes"
+)
+
+// CreateTextureArray creates a width x height 2D array texture with layers
+// array layers. mipLevelCount 0 is treated as 1. TextureUsageTextureBinding
+// and TextureUsageCopyDst are always included.
+func (d *Device) CreateTextureArray(label string, width, height, layers uint32, format gputypes.TextureFormat, mipLevelCount uint32, usage gputypes.TextureUsage) (*Texture, error) {
+	return d.CreateTexture(&TextureDescriptor{
+		Label:         label,
+		Usage:         usage | gputypes.TextureUsageTextureBinding | gputypes.TextureUsageCopyDst,
+		Dimension:     gputypes.TextureDimension2D,
+		Size:          gputypes.Extent3D{Width: width, Height: height, DepthOrArrayLayers: layers},
+		Format:        format,
+		MipLevelCount: mipLevelCount,
+		SampleCount:   1,
+	})
+}
+
+// WriteTextureLayer uploads img into mip level 0 of array layer layer of tex.
+//
+// The image is converted the same way as in [Device.CreateTextureFromImage]
+// and must match the texture size and format: R8Unorm textures accept
+// *image.Gray, RGBA8Unorm and RGBA8UnormSrgb textures accept everything else.
+func (q *Queue) WriteTextureLayer(tex *Texture, layer uint32, img image.Image) error {
+	if tex == nil || tex.handle == 0 {
+		return &WGPUError{Op: "WriteTextureLayer", Message: "texture is nil or released"}
+	}
+	if img == nil {
+		return &WGPUError{Op: "WriteTextureLayer", Message: "image is nil"}
+	}
+	pix, channels, width, height := imagePixels(img)
+	if err := checkTextureLayer(tex.Format(), tex.Width(), tex.Height(), tex.DepthOrArrayLayers(), layer, channels, width, height); err != nil {
+		return &WGPUError{Op: "WriteTextureLayer", Message: err.Error()}
+	}
+	return q.WriteTextureData(tex, 0, gputypes.Origin3D{Z: layer}, pix, width, height)
+}
+
+// checkTextureLayer validates a WriteTextureLayer call against the texture description.
+func checkTextureLayer(format gputypes.TextureFormat, texW, texH, layers, layer uint32, channels int, w, h uint32) error {
+	if layer >= layers {
+		return fmt.Errorf("layer %d out of range (texture has %d layers)", layer, layers)
+	}
+	if w != texW || h != texH {
+		return fmt.Errorf("image is %dx%d, texture is %dx%d", w, h, texW, texH)
+	}
+	want := imageTextureFormat(channels, format == gputypes.TextureFormatRGBA8UnormSrgb)
+	if want != format {
+		return fmt.Errorf("image pixels convert to %s, texture format is %s", want, format)
+	}
+	return nil
+}
+
+// CreateLayerView creates a 2D view of a single array layer of t, covering
+// all mip levels. Useful for rendering into one shadow cascade or atlas page.
+func (t *Texture) CreateLayerView(layer uint32) (*TextureView, error) {
+	return t.CreateView(&TextureViewDescriptor{
+		Format:          t.Format(),
+		Dimension:       gputypes.TextureViewDimension2D,
+		MipLevelCount:   t.MipLevelCount(),
+		BaseArrayLayer:  layer,
+		ArrayLayerCount: 1,
+		Aspect:          TextureAspectAll,
+	})
+}
+
+// CreateArrayView creates a 2D array view of count layers of t starting at
+// baseLayer, covering all mip levels.
+func (t *Texture) CreateArrayView(baseLayer, count uint32) (*TextureView, error) {
+	return t.CreateView(&TextureViewDescriptor{
+		Format:          t.Format(),
+		Dimension:       gputypes.TextureViewDimension2DArray,
+		MipLevelCount:   t.MipLevelCount(),
+		BaseArrayLayer:  baseLayer,
+		ArrayLayerCount: count,
+		Aspect:          TextureAspectAll,
+	})
+}
+
+// TextureArrayLayoutEntry returns a BindGroupLayoutEntry for a filterable
+// float texture_2d_array binding.
+func TextureArrayLayoutEntry(binding uint32, visibility gputypes.ShaderStage) BindGroupLayoutEntry {
+	return BindGroupLayoutEntry{
+		Binding:    binding,
+		Visibility: visibility,
+		Texture: &TextureBindingLayout{
+			SampleType:    gputypes.TextureSampleTypeFloat,
+			ViewDimension: gputypes.TextureViewDimension2DArray,
+		},
+	}
+}
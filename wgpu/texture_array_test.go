@@ -0,0 +1,42 @@
+package wgpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestCheckTextureLayer(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   gputypes.TextureFormat
+		layer    uint32
+		channels int
+		w, h     uint32
+		wantErr  bool
+	}{
+		{"rgba ok", gputypes.TextureFormatRGBA8Unorm, 3, 4, 32, 32, false},
+		{"srgb ok", gputypes.TextureFormatRGBA8UnormSrgb, 0, 4, 32, 32, false},
+		{"gray ok", gputypes.TextureFormatR8Unorm, 0, 1, 32, 32, false},
+		{"layer out of range", gputypes.TextureFormatRGBA8Unorm, 4, 4, 32, 32, true},
+		{"size mismatch", gputypes.TextureFormatRGBA8Unorm, 0, 4, 16, 32, true},
+		{"gray into rgba", gputypes.TextureFormatRGBA8Unorm, 0, 1, 32, 32, true},
+		{"color into float", gputypes.TextureFormatRGBA16Float, 0, 4, 32, 32, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkTextureLayer(tt.format, 32, 32, 4, tt.layer, tt.channels, tt.w, tt.h)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTextureArrayLayoutEntry(t *testing.T) {
+	e := TextureArrayLayoutEntry(0, gputypes.ShaderStageFragment)
+	wire := e.toWire()
+	if wire.Texture.ViewDimension != uint32(gputypes.TextureViewDimension2DArray) {
+		t.Errorf("wire ViewDimension = %d, want 2DArray", wire.Texture.ViewDimension)
+	}
+}
@@ -0,0 +1,62 @@
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+)
+
+// aspectViewFormat returns the format a view selecting aspect of a texture
+// in format must use. Depth-only views of combined depth-stencil formats use
+// the depth format, stencil-only views use Stencil8.
+func aspectViewFormat(format gputypes.TextureFormat, aspect TextureAspect) (gputypes.TextureFormat, error) {
+	switch aspect {
+	case TextureAspectUndefined, TextureAspectAll:
+		return format, nil
+	case TextureAspectDepthOnly:
+		if !format.HasDepth() {
+			return 0, fmt.Errorf("format %s has no depth aspect", format)
+		}
+		switch format {
+		case gputypes.TextureFormatDepth24PlusStencil8:
+			return gputypes.TextureFormatDepth24Plus, nil
+		case gputypes.TextureFormatDepth32FloatStencil8:
+			return gputypes.TextureFormatDepth32Float, nil
+		}
+		return format, nil
+	case TextureAspectStencilOnly:
+		if !format.HasStencil() {
+			return 0, fmt.Errorf("format %s has no stencil aspect", format)
+		}
+		return gputypes.TextureFormatStencil8, nil
+	default:
+		return 0, fmt.Errorf("unknown texture aspect 0x%X", uint32(aspect))
+	}
+}
+
+// CreateDepthView creates a 2D view of the depth aspect of a depth or
+// depth-stencil texture, suitable for sampling as texture_depth_2d.
+func (t *Texture) CreateDepthView() (*TextureView, error) {
+	return t.createAspectView(TextureAspectDepthOnly)
+}
+
+// CreateStencilView creates a 2D view of the stencil aspect of a stencil or
+// depth-stencil texture, suitable for sampling as texture_2d<u32>.
+func (t *Texture) CreateStencilView() (*TextureView, error) {
+	return t.createAspectView(TextureAspectStencilOnly)
+}
+
+func (t *Texture) createAspectView(aspect TextureAspect) (*TextureView, error) {
+	if err := checkInit(); err != nil {
+		return nil, err
+	}
+	if t == nil || t.handle == 0 {
+		return nil, &WGPUError{Op: "CreateView", Message: "texture is nil or released"}
+	}
+	return t.CreateView(&TextureViewDescriptor{
+		Dimension:       gputypes.TextureViewDimension2D,
+		MipLevelCount:   t.MipLevelCount(),
+		ArrayLayerCount: 1,
+		Aspect:          aspect,
+	})
+}
@@ -0,0 +1,38 @@
+package wgpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestAspectViewFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  gputypes.TextureFormat
+		aspect  TextureAspect
+		want    gputypes.TextureFormat
+		wantErr bool
+	}{
+		{"D24S8 depth", gputypes.TextureFormatDepth24PlusStencil8, TextureAspectDepthOnly, gputypes.TextureFormatDepth24Plus, false},
+		{"D24S8 stencil", gputypes.TextureFormatDepth24PlusStencil8, TextureAspectStencilOnly, gputypes.TextureFormatStencil8, false},
+		{"D32S8 depth", gputypes.TextureFormatDepth32FloatStencil8, TextureAspectDepthOnly, gputypes.TextureFormatDepth32Float, false},
+		{"D32S8 stencil", gputypes.TextureFormatDepth32FloatStencil8, TextureAspectStencilOnly, gputypes.TextureFormatStencil8, false},
+		{"D32 depth", gputypes.TextureFormatDepth32Float, TextureAspectDepthOnly, gputypes.TextureFormatDepth32Float, false},
+		{"D24S8 all", gputypes.TextureFormatDepth24PlusStencil8, TextureAspectAll, gputypes.TextureFormatDepth24PlusStencil8, false},
+		{"D32 stencil", gputypes.TextureFormatDepth32Float, TextureAspectStencilOnly, 0, true},
+		{"RGBA8 depth", gputypes.TextureFormatRGBA8Unorm, TextureAspectDepthOnly, 0, true},
+		{"unknown aspect", gputypes.TextureFormatDepth16Unorm, TextureAspect(9), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := aspectViewFormat(tt.format, tt.aspect)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+)
+
+// copySubresource is one side of a texture-to-texture copy with the texture
+// properties the copy is validated against.
+type copySubresource struct {
+	texture     *Texture
+	format      gputypes.TextureFormat
+	sampleCount uint32
+	mipLevels   uint32
+	dimension   gputypes.TextureDimension
+	size        gputypes.Extent3D // size of mip level 0
+	mipLevel    uint32
+	origin      gputypes.Origin3D
+	aspect      TextureAspect
+}
+
+// newCopySubresource reads the properties of info.Texture needed by
+// validateTextureCopy.
+func newCopySubresource(info *ImageCopyTexture) copySubresource {
+	t := info.Texture
+	return copySubresource{
+		texture:     t,
+		format:      t.Format(),
+		sampleCount: t.SampleCount(),
+		mipLevels:   t.MipLevelCount(),
+		dimension:   t.Dimension(),
+		size:        gputypes.Extent3D{Width: t.Width(), Height: t.Height(), DepthOrArrayLayers: t.DepthOrArrayLayers()},
+		mipLevel:    info.MipLevel,
+		origin:      info.Origin,
+		aspect:      info.Aspect,
+	}
+}
+
+// mipSize returns the physical size of the copied mip level: the texel size
+// rounded up to whole blocks. The depth of a 3D texture halves with each
+// level like its width and height; array layers are not reduced.
+func (s *copySubresource) mipSize() gputypes.Extent3D {
+	bw, bh := TextureFormatBlockDimensions(s.format)
+	w := max(s.size.Width>>s.mipLevel, 1)
+	h := max(s.size.Height>>s.mipLevel, 1)
+	d := s.size.DepthOrArrayLayers
+	if s.dimension == gputypes.TextureDimension3D {
+		d = max(d>>s.mipLevel, 1)
+	}
+	return gputypes.Extent3D{
+		Width:              (w + bw - 1) / bw * bw,
+		Height:             (h + bh - 1) / bh * bh,
+		DepthOrArrayLayers: d,
+	}
+}
+
+// check validates the mip level, aspect, block alignment and bounds of a
+// copy of size at s. what names the side in errors.
+func (s *copySubresource) check(what string, size gputypes.Extent3D) error {
+	if s.mipLevel >= s.mipLevels {
+		return fmt.Errorf("%s mip level %d out of range, texture has %d", what, s.mipLevel, s.mipLevels)
+	}
+	if _, err := aspectViewFormat(s.format, s.aspect); err != nil {
+		return fmt.Errorf("%s: %w", what, err)
+	}
+	bw, bh := TextureFormatBlockDimensions(s.format)
+	if s.origin.X%bw != 0 || s.origin.Y%bh != 0 || size.Width%bw != 0 || size.Height%bh != 0 {
+		return fmt.Errorf("%s origin and size must be multiples of the %dx%d block of %s", what, bw, bh, s.format)
+	}
+	m := s.mipSize()
+	if uint64(s.origin.X)+uint64(size.Width) > uint64(m.Width) ||
+		uint64(s.origin.Y)+uint64(size.Height) > uint64(m.Height) ||
+		uint64(s.origin.Z)+uint64(size.DepthOrArrayLayers) > uint64(m.DepthOrArrayLayers) {
+		return fmt.Errorf("%s region %v+%v exceeds mip level %d size %v", what, s.origin, size, s.mipLevel, m)
+	}
+	wholeOnly := s.sampleCount > 1 || s.format.HasDepth() || s.format.HasStencil()
+	if wholeOnly && (s.origin != gputypes.Origin3D{} || size.Width != m.Width || size.Height != m.Height) {
+		return fmt.Errorf("%s: multisampled and depth/stencil copies must cover the whole mip level", what)
+	}
+	return nil
+}
+
+// validateTextureCopy checks a copy of size from src to dst: both sides are
+// in bounds, the formats are equal or differ only in sRGB-ness, sample
+// counts match, and a copy within one texture does not overlap itself.
+func validateTextureCopy(src, dst *copySubresource, size gputypes.Extent3D) error {
+	if err := src.check("source", size); err != nil {
+		return err
+	}
+	if err := dst.check("destination", size); err != nil {
+		return err
+	}
+	if src.format != dst.format {
+		if pair, ok := TextureFormatSrgbCounterpart(src.format); !ok || pair != dst.format {
+			return fmt.Errorf("cannot copy %s to %s", src.format, dst.format)
+		}
+	}
+	if src.sampleCount != dst.sampleCount {
+		return fmt.Errorf("source sample count %d, destination sample count %d", src.sampleCount, dst.sampleCount)
+	}
+	if src.texture == dst.texture && src.mipLevel == dst.mipLevel &&
+		src.origin.Z < dst.origin.Z+size.DepthOrArrayLayers && dst.origin.Z < src.origin.Z+size.DepthOrArrayLayers {
+		return fmt.Errorf("source and destination overlap in mip level %d", src.mipLevel)
+	}
+	return nil
+}
+
+// CopyTexture copies a size region from src to dst, one [TextureCopy] region
+// of [CommandEncoder.CopyTextureToTexture]. The copy is validated on the Go
+// side; an invalid copy is skipped and its error returned by
+// [CommandEncoder.Finish].
+func (enc *CommandEncoder) CopyTexture(src, dst *ImageCopyTexture, size gputypes.Extent3D) {
+	if src == nil || dst == nil {
+		return
+	}
+	enc.CopyTextureToTexture(src.Texture, dst.Texture, []TextureCopy{{Source: *src, Destination: *dst, Size: size}})
+}
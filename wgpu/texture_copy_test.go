@@ -0,0 +1,73 @@
+package wgpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestValidateTextureCopy(t *testing.T) {
+	texA, texB := &Texture{}, &Texture{}
+	side := func(tex *Texture, format gputypes.TextureFormat, mip uint32, origin gputypes.Origin3D) copySubresource {
+		return copySubresource{
+			texture:     tex,
+			format:      format,
+			sampleCount: 1,
+			mipLevels:   3,
+			size:        gputypes.Extent3D{Width: 64, Height: 64, DepthOrArrayLayers: 4},
+			mipLevel:    mip,
+			origin:      origin,
+		}
+	}
+	rgba := gputypes.TextureFormatRGBA8Unorm
+	ext := func(w, h, d uint32) gputypes.Extent3D {
+		return gputypes.Extent3D{Width: w, Height: h, DepthOrArrayLayers: d}
+	}
+	tests := []struct {
+		name     string
+		src, dst copySubresource
+		size     gputypes.Extent3D
+		wantErr  bool
+	}{
+		{"full copy", side(texA, rgba, 0, gputypes.Origin3D{}), side(texB, rgba, 0, gputypes.Origin3D{}), ext(64, 64, 4), false},
+		{"mip 2 region", side(texA, rgba, 2, gputypes.Origin3D{X: 8}), side(texB, rgba, 0, gputypes.Origin3D{}), ext(8, 16, 1), false},
+		{"srgb counterpart", side(texA, rgba, 0, gputypes.Origin3D{}), side(texB, gputypes.TextureFormatRGBA8UnormSrgb, 0, gputypes.Origin3D{}), ext(1, 1, 1), false},
+		{"other layer of same texture", side(texA, rgba, 0, gputypes.Origin3D{}), side(texA, rgba, 0, gputypes.Origin3D{Z: 1}), ext(64, 64, 1), false},
+		{"mip out of range", side(texA, rgba, 3, gputypes.Origin3D{}), side(texB, rgba, 0, gputypes.Origin3D{}), ext(1, 1, 1), true},
+		{"out of bounds", side(texA, rgba, 1, gputypes.Origin3D{X: 16}), side(texB, rgba, 0, gputypes.Origin3D{}), ext(32, 1, 1), true},
+		{"too many layers", side(texA, rgba, 0, gputypes.Origin3D{Z: 2}), side(texB, rgba, 0, gputypes.Origin3D{}), ext(1, 1, 3), true},
+		{"format mismatch", side(texA, rgba, 0, gputypes.Origin3D{}), side(texB, gputypes.TextureFormatBGRA8Unorm, 0, gputypes.Origin3D{}), ext(1, 1, 1), true},
+		{"overlap", side(texA, rgba, 0, gputypes.Origin3D{}), side(texA, rgba, 0, gputypes.Origin3D{X: 32}), ext(16, 16, 1), true},
+		{"unaligned block", side(texA, gputypes.TextureFormatBC1RGBAUnorm, 0, gputypes.Origin3D{X: 2}), side(texB, gputypes.TextureFormatBC1RGBAUnorm, 0, gputypes.Origin3D{}), ext(4, 4, 1), true},
+		{"partial depth copy", side(texA, gputypes.TextureFormatDepth32Float, 0, gputypes.Origin3D{}), side(texB, gputypes.TextureFormatDepth32Float, 0, gputypes.Origin3D{}), ext(32, 32, 1), true},
+		{"whole depth layer", side(texA, gputypes.TextureFormatDepth32Float, 0, gputypes.Origin3D{}), side(texB, gputypes.TextureFormatDepth32Float, 0, gputypes.Origin3D{}), ext(64, 64, 1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTextureCopy(&tt.src, &tt.dst, tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	ms := side(texA, rgba, 0, gputypes.Origin3D{})
+	ms.sampleCount = 4
+	single := side(texB, rgba, 0, gputypes.Origin3D{})
+	if err := validateTextureCopy(&ms, &single, ext(64, 64, 1)); err == nil {
+		t.Error("sample count mismatch: expected an error")
+	}
+	vol := side(texA, rgba, 1, gputypes.Origin3D{})
+	vol.dimension = gputypes.TextureDimension3D
+	if err := validateTextureCopy(&vol, &single, ext(32, 32, 2)); err != nil {
+		t.Errorf("3D mip 1 full depth: %v", err)
+	}
+	if err := validateTextureCopy(&vol, &single, ext(32, 32, 3)); err == nil {
+		t.Error("3D mip 1 depth beyond the level: expected an error")
+	}
+	stencil := side(texA, rgba, 0, gputypes.Origin3D{})
+	stencil.aspect = TextureAspectStencilOnly
+	if err := validateTextureCopy(&stencil, &single, ext(1, 1, 1)); err == nil {
+		t.Error("stencil aspect of a color format: expected an error")
+	}
+}
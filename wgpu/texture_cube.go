@@ -0,0 +1,140 @@
+package wgpu
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/gogpu/gputypes"
+)
+
+// CubeFace identifies one face of a cube map. The value is the array layer
+// the face occupies, in the order WGSL texture_cube sampling expects.
+type CubeFace uint32
+
+const (
+	// CubeFacePositiveX is the +X face (array layer 0).
+	CubeFacePositiveX CubeFace = iota
+	// CubeFaceNegativeX is the -X face (array layer 1).
+	CubeFaceNegativeX
+	// CubeFacePositiveY is the +Y face (array layer 2).
+	CubeFacePositiveY
+	// CubeFaceNegativeY is the -Y face (array layer 3).
+	CubeFaceNegativeY
+	// CubeFacePositiveZ is the +Z face (array layer 4).
+	CubeFacePositiveZ
+	// CubeFaceNegativeZ is the -Z face (array layer 5).
+	CubeFaceNegativeZ
+)
+
+// cubeFaceCount is the number of array layers in a cube map.
+const cubeFaceCount = 6
+
+// CreateCubeTexture creates a size x size cube map texture with six array layers.
+// mipLevelCount 0 is treated as 1. TextureUsageTextureBinding is always included.
+func (d *Device) CreateCubeTexture(label string, size uint32, format gputypes.TextureFormat, mipLevelCount uint32, usage gputypes.TextureUsage) (*Texture, error) {
+	return d.CreateTexture(&TextureDescriptor{
+		Label:         label,
+		Usage:         usage | gputypes.TextureUsageTextureBinding,
+		Dimension:     gputypes.TextureDimension2D,
+		Size:          gputypes.Extent3D{Width: size, Height: size, DepthOrArrayLayers: cubeFaceCount},
+		Format:        format,
+		MipLevelCount: mipLevelCount,
+		SampleCount:   1,
+	})
+}
+
+// CreateCubeView creates a TextureViewDimensionCube view covering all six
+// layers and every mip level of t.
+func (t *Texture) CreateCubeView() (*TextureView, error) {
+	return t.CreateView(&TextureViewDescriptor{
+		Format:          t.Format(),
+		Dimension:       gputypes.TextureViewDimensionCube,
+		MipLevelCount:   t.MipLevelCount(),
+		ArrayLayerCount: cubeFaceCount,
+		Aspect:          TextureAspectAll,
+	})
+}
+
+// CreateCubeMapFromImages creates a cube map from six square images of equal
+// size, ordered +X, -X, +Y, -Y, +Z, -Z (see [CubeFace]). Format selection and
+// mipmap generation follow [Device.CreateTextureFromImage]; all faces must
+// resolve to the same format.
+func (d *Device) CreateCubeMapFromImages(faces [6]image.Image, opts *ImageTextureOptions) (*Texture, error) {
+	if err := checkInit(); err != nil {
+		return nil, err
+	}
+	if d == nil || d.handle == 0 {
+		return nil, &WGPUError{Op: "CreateCubeMapFromImages", Message: "device is nil or released"}
+	}
+	if opts == nil {
+		opts = &ImageTextureOptions{}
+	}
+
+	var pix [cubeFaceCount][]byte
+	var channels int
+	var size uint32
+	for i, img := range faces {
+		if img == nil {
+			return nil, &WGPUError{Op: "CreateCubeMapFromImages", Message: fmt.Sprintf("face %d is nil", i)}
+		}
+		p, ch, w, h := imagePixels(img)
+		if err := checkCubeFace(i, w, h, ch, size, channels); err != nil {
+			return nil, &WGPUError{Op: "CreateCubeMapFromImages", Message: err.Error()}
+		}
+		pix[i], channels, size = p, ch, w
+	}
+
+	mipLevels := uint32(1)
+	if opts.GenerateMipmaps {
+		mipLevels = mipLevelCount(size, size)
+	}
+	format := imageTextureFormat(channels, opts.SRGB)
+	tex, err := d.CreateCubeTexture(opts.Label, size, format, mipLevels,
+		opts.Usage|gputypes.TextureUsageCopyDst)
+	if err != nil {
+		return nil, err
+	}
+
+	queue := d.Queue()
+	if queue == nil {
+		tex.Release()
+		return nil, &WGPUError{Op: "CreateCubeMapFromImages", Message: "device queue unavailable"}
+	}
+	defer queue.Release()
+
+	srgb := format == gputypes.TextureFormatRGBA8UnormSrgb
+	for face := range pix {
+		if err := writeImageMips(queue, tex, uint32(face), pix[face], channels, srgb, size, size, mipLevels); err != nil {
+			tex.Release()
+			return nil, err
+		}
+	}
+	return tex, nil
+}
+
+// checkCubeFace validates face i against the size and channel count of the
+// faces before it. size and channels are zero for the first face.
+func checkCubeFace(i int, w, h uint32, ch int, size uint32, channels int) error {
+	switch {
+	case w == 0 || w != h:
+		return fmt.Errorf("face %d is %dx%d, cube faces must be square and non-empty", i, w, h)
+	case size != 0 && w != size:
+		return fmt.Errorf("face %d is %dx%d, expected %dx%d", i, w, h, size, size)
+	case channels != 0 && ch != channels:
+		return fmt.Errorf("face %d mixes grayscale and color images", i)
+	}
+	return nil
+}
+
+// CubeTextureLayoutEntry returns a BindGroupLayoutEntry for a filterable
+// float texture_cube binding.
+func CubeTextureLayoutEntry(binding uint32, visibility gputypes.ShaderStage) BindGroupLayoutEntry {
+	return BindGroupLayoutEntry{
+		Binding:    binding,
+		Visibility: visibility,
+		Texture: &TextureBindingLayout{
+			SampleType:    gputypes.TextureSampleTypeFloat,
+			ViewDimension: gputypes.TextureViewDimensionCube,
+		},
+	}
+}
@@ -0,0 +1,53 @@
+package wgpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestCheckCubeFace(t *testing.T) {
+	tests := []struct {
+		name     string
+		w, h     uint32
+		ch       int
+		size     uint32
+		channels int
+		wantErr  bool
+	}{
+		{"first face", 64, 64, 4, 0, 0, false},
+		{"matching face", 64, 64, 4, 64, 4, false},
+		{"not square", 64, 32, 4, 0, 0, true},
+		{"empty", 0, 0, 0, 0, 0, true},
+		{"size mismatch", 32, 32, 4, 64, 4, true},
+		{"gray after color", 64, 64, 1, 64, 4, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkCubeFace(1, tt.w, tt.h, tt.ch, tt.size, tt.channels)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCubeTextureLayoutEntry(t *testing.T) {
+	e := CubeTextureLayoutEntry(3, gputypes.ShaderStageFragment)
+	if e.Binding != 3 || e.Texture == nil || e.Buffer != nil || e.Sampler != nil {
+		t.Fatalf("unexpected entry %+v", e)
+	}
+	wire := e.toWire()
+	if wire.Texture.ViewDimension != uint32(gputypes.TextureViewDimensionCube) {
+		t.Errorf("wire ViewDimension = %d, want Cube (%d)", wire.Texture.ViewDimension, gputypes.TextureViewDimensionCube)
+	}
+	if wire.Texture.SampleType != toWGPUTextureSampleType(gputypes.TextureSampleTypeFloat) {
+		t.Errorf("wire SampleType = %d", wire.Texture.SampleType)
+	}
+}
+
+func TestCubeFaceOrder(t *testing.T) {
+	if CubeFacePositiveX != 0 || CubeFaceNegativeZ != 5 {
+		t.Errorf("cube face layers out of order: +X=%d -Z=%d", CubeFacePositiveX, CubeFaceNegativeZ)
+	}
+}
@@ -0,0 +1,166 @@
+package wgpu
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/gogpu/gputypes"
+)
+
+// ColorSpaceConversion selects the transfer-function conversion applied by
+// [Queue.CopyExternalImageToTexture].
+type ColorSpaceConversion uint32
+
+const (
+	// ColorSpaceConversionNone copies color values unchanged, like the
+	// browser's colorSpaceConversion: "none".
+	ColorSpaceConversionNone ColorSpaceConversion = iota
+	// ColorSpaceConversionSRGBToLinear decodes sRGB-encoded source values to
+	// linear values, for images stored in non-sRGB (linear) textures.
+	ColorSpaceConversionSRGBToLinear
+	// ColorSpaceConversionLinearToSRGB encodes linear source values with the
+	// sRGB transfer function.
+	ColorSpaceConversionLinearToSRGB
+)
+
+// CopyExternalImageOptions mirrors the flipY, premultipliedAlpha and
+// colorSpace parameters of the WebGPU copyExternalImageToTexture call.
+type CopyExternalImageOptions struct {
+	// FlipY uploads the image bottom row first.
+	FlipY bool
+	// PremultiplyAlpha multiplies color channels by alpha after any color
+	// space conversion.
+	PremultiplyAlpha bool
+	// ColorSpaceConversion converts color channels before upload.
+	ColorSpaceConversion ColorSpaceConversion
+}
+
+// CopyExternalImageToTexture uploads img into dst, doing the conversions
+// browsers perform for copyExternalImageToTexture on the CPU first, so code
+// ported from WebGPU JS keeps its behavior. The copy size is the image size.
+//
+// The destination texture must be RGBA8Unorm, RGBA8UnormSrgb, BGRA8Unorm,
+// BGRA8UnormSrgb or R8Unorm (which receives the red channel). Source images
+// are read as non-premultiplied RGBA8 (see [Device.CreateTextureFromImage]).
+func (q *Queue) CopyExternalImageToTexture(img image.Image, dst *ImageCopyTexture, opts *CopyExternalImageOptions) error {
+	if q == nil || q.handle == 0 {
+		return &WGPUError{Op: "CopyExternalImageToTexture", Message: "queue is nil or released"}
+	}
+	if dst == nil || dst.Texture == nil || dst.Texture.handle == 0 {
+		return &WGPUError{Op: "CopyExternalImageToTexture", Message: "destination texture is nil or released"}
+	}
+	if img == nil {
+		return &WGPUError{Op: "CopyExternalImageToTexture", Message: "image is nil"}
+	}
+	if opts == nil {
+		opts = &CopyExternalImageOptions{}
+	}
+	b := img.Bounds()
+	if b.Empty() {
+		return &WGPUError{Op: "CopyExternalImageToTexture", Message: "image is empty"}
+	}
+
+	pix, err := convertExternalImage(toNRGBA(img), b.Dx(), b.Dy(), dst.Texture.Format(), opts)
+	if err != nil {
+		return &WGPUError{Op: "CopyExternalImageToTexture", Type: ErrorTypeValidation, Message: err.Error()}
+	}
+	return q.WriteTextureData(dst.Texture, dst.MipLevel, dst.Origin, pix, uint32(b.Dx()), uint32(b.Dy()))
+}
+
+// toNRGBA returns the pixels of img as tightly packed non-premultiplied RGBA8.
+func toNRGBA(img image.Image) []byte {
+	pix, channels, _, _ := imagePixels(img)
+	if channels == 4 {
+		return pix
+	}
+	out := make([]byte, len(pix)*4)
+	for i, g := range pix {
+		out[4*i], out[4*i+1], out[4*i+2], out[4*i+3] = g, g, g, 0xFF
+	}
+	return out
+}
+
+// convertExternalImage applies opts to width x height NRGBA pixels and
+// re-encodes them in format. The input slice is not modified.
+func convertExternalImage(src []byte, width, height int, format gputypes.TextureFormat, opts *CopyExternalImageOptions) ([]byte, error) {
+	var channels int
+	var swapRB bool
+	switch format {
+	case gputypes.TextureFormatRGBA8Unorm, gputypes.TextureFormatRGBA8UnormSrgb:
+		channels = 4
+	case gputypes.TextureFormatBGRA8Unorm, gputypes.TextureFormatBGRA8UnormSrgb:
+		channels, swapRB = 4, true
+	case gputypes.TextureFormatR8Unorm:
+		channels = 1
+	default:
+		return nil, fmt.Errorf("unsupported destination format %s", format)
+	}
+
+	lut := colorConversionTable(opts.ColorSpaceConversion)
+	dst := make([]byte, width*height*channels)
+	for y := 0; y < height; y++ {
+		sy := y
+		if opts.FlipY {
+			sy = height - 1 - y
+		}
+		srcRow := src[sy*width*4 : (sy+1)*width*4]
+		dstRow := dst[y*width*channels : (y+1)*width*channels]
+		for x := 0; x < width; x++ {
+			r, g, bl, a := srcRow[4*x], srcRow[4*x+1], srcRow[4*x+2], srcRow[4*x+3]
+			if lut != nil {
+				r, g, bl = lut[r], lut[g], lut[bl]
+			}
+			if opts.PremultiplyAlpha {
+				r, g, bl = premultiply(r, a), premultiply(g, a), premultiply(bl, a)
+			}
+			if channels == 1 {
+				dstRow[x] = r
+				continue
+			}
+			if swapRB {
+				r, bl = bl, r
+			}
+			dstRow[4*x], dstRow[4*x+1], dstRow[4*x+2], dstRow[4*x+3] = r, g, bl, a
+		}
+	}
+	return dst, nil
+}
+
+// premultiply scales an 8-bit color value by an 8-bit alpha, rounding.
+func premultiply(c, a byte) byte {
+	return byte((uint32(c)*uint32(a) + 127) / 255)
+}
+
+// colorConversionTable returns an 8-bit lookup table for conv, or nil for
+// ColorSpaceConversionNone.
+func colorConversionTable(conv ColorSpaceConversion) *[256]byte {
+	var f func(float64) float64
+	switch conv {
+	case ColorSpaceConversionSRGBToLinear:
+		f = srgbToLinear
+	case ColorSpaceConversionLinearToSRGB:
+		f = linearToSRGB
+	default:
+		return nil
+	}
+	var lut [256]byte
+	for i := range lut {
+		lut[i] = byte(math.Round(f(float64(i)/255) * 255))
+	}
+	return &lut
+}
+
+func srgbToLinear(c float64) float64 {
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(c float64) float64 {
+	if c <= 0.0031308 {
+		return c * 12.92
+	}
+	return 1.055*math.Pow(c, 1/2.4) - 0.055
+}
@@ -0,0 +1,70 @@
+package wgpu
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestConvertExternalImage(t *testing.T) {
+	// 1x2 NRGBA image: top pixel half-transparent red, bottom opaque blue.
+	src := []byte{
+		255, 0, 0, 128,
+		0, 0, 255, 255,
+	}
+	tests := []struct {
+		name   string
+		format gputypes.TextureFormat
+		opts   CopyExternalImageOptions
+		want   []byte
+	}{
+		{"copy", gputypes.TextureFormatRGBA8Unorm, CopyExternalImageOptions{}, src},
+		{"flipY", gputypes.TextureFormatRGBA8Unorm, CopyExternalImageOptions{FlipY: true},
+			[]byte{0, 0, 255, 255, 255, 0, 0, 128}},
+		{"premultiply", gputypes.TextureFormatRGBA8UnormSrgb, CopyExternalImageOptions{PremultiplyAlpha: true},
+			[]byte{128, 0, 0, 128, 0, 0, 255, 255}},
+		{"BGRA swizzle", gputypes.TextureFormatBGRA8Unorm, CopyExternalImageOptions{},
+			[]byte{0, 0, 255, 128, 255, 0, 0, 255}},
+		{"R8 red channel", gputypes.TextureFormatR8Unorm, CopyExternalImageOptions{}, []byte{255, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertExternalImage(src, 1, 2, tt.format, &tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if _, err := convertExternalImage(src, 1, 2, gputypes.TextureFormatRGBA16Float, &CopyExternalImageOptions{}); err == nil {
+		t.Error("expected error for RGBA16Float destination")
+	}
+}
+
+func TestColorConversionTable(t *testing.T) {
+	if colorConversionTable(ColorSpaceConversionNone) != nil {
+		t.Error("None should not convert")
+	}
+	toLinear := colorConversionTable(ColorSpaceConversionSRGBToLinear)
+	toSRGB := colorConversionTable(ColorSpaceConversionLinearToSRGB)
+	if toLinear[0] != 0 || toLinear[255] != 255 || toLinear[188] != 128 {
+		t.Errorf("sRGB->linear: 0=%d 188=%d 255=%d", toLinear[0], toLinear[188], toLinear[255])
+	}
+	if toSRGB[0] != 0 || toSRGB[255] != 255 || toSRGB[128] != 188 {
+		t.Errorf("linear->sRGB: 0=%d 128=%d 255=%d", toSRGB[0], toSRGB[128], toSRGB[255])
+	}
+}
+
+func TestCopyExternalImageToTextureNilGuards(t *testing.T) {
+	var q *Queue
+	if err := q.CopyExternalImageToTexture(nil, nil, nil); err == nil {
+		t.Error("expected error for nil queue")
+	}
+	q = &Queue{handle: 1}
+	if err := q.CopyExternalImageToTexture(nil, &ImageCopyTexture{}, nil); err == nil {
+		t.Error("expected error for nil destination texture")
+	}
+}
@@ -0,0 +1,129 @@
+package wgpu
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/gogpu/gputypes"
+)
+
+// astcBlockDimensions lists ASTC block sizes in gputypes enum order. ASTC
+// formats come in Unorm/UnormSrgb pairs, so format index i/2 selects the entry.
+var astcBlockDimensions = [...][2]uint32{
+	{4, 4}, {5, 4}, {5, 5}, {6, 5}, {6, 6}, {8, 5}, {8, 6},
+	{8, 8}, {10, 5}, {10, 6}, {10, 8}, {10, 10}, {12, 10}, {12, 12},
+}
+
+// isBCFormat reports whether format is one of the BC1–BC7 formats.
+func isBCFormat(format gputypes.TextureFormat) bool {
+	return format >= gputypes.TextureFormatBC1RGBAUnorm && format <= gputypes.TextureFormatBC7RGBAUnormSrgb
+}
+
+// isETC2Format reports whether format is one of the ETC2 or EAC formats.
+func isETC2Format(format gputypes.TextureFormat) bool {
+	return format >= gputypes.TextureFormatETC2RGB8Unorm && format <= gputypes.TextureFormatEACRG11Snorm
+}
+
+// isASTCFormat reports whether format is one of the ASTC formats.
+func isASTCFormat(format gputypes.TextureFormat) bool {
+	return format >= gputypes.TextureFormatASTC4x4Unorm && format <= gputypes.TextureFormatASTC12x12UnormSrgb
+}
+
+// IsCompressedFormat reports whether format is block-compressed (BC, ETC2/EAC or ASTC).
+func IsCompressedFormat(format gputypes.TextureFormat) bool {
+	return isBCFormat(format) || isETC2Format(format) || isASTCFormat(format)
+}
+
+// TextureFormatBlockDimensions returns the texel block width and height of format.
+// BC and ETC2/EAC formats use 4x4 blocks, ASTC formats use their named block
+// size, and every uncompressed format reports 1x1.
+func TextureFormatBlockDimensions(format gputypes.TextureFormat) (width, height uint32) {
+	switch {
+	case isBCFormat(format), isETC2Format(format):
+		return 4, 4
+	case isASTCFormat(format):
+		d := astcBlockDimensions[(format-gputypes.TextureFormatASTC4x4Unorm)/2]
+		return d[0], d[1]
+	default:
+		return 1, 1
+	}
+}
+
+// TextureFormatRequiredFeature returns the device feature that must be enabled
+// to create textures of format. ok is false if the format needs no feature.
+func TextureFormatRequiredFeature(format gputypes.TextureFormat) (feature FeatureName, ok bool) {
+	switch {
+	case isBCFormat(format):
+		return FeatureNameTextureCompressionBC, true
+	case isETC2Format(format):
+		return FeatureNameTextureCompressionETC2, true
+	case isASTCFormat(format):
+		return FeatureNameTextureCompressionASTC, true
+	case format == gputypes.TextureFormatDepth32FloatStencil8:
+		return FeatureNameDepth32FloatStencil8, true
+	default:
+		return 0, false
+	}
+}
+
+// validateTextureFormatFeatures checks that a texture with the given format
+// and dimension can be created given the features reported by hasFeature.
+func validateTextureFormatFeatures(format gputypes.TextureFormat, dimension gputypes.TextureDimension, hasFeature func(FeatureName) bool) error {
+	feature, ok := TextureFormatRequiredFeature(format)
+	if !ok {
+		return nil
+	}
+	if !hasFeature(feature) {
+		return fmt.Errorf("format %s requires feature 0x%X, which is not enabled on the device", format, uint32(feature))
+	}
+	if dimension != gputypes.TextureDimension3D || !IsCompressedFormat(format) {
+		return nil
+	}
+	switch {
+	case isBCFormat(format) && hasFeature(FeatureNameTextureCompressionBCSliced3D):
+		return nil
+	case isASTCFormat(format) && hasFeature(FeatureNameTextureCompressionASTCSliced3D):
+		return nil
+	}
+	return fmt.Errorf("format %s cannot be used for 3D textures on this device", format)
+}
+
+// TextureFormatSrgbCounterpart returns the sRGB variant of a linear format or
+// the linear variant of an sRGB format, e.g. RGBA8Unorm <-> RGBA8UnormSrgb.
+// ok is false for formats that have no sRGB pair.
+func TextureFormatSrgbCounterpart(format gputypes.TextureFormat) (pair gputypes.TextureFormat, ok bool) {
+	// Every sRGB format directly follows its linear variant in the enum.
+	if format.IsSrgb() {
+		return format - 1, true
+	}
+	if (format + 1).IsSrgb() {
+		return format + 1, true
+	}
+	return 0, false
+}
+
+// validateViewFormats checks that every entry of viewFormats is format itself
+// or its sRGB counterpart, the only reinterpretation WebGPU permits.
+func validateViewFormats(format gputypes.TextureFormat, viewFormats []gputypes.TextureFormat) error {
+	pair, hasPair := TextureFormatSrgbCounterpart(format)
+	for _, vf := range viewFormats {
+		if vf != format && (!hasPair || vf != pair) {
+			return fmt.Errorf("view format %s is not compatible with %s", vf, format)
+		}
+	}
+	return nil
+}
+
+// viewFormatsWire converts viewFormats to the uint32 array wgpu-native expects.
+// The returned slice must stay reachable until the FFI call returns.
+func viewFormatsWire(viewFormats []gputypes.TextureFormat) (wire []uint32, count, ptr uintptr) {
+	if len(viewFormats) == 0 {
+		return nil, 0, 0
+	}
+	// gputypes values equal wgpu-native values.
+	wire = make([]uint32, len(viewFormats))
+	for i, f := range viewFormats {
+		wire[i] = uint32(f)
+	}
+	return wire, uintptr(len(wire)), uintptr(unsafe.Pointer(&wire[0]))
+}
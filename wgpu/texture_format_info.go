@@ -0,0 +1,178 @@
+package wgpu
+
+import "github.com/gogpu/gputypes"
+
+// TextureFormatInfo describes the layout and core WebGPU capabilities of a
+// texture format. Capabilities that optional features extend (for example
+// FeatureNameFloat32Filterable or FeatureNameBGRA8UnormStorage) are reported
+// as in core WebGPU, without the feature.
+type TextureFormatInfo struct {
+	Format gputypes.TextureFormat
+
+	// BlockCopySize is the size in bytes of one texel block in buffer copies.
+	// It is 0 for formats whose combined aspects cannot be copied as a whole
+	// (Depth24Plus and the combined depth-stencil formats).
+	BlockCopySize uint32
+	// BlockWidth and BlockHeight are the texel block dimensions: 1x1 for
+	// uncompressed formats.
+	BlockWidth, BlockHeight uint32
+	// Components is the number of channels (color, depth and stencil).
+	Components uint32
+
+	HasColor, HasDepth, HasStencil bool
+	Compressed                     bool
+	Srgb                           bool
+
+	// SampleType is the sample type of the format's default aspect (the
+	// depth aspect for combined depth-stencil formats).
+	SampleType gputypes.TextureSampleType
+	// Renderable reports whether the format can be a color or depth-stencil
+	// render attachment.
+	Renderable bool
+	// Blendable reports whether a color attachment of this format can blend.
+	Blendable bool
+	// StorageBinding reports whether the format can back a storage texture.
+	StorageBinding bool
+
+	// SrgbPair is the sRGB or linear counterpart (see
+	// [TextureFormatSrgbCounterpart]); valid when HasSrgbPair is set.
+	SrgbPair    gputypes.TextureFormat
+	HasSrgbPair bool
+	// RequiredFeature must be enabled on the device to create textures of
+	// this format; valid when NeedsFeature is set.
+	RequiredFeature FeatureName
+	NeedsFeature    bool
+}
+
+// formatFlags are the capability bits of an uncompressed format.
+type formatFlags uint8
+
+const (
+	formatRenderable formatFlags = 1 << iota
+	formatBlendable
+	formatStorage
+)
+
+// formatTraits describes an uncompressed format in uncompressedFormats.
+type formatTraits struct {
+	components uint32
+	sampleType gputypes.TextureSampleType
+	flags      formatFlags
+}
+
+const (
+	sampleFloat        = gputypes.TextureSampleTypeFloat
+	sampleUnfilterable = gputypes.TextureSampleTypeUnfilterableFloat
+	sampleDepth        = gputypes.TextureSampleTypeDepth
+	sampleUint         = gputypes.TextureSampleTypeUint
+	sampleSint         = gputypes.TextureSampleTypeSint
+
+	renderBlend = formatRenderable | formatBlendable
+)
+
+// uncompressedFormats follows the texture format capability tables of the
+// WebGPU specification.
+var uncompressedFormats = map[gputypes.TextureFormat]formatTraits{
+	gputypes.TextureFormatR8Unorm:  {1, sampleFloat, renderBlend},
+	gputypes.TextureFormatR8Snorm:  {1, sampleFloat, 0},
+	gputypes.TextureFormatR8Uint:   {1, sampleUint, formatRenderable},
+	gputypes.TextureFormatR8Sint:   {1, sampleSint, formatRenderable},
+	gputypes.TextureFormatR16Unorm: {1, sampleFloat, 0},
+	gputypes.TextureFormatR16Snorm: {1, sampleFloat, 0},
+	gputypes.TextureFormatR16Uint:  {1, sampleUint, formatRenderable},
+	gputypes.TextureFormatR16Sint:  {1, sampleSint, formatRenderable},
+	gputypes.TextureFormatR16Float: {1, sampleFloat, renderBlend},
+	gputypes.TextureFormatRG8Unorm: {2, sampleFloat, renderBlend},
+	gputypes.TextureFormatRG8Snorm: {2, sampleFloat, 0},
+	gputypes.TextureFormatRG8Uint:  {2, sampleUint, formatRenderable},
+	gputypes.TextureFormatRG8Sint:  {2, sampleSint, formatRenderable},
+
+	gputypes.TextureFormatR32Float:       {1, sampleUnfilterable, formatRenderable | formatStorage},
+	gputypes.TextureFormatR32Uint:        {1, sampleUint, formatRenderable | formatStorage},
+	gputypes.TextureFormatR32Sint:        {1, sampleSint, formatRenderable | formatStorage},
+	gputypes.TextureFormatRG16Unorm:      {2, sampleFloat, 0},
+	gputypes.TextureFormatRG16Snorm:      {2, sampleFloat, 0},
+	gputypes.TextureFormatRG16Uint:       {2, sampleUint, formatRenderable},
+	gputypes.TextureFormatRG16Sint:       {2, sampleSint, formatRenderable},
+	gputypes.TextureFormatRG16Float:      {2, sampleFloat, renderBlend},
+	gputypes.TextureFormatRGBA8Unorm:     {4, sampleFloat, renderBlend | formatStorage},
+	gputypes.TextureFormatRGBA8UnormSrgb: {4, sampleFloat, renderBlend},
+	gputypes.TextureFormatRGBA8Snorm:     {4, sampleFloat, formatStorage},
+	gputypes.TextureFormatRGBA8Uint:      {4, sampleUint, formatRenderable | formatStorage},
+	gputypes.TextureFormatRGBA8Sint:      {4, sampleSint, formatRenderable | formatStorage},
+	gputypes.TextureFormatBGRA8Unorm:     {4, sampleFloat, renderBlend},
+	gputypes.TextureFormatBGRA8UnormSrgb: {4, sampleFloat, renderBlend},
+	gputypes.TextureFormatRGB10A2Uint:    {4, sampleUint, formatRenderable},
+	gputypes.TextureFormatRGB10A2Unorm:   {4, sampleFloat, renderBlend},
+	gputypes.TextureFormatRG11B10Ufloat:  {3, sampleFloat, 0},
+	gputypes.TextureFormatRGB9E5Ufloat:   {3, sampleFloat, 0},
+
+	gputypes.TextureFormatRG32Float:    {2, sampleUnfilterable, formatRenderable | formatStorage},
+	gputypes.TextureFormatRG32Uint:     {2, sampleUint, formatRenderable | formatStorage},
+	gputypes.TextureFormatRG32Sint:     {2, sampleSint, formatRenderable | formatStorage},
+	gputypes.TextureFormatRGBA16Unorm:  {4, sampleFloat, 0},
+	gputypes.TextureFormatRGBA16Snorm:  {4, sampleFloat, 0},
+	gputypes.TextureFormatRGBA16Uint:   {4, sampleUint, formatRenderable | formatStorage},
+	gputypes.TextureFormatRGBA16Sint:   {4, sampleSint, formatRenderable | formatStorage},
+	gputypes.TextureFormatRGBA16Float:  {4, sampleFloat, renderBlend | formatStorage},
+	gputypes.TextureFormatRGBA32Float:  {4, sampleUnfilterable, formatRenderable | formatStorage},
+	gputypes.TextureFormatRGBA32Uint:   {4, sampleUint, formatRenderable | formatStorage},
+	gputypes.TextureFormatRGBA32Sint:   {4, sampleSint, formatRenderable | formatStorage},
+	gputypes.TextureFormatStencil8:     {1, sampleUint, formatRenderable},
+	gputypes.TextureFormatDepth16Unorm: {1, sampleDepth, formatRenderable},
+	gputypes.TextureFormatDepth24Plus:  {1, sampleDepth, formatRenderable},
+	gputypes.TextureFormatDepth32Float: {1, sampleDepth, formatRenderable},
+
+	gputypes.TextureFormatDepth24PlusStencil8:  {2, sampleDepth, formatRenderable},
+	gputypes.TextureFormatDepth32FloatStencil8: {2, sampleDepth, formatRenderable},
+}
+
+// FormatInfo returns layout and capability information for format. ok is
+// false for TextureFormatUndefined and values this package does not know.
+func FormatInfo(format gputypes.TextureFormat) (info TextureFormatInfo, ok bool) {
+	info = TextureFormatInfo{
+		Format:        format,
+		BlockCopySize: format.BlockCopySize(),
+		HasDepth:      format.HasDepth(),
+		HasStencil:    format.HasStencil(),
+		Srgb:          format.IsSrgb(),
+		Compressed:    IsCompressedFormat(format),
+	}
+	info.BlockWidth, info.BlockHeight = TextureFormatBlockDimensions(format)
+	info.HasColor = !info.HasDepth && !info.HasStencil
+	info.SrgbPair, info.HasSrgbPair = TextureFormatSrgbCounterpart(format)
+	info.RequiredFeature, info.NeedsFeature = TextureFormatRequiredFeature(format)
+
+	if info.Compressed {
+		// Compressed formats are filterable, sampled-only color formats.
+		info.Components = compressedComponents(format)
+		info.SampleType = gputypes.TextureSampleTypeFloat
+		return info, true
+	}
+	traits, ok := uncompressedFormats[format]
+	if !ok {
+		return TextureFormatInfo{}, false
+	}
+	info.Components = traits.components
+	info.SampleType = traits.sampleType
+	info.Renderable = traits.flags&formatRenderable != 0
+	info.Blendable = traits.flags&formatBlendable != 0
+	info.StorageBinding = traits.flags&formatStorage != 0
+	return info, true
+}
+
+// compressedComponents returns the channel count of a compressed format.
+func compressedComponents(format gputypes.TextureFormat) uint32 {
+	switch format {
+	case gputypes.TextureFormatBC4RUnorm, gputypes.TextureFormatBC4RSnorm,
+		gputypes.TextureFormatEACR11Unorm, gputypes.TextureFormatEACR11Snorm:
+		return 1
+	case gputypes.TextureFormatBC5RGUnorm, gputypes.TextureFormatBC5RGSnorm,
+		gputypes.TextureFormatEACRG11Unorm, gputypes.TextureFormatEACRG11Snorm:
+		return 2
+	case gputypes.TextureFormatBC6HRGBUfloat, gputypes.TextureFormatBC6HRGBFloat,
+		gputypes.TextureFormatETC2RGB8Unorm, gputypes.TextureFormatETC2RGB8UnormSrgb:
+		return 3
+	}
+	return 4
+}
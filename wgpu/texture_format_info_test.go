@@ -0,0 +1,75 @@
+package wgpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestFormatInfo(t *testing.T) {
+	tests := []struct {
+		format     gputypes.TextureFormat
+		copySize   uint32
+		block      uint32
+		components uint32
+		sample     gputypes.TextureSampleType
+		render     bool
+		blend      bool
+		storage    bool
+	}{
+		{gputypes.TextureFormatRGBA8Unorm, 4, 1, 4, gputypes.TextureSampleTypeFloat, true, true, true},
+		{gputypes.TextureFormatRGBA8UnormSrgb, 4, 1, 4, gputypes.TextureSampleTypeFloat, true, true, false},
+		{gputypes.TextureFormatRGBA8Snorm, 4, 1, 4, gputypes.TextureSampleTypeFloat, false, false, true},
+		{gputypes.TextureFormatR32Float, 4, 1, 1, gputypes.TextureSampleTypeUnfilterableFloat, true, false, true},
+		{gputypes.TextureFormatRG16Uint, 4, 1, 2, gputypes.TextureSampleTypeUint, true, false, false},
+		{gputypes.TextureFormatRGBA32Sint, 16, 1, 4, gputypes.TextureSampleTypeSint, true, false, true},
+		{gputypes.TextureFormatRG11B10Ufloat, 4, 1, 3, gputypes.TextureSampleTypeFloat, false, false, false},
+		{gputypes.TextureFormatDepth32Float, 4, 1, 1, gputypes.TextureSampleTypeDepth, true, false, false},
+		{gputypes.TextureFormatDepth24PlusStencil8, 0, 1, 2, gputypes.TextureSampleTypeDepth, true, false, false},
+		{gputypes.TextureFormatStencil8, 1, 1, 1, gputypes.TextureSampleTypeUint, true, false, false},
+		{gputypes.TextureFormatBC5RGUnorm, 16, 4, 2, gputypes.TextureSampleTypeFloat, false, false, false},
+		{gputypes.TextureFormatASTC8x8Unorm, 16, 8, 4, gputypes.TextureSampleTypeFloat, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			info, ok := FormatInfo(tt.format)
+			if !ok {
+				t.Fatal("format not found")
+			}
+			if info.BlockCopySize != tt.copySize || info.BlockWidth != tt.block || info.BlockHeight != tt.block {
+				t.Errorf("layout = %d bytes %dx%d, want %d bytes %dx%d",
+					info.BlockCopySize, info.BlockWidth, info.BlockHeight, tt.copySize, tt.block, tt.block)
+			}
+			if info.Components != tt.components || info.SampleType != tt.sample {
+				t.Errorf("components/sample = %d/%d, want %d/%d", info.Components, info.SampleType, tt.components, tt.sample)
+			}
+			if info.Renderable != tt.render || info.Blendable != tt.blend || info.StorageBinding != tt.storage {
+				t.Errorf("render/blend/storage = %v/%v/%v, want %v/%v/%v",
+					info.Renderable, info.Blendable, info.StorageBinding, tt.render, tt.blend, tt.storage)
+			}
+		})
+	}
+}
+
+func TestFormatInfoAspectsAndPairs(t *testing.T) {
+	ds, _ := FormatInfo(gputypes.TextureFormatDepth32FloatStencil8)
+	if ds.HasColor || !ds.HasDepth || !ds.HasStencil || !ds.NeedsFeature {
+		t.Errorf("Depth32FloatStencil8 = %+v", ds)
+	}
+	bgra, _ := FormatInfo(gputypes.TextureFormatBGRA8Unorm)
+	if !bgra.HasColor || !bgra.HasSrgbPair || bgra.SrgbPair != gputypes.TextureFormatBGRA8UnormSrgb || bgra.Srgb {
+		t.Errorf("BGRA8Unorm = %+v", bgra)
+	}
+	if _, ok := FormatInfo(gputypes.TextureFormatUndefined); ok {
+		t.Error("Undefined format reported as known")
+	}
+}
+
+// Every format between R8Unorm and ASTC12x12UnormSrgb must be described.
+func TestFormatInfoCoversAllFormats(t *testing.T) {
+	for f := gputypes.TextureFormatR8Unorm; f <= gputypes.TextureFormatASTC12x12UnormSrgb; f++ {
+		if _, ok := FormatInfo(f); !ok {
+			t.Errorf("FormatInfo(%s) not found", f)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package wgpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestTextureFormatBlockDimensions(t *testing.T) {
+	tests := []struct {
+		format gputypes.TextureFormat
+		w, h   uint32
+	}{
+		{gputypes.TextureFormatRGBA8Unorm, 1, 1},
+		{gputypes.TextureFormatDepth32Float, 1, 1},
+		{gputypes.TextureFormatBC1RGBAUnorm, 4, 4},
+		{gputypes.TextureFormatBC7RGBAUnormSrgb, 4, 4},
+		{gputypes.TextureFormatETC2RGB8Unorm, 4, 4},
+		{gputypes.TextureFormatEACRG11Snorm, 4, 4},
+		{gputypes.TextureFormatASTC4x4Unorm, 4, 4},
+		{gputypes.TextureFormatASTC5x4UnormSrgb, 5, 4},
+		{gputypes.TextureFormatASTC10x8Unorm, 10, 8},
+		{gputypes.TextureFormatASTC12x12UnormSrgb, 12, 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			w, h := TextureFormatBlockDimensions(tt.format)
+			if w != tt.w || h != tt.h {
+				t.Errorf("got %dx%d, want %dx%d", w, h, tt.w, tt.h)
+			}
+		})
+	}
+}
+
+func TestValidateTextureFormatFeatures(t *testing.T) {
+	only := func(features ...FeatureName) func(FeatureName) bool {
+		return func(f FeatureName) bool {
+			for _, have := range features {
+				if f == have {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	tests := []struct {
+		name    string
+		format  gputypes.TextureFormat
+		dim     gputypes.TextureDimension
+		has     func(FeatureName) bool
+		wantErr bool
+	}{
+		{"uncompressed needs nothing", gputypes.TextureFormatRGBA8Unorm, gputypes.TextureDimension2D, only(), false},
+		{"BC without feature", gputypes.TextureFormatBC3RGBAUnorm, gputypes.TextureDimension2D, only(), true},
+		{"BC with feature", gputypes.TextureFormatBC3RGBAUnorm, gputypes.TextureDimension2D, only(FeatureNameTextureCompressionBC), false},
+		{"ETC2 with BC feature", gputypes.TextureFormatETC2RGBA8Unorm, gputypes.TextureDimension2D, only(FeatureNameTextureCompressionBC), true},
+		{"ASTC with feature", gputypes.TextureFormatASTC6x6Unorm, gputypes.TextureDimension2D, only(FeatureNameTextureCompressionASTC), false},
+		{"BC 3D without sliced", gputypes.TextureFormatBC1RGBAUnorm, gputypes.TextureDimension3D, only(FeatureNameTextureCompressionBC), true},
+		{"BC 3D with sliced", gputypes.TextureFormatBC1RGBAUnorm, gputypes.TextureDimension3D,
+			only(FeatureNameTextureCompressionBC, FeatureNameTextureCompressionBCSliced3D), false},
+		{"ETC2 3D never", gputypes.TextureFormatETC2RGB8Unorm, gputypes.TextureDimension3D, only(FeatureNameTextureCompressionETC2), true},
+		{"Depth32FloatStencil8 gated", gputypes.TextureFormatDepth32FloatStencil8, gputypes.TextureDimension2D, only(), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTextureFormatFeatures(tt.format, tt.dim, tt.has)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTextureFormatSrgbCounterpart(t *testing.T) {
+	tests := []struct {
+		format, want gputypes.TextureFormat
+		ok           bool
+	}{
+		{gputypes.TextureFormatRGBA8Unorm, gputypes.TextureFormatRGBA8UnormSrgb, true},
+		{gputypes.TextureFormatRGBA8UnormSrgb, gputypes.TextureFormatRGBA8Unorm, true},
+		{gputypes.TextureFormatBGRA8Unorm, gputypes.TextureFormatBGRA8UnormSrgb, true},
+		{gputypes.TextureFormatBC7RGBAUnormSrgb, gputypes.TextureFormatBC7RGBAUnorm, true},
+		{gputypes.TextureFormatASTC12x12Unorm, gputypes.TextureFormatASTC12x12UnormSrgb, true},
+		{gputypes.TextureFormatRGBA8Snorm, 0, false},
+		{gputypes.TextureFormatRGBA16Float, 0, false},
+		{gputypes.TextureFormatBC4RUnorm, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			got, ok := TextureFormatSrgbCounterpart(tt.format)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("got (%s, %v), want (%s, %v)", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestValidateViewFormats(t *testing.T) {
+	rgba := gputypes.TextureFormatRGBA8Unorm
+	if err := validateViewFormats(rgba, nil); err != nil {
+		t.Errorf("empty list: %v", err)
+	}
+	if err := validateViewFormats(rgba, []gputypes.TextureFormat{rgba, gputypes.TextureFormatRGBA8UnormSrgb}); err != nil {
+		t.Errorf("sRGB view: %v", err)
+	}
+	if err := validateViewFormats(rgba, []gputypes.TextureFormat{gputypes.TextureFormatBGRA8UnormSrgb}); err == nil {
+		t.Error("expected error for BGRA view of RGBA texture")
+	}
+	if err := validateViewFormats(gputypes.TextureFormatRGBA16Float, []gputypes.TextureFormat{gputypes.TextureFormatRGBA8Unorm}); err == nil {
+		t.Error("expected error for format without sRGB pair")
+	}
+}
@@ -0,0 +1,218 @@
+package wgpu
+
+import (
+	"image"
+	"image/draw"
+	"math"
+	"math/bits"
+	"sync"
+
+	"github.com/gogpu/gputypes"
+)
+
+// ImageTextureOptions controls how CreateTextureFromImage builds a texture.
+// A nil *ImageTextureOptions selects the defaults described on each field.
+type ImageTextureOptions struct {
+	// Label is the debug label of the created texture.
+	Label string
+	// Usage is OR'd with TextureUsageTextureBinding|TextureUsageCopyDst,
+	// which are always set.
+	Usage gputypes.TextureUsage
+	// SRGB selects RGBA8UnormSrgb instead of RGBA8Unorm for color images.
+	// Ignored for *image.Gray, which always maps to R8Unorm.
+	SRGB bool
+	// GenerateMipmaps builds a full mip chain on the CPU with a 2x2 box filter.
+	// Colors are weighted by alpha, and levels of sRGB textures are averaged
+	// in linear space.
+	GenerateMipmaps bool
+}
+
+// CreateTextureFromImage creates a 2D texture from img and uploads its pixels.
+//
+// *image.Gray becomes R8Unorm; every other image type (NRGBA, RGBA, YCbCr,
+// paletted, ...) is converted to non-premultiplied RGBA8. Row padding is
+// handled by [Queue.WriteTextureData].
+func (d *Device) CreateTextureFromImage(img image.Image, opts *ImageTextureOptions) (*Texture, error) {
+	if err := checkInit(); err != nil {
+		return nil, err
+	}
+	if d == nil || d.handle == 0 {
+		return nil, &WGPUError{Op: "CreateTextureFromImage", Message: "device is nil or released"}
+	}
+	if img == nil {
+		return nil, &WGPUError{Op: "CreateTextureFromImage", Message: "image is nil"}
+	}
+	if opts == nil {
+		opts = &ImageTextureOptions{}
+	}
+
+	pix, channels, width, height := imagePixels(img)
+	if width == 0 || height == 0 {
+		return nil, &WGPUError{Op: "CreateTextureFromImage", Message: "image is empty"}
+	}
+
+	format := imageTextureFormat(channels, opts.SRGB)
+	mipLevels := uint32(1)
+	if opts.GenerateMipmaps {
+		mipLevels = mipLevelCount(width, height)
+	}
+
+	tex, err := d.CreateTexture(&TextureDescriptor{
+		Label:         opts.Label,
+		Usage:         opts.Usage | gputypes.TextureUsageTextureBinding | gputypes.TextureUsageCopyDst,
+		Dimension:     gputypes.TextureDimension2D,
+		Size:          gputypes.Extent3D{Width: width, Height: height, DepthOrArrayLayers: 1},
+		Format:        format,
+		MipLevelCount: mipLevels,
+		SampleCount:   1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	queue := d.Queue()
+	if queue == nil {
+		tex.Release()
+		return nil, &WGPUError{Op: "CreateTextureFromImage", Message: "device queue unavailable"}
+	}
+	defer queue.Release()
+
+	srgb := format == gputypes.TextureFormatRGBA8UnormSrgb
+	if err := writeImageMips(queue, tex, 0, pix, channels, srgb, width, height, mipLevels); err != nil {
+		tex.Release()
+		return nil, err
+	}
+	return tex, nil
+}
+
+// writeImageMips uploads pix into array layer layer of tex, downsampling on
+// the CPU for each of mipLevels levels; srgb selects gamma-correct averaging.
+func writeImageMips(queue *Queue, tex *Texture, layer uint32, pix []byte, channels int, srgb bool, width, height, mipLevels uint32) error {
+	for level := uint32(0); level < mipLevels; level++ {
+		if level > 0 {
+			pix, width, height = downsampleBox(pix, channels, srgb, width, height)
+		}
+		if err := queue.WriteTextureData(tex, level, gputypes.Origin3D{Z: layer}, pix, width, height); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// imageTextureFormat selects the texture format for pixels produced by imagePixels.
+func imageTextureFormat(channels int, srgb bool) gputypes.TextureFormat {
+	switch {
+	case channels == 1:
+		return gputypes.TextureFormatR8Unorm
+	case srgb:
+		return gputypes.TextureFormatRGBA8UnormSrgb
+	default:
+		return gputypes.TextureFormatRGBA8Unorm
+	}
+}
+
+// mipLevelCount returns the length of a full mip chain for a width x height image.
+func mipLevelCount(width, height uint32) uint32 {
+	return uint32(bits.Len32(max(width, height)))
+}
+
+// imagePixels returns tightly packed pixel rows for img along with the
+// number of 8-bit channels per pixel (1 for Gray, 4 otherwise).
+func imagePixels(img image.Image) (pix []byte, channels int, width, height uint32) {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= 0 || h <= 0 {
+		return nil, 0, 0, 0
+	}
+
+	switch src := img.(type) {
+	case *image.Gray:
+		return packRows(src.Pix, src.Stride, src.PixOffset(b.Min.X, b.Min.Y), w, h), 1, uint32(w), uint32(h)
+	case *image.NRGBA:
+		return packRows(src.Pix, src.Stride, src.PixOffset(b.Min.X, b.Min.Y), w*4, h), 4, uint32(w), uint32(h)
+	}
+
+	// draw.Draw handles premultiplied RGBA, YCbCr and paletted sources.
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Src)
+	return dst.Pix, 4, uint32(w), uint32(h)
+}
+
+// packRows copies h rows of rowBytes each out of a strided pixel buffer.
+func packRows(src []byte, stride, offset, rowBytes, h int) []byte {
+	if stride == rowBytes && offset == 0 && len(src) == rowBytes*h {
+		return src
+	}
+	dst := make([]byte, rowBytes*h)
+	for y := 0; y < h; y++ {
+		copy(dst[y*rowBytes:(y+1)*rowBytes], src[offset+y*stride:])
+	}
+	return dst
+}
+
+// downsampleBox halves an 8-bit image in each dimension (clamped to 1)
+// by averaging 2x2 texel neighborhoods.
+//
+// RGBA pixels hold straight (non-premultiplied) alpha, so their color
+// channels are weighted by alpha: transparent texels do not darken the
+// edges of opaque ones. The result stays straight alpha; a neighborhood
+// that is fully transparent averages its colors unweighted. With srgb set,
+// colors are averaged in linear space and re-encoded, as the GPU does when
+// sampling an sRGB texture. Alpha is averaged as stored.
+func downsampleBox(src []byte, channels int, srgb bool, width, height uint32) (dst []byte, w, h uint32) {
+	w, h = max(width/2, 1), max(height/2, 1)
+	sw := int(width)
+	var decode *[256]float64
+	if srgb && channels == 4 {
+		decode = srgbDecodeTable()
+	}
+	dst = make([]byte, int(w)*int(h)*channels)
+	for y := 0; y < int(h); y++ {
+		y0 := min(2*y, int(height)-1)
+		y1 := min(2*y+1, int(height)-1)
+		for x := 0; x < int(w); x++ {
+			x0 := min(2*x, sw-1)
+			x1 := min(2*x+1, sw-1)
+			px := [4]int{(y0*sw + x0) * channels, (y0*sw + x1) * channels, (y1*sw + x0) * channels, (y1*sw + x1) * channels}
+			out := dst[(y*int(w)+x)*channels:][:channels]
+			if channels != 4 {
+				for c := range out {
+					out[c] = byte((int(src[px[0]+c]) + int(src[px[1]+c]) + int(src[px[2]+c]) + int(src[px[3]+c]) + 2) / 4)
+				}
+				continue
+			}
+			alpha := int(src[px[0]+3]) + int(src[px[1]+3]) + int(src[px[2]+3]) + int(src[px[3]+3])
+			out[3] = byte((alpha + 2) / 4)
+			for c := range 3 {
+				var sum, weight float64
+				for _, p := range px {
+					v := float64(src[p+c]) / 255
+					if decode != nil {
+						v = decode[src[p+c]]
+					}
+					wt := 1.0
+					if alpha > 0 {
+						wt = float64(src[p+3])
+					}
+					sum += v * wt
+					weight += wt
+				}
+				v := sum / weight
+				if decode != nil {
+					v = linearToSRGB(v)
+				}
+				out[c] = byte(math.Round(v * 255))
+			}
+		}
+	}
+	return dst, w, h
+}
+
+// srgbDecodeTable maps each 8-bit sRGB value to its linear intensity in [0, 1].
+var srgbDecodeTable = sync.OnceValue(func() *[256]float64 {
+	var lut [256]float64
+	for i := range lut {
+		lut[i] = srgbToLinear(float64(i) / 255)
+	}
+	return &lut
+})
@@ -0,0 +1,114 @@
+package wgpu
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestMipLevelCount(t *testing.T) {
+	tests := []struct {
+		w, h, want uint32
+	}{
+		{1, 1, 1},
+		{2, 1, 2},
+		{256, 256, 9},
+		{300, 17, 9},
+		{1, 1024, 11},
+	}
+	for _, tt := range tests {
+		if got := mipLevelCount(tt.w, tt.h); got != tt.want {
+			t.Errorf("mipLevelCount(%d, %d) = %d, want %d", tt.w, tt.h, got, tt.want)
+		}
+	}
+}
+
+func TestImagePixels(t *testing.T) {
+	t.Run("Gray", func(t *testing.T) {
+		img := image.NewGray(image.Rect(0, 0, 3, 2))
+		img.SetGray(2, 1, color.Gray{Y: 9})
+		pix, ch, w, h := imagePixels(img)
+		if ch != 1 || w != 3 || h != 2 || len(pix) != 6 || pix[5] != 9 {
+			t.Errorf("got ch=%d %dx%d pix=%v", ch, w, h, pix)
+		}
+	})
+
+	t.Run("NRGBA subimage", func(t *testing.T) {
+		full := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+		full.SetNRGBA(2, 2, color.NRGBA{R: 1, G: 2, B: 3, A: 4})
+		sub := full.SubImage(image.Rect(2, 2, 4, 4))
+		pix, ch, w, h := imagePixels(sub)
+		if ch != 4 || w != 2 || h != 2 || len(pix) != 16 {
+			t.Fatalf("got ch=%d %dx%d len=%d", ch, w, h, len(pix))
+		}
+		if !bytes.Equal(pix[:4], []byte{1, 2, 3, 4}) {
+			t.Errorf("first pixel = %v, want [1 2 3 4]", pix[:4])
+		}
+	})
+
+	t.Run("RGBA is unpremultiplied", func(t *testing.T) {
+		img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+		img.SetRGBA(0, 0, color.RGBA{R: 64, A: 128})
+		pix, ch, _, _ := imagePixels(img)
+		if ch != 4 || pix[0] != 127 || pix[3] != 128 {
+			t.Errorf("got %v", pix)
+		}
+	})
+
+	t.Run("YCbCr", func(t *testing.T) {
+		img := image.NewYCbCr(image.Rect(0, 0, 2, 2), image.YCbCrSubsampleRatio420)
+		pix, ch, w, h := imagePixels(img)
+		if ch != 4 || w != 2 || h != 2 || len(pix) != 16 || pix[3] != 255 {
+			t.Errorf("got ch=%d %dx%d pix=%v", ch, w, h, pix)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		if _, _, w, h := imagePixels(image.NewNRGBA(image.Rect(0, 0, 0, 5))); w != 0 || h != 0 {
+			t.Errorf("got %dx%d for empty image", w, h)
+		}
+	})
+}
+
+func TestDownsampleBox(t *testing.T) {
+	src := []byte{
+		0, 4, 10, 10, 7,
+		8, 12, 10, 10, 7,
+	}
+	dst, w, h := downsampleBox(src, 1, false, 5, 2)
+	if w != 2 || h != 1 {
+		t.Fatalf("size = %dx%d, want 2x1", w, h)
+	}
+	if !bytes.Equal(dst, []byte{6, 10}) {
+		t.Errorf("dst = %v, want [6 10]", dst)
+	}
+
+	dst, w, h = downsampleBox([]byte{1, 2, 3, 4}, 4, false, 1, 1)
+	if w != 1 || h != 1 || !bytes.Equal(dst, []byte{1, 2, 3, 4}) {
+		t.Errorf("1x1 downsample = %v (%dx%d)", dst, w, h)
+	}
+
+	// Opaque black and white average to 50% linear intensity, which sRGB
+	// encodes as 188 rather than the 128 of averaging the stored bytes.
+	checker := []byte{
+		0, 0, 0, 255, 255, 255, 255, 255,
+		255, 255, 255, 255, 0, 0, 0, 255,
+	}
+	if dst, _, _ := downsampleBox(checker, 4, false, 2, 2); !bytes.Equal(dst, []byte{128, 128, 128, 255}) {
+		t.Errorf("linear downsample = %v, want [128 128 128 255]", dst)
+	}
+	if dst, _, _ := downsampleBox(checker, 4, true, 2, 2); !bytes.Equal(dst, []byte{188, 188, 188, 255}) {
+		t.Errorf("sRGB downsample = %v, want [188 188 188 255]", dst)
+	}
+
+	// Transparent black texels next to opaque red do not darken it; only
+	// the alpha drops. A fully transparent block keeps its average color.
+	edge := []byte{
+		255, 0, 0, 255, 0, 0, 0, 0, 10, 20, 30, 0, 30, 40, 50, 0,
+		255, 0, 0, 255, 0, 0, 0, 0, 10, 20, 30, 0, 30, 40, 50, 0,
+	}
+	if dst, _, _ := downsampleBox(edge, 4, false, 4, 2); !bytes.Equal(dst, []byte{255, 0, 0, 128, 20, 30, 40, 0}) {
+		t.Errorf("straight-alpha downsample = %v, want [255 0 0 128 20 30 40 0]", dst)
+	}
+}
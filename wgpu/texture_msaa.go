@@ -0,0 +1,152 @@
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+)
+
+// validMultisampleCount reports whether n is a sample count wgpu can allocate.
+// WebGPU guarantees 1 and 4; 2, 8 and 16 depend on the adapter and format.
+func validMultisampleCount(n uint32) bool {
+	switch n {
+	case 1, 2, 4, 8, 16:
+		return true
+	default:
+		return false
+	}
+}
+
+// CreateMultisampleColorTexture creates a width x height render attachment
+// with the given sample count, intended to be resolved into a single-sampled
+// texture. Pair it with [MultisampleState] Count set to the same value.
+func (d *Device) CreateMultisampleColorTexture(width, height uint32, format gputypes.TextureFormat, samples uint32) (*Texture, error) {
+	if !validMultisampleCount(samples) {
+		return nil, &WGPUError{
+			Op:      "CreateMultisampleColorTexture",
+			Type:    ErrorTypeValidation,
+			Message: fmt.Sprintf("invalid sample count %d", samples),
+		}
+	}
+	return d.CreateTexture(&TextureDescriptor{
+		Label:         "msaa color",
+		Usage:         gputypes.TextureUsageRenderAttachment,
+		Dimension:     gputypes.TextureDimension2D,
+		Size:          gputypes.Extent3D{Width: width, Height: height, DepthOrArrayLayers: 1},
+		Format:        format,
+		MipLevelCount: 1,
+		SampleCount:   samples,
+	})
+}
+
+// validateAttachmentSampleCounts checks MSAA consistency of a render pass:
+// every attachment shares one sample count, resolve targets are only used
+// with multisampled views, and resolve targets are themselves single-sampled.
+// Views whose sample count is unknown are skipped.
+func validateAttachmentSampleCounts(desc *RenderPassDescriptor) error {
+	var passSamples uint32
+	check := func(what string, view *TextureView) error {
+		if view == nil || view.sampleCount == 0 {
+			return nil
+		}
+		if passSamples == 0 {
+			passSamples = view.sampleCount
+			return nil
+		}
+		if view.sampleCount != passSamples {
+			return fmt.Errorf("%s has sample count %d, other attachments have %d", what, view.sampleCount, passSamples)
+		}
+		return nil
+	}
+
+	for i, ca := range desc.ColorAttachments {
+		if err := check(fmt.Sprintf("color attachment %d", i), ca.View); err != nil {
+			return err
+		}
+		if ca.ResolveTarget == nil {
+			continue
+		}
+		if ca.View != nil && ca.View.sampleCount == 1 {
+			return fmt.Errorf("color attachment %d has a resolve target but is not multisampled", i)
+		}
+		if ca.ResolveTarget.sampleCount > 1 {
+			return fmt.Errorf("color attachment %d resolve target has sample count %d, must be 1", i, ca.ResolveTarget.sampleCount)
+		}
+	}
+	if ds := desc.DepthStencilAttachment; ds != nil {
+		return check("depth/stencil attachment", ds.View)
+	}
+	return nil
+}
+
+// MultisampleTarget owns a multisampled color texture and resolves it into
+// a caller-provided view each frame. Call Resize when the surface size changes.
+type MultisampleTarget struct {
+	device  *Device
+	texture *Texture
+	view    *TextureView
+	format  gputypes.TextureFormat
+	samples uint32
+	width   uint32
+	height  uint32
+}
+
+// NewMultisampleTarget creates a width x height multisampled color target.
+func (d *Device) NewMultisampleTarget(width, height uint32, format gputypes.TextureFormat, samples uint32) (*MultisampleTarget, error) {
+	m := &MultisampleTarget{device: d, format: format, samples: samples}
+	if err := m.Resize(width, height); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Resize recreates the multisampled texture if the size changed.
+func (m *MultisampleTarget) Resize(width, height uint32) error {
+	if m.texture != nil && m.width == width && m.height == height {
+		return nil
+	}
+	tex, err := m.device.CreateMultisampleColorTexture(width, height, m.format, m.samples)
+	if err != nil {
+		return err
+	}
+	view, err := tex.CreateView(nil)
+	if err != nil {
+		tex.Release()
+		return err
+	}
+	m.Release()
+	m.texture, m.view, m.width, m.height = tex, view, width, height
+	return nil
+}
+
+// SampleCount returns the number of samples per texel.
+func (m *MultisampleTarget) SampleCount() uint32 { return m.samples }
+
+// View returns the multisampled view; render pipelines drawing into it must
+// use a MultisampleState with Count equal to SampleCount.
+func (m *MultisampleTarget) View() *TextureView { return m.view }
+
+// ColorAttachment returns an attachment that clears the multisampled texture,
+// renders into it and resolves into resolveTarget. The multisampled contents
+// are discarded after the resolve.
+func (m *MultisampleTarget) ColorAttachment(resolveTarget *TextureView, clear Color) RenderPassColorAttachment {
+	return RenderPassColorAttachment{
+		View:          m.view,
+		ResolveTarget: resolveTarget,
+		LoadOp:        gputypes.LoadOpClear,
+		StoreOp:       gputypes.StoreOpDiscard,
+		ClearValue:    clear,
+	}
+}
+
+// Release releases the multisampled texture and view.
+func (m *MultisampleTarget) Release() {
+	if m.view != nil {
+		m.view.Release()
+		m.view = nil
+	}
+	if m.texture != nil {
+		m.texture.Release()
+		m.texture = nil
+	}
+}
@@ -0,0 +1,61 @@
+package wgpu
+
+import "testing"
+
+func TestValidateAttachmentSampleCounts(t *testing.T) {
+	msaa := &TextureView{handle: 1, sampleCount: 4}
+	msaa2 := &TextureView{handle: 2, sampleCount: 2}
+	single := &TextureView{handle: 3, sampleCount: 1}
+	unknown := &TextureView{handle: 4}
+
+	tests := []struct {
+		name    string
+		desc    RenderPassDescriptor
+		wantErr bool
+	}{
+		{"single sampled", RenderPassDescriptor{
+			ColorAttachments: []RenderPassColorAttachment{{View: single}},
+		}, false},
+		{"msaa with resolve", RenderPassDescriptor{
+			ColorAttachments: []RenderPassColorAttachment{{View: msaa, ResolveTarget: single}},
+		}, false},
+		{"resolve on single sampled view", RenderPassDescriptor{
+			ColorAttachments: []RenderPassColorAttachment{{View: single, ResolveTarget: single}},
+		}, true},
+		{"multisampled resolve target", RenderPassDescriptor{
+			ColorAttachments: []RenderPassColorAttachment{{View: msaa, ResolveTarget: msaa}},
+		}, true},
+		{"mixed color sample counts", RenderPassDescriptor{
+			ColorAttachments: []RenderPassColorAttachment{{View: msaa}, {View: msaa2}},
+		}, true},
+		{"depth mismatch", RenderPassDescriptor{
+			ColorAttachments:       []RenderPassColorAttachment{{View: msaa}},
+			DepthStencilAttachment: &RenderPassDepthStencilAttachment{View: single},
+		}, true},
+		{"unknown views skipped", RenderPassDescriptor{
+			ColorAttachments:       []RenderPassColorAttachment{{View: unknown, ResolveTarget: unknown}},
+			DepthStencilAttachment: &RenderPassDepthStencilAttachment{View: msaa},
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAttachmentSampleCounts(&tt.desc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidMultisampleCount(t *testing.T) {
+	for _, n := range []uint32{1, 4} {
+		if !validMultisampleCount(n) {
+			t.Errorf("validMultisampleCount(%d) = false", n)
+		}
+	}
+	for _, n := range []uint32{0, 3, 32} {
+		if validMultisampleCount(n) {
+			t.Errorf("validMultisampleCount(%d) = true", n)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package wgpu
+
+import (
+	"sync"
+
+	"github.com/gogpu/gputypes"
+)
+
+// TexturePoolKey identifies interchangeable pooled textures.
+type TexturePoolKey struct {
+	Width, Height uint32
+	Format        gputypes.TextureFormat
+	Usage         gputypes.TextureUsage
+	SampleCount   uint32
+}
+
+// pooledTexture is a free texture together with the frame it was returned in.
+type pooledTexture struct {
+	tex      *Texture
+	lastUsed uint64
+}
+
+// TexturePool recycles transient 2D render targets, such as the intermediate
+// images of a post-processing chain, across frames.
+//
+// Acquire hands out a texture matching a key, reusing one released earlier
+// when possible. Textures acquired during a frame are returned to the pool by
+// [TexturePool.EndFrame], so they must not be used after the frame's command
+// buffers have been submitted and EndFrame has been called. Textures that
+// stay unused for MaxIdleFrames frames are released.
+type TexturePool struct {
+	// MaxIdleFrames is how many EndFrame calls a free texture survives
+	// without being reused. Zero selects the default of 3.
+	MaxIdleFrames uint64
+
+	device *Device
+	mu     sync.Mutex
+	frame  uint64
+	free   map[TexturePoolKey][]pooledTexture
+	inUse  map[*Texture]TexturePoolKey
+}
+
+// defaultMaxIdleFrames keeps textures alive across the usual two or three
+// frames in flight so that a steady chain never reallocates.
+const defaultMaxIdleFrames = 3
+
+// NewTexturePool creates an empty pool allocating from device.
+func NewTexturePool(device *Device) *TexturePool {
+	return &TexturePool{
+		device: device,
+		free:   make(map[TexturePoolKey][]pooledTexture),
+		inUse:  make(map[*Texture]TexturePoolKey),
+	}
+}
+
+// Acquire returns a texture for key, creating one with
+// [Device.CreateTextureE] if no free texture matches, so allocation failures
+// are returned and an out-of-memory error first goes to the device's
+// [OutOfMemoryHandler]. A zero SampleCount is treated as 1. The texture stays owned by the
+// pool; use [Texture.DefaultView] for its view instead of creating views that
+// outlive the frame.
+func (p *TexturePool) Acquire(key TexturePoolKey) (*Texture, error) {
+	if key.SampleCount == 0 {
+		key.SampleCount = 1
+	}
+	p.mu.Lock()
+	if list := p.free[key]; len(list) > 0 {
+		last := list[len(list)-1]
+		p.free[key] = list[:len(list)-1]
+		p.inUse[last.tex] = key
+		p.mu.Unlock()
+		return last.tex, nil
+	}
+	// Create without holding the lock: an OutOfMemoryHandler may Trim the
+	// pool before CreateTextureE retries the allocation.
+	p.mu.Unlock()
+	tex, err := p.device.CreateTextureE(&TextureDescriptor{
+		Label:         "pooled texture",
+		Usage:         key.Usage,
+		Dimension:     gputypes.TextureDimension2D,
+		Size:          gputypes.Extent3D{Width: key.Width, Height: key.Height, DepthOrArrayLayers: 1},
+		Format:        key.Format,
+		MipLevelCount: 1,
+		SampleCount:   key.SampleCount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	p.inUse[tex] = key
+	p.mu.Unlock()
+	return tex, nil
+}
+
+// Release returns tex to the pool before the end of the frame, making it
+// available to later Acquire calls in the same frame. The caller must not
+// have recorded further uses of tex after handing it back. Textures not
+// owned by the pool are ignored.
+func (p *TexturePool) Release(tex *Texture) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.recycle(tex)
+}
+
+// recycle moves tex from the in-use set to the free list. p.mu must be held.
+func (p *TexturePool) recycle(tex *Texture) {
+	key, ok := p.inUse[tex]
+	if !ok {
+		return
+	}
+	delete(p.inUse, tex)
+	p.free[key] = append(p.free[key], pooledTexture{tex: tex, lastUsed: p.frame})
+}
+
+// EndFrame returns every texture acquired during the frame to the pool and
+// releases free textures idle for more than MaxIdleFrames frames.
+func (p *TexturePool) EndFrame() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for tex := range p.inUse {
+		p.recycle(tex)
+	}
+	p.frame++
+
+	maxIdle := p.MaxIdleFrames
+	if maxIdle == 0 {
+		maxIdle = defaultMaxIdleFrames
+	}
+	for key, list := range p.free {
+		kept := list[:0]
+		for _, pt := range list {
+			if p.frame-pt.lastUsed > maxIdle {
+				pt.tex.Release()
+				continue
+			}
+			kept = append(kept, pt)
+		}
+		if len(kept) == 0 {
+			delete(p.free, key)
+		} else {
+			p.free[key] = kept
+		}
+	}
+}
+
+// Trim releases every free texture, keeping the ones handed out. It suits
+// an [OutOfMemoryHandler]:
+//
+//	device.SetOutOfMemoryHandler(func(error) bool {
+//	    pool.Trim()
+//	    return true
+//	})
+func (p *TexturePool) Trim() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, list := range p.free {
+		for _, pt := range list {
+			pt.tex.Release()
+		}
+	}
+	p.free = make(map[TexturePoolKey][]pooledTexture)
+}
+
+// Stats returns the number of textures currently handed out and the number
+// waiting in the pool.
+func (p *TexturePool) Stats() (inUse, free int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, list := range p.free {
+		free += len(list)
+	}
+	return len(p.inUse), free
+}
+
+// Destroy releases every texture owned by the pool, including ones still
+// handed out.
+func (p *TexturePool) Destroy() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for tex := range p.inUse {
+		tex.Release()
+	}
+	for _, list := range p.free {
+		for _, pt := range list {
+			pt.tex.Release()
+		}
+	}
+	p.inUse = make(map[*Texture]TexturePoolKey)
+	p.free = make(map[TexturePoolKey][]pooledTexture)
+}
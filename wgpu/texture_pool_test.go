@@ -0,0 +1,84 @@
+package wgpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+// seedPool puts a texture for key into p's free list without touching the GPU.
+func seedPool(p *TexturePool, key TexturePoolKey) *Texture {
+	tex := &Texture{}
+	p.free[key] = append(p.free[key], pooledTexture{tex: tex, lastUsed: p.frame})
+	return tex
+}
+
+func TestTexturePoolReuse(t *testing.T) {
+	p := NewTexturePool(nil)
+	key := TexturePoolKey{Width: 64, Height: 64, Format: gputypes.TextureFormatRGBA16Float,
+		Usage: gputypes.TextureUsageRenderAttachment, SampleCount: 1}
+	seeded := seedPool(p, key)
+
+	// A zero SampleCount matches the single-sampled key.
+	zero := key
+	zero.SampleCount = 0
+	got, err := p.Acquire(zero)
+	if err != nil || got != seeded {
+		t.Fatalf("Acquire = %p, %v; want seeded texture", got, err)
+	}
+	if inUse, free := p.Stats(); inUse != 1 || free != 0 {
+		t.Errorf("Stats = %d, %d; want 1, 0", inUse, free)
+	}
+
+	p.EndFrame()
+	if inUse, free := p.Stats(); inUse != 0 || free != 1 {
+		t.Errorf("after EndFrame Stats = %d, %d; want 0, 1", inUse, free)
+	}
+	if again, _ := p.Acquire(key); again != seeded {
+		t.Error("texture was not recycled across frames")
+	}
+}
+
+func TestTexturePoolReleaseWithinFrame(t *testing.T) {
+	p := NewTexturePool(nil)
+	key := TexturePoolKey{Width: 8, Height: 8, Format: gputypes.TextureFormatRGBA8Unorm, SampleCount: 1}
+	seeded := seedPool(p, key)
+	tex, _ := p.Acquire(key)
+	p.Release(tex)
+	p.Release(&Texture{}) // not owned, ignored
+	if again, _ := p.Acquire(key); again != seeded {
+		t.Error("released texture not available within the frame")
+	}
+}
+
+func TestTexturePoolEvictsIdle(t *testing.T) {
+	p := NewTexturePool(nil)
+	p.MaxIdleFrames = 2
+	key := TexturePoolKey{Width: 8, Height: 8, Format: gputypes.TextureFormatRGBA8Unorm, SampleCount: 1}
+	seedPool(p, key)
+	p.EndFrame()
+	p.EndFrame()
+	if _, free := p.Stats(); free != 1 {
+		t.Fatalf("texture evicted after 2 idle frames")
+	}
+	p.EndFrame()
+	if _, free := p.Stats(); free != 0 {
+		t.Errorf("texture not evicted after 3 idle frames")
+	}
+}
+
+func TestTexturePoolTrim(t *testing.T) {
+	p := NewTexturePool(nil)
+	key := TexturePoolKey{Width: 8, Height: 8, Format: gputypes.TextureFormatRGBA8Unorm, SampleCount: 1}
+	seedPool(p, key)
+	seedPool(p, key)
+	held, _ := p.Acquire(key)
+	p.Trim()
+	if inUse, free := p.Stats(); inUse != 1 || free != 0 {
+		t.Errorf("after Trim Stats = %d, %d; want 1, 0", inUse, free)
+	}
+	p.EndFrame()
+	if again, _ := p.Acquire(key); again != held {
+		t.Error("texture handed out during Trim was not recycled")
+	}
+}
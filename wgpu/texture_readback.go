@@ -0,0 +1,116 @@
+package wgpu
+
+import (
+	"context"
+	"fmt"
+	"image"
+
+	"github.com/gogpu/gputypes"
+)
+
+// CaptureFrame reads mip level 0 of texture back into an image, for
+// screenshots, bug reports and golden-image tests. It submits a copy on
+// queue and blocks until the pixels arrive, so it is meant for occasional
+// use, not every frame.
+//
+// texture must have an 8-bit RGBA or BGRA format and CopySrc usage. To
+// capture a surface frame, configure the surface with
+// [SurfaceConfiguration.Capturable] and pass the [SurfaceTexture]'s Texture
+// after submitting the frame's commands and before Present. sRGB formats
+// are returned as stored, i.e. already encoded, which is what PNG expects.
+func CaptureFrame(device *Device, queue *Queue, texture *Texture) (*image.NRGBA, error) {
+	const op = "CaptureFrame"
+	if err := checkInit(); err != nil {
+		return nil, err
+	}
+	if device == nil || device.handle == 0 || queue == nil || queue.handle == 0 {
+		return nil, &WGPUError{Op: op, Message: "device or queue is nil or released"}
+	}
+	if texture == nil || texture.handle == 0 {
+		return nil, &WGPUError{Op: op, Message: "texture is nil or released"}
+	}
+	return readTextureNRGBA(op, device, queue, texture)
+}
+
+// readbackFormat reports whether textures of format can be read back into an
+// image.NRGBA, and whether their channels are stored in BGRA order.
+func readbackFormat(format gputypes.TextureFormat) (bgra, ok bool) {
+	switch format {
+	case gputypes.TextureFormatRGBA8Unorm, gputypes.TextureFormatRGBA8UnormSrgb:
+		return false, true
+	case gputypes.TextureFormatBGRA8Unorm, gputypes.TextureFormatBGRA8UnormSrgb:
+		return true, true
+	}
+	return false, false
+}
+
+// readTextureNRGBA copies mip level 0 of the 8-bit RGBA or BGRA texture tex
+// to a staging buffer, submits the copy on queue and waits for the pixels.
+// tex needs CopySrc usage. op names the caller in errors.
+func readTextureNRGBA(op string, device *Device, queue *Queue, tex *Texture) (*image.NRGBA, error) {
+	format := tex.Format()
+	bgra, ok := readbackFormat(format)
+	if !ok {
+		return nil, &WGPUError{Op: op, Type: ErrorTypeValidation,
+			Message: fmt.Sprintf("cannot read back format %v, need an 8-bit RGBA or BGRA format", format)}
+	}
+	if tex.Usage()&gputypes.TextureUsageCopySrc == 0 {
+		return nil, &WGPUError{Op: op, Type: ErrorTypeValidation, Message: "texture lacks CopySrc usage"}
+	}
+	size := gputypes.Extent3D{Width: tex.Width(), Height: tex.Height(), DepthOrArrayLayers: 1}
+	layout, bufSize, err := TextureCopyLayout(format, size, 0)
+	if err != nil {
+		return nil, err
+	}
+	staging, err := device.CreateBuffer(&BufferDescriptor{
+		Label: "texture readback",
+		Usage: gputypes.BufferUsageMapRead | gputypes.BufferUsageCopyDst,
+		Size:  bufSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer staging.Release()
+
+	encoder, err := device.CreateCommandEncoder(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer encoder.Release()
+	encoder.CopyTextureToBufferRegion(&ImageCopyTexture{Texture: tex, Aspect: TextureAspectAll}, staging, 0, size)
+	cmd, err := encoder.Finish()
+	if err != nil {
+		return nil, err
+	}
+	defer cmd.Release()
+	if _, err := queue.Submit(cmd); err != nil {
+		return nil, err
+	}
+
+	if err := staging.Map(context.Background(), MapModeRead, 0, bufSize); err != nil {
+		return nil, err
+	}
+	defer staging.Unmap() //nolint:errcheck
+	rng, err := staging.MappedRange(0, bufSize)
+	if err != nil {
+		return nil, err
+	}
+	img := image.NewNRGBA(image.Rect(0, 0, int(size.Width), int(size.Height)))
+	decodeRGBA8(img, rng.Bytes(), int(layout.BytesPerRow), bgra)
+	return img, nil
+}
+
+// decodeRGBA8 copies the rows of src, bytesPerRow apart, into dst, swapping
+// red and blue when bgra is set.
+func decodeRGBA8(dst *image.NRGBA, src []byte, bytesPerRow int, bgra bool) {
+	rowBytes := dst.Rect.Dx() * 4
+	for y := range dst.Rect.Dy() {
+		row := dst.Pix[y*dst.Stride : y*dst.Stride+rowBytes]
+		copy(row, src[y*bytesPerRow:y*bytesPerRow+rowBytes])
+		if bgra {
+			for i := 0; i < len(row); i += 4 {
+				row[i], row[i+2] = row[i+2], row[i]
+			}
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package wgpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+)
+
+// CopyBytesPerRowAlignment is the required alignment, in bytes, of
+// BytesPerRow for buffer-texture copies. WriteTextureData also pads rows
+// to this alignment so the same staging data can be reused for
+// CopyBufferToTexture.
+const CopyBytesPerRowAlignment = 256
+
+// alignBytesPerRow rounds n up to CopyBytesPerRowAlignment.
+func alignBytesPerRow(n uint32) uint32 {
+	return (n + CopyBytesPerRowAlignment - 1) &^ (CopyBytesPerRowAlignment - 1)
+}
+
+// textureRowLayout computes the tightly packed and aligned row pitch of a
+// width x height region, plus the number of block rows it spans.
+func textureRowLayout(format gputypes.TextureFormat, width, height uint32) (tight, aligned, rows uint32, err error) {
+	blockSize := format.BlockCopySize()
+	if blockSize == 0 {
+		return 0, 0, 0, fmt.Errorf("format %s has no defined copy size", format)
+	}
+	bw, bh := TextureFormatBlockDimensions(format)
+	tight = (width + bw - 1) / bw * blockSize
+	rows = (height + bh - 1) / bh
+	return tight, alignBytesPerRow(tight), rows, nil
+}
+
+// padTextureRows copies rows of tight bytes each into a new buffer with a
+// row pitch of aligned bytes. src must hold at least tight*rows bytes.
+func padTextureRows(src []byte, tight, aligned, rows uint32) []byte {
+	dst := make([]byte, int(aligned)*int(rows))
+	for r := 0; r < int(rows); r++ {
+		copy(dst[r*int(aligned):r*int(aligned)+int(tight)], src[r*int(tight):(r+1)*int(tight)])
+	}
+	return dst
+}
+
+// WriteTextureData uploads a width x height region of pixel data into
+// mip level mip of tex, starting at origin.
+//
+// data may be tightly packed (one row directly after the other) or already
+// padded to CopyBytesPerRowAlignment; tightly packed rows are repacked into
+// an aligned staging copy. For block-compressed formats, rows are counted in
+// blocks and width/height are rounded up to whole blocks.
+func (q *Queue) WriteTextureData(tex *Texture, mip uint32, origin gputypes.Origin3D, data []byte, width, height uint32) error {
+	return q.writeTextureRegion("WriteTextureData", tex, mip, origin, data, gputypes.Extent3D{
+		Width: width, Height: height, DepthOrArrayLayers: 1,
+	})
+}
+
+// WriteVolume uploads a width x height x depth region of a 3D texture, or
+// depth consecutive layers of a 2D array texture, starting at origin.
+//
+// Slices follow each other with no gap between the last row of one slice and
+// the first row of the next, so RowsPerImage equals the slice height in block
+// rows. Row packing rules are the same as for [Queue.WriteTextureData].
+func (q *Queue) WriteVolume(tex *Texture, mip uint32, origin gputypes.Origin3D, data []byte, width, height, depth uint32) error {
+	return q.writeTextureRegion("WriteVolume", tex, mip, origin, data, gputypes.Extent3D{
+		Width: width, Height: height, DepthOrArrayLayers: depth,
+	})
+}
+
+// writeTextureRegion implements WriteTextureData and WriteVolume.
+func (q *Queue) writeTextureRegion(op string, tex *Texture, mip uint32, origin gputypes.Origin3D, data []byte, size gputypes.Extent3D) error {
+	if q == nil || q.handle == 0 {
+		return &WGPUError{Op: op, Message: "queue is nil or released"}
+	}
+	if tex == nil || tex.handle == 0 {
+		return &WGPUError{Op: op, Message: "texture is nil or released"}
+	}
+	if size.Width == 0 || size.Height == 0 || size.DepthOrArrayLayers == 0 {
+		return nil
+	}
+
+	tight, aligned, rows, err := textureRowLayout(tex.Format(), size.Width, size.Height)
+	if err != nil {
+		return &WGPUError{Op: op, Message: err.Error()}
+	}
+
+	// Slices are stored back to back, so the whole region is just
+	// rows*depth rows as far as padding is concerned.
+	totalRows := uint64(rows) * uint64(size.DepthOrArrayLayers)
+	need := uint64(tight) * totalRows
+	padded := uint64(aligned)*(totalRows-1) + uint64(tight)
+	switch {
+	case uint64(len(data)) == need && tight != aligned:
+		data = padTextureRows(data, tight, aligned, uint32(totalRows))
+	case uint64(len(data)) >= padded:
+		// Already aligned (or tight == aligned); upload as-is.
+	default:
+		return &WGPUError{
+			Op:      op,
+			Message: fmt.Sprintf("data too short: got %d bytes, need %d", len(data), need),
+		}
+	}
+
+	dest := ImageCopyTexture{
+		Texture:  tex,
+		MipLevel: mip,
+		Origin:   origin,
+		Aspect:   TextureAspectAll,
+	}
+	layout := ImageDataLayout{
+		BytesPerRow:  aligned,
+		RowsPerImage: rows,
+	}
+	return q.WriteTexture(&dest, data, &layout, &size)
+}
+
+// TextureCopyLayout returns the buffer layout of a size region of format
+// for CopyBufferToTextureRegion and CopyTextureToBufferRegion: rows are
+// padded to CopyBytesPerRowAlignment and images follow each other directly.
+// bufferSize is the number of bytes the region occupies after offset.
+// For depth or stencil copies pass the format of the copied aspect, e.g.
+// Stencil8 for the stencil of Depth24PlusStencil8.
+func TextureCopyLayout(format gputypes.TextureFormat, size gputypes.Extent3D, offset uint64) (layout ImageDataLayout, bufferSize uint64, err error) {
+	tight, aligned, rows, err := textureRowLayout(format, size.Width, size.Height)
+	if err != nil {
+		return ImageDataLayout{}, 0, err
+	}
+	if offset%uint64(format.BlockCopySize()) != 0 {
+		return ImageDataLayout{}, 0, fmt.Errorf("offset %d is not a multiple of the %d-byte block of %s",
+			offset, format.BlockCopySize(), format)
+	}
+	layout = ImageDataLayout{Offset: offset, BytesPerRow: aligned, RowsPerImage: rows}
+	if totalRows := uint64(rows) * uint64(size.DepthOrArrayLayers); totalRows > 0 {
+		bufferSize = uint64(aligned)*(totalRows-1) + uint64(tight)
+	}
+	return layout, bufferSize, nil
+}
+
+// bufferCopyRegion lays out a copy of size between buf at offset and tex,
+// checking that the region fits in the buffer.
+func bufferCopyRegion(buf *Buffer, offset uint64, tex *ImageCopyTexture, size gputypes.Extent3D) (BufferTextureCopy, error) {
+	if tex == nil || tex.Texture == nil || tex.Texture.handle == 0 {
+		return BufferTextureCopy{}, fmt.Errorf("texture is nil or released")
+	}
+	format, err := aspectViewFormat(tex.Texture.Format(), tex.Aspect)
+	if err != nil {
+		return BufferTextureCopy{}, err
+	}
+	layout, need, err := TextureCopyLayout(format, size, offset)
+	if err != nil {
+		return BufferTextureCopy{}, err
+	}
+	if have := buf.Size(); offset+need > have {
+		return BufferTextureCopy{}, fmt.Errorf("region needs %d bytes at offset %d, buffer has %d", need, offset, have)
+	}
+	return BufferTextureCopy{BufferLayout: layout, TextureBase: *tex, Size: size}, nil
+}
+
+// CopyBufferToTextureRegion copies a size region from src, starting at
+// offset and laid out as by [TextureCopyLayout], into dst. Invalid copies
+// are skipped and reported by [CommandEncoder.Finish].
+func (enc *CommandEncoder) CopyBufferToTextureRegion(src *Buffer, offset uint64, dst *ImageCopyTexture, size gputypes.Extent3D) {
+	mustInit()
+	if enc == nil || enc.handle == 0 || src == nil || src.handle == 0 {
+		return
+	}
+	r, err := bufferCopyRegion(src, offset, dst, size)
+	if err != nil {
+		enc.recordErr("CommandEncoder.CopyBufferToTextureRegion", err)
+		return
+	}
+	srcWire := TexelCopyBufferInfo{
+		Layout: TexelCopyBufferLayout{
+			Offset:       r.BufferLayout.Offset,
+			BytesPerRow:  r.BufferLayout.BytesPerRow,
+			RowsPerImage: r.BufferLayout.RowsPerImage,
+		},
+		Buffer: src.handle,
+	}
+	dstWire := r.TextureBase.toWire()
+	enc.CopyBufferToTexture(&srcWire, &dstWire, &r.Size)
+}
+
+// CopyTextureToBufferRegion copies a size region of src into dst, starting
+// at offset and laid out as by [TextureCopyLayout], for readback. Invalid
+// copies are skipped and reported by [CommandEncoder.Finish].
+func (enc *CommandEncoder) CopyTextureToBufferRegion(src *ImageCopyTexture, dst *Buffer, offset uint64, size gputypes.Extent3D) {
+	mustInit()
+	if enc == nil || enc.handle == 0 || dst == nil || dst.handle == 0 {
+		return
+	}
+	r, err := bufferCopyRegion(dst, offset, src, size)
+	if err != nil {
+		enc.recordErr("CommandEncoder.CopyTextureToBufferRegion", err)
+		return
+	}
+	enc.CopyTextureToBuffer(src.Texture, dst, []BufferTextureCopy{r})
+}
@@ -0,0 +1,110 @@
+package wgpu
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestTextureRowLayout(t *testing.T) {
+	tests := []struct {
+		name                 string
+		format               gputypes.TextureFormat
+		width, height        uint32
+		tight, aligned, rows uint32
+	}{
+		{"RGBA8 8x8", gputypes.TextureFormatRGBA8Unorm, 8, 8, 32, 256, 8},
+		{"RGBA8 64x2", gputypes.TextureFormatRGBA8Unorm, 64, 2, 256, 256, 2},
+		{"R8 300x1", gputypes.TextureFormatR8Unorm, 300, 1, 300, 512, 1},
+		{"RGBA32F 17x3", gputypes.TextureFormatRGBA32Float, 17, 3, 272, 512, 3},
+		{"BC1 16x16", gputypes.TextureFormatBC1RGBAUnorm, 16, 16, 32, 256, 4},
+		{"BC7 6x6 partial blocks", gputypes.TextureFormatBC7RGBAUnorm, 6, 6, 32, 256, 2},
+		{"ETC2 RGBA 128x4", gputypes.TextureFormatETC2RGBA8Unorm, 128, 4, 512, 512, 1},
+		{"ASTC 8x6 srgb", gputypes.TextureFormatASTC8x6UnormSrgb, 64, 12, 128, 256, 2},
+		{"ASTC 12x12", gputypes.TextureFormatASTC12x12Unorm, 24, 24, 32, 256, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tight, aligned, rows, err := textureRowLayout(tt.format, tt.width, tt.height)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tight != tt.tight || aligned != tt.aligned || rows != tt.rows {
+				t.Errorf("got (%d, %d, %d), want (%d, %d, %d)",
+					tight, aligned, rows, tt.tight, tt.aligned, tt.rows)
+			}
+		})
+	}
+}
+
+func TestTextureRowLayoutUndefinedCopySize(t *testing.T) {
+	if _, _, _, err := textureRowLayout(gputypes.TextureFormatDepth24Plus, 4, 4); err == nil {
+		t.Error("expected error for Depth24Plus")
+	}
+}
+
+func TestPadTextureRows(t *testing.T) {
+	src := []byte{1, 2, 3, 4, 5, 6}
+	got := padTextureRows(src, 3, 256, 2)
+	if len(got) != 512 {
+		t.Fatalf("len = %d, want 512", len(got))
+	}
+	if !bytes.Equal(got[0:3], []byte{1, 2, 3}) || !bytes.Equal(got[256:259], []byte{4, 5, 6}) {
+		t.Errorf("rows not placed at aligned offsets")
+	}
+	if got[3] != 0 || got[255] != 0 {
+		t.Errorf("padding not zeroed")
+	}
+}
+
+func TestWriteTextureDataNilGuards(t *testing.T) {
+	var q *Queue
+	if err := q.WriteTextureData(&Texture{handle: 1}, 0, gputypes.Origin3D{}, []byte{0}, 1, 1); err == nil {
+		t.Error("expected error for nil queue")
+	}
+	q = &Queue{handle: 1}
+	if err := q.WriteTextureData(nil, 0, gputypes.Origin3D{}, []byte{0}, 1, 1); err == nil {
+		t.Error("expected error for nil texture")
+	}
+}
+
+func TestWriteVolumeNilGuards(t *testing.T) {
+	var q *Queue
+	if err := q.WriteVolume(&Texture{handle: 1}, 0, gputypes.Origin3D{}, []byte{0}, 1, 1, 1); err == nil {
+		t.Error("expected error for nil queue")
+	}
+	q = &Queue{handle: 1}
+	if err := q.WriteVolume(nil, 0, gputypes.Origin3D{}, []byte{0}, 1, 1, 1); err == nil {
+		t.Error("expected error for nil texture")
+	}
+}
+
+func TestPadTextureRowsAcrossSlices(t *testing.T) {
+	// Two 1-row slices of 2 bytes: each becomes one aligned row.
+	got := padTextureRows([]byte{1, 2, 3, 4}, 2, 256, 2)
+	if got[0] != 1 || got[1] != 2 || got[256] != 3 || got[257] != 4 {
+		t.Errorf("slice rows not placed at aligned offsets")
+	}
+}
+
+func TestTextureCopyLayout(t *testing.T) {
+	layout, size, err := TextureCopyLayout(gputypes.TextureFormatRGBA8Unorm, gputypes.Extent3D{Width: 10, Height: 3, DepthOrArrayLayers: 2}, 512)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ImageDataLayout{Offset: 512, BytesPerRow: 256, RowsPerImage: 3}
+	if layout != want {
+		t.Errorf("layout = %+v, want %+v", layout, want)
+	}
+	// Five padded rows plus the 40 tight bytes of the last one.
+	if size != 5*256+40 {
+		t.Errorf("bufferSize = %d, want %d", size, 5*256+40)
+	}
+	if _, _, err := TextureCopyLayout(gputypes.TextureFormatRGBA8Unorm, gputypes.Extent3D{Width: 1, Height: 1, DepthOrArrayLayers: 1}, 2); err == nil {
+		t.Error("unaligned offset: expected an error")
+	}
+	if _, _, err := TextureCopyLayout(gputypes.TextureFormatDepth24Plus, gputypes.Extent3D{Width: 1, Height: 1, DepthOrArrayLayers: 1}, 0); err == nil {
+		t.Error("Depth24Plus has no copy size: expected an error")
+	}
+}
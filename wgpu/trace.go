@@ -0,0 +1,546 @@
+package wgpu
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gogpu/gputypes"
+)
+
+// API traces record the calls an application makes, so a rendering bug can
+// be reproduced without the application:
+//
+//	f, _ := os.Create("app.trace")
+//	wgpu.StartTrace(f)
+//	// ... run the frames showing the bug ...
+//	wgpu.StopTrace()
+//	f.Close()
+//
+// and later, in a test or a bug report:
+//
+//	f, _ := os.Open("app.trace")
+//	err := wgpu.ReplayTrace(f, device)
+//
+// A trace is a JSON object per line. Descriptors are stored whole, with
+// references to other objects replaced by trace IDs, and buffer and texture
+// uploads are stored with their data.
+//
+// Traced calls are resource creation on the device (buffers, textures,
+// views, samplers, query sets, WGSL, SPIR-V and GLSL shader modules, bind
+// group and pipeline layouts, bind groups, pipelines, command encoders),
+// pipeline bind group layouts, command encoder copies and passes, render
+// and compute pass commands, debug markers and groups, queue writes and
+// submits, and the release of traced objects. Surface textures are
+// replayed as offscreen render targets of the same size and format. The
+// device and queue are those given to ReplayTrace; objects created before
+// StartTrace cannot be referenced by the trace.
+//
+// Not traced are the wire-level calls taking raw descriptors
+// (Device.CreateShaderModule with a *ShaderModuleDescriptor and the *Raw
+// copy and write methods) and render bundles: bundle encoders, their
+// commands and RenderPassEncoder.ExecuteBundles. A trace of an application
+// using them replays without that work, or fails at the first call that
+// references an object they created. A descriptor or argument holding a
+// uintptr or unsafe.Pointer stops the trace with an error returned by
+// StopTrace, since the native address would not be valid on replay.
+
+// traceVersion is the version of the trace format, written in the first
+// record.
+const traceVersion = 1
+
+// traceActive is checked by every traced call before anything else.
+var traceActive atomic.Bool
+
+var tracer traceState
+
+type traceState struct {
+	mu   sync.Mutex
+	enc  *json.Encoder
+	ids  map[uintptr]uint64 // native handle to trace ID
+	next uint64
+	err  error // first encoding or write error
+}
+
+// traceRecord is one line of a trace.
+type traceRecord struct {
+	Op   string            `json:"op"`
+	Recv uint64            `json:"recv,omitempty"` // trace ID of the receiver
+	ID   uint64            `json:"id,omitempty"`   // trace ID of the created object
+	Args []json.RawMessage `json:"args,omitempty"`
+}
+
+// traceRef stands in for an object in a traced descriptor or argument.
+type traceRef struct {
+	Ref uint64 `json:"$ref"`
+}
+
+// traceBufferCopy is the trace form of a TexelCopyBufferInfo, with the
+// buffer handle replaced by its object.
+type traceBufferCopy struct {
+	Buffer *Buffer
+	Layout TexelCopyBufferLayout
+}
+
+// traceHandle is implemented by every traceable object.
+type traceHandle interface{ Handle() uintptr }
+
+// traceRefTypes are the object types stored as references.
+var traceRefTypes = map[reflect.Type]bool{}
+
+func init() {
+	for _, v := range []traceHandle{
+		(*Buffer)(nil), (*Texture)(nil), (*TextureView)(nil), (*Sampler)(nil),
+		(*QuerySet)(nil), (*ShaderModule)(nil), (*BindGroupLayout)(nil),
+		(*BindGroup)(nil), (*PipelineLayout)(nil), (*RenderPipeline)(nil),
+		(*ComputePipeline)(nil), (*CommandEncoder)(nil), (*CommandBuffer)(nil),
+		(*RenderPassEncoder)(nil), (*ComputePassEncoder)(nil), (*RenderBundle)(nil),
+	} {
+		traceRefTypes[reflect.TypeOf(v)] = true
+	}
+}
+
+// StartTrace starts recording API calls to w. Only one trace can be
+// recorded at a time. Render bundles, RenderPassEncoder.ExecuteBundles,
+// Device.CreateShaderModule with a wire-level descriptor and the Raw copy
+// and write methods are not recorded.
+func StartTrace(w io.Writer) error {
+	if w == nil {
+		return &WGPUError{Op: "StartTrace", Message: "writer is nil"}
+	}
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+	if traceActive.Load() {
+		return &WGPUError{Op: "StartTrace", Type: ErrorTypeValidation, Message: "a trace is already being recorded"}
+	}
+	tracer.enc = json.NewEncoder(w)
+	tracer.ids = make(map[uintptr]uint64)
+	tracer.next = 0
+	tracer.err = nil
+	tracer.write(traceRecord{Op: "Trace", Args: []json.RawMessage{json.RawMessage(fmt.Sprint(traceVersion))}})
+	traceActive.Store(tracer.err == nil)
+	return tracer.err
+}
+
+// StopTrace stops recording and returns the first error met while
+// writing the trace, if any.
+func StopTrace() error {
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+	traceActive.Store(false)
+	err := tracer.err
+	tracer.enc, tracer.ids, tracer.err = nil, nil, nil
+	return err
+}
+
+// traceCall records a call of op. recv is the receiving object, nil for the
+// device and queue, and result the object the call created, if any.
+func traceCall(op string, recv, result traceHandle, args ...any) {
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+	if !traceActive.Load() || tracer.err != nil {
+		return
+	}
+	rec := traceRecord{Op: op}
+	if !isNilHandle(recv) {
+		rec.Recv = tracer.ids[recv.Handle()]
+	}
+	for _, arg := range args {
+		enc, err := traceEncode(reflect.ValueOf(arg))
+		if err != nil {
+			tracer.err = fmt.Errorf("wgpu: trace %s: %w", op, err)
+			return
+		}
+		raw, err := json.Marshal(enc)
+		if err != nil {
+			tracer.err = fmt.Errorf("wgpu: trace %s: %w", op, err)
+			return
+		}
+		rec.Args = append(rec.Args, raw)
+	}
+	if !isNilHandle(result) && result.Handle() != 0 {
+		// Cached objects, such as pipeline bind group layouts, keep the ID
+		// they were first returned with.
+		if id, ok := tracer.ids[result.Handle()]; ok {
+			rec.ID = id
+		} else {
+			tracer.next++
+			rec.ID = tracer.next
+			tracer.ids[result.Handle()] = rec.ID
+		}
+	}
+	tracer.write(rec)
+}
+
+// traceRelease records the release of the object with handle h, if it was
+// created while tracing.
+func traceRelease(h uintptr) {
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+	id, ok := tracer.ids[h]
+	if !ok || tracer.err != nil {
+		return
+	}
+	delete(tracer.ids, h)
+	tracer.write(traceRecord{Op: "Release", Recv: id})
+}
+
+// write writes rec; t.mu is held.
+func (t *traceState) write(rec traceRecord) {
+	if err := t.enc.Encode(rec); err != nil {
+		t.err = fmt.Errorf("wgpu: trace: %w", err)
+	}
+}
+
+func isNilHandle(h traceHandle) bool {
+	return h == nil || reflect.ValueOf(h).IsNil()
+}
+
+// traceEncode converts v to a JSON-encodable value, replacing objects with
+// references. Unexported fields, functions and channels are left out.
+// Native handles and pointers held as uintptr or unsafe.Pointer would not
+// be valid when the trace is replayed, so they fail the trace instead.
+func traceEncode(v reflect.Value) (any, error) {
+	switch v.Kind() {
+	case reflect.Invalid, reflect.Func, reflect.Chan:
+		return nil, nil
+	case reflect.Uintptr, reflect.UnsafePointer:
+		if v.IsZero() {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%s holds a native pointer and cannot be traced", v.Type())
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if traceRefTypes[v.Type()] {
+			return traceRef{Ref: tracer.ids[v.Interface().(traceHandle).Handle()]}, nil
+		}
+		return traceEncode(v.Elem())
+	case reflect.Struct:
+		m := make(map[string]any, v.NumField())
+		for i := range v.NumField() {
+			if f := v.Type().Field(i); f.IsExported() {
+				e, err := traceEncode(v.Field(i))
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", f.Name, err)
+				}
+				m[f.Name] = e
+			}
+		}
+		return m, nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Bytes(), nil
+		}
+		fallthrough
+	case reflect.Array:
+		s := make([]any, v.Len())
+		for i := range s {
+			e, err := traceEncode(v.Index(i))
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			s[i] = e
+		}
+		return s, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		m := make(map[string]any, v.Len())
+		for it := v.MapRange(); it.Next(); {
+			k := fmt.Sprint(it.Key().Interface())
+			e, err := traceEncode(it.Value())
+			if err != nil {
+				return nil, fmt.Errorf("[%q]: %w", k, err)
+			}
+			m[k] = e
+		}
+		return m, nil
+	default:
+		return v.Interface(), nil
+	}
+}
+
+// traceReplayer re-executes a trace.
+type traceReplayer struct {
+	device  *Device
+	queue   *Queue
+	objects map[uint64]traceHandle
+}
+
+// ReplayTrace re-executes a trace recorded by [StartTrace] on device. It
+// stops at the first call that fails, returning its error with the trace
+// line, and releases the objects the trace left alive before returning.
+func ReplayTrace(r io.Reader, device *Device) error {
+	const op = "ReplayTrace"
+	if err := checkInit(); err != nil {
+		return err
+	}
+	if device == nil || device.handle == 0 {
+		return &WGPUError{Op: op, Message: "device is nil or released"}
+	}
+	if r == nil {
+		return &WGPUError{Op: op, Message: "reader is nil"}
+	}
+	rp := &traceReplayer{device: device, queue: device.Queue(), objects: make(map[uint64]traceHandle)}
+	defer rp.releaseAll()
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<30) // uploads are stored inline
+	for line := 1; sc.Scan(); line++ {
+		var rec traceRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			return fmt.Errorf("wgpu: trace line %d: %w", line, err)
+		}
+		if line == 1 {
+			if err := checkTraceHeader(&rec); err != nil {
+				return fmt.Errorf("wgpu: trace line 1: %w", err)
+			}
+			continue
+		}
+		if err := rp.replay(&rec); err != nil {
+			return fmt.Errorf("wgpu: trace line %d: %s: %w", line, rec.Op, err)
+		}
+	}
+	return sc.Err()
+}
+
+func checkTraceHeader(rec *traceRecord) error {
+	var version int
+	if rec.Op != "Trace" || len(rec.Args) != 1 || json.Unmarshal(rec.Args[0], &version) != nil {
+		return errors.New("not a wgpu trace")
+	}
+	if version != traceVersion {
+		return fmt.Errorf("trace version %d, want %d", version, traceVersion)
+	}
+	return nil
+}
+
+// replay executes one record. Calls are made by reflection on the method
+// named by the record, with arguments decoded to its parameter types.
+func (rp *traceReplayer) replay(rec *traceRecord) error {
+	switch rec.Op {
+	case "Release":
+		obj, ok := rp.objects[rec.Recv]
+		if !ok {
+			return fmt.Errorf("unknown object %d", rec.Recv)
+		}
+		delete(rp.objects, rec.Recv)
+		return callRelease(obj)
+	case "Surface.GetCurrentTexture":
+		var desc TextureDescriptor
+		if len(rec.Args) != 1 {
+			return errors.New("missing texture description")
+		}
+		if err := rp.decode(rec.Args[0], reflect.ValueOf(&desc).Elem()); err != nil {
+			return err
+		}
+		desc.Usage |= gputypes.TextureUsageCopySrc
+		tex, err := rp.device.CreateTexture(&desc)
+		if err != nil {
+			return err
+		}
+		rp.objects[rec.ID] = tex
+		return nil
+	case "CommandEncoder.CopyBufferToTexture":
+		return rp.copyBufferToTexture(rec)
+	}
+
+	typeName, method, ok := strings.Cut(rec.Op, ".")
+	if !ok {
+		return errors.New("unknown operation")
+	}
+	var recv reflect.Value
+	switch typeName {
+	case "Device":
+		recv = reflect.ValueOf(rp.device)
+	case "Queue":
+		recv = reflect.ValueOf(rp.queue)
+	default:
+		obj, ok := rp.objects[rec.Recv]
+		if !ok {
+			return fmt.Errorf("unknown receiver %d", rec.Recv)
+		}
+		recv = reflect.ValueOf(obj)
+	}
+	m := recv.MethodByName(method)
+	if !m.IsValid() || recv.Type().Elem().Name() != typeName {
+		return fmt.Errorf("no method %s on %s", method, recv.Type())
+	}
+	mt := m.Type()
+	if len(rec.Args) != mt.NumIn() {
+		return fmt.Errorf("%d arguments, want %d", len(rec.Args), mt.NumIn())
+	}
+	in := make([]reflect.Value, mt.NumIn())
+	for i := range in {
+		in[i] = reflect.New(mt.In(i)).Elem()
+		if err := rp.decode(rec.Args[i], in[i]); err != nil {
+			return fmt.Errorf("argument %d: %w", i, err)
+		}
+	}
+	var out []reflect.Value
+	if mt.IsVariadic() {
+		out = m.CallSlice(in)
+	} else {
+		out = m.Call(in)
+	}
+	if n := len(out); n > 0 && mt.Out(n-1) == reflect.TypeFor[error]() && !out[n-1].IsNil() {
+		return out[n-1].Interface().(error)
+	}
+	if rec.ID != 0 {
+		if len(out) == 0 || !traceRefTypes[out[0].Type()] || out[0].IsNil() {
+			return errors.New("call created no object")
+		}
+		rp.objects[rec.ID] = out[0].Interface().(traceHandle)
+	}
+	return nil
+}
+
+// copyBufferToTexture replays CommandEncoder.CopyBufferToTexture, whose
+// wire-level arguments are traced with objects in place of their handles.
+func (rp *traceReplayer) copyBufferToTexture(rec *traceRecord) error {
+	enc, ok := rp.objects[rec.Recv].(*CommandEncoder)
+	if !ok {
+		return fmt.Errorf("unknown command encoder %d", rec.Recv)
+	}
+	if len(rec.Args) != 3 {
+		return fmt.Errorf("%d arguments, want 3", len(rec.Args))
+	}
+	var (
+		src  traceBufferCopy
+		dst  ImageCopyTexture
+		size gputypes.Extent3D
+	)
+	for i, v := range []any{&src, &dst, &size} {
+		if err := rp.decode(rec.Args[i], reflect.ValueOf(v).Elem()); err != nil {
+			return fmt.Errorf("argument %d: %w", i, err)
+		}
+	}
+	if src.Buffer == nil || dst.Texture == nil {
+		return errors.New("buffer or texture missing")
+	}
+	srcWire := TexelCopyBufferInfo{Layout: src.Layout, Buffer: src.Buffer.handle}
+	dstWire := dst.toWire()
+	enc.CopyBufferToTexture(&srcWire, &dstWire, &size)
+	return nil
+}
+
+// decode decodes raw, as written by traceEncode, into v.
+func (rp *traceReplayer) decode(raw json.RawMessage, v reflect.Value) error {
+	if string(raw) == "null" {
+		v.SetZero()
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer, reflect.Interface:
+		return nil
+	case reflect.Pointer:
+		if traceRefTypes[v.Type()] {
+			var ref traceRef
+			if err := json.Unmarshal(raw, &ref); err != nil {
+				return err
+			}
+			obj, ok := rp.objects[ref.Ref]
+			if !ok {
+				return fmt.Errorf("%s %d was not created in the trace", v.Type().Elem().Name(), ref.Ref)
+			}
+			if reflect.TypeOf(obj) != v.Type() {
+				return fmt.Errorf("object %d is a %T, not a %s", ref.Ref, obj, v.Type())
+			}
+			v.Set(reflect.ValueOf(obj))
+			return nil
+		}
+		p := reflect.New(v.Type().Elem())
+		if err := rp.decode(raw, p.Elem()); err != nil {
+			return err
+		}
+		v.Set(p)
+		return nil
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return err
+		}
+		for i := range v.NumField() {
+			f := v.Type().Field(i)
+			if fraw, ok := fields[f.Name]; ok && f.IsExported() {
+				if err := rp.decode(fraw, v.Field(i)); err != nil {
+					return fmt.Errorf("%s: %w", f.Name, err)
+				}
+			}
+		}
+		return nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		var elems []json.RawMessage
+		if err := json.Unmarshal(raw, &elems); err != nil {
+			return err
+		}
+		if v.Kind() == reflect.Slice {
+			v.Set(reflect.MakeSlice(v.Type(), len(elems), len(elems)))
+		} else if len(elems) != v.Len() {
+			return fmt.Errorf("%d elements, want %d", len(elems), v.Len())
+		}
+		for i, e := range elems {
+			if err := rp.decode(e, v.Index(i)); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		return nil
+	case reflect.Map:
+		var elems map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &elems); err != nil {
+			return err
+		}
+		if v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map type %s", v.Type())
+		}
+		m := reflect.MakeMapWithSize(v.Type(), len(elems))
+		for k, e := range elems {
+			ev := reflect.New(v.Type().Elem()).Elem()
+			if err := rp.decode(e, ev); err != nil {
+				return fmt.Errorf("[%q]: %w", k, err)
+			}
+			m.SetMapIndex(reflect.ValueOf(k).Convert(v.Type().Key()), ev)
+		}
+		v.Set(m)
+		return nil
+	}
+	p := reflect.New(v.Type())
+	if err := json.Unmarshal(raw, p.Interface()); err != nil {
+		return err
+	}
+	v.Set(p.Elem())
+	return nil
+}
+
+// callRelease releases obj.
+func callRelease(obj traceHandle) error {
+	r, ok := obj.(interface{ Release() })
+	if !ok {
+		return fmt.Errorf("%T cannot be released", obj)
+	}
+	r.Release()
+	return nil
+}
+
+// releaseAll releases the objects the trace did not release.
+func (rp *traceReplayer) releaseAll() {
+	for id, obj := range rp.objects {
+		callRelease(obj) //nolint:errcheck // every traced type has Release
+		delete(rp.objects, id)
+	}
+}
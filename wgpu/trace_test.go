@@ -0,0 +1,160 @@
+package wgpu
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestTraceRecord(t *testing.T) {
+	var out bytes.Buffer
+	if err := StartTrace(&out); err != nil {
+		t.Fatal(err)
+	}
+	if err := StartTrace(&out); err == nil {
+		t.Error("second StartTrace succeeded")
+	}
+	buf := &Buffer{handle: 0x10}
+	untraced := &BindGroupLayout{handle: 0x30}
+	traceCall("Device.CreateBuffer", nil, buf, &BufferDescriptor{Label: "vb", Usage: gputypes.BufferUsageVertex, Size: 64})
+	traceCall("Device.CreateBindGroup", nil, &BindGroup{handle: 0x20}, &BindGroupDescriptor{
+		Layout:  untraced,
+		Entries: []BindGroupEntry{{Binding: 1, Buffer: buf, Size: 16}},
+	})
+	traceCall("Queue.WriteBuffer", nil, nil, buf, uint64(4), []byte{1, 2, 3})
+	traceRelease(0x10)
+	traceRelease(0x99) // not traced
+	if err := StopTrace(); err != nil {
+		t.Fatal(err)
+	}
+	traceCall("Device.CreateBuffer", nil, &Buffer{handle: 0x40}, &BufferDescriptor{}) // after StopTrace
+
+	want := []string{
+		`{"op":"Trace","args":[1]}`,
+		`{"op":"Device.CreateBuffer","id":1,"args":[{"Label":"vb","MappedAtCreation":false,"Size":64,"Usage":32}]}`,
+		`{"op":"Device.CreateBindGroup","id":2,"args":[{"Entries":[{"Binding":1,"Buffer":{"$ref":1},"Buffers":null,"Offset":0,"Sampler":null,"Samplers":null,"Size":16,"TextureView":null,"TextureViews":null}],"Label":"","Layout":{"$ref":0}}]}`,
+		`{"op":"Queue.WriteBuffer","args":[{"$ref":1},4,"AQID"]}`,
+		`{"op":"Release","recv":1}`,
+	}
+	got := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(got) != len(want) {
+		t.Fatalf("trace has %d lines, want %d:\n%s", len(got), len(want), out.String())
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d:\n got %s\nwant %s", i+1, got[i], want[i])
+		}
+	}
+}
+
+func TestTraceRejectsNativePointers(t *testing.T) {
+	var out bytes.Buffer
+	if err := StartTrace(&out); err != nil {
+		t.Fatal(err)
+	}
+	traceCall("Device.CreateShaderModule", nil, &ShaderModule{handle: 0x10}, &ShaderModuleDescriptor{NextInChain: 0x1234})
+	traceCall("Device.CreateBuffer", nil, &Buffer{handle: 0x20}, &BufferDescriptor{Size: 4})
+	err := StopTrace()
+	if err == nil || !strings.Contains(err.Error(), "NextInChain") {
+		t.Errorf("StopTrace = %v, want an error naming NextInChain", err)
+	}
+	if n := strings.Count(out.String(), "\n"); n != 1 {
+		t.Errorf("trace has %d lines after the rejected call, want only the header:\n%s", n, out.String())
+	}
+}
+
+func TestTraceBufferToTextureCopy(t *testing.T) {
+	var out bytes.Buffer
+	if err := StartTrace(&out); err != nil {
+		t.Fatal(err)
+	}
+	traceCall("Device.CreateBuffer", nil, &Buffer{handle: 0x10}, &BufferDescriptor{Size: 256})
+	traceCall("Device.CreateTexture", nil, &Texture{handle: 0x20}, &TextureDescriptor{})
+	traceCall("CommandEncoder.CopyBufferToTexture", nil, nil,
+		traceBufferCopy{Buffer: &Buffer{handle: 0x10}, Layout: TexelCopyBufferLayout{BytesPerRow: 256}},
+		ImageCopyTexture{Texture: &Texture{handle: 0x20}, MipLevel: 1},
+		&gputypes.Extent3D{Width: 4, Height: 4, DepthOrArrayLayers: 1})
+	if err := StopTrace(); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	var rec traceRecord
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &rec); err != nil || len(rec.Args) != 3 {
+		t.Fatalf("copy record %s: %v", lines[len(lines)-1], err)
+	}
+
+	buf, tex := &Buffer{handle: 0x30}, &Texture{handle: 0x40}
+	rp := &traceReplayer{objects: map[uint64]traceHandle{1: buf, 2: tex}}
+	var src traceBufferCopy
+	var dst ImageCopyTexture
+	if err := rp.decode(rec.Args[0], reflect.ValueOf(&src).Elem()); err != nil || src.Buffer != buf || src.Layout.BytesPerRow != 256 {
+		t.Errorf("source = %+v, %v", src, err)
+	}
+	if err := rp.decode(rec.Args[1], reflect.ValueOf(&dst).Elem()); err != nil || dst.Texture != tex || dst.MipLevel != 1 {
+		t.Errorf("destination = %+v, %v", dst, err)
+	}
+}
+
+func TestTraceDecode(t *testing.T) {
+	buf := &Buffer{handle: 0x10}
+	rp := &traceReplayer{objects: map[uint64]traceHandle{1: buf, 2: &Sampler{handle: 0x20}}}
+
+	var desc BindGroupDescriptor
+	raw := json.RawMessage(`{"Label":"g","Entries":[{"Binding":1,"Buffer":{"$ref":1},"Buffers":[{"$ref":1},null]}]}`)
+	if err := rp.decode(raw, reflect.ValueOf(&desc).Elem()); err != nil {
+		t.Fatal(err)
+	}
+	if desc.Label != "g" || len(desc.Entries) != 1 || desc.Entries[0].Buffer != buf {
+		t.Errorf("decoded %+v", desc)
+	}
+	if b := desc.Entries[0].Buffers; len(b) != 2 || b[0] != buf || b[1] != nil {
+		t.Errorf("Buffers = %v", b)
+	}
+
+	var consts map[string]float64
+	if err := rp.decode(json.RawMessage(`{"size":64}`), reflect.ValueOf(&consts).Elem()); err != nil || consts["size"] != 64 {
+		t.Errorf("map decode = %v, %v", consts, err)
+	}
+	var data []byte
+	if err := rp.decode(json.RawMessage(`"AQID"`), reflect.ValueOf(&data).Elem()); err != nil || !bytes.Equal(data, []byte{1, 2, 3}) {
+		t.Errorf("bytes decode = %v, %v", data, err)
+	}
+
+	for _, tt := range []struct {
+		raw  string
+		want string
+	}{
+		{`{"Layout":{"$ref":7}}`, "BindGroupLayout 7 was not created in the trace"},
+		{`{"Entries":[{"Buffer":{"$ref":2}}]}`, "object 2 is a *wgpu.Sampler, not a *wgpu.Buffer"},
+		{`{"Entries":"x"}`, "Entries"},
+	} {
+		var d BindGroupDescriptor
+		err := rp.decode(json.RawMessage(tt.raw), reflect.ValueOf(&d).Elem())
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("decode(%s) = %v, want error containing %q", tt.raw, err, tt.want)
+		}
+	}
+}
+
+func TestCheckTraceHeader(t *testing.T) {
+	for _, tt := range []struct {
+		line string
+		ok   bool
+	}{
+		{`{"op":"Trace","args":[1]}`, true},
+		{`{"op":"Trace","args":[2]}`, false},
+		{`{"op":"Device.CreateBuffer","id":1}`, false},
+	} {
+		var rec traceRecord
+		if err := json.Unmarshal([]byte(tt.line), &rec); err != nil {
+			t.Fatal(err)
+		}
+		if err := checkTraceHeader(&rec); (err == nil) != tt.ok {
+			t.Errorf("checkTraceHeader(%s) = %v", tt.line, err)
+		}
+	}
+}
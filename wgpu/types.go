@@ -1,6 +1,12 @@
 package wgpu
 
-import "unsafe"
+import (
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/gogpu/gputypes"
+)
 
 // ptrFromUintptr converts a uintptr to unsafe.Pointer without triggering go vet
 // "possible misuse of unsafe.Pointer" warnings. This is the standard idiom for
@@ -33,86 +39,219 @@ type Adapter struct {
 type Device struct {
 	handle uintptr
 	limits Limits // cached at request time, returned by Limits() without FFI call
+	// errors receives uncaptured errors; errorsID is its callback userdata.
+	errors   *deviceErrors
+	errorsID uintptr
+	watchdog atomic.Pointer[Watchdog]
 }
 
 // Queue is used to submit command buffers and write data to buffers/textures.
 // Obtained via [Device.Queue], release with [Queue.Release].
-type Queue struct{ handle uintptr }
+type Queue struct {
+	handle uintptr
+	device *Device // set by Device.Queue; reports submissions to a Watchdog
+}
 
 // Buffer represents a block of GPU-accessible memory.
 // Create with [Device.CreateBuffer], release with [Buffer.Release].
 type Buffer struct {
-	handle uintptr
-	device *Device // retained for Map/Poll; set by CreateBuffer
+	handle   uintptr
+	device   *Device // retained for Map/Poll; set by CreateBuffer
+	released releaseFlag
 }
 
 // Texture represents a GPU texture resource (1D, 2D, or 3D).
 // Create with [Device.CreateTexture], release with [Texture.Release].
-type Texture struct{ handle uintptr }
+type Texture struct {
+	handle uintptr
+	// device is the device that created the texture, used to capture
+	// validation errors of its views; nil for textures of a [Surface].
+	device *Device
+	// defaultView is created on first use by DefaultView and released with
+	// the texture; viewMu guards its lazy creation.
+	viewMu      sync.Mutex
+	defaultView *TextureView
+	// surface is set on surface textures; releasing one before it is
+	// presented discards acquisition surfaceFrame of the surface.
+	surface      *Surface
+	surfaceFrame uint64
+	released     releaseFlag
+}
 
 // TextureView is a view into a subset of a [Texture], used in bind groups and render passes.
 // Create with [Texture.CreateView], release with [TextureView.Release].
-type TextureView struct{ handle uintptr }
+type TextureView struct {
+	handle uintptr
+	// sampleCount is copied from the parent texture at creation so render
+	// passes can validate MSAA attachments without an FFI round trip.
+	// Zero means unknown.
+	sampleCount uint32
+	// format and the size of the base mip level are recorded for the same
+	// reason and used by [Blit]. Zero means unknown.
+	format        gputypes.TextureFormat
+	width, height uint32
+	// texture is the texture the view was created from, whose release also
+	// invalidates the view; nil when unknown.
+	texture  *Texture
+	released releaseFlag
+}
 
 // Sampler defines how a shader samples a [Texture].
 // Create with [Device.CreateSampler], release with [Sampler.Release].
-type Sampler struct{ handle uintptr }
+type Sampler struct {
+	handle   uintptr
+	released releaseFlag
+}
 
 // ShaderModule holds compiled shader code (WGSL or SPIR-V).
 // Create with [Device.CreateShaderModuleWGSL], release with [ShaderModule.Release].
-type ShaderModule struct{ handle uintptr }
+type ShaderModule struct {
+	handle uintptr
+	device *Device // polled while waiting for compilation info
+	source string  // WGSL source, for reflection and pipeline error excerpts
+}
 
 // BindGroupLayout defines the layout of resource bindings for a shader stage.
 // Create with [Device.CreateBindGroupLayout], release with [BindGroupLayout.Release].
-type BindGroupLayout struct{ handle uintptr }
+type BindGroupLayout struct {
+	handle uintptr
+	label  string
+	// arrayCounts maps the binding numbers of binding arrays to their Count
+	// so [Device.CreateBindGroup] can check array entries. Nil for layouts
+	// obtained from a pipeline.
+	arrayCounts map[uint32]uint32
+	// entries is a copy of the descriptor entries, used to check pipelines
+	// against their layout. Nil for layouts obtained from a pipeline.
+	entries []BindGroupLayoutEntry
+	// pipelineOwned is set for layouts returned by GetBindGroupLayout, which
+	// are released together with their pipeline.
+	pipelineOwned bool
+	released      releaseFlag
+}
 
 // BindGroup binds actual GPU resources (buffers, textures, samplers) to shader slots.
 // Create with [Device.CreateBindGroup], release with [BindGroup.Release].
-type BindGroup struct{ handle uintptr }
+type BindGroup struct {
+	handle uintptr
+	label  string
+	// dynamic lists the dynamic buffer bindings in binding order;
+	// dynamicKnown is false when the layout's entries are unknown.
+	dynamic      []dynamicBinding
+	dynamicKnown bool
+	released     releaseFlag
+}
 
 // PipelineLayout defines the bind group layouts used by a pipeline.
 // Create with [Device.CreatePipelineLayout], release with [PipelineLayout.Release].
-type PipelineLayout struct{ handle uintptr }
+type PipelineLayout struct {
+	handle uintptr
+	// groups holds the bind group layouts the layout was created from.
+	groups []*BindGroupLayout
+	// immediateSize is PipelineLayoutDescriptor.ImmediateSize.
+	immediateSize uint32
+}
 
 // RenderPipeline is a compiled render pipeline configuration (shaders, vertex layout, blend state).
 // Create with [Device.CreateRenderPipeline], release with [RenderPipeline.Release].
-type RenderPipeline struct{ handle uintptr }
+type RenderPipeline struct {
+	handle           uintptr
+	bindGroupLayouts *bindGroupLayoutCache
+}
 
 // ComputePipeline is a compiled compute pipeline configuration.
 // Create with [Device.CreateComputePipeline], release with [ComputePipeline.Release].
-type ComputePipeline struct{ handle uintptr }
+type ComputePipeline struct {
+	handle           uintptr
+	bindGroupLayouts *bindGroupLayoutCache
+}
 
 // CommandEncoder records GPU commands into a [CommandBuffer].
 // Create with [Device.CreateCommandEncoder], finalize with [CommandEncoder.Finish].
-type CommandEncoder struct{ handle uintptr }
+type CommandEncoder struct {
+	handle uintptr
+	device *Device // retained for Blit; set by CreateCommandEncoder
+	// err is the first error recorded by a pass on the Go side, returned
+	// by Finish.
+	err error
+	// openPasses counts passes begun and not yet ended; Finish fails
+	// while it is non-zero.
+	openPasses int
+	finished   bool
+	// pins keeps pass descriptor memory alive until Finish or Release,
+	// along with the pooled native descriptors of its passes.
+	pins             pinSet
+	renderScratches  []*renderPassScratch
+	computeScratches []*computePassScratch
+}
 
 // CommandBuffer holds encoded GPU commands ready for submission via [Queue.Submit].
 // Obtained from [CommandEncoder.Finish], release with [CommandBuffer.Release].
-type CommandBuffer struct{ handle uintptr }
+type CommandBuffer struct {
+	handle    uintptr
+	submitted bool   // set by Queue.Submit; a command buffer runs at most once
+	label     string // for Watchdog reports
+}
 
 // RenderPassEncoder records draw commands within a render pass.
 // Begin with [CommandEncoder.BeginRenderPass], end with [RenderPassEncoder.End].
-type RenderPassEncoder struct{ handle uintptr }
+type RenderPassEncoder struct {
+	handle  uintptr
+	encoder *CommandEncoder // receives errors recorded by the pass
+	layout  attachmentLayout
+	ended   bool
+}
 
 // ComputePassEncoder records dispatch commands within a compute pass.
 // Begin with [CommandEncoder.BeginComputePass], end with [ComputePassEncoder.End].
-type ComputePassEncoder struct{ handle uintptr }
+type ComputePassEncoder struct {
+	handle  uintptr
+	encoder *CommandEncoder // receives errors recorded by the pass
+	ended   bool
+}
 
 // Surface represents a platform window surface for presenting rendered frames.
 // Create with platform-specific CreateSurface, release with [Surface.Release].
-type Surface struct{ handle uintptr }
+type Surface struct {
+	handle uintptr
+
+	// mu guards the capabilities, configuration and acquisition state, so
+	// surfaces of several windows can be driven from their own goroutines.
+	mu       sync.Mutex
+	caps     *SurfaceCapabilities  // last GetCapabilities result, checked by Configure
+	config   *SurfaceConfiguration // last successful Configure; nil when unconfigured
+	device   *Device
+	acquired bool   // a texture was acquired and not yet presented or released
+	frame    uint64 // counts acquisitions, to match textures to them
+	// suboptimalLogged is set once a suboptimal acquisition has been logged
+	// for the current configuration.
+	suboptimalLogged bool
+}
 
 // QuerySet holds a set of GPU queries (occlusion or timestamp).
 // Create with [Device.CreateQuerySet], release with [QuerySet.Release].
-type QuerySet struct{ handle uintptr }
+type QuerySet struct {
+	handle uintptr
+	typ    QueryType
+	count  uint32
+	// statistics are the counters of a pipeline statistics query set, in
+	// the order their values are resolved.
+	statistics []PipelineStatisticName
+}
 
 // RenderBundle is a pre-recorded set of render commands for efficient replay.
 // Obtained from [RenderBundleEncoder.Finish], release with [RenderBundle.Release].
-type RenderBundle struct{ handle uintptr }
+type RenderBundle struct {
+	handle uintptr
+	layout attachmentLayout // checked by RenderPassEncoder.ExecuteBundles
+}
 
 // RenderBundleEncoder records render commands into a [RenderBundle].
 // Create with [Device.CreateRenderBundleEncoder], finalize with [RenderBundleEncoder.Finish].
-type RenderBundleEncoder struct{ handle uintptr }
+type RenderBundleEncoder struct {
+	handle uintptr
+	err    error // first error recorded on the Go side, see Err
+	layout attachmentLayout
+}
 
 // DrawIndirectArgs contains arguments for indirect (GPU-driven) draw calls.
 // This struct must be written to a Buffer for use with DrawIndirect.
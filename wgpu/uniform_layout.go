@@ -0,0 +1,152 @@
+package wgpu
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gogpu/gputypes"
+)
+
+// UniformEntry returns a layout entry for a uniform buffer holding a T, with
+// MinBindingSize set to the size of T in WGSL's uniform address space, so
+// bind groups binding a smaller buffer range fail when they are created.
+//
+// T must already be laid out as WGSL expects: see [UniformSize] for the
+// mapping of Go types. UniformEntry panics if it is not, since that is a
+// mistake in the program rather than a runtime condition.
+func UniformEntry[T any](binding uint32, visibility gputypes.ShaderStage) BindGroupLayoutEntry {
+	size, err := UniformSize[T]()
+	if err != nil {
+		panic(err)
+	}
+	return BindGroupLayoutEntry{
+		Binding:    binding,
+		Visibility: visibility,
+		Buffer: &BufferBindingLayout{
+			Type:           gputypes.BufferBindingTypeUniform,
+			MinBindingSize: size,
+		},
+	}
+}
+
+// UniformSize returns the size of T in WGSL's uniform address space, or an
+// error if T's Go memory layout differs from the WGSL layout so writing a T
+// to a buffer would not produce what the shader reads.
+//
+// float32, int32 and uint32 map to f32, i32 and u32; arrays of 2 to 4
+// scalars, [Vec3] and [Vec4] to vectors; arrays of such vectors to matrices
+// (matCxR for [C][R]float32) and [Mat4] to mat4x4f; other arrays to WGSL
+// arrays, whose element stride must be a multiple of 16 in uniform buffers;
+// and structs to structs. Struct fields named _ are Go padding and are
+// skipped.
+func UniformSize[T any]() (uint64, error) {
+	t := reflect.TypeFor[T]()
+	_, size, err := uniformLayout(t)
+	if err != nil {
+		return 0, fmt.Errorf("wgpu: uniform type %v: %w", t, err)
+	}
+	return size, nil
+}
+
+var (
+	vec3Type = reflect.TypeFor[Vec3]()
+	vec4Type = reflect.TypeFor[Vec4]()
+	mat4Type = reflect.TypeFor[Mat4]()
+)
+
+// uniformLayout returns the WGSL alignment and size of t in the uniform
+// address space, checking that t's Go layout matches.
+func uniformLayout(t reflect.Type) (align, size uint64, err error) {
+	switch t {
+	case vec3Type:
+		return 16, 12, nil
+	case vec4Type:
+		return 16, 16, nil
+	case mat4Type:
+		return 16, 64, nil
+	}
+	switch t.Kind() {
+	case reflect.Float32, reflect.Int32, reflect.Uint32:
+		return 4, 4, nil
+	case reflect.Array:
+		return uniformArrayLayout(t)
+	case reflect.Struct:
+		return uniformStructLayout(t)
+	}
+	return 0, 0, fmt.Errorf("%v has no WGSL equivalent", t)
+}
+
+// isScalar reports whether t maps to a 4-byte WGSL scalar.
+func isScalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Float32, reflect.Int32, reflect.Uint32:
+		return true
+	}
+	return false
+}
+
+// isVector reports whether t maps to a WGSL vector.
+func isVector(t reflect.Type) bool {
+	return t == vec3Type || t == vec4Type ||
+		t.Kind() == reflect.Array && t.Len() >= 2 && t.Len() <= 4 && isScalar(t.Elem())
+}
+
+func uniformArrayLayout(t reflect.Type) (align, size uint64, err error) {
+	n, elem := uint64(t.Len()), t.Elem()
+	if isVector(t) {
+		return vecAlign(n) * 4, n * 4, nil
+	}
+	ea, es, err := uniformLayout(elem)
+	if err != nil {
+		return 0, 0, err
+	}
+	stride := roundUp(ea, es)
+	if isVector(elem) && n >= 2 && n <= 4 {
+		// matCxR: columns are padded vectors.
+		if uint64(elem.Size()) != stride {
+			return 0, 0, fmt.Errorf("matrix columns of %v are %d bytes apart in WGSL but %d in Go; use %d-component columns",
+				t, stride, elem.Size(), stride/4)
+		}
+		return ea, stride * n, nil
+	}
+	if stride%16 != 0 {
+		return 0, 0, fmt.Errorf("elements of %v are %d bytes apart, but uniform buffer arrays need a stride that is a multiple of 16", t, stride)
+	}
+	if uint64(elem.Size()) != stride {
+		return 0, 0, fmt.Errorf("elements of %v are %d bytes apart in WGSL but %d in Go", t, stride, elem.Size())
+	}
+	return roundUp(16, ea), stride * n, nil
+}
+
+func uniformStructLayout(t reflect.Type) (align, size uint64, err error) {
+	var offset uint64
+	align = 1
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Name == "_" {
+			continue
+		}
+		fa, fs, err := uniformLayout(f.Type)
+		if err != nil {
+			return 0, 0, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		if f.Type.Kind() == reflect.Struct && !isVector(f.Type) {
+			// Structs nested in uniform buffers start on 16-byte boundaries.
+			fa = roundUp(16, fa)
+		}
+		offset = roundUp(fa, offset)
+		if uint64(f.Offset) != offset {
+			return 0, 0, fmt.Errorf("field %s is at offset %d in Go but %d in WGSL; add padding before it", f.Name, f.Offset, offset)
+		}
+		offset += fs
+		align = max(align, fa)
+	}
+	size = roundUp(align, offset)
+	if goSize := uint64(t.Size()); goSize < size {
+		return 0, 0, fmt.Errorf("%v is %d bytes in Go but %d in WGSL; add %d bytes of trailing padding",
+			t, goSize, size, size-goSize)
+	} else if goSize > size {
+		return 0, 0, fmt.Errorf("%v is %d bytes in Go but only %d in WGSL", t, goSize, size)
+	}
+	return align, size, nil
+}
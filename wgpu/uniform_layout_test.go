@@ -0,0 +1,105 @@
+package wgpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+type cameraUniforms struct {
+	ViewProj Mat4
+	Eye      Vec3
+	Time     float32
+	Viewport [2]float32
+	_        [2]float32
+}
+
+type lightUniforms struct {
+	Color     [3]float32
+	Intensity float32
+	Lights    [4]Vec4
+	Normal    [3][4]float32 // mat3x3f
+}
+
+type misalignedUniforms struct {
+	Scale float32
+	Eye   Vec3 // WGSL puts vec3f at offset 16
+}
+
+type shortUniforms struct {
+	Eye Vec3 // WGSL rounds the struct up to 16 bytes
+}
+
+func TestUniformSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    func() (uint64, error)
+		want    uint64
+		wantErr bool
+	}{
+		{"scalar", UniformSize[float32], 4, false},
+		{"vec4", UniformSize[[4]float32], 16, false},
+		{"mat4", UniformSize[Mat4], 64, false},
+		{"camera", UniformSize[cameraUniforms], 96, false},
+		{"light", UniformSize[lightUniforms], 128, false},
+		{"misaligned field", UniformSize[misalignedUniforms], 0, true},
+		{"missing trailing padding", UniformSize[shortUniforms], 0, true},
+		{"tightly packed array", UniformSize[[8]float32], 0, true},
+		{"unpadded mat3", UniformSize[[3][3]float32], 0, true},
+		{"float64", UniformSize[float64], 0, true},
+		{"bool", UniformSize[struct{ On bool }], 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.size()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("size = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUniformEntry(t *testing.T) {
+	e := UniformEntry[cameraUniforms](0, gputypes.ShaderStageVertex)
+	if e.Buffer == nil || e.Buffer.Type != gputypes.BufferBindingTypeUniform || e.Buffer.MinBindingSize != 96 {
+		t.Errorf("entry = %+v", e.Buffer)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("UniformEntry should panic for a type that does not match WGSL")
+		}
+	}()
+	UniformEntry[misalignedUniforms](0, gputypes.ShaderStageVertex)
+}
+
+func TestCheckBufferBindingSizes(t *testing.T) {
+	layout := []BindGroupLayoutEntry{UniformEntry[cameraUniforms](0, gputypes.ShaderStageVertex)}
+	buf := &Buffer{}
+	sizeOf := func(size uint64) func(*Buffer) uint64 {
+		return func(*Buffer) uint64 { return size }
+	}
+	tests := []struct {
+		name    string
+		entry   BindGroupEntry
+		size    uint64
+		wantErr bool
+	}{
+		{"explicit size", BindGroupEntry{Binding: 0, Buffer: buf, Size: 96}, 0, false},
+		{"explicit size too small", BindGroupEntry{Binding: 0, Buffer: buf, Size: 64}, 0, true},
+		{"whole buffer", BindGroupEntry{Binding: 0, Buffer: buf}, 256, false},
+		{"rest of buffer too small", BindGroupEntry{Binding: 0, Buffer: buf, Offset: 192}, 256, true},
+		{"unknown buffer size", BindGroupEntry{Binding: 0, Buffer: buf}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkBufferBindingSizes(layout, []BindGroupEntry{tt.entry}, sizeOf(tt.size))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package video
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/go-webgpu/webgpu/wgpu"
+	"github.com/gogpu/gputypes"
+)
+
+// ColorSpace selects the YUV to RGB matrix and the value range of a frame.
+type ColorSpace uint8
+
+const (
+	// BT601Limited is SD video: BT.601 matrix, Y in [16, 235].
+	BT601Limited ColorSpace = iota
+	// BT601Full is BT.601 with full-range [0, 255] samples (JPEG/JFIF).
+	BT601Full
+	// BT709Limited is HD video: BT.709 matrix, Y in [16, 235].
+	BT709Limited
+	// BT709Full is BT.709 with full-range samples.
+	BT709Full
+)
+
+// convertShader renders a fullscreen triangle that converts the plane
+// textures to RGB. NV12 binds its UV plane to both chroma slots.
+const convertShader = `
+struct Params {
+    matrix: mat3x3<f32>,
+    offset: vec4<f32>,
+}
+
+@group(0) @binding(0) var y_plane: texture_2d<f32>;
+@group(0) @binding(1) var u_plane: texture_2d<f32>;
+@group(0) @binding(2) var v_plane: texture_2d<f32>;
+@group(0) @binding(3) var plane_sampler: sampler;
+@group(0) @binding(4) var<uniform> params: Params;
+
+struct VertexOutput {
+    @builtin(position) position: vec4<f32>,
+    @location(0) uv: vec2<f32>,
+}
+
+@vertex
+fn vs_main(@builtin(vertex_index) index: u32) -> VertexOutput {
+    let xy = vec2<f32>(f32((index << 1u) & 2u), f32(index & 2u));
+    var out: VertexOutput;
+    out.position = vec4<f32>(xy * 2.0 - 1.0, 0.0, 1.0);
+    out.uv = vec2<f32>(xy.x, 1.0 - xy.y);
+    return out;
+}
+
+fn to_rgb(yuv: vec3<f32>) -> vec4<f32> {
+    let rgb = params.matrix * (yuv - params.offset.xyz);
+    return vec4<f32>(clamp(rgb, vec3<f32>(0.0), vec3<f32>(1.0)), 1.0);
+}
+
+@fragment
+fn fs_nv12(in: VertexOutput) -> @location(0) vec4<f32> {
+    let y = textureSample(y_plane, plane_sampler, in.uv).r;
+    let uv = textureSample(u_plane, plane_sampler, in.uv).rg;
+    return to_rgb(vec3<f32>(y, uv));
+}
+
+@fragment
+fn fs_i420(in: VertexOutput) -> @location(0) vec4<f32> {
+    let y = textureSample(y_plane, plane_sampler, in.uv).r;
+    let u = textureSample(u_plane, plane_sampler, in.uv).r;
+    let v = textureSample(v_plane, plane_sampler, in.uv).r;
+    return to_rgb(vec3<f32>(y, u, v));
+}
+`
+
+// paramsSize is the size of Params in convertShader: a mat3x3 (three
+// 16-byte columns) followed by a vec4.
+const paramsSize = 64
+
+// Converter renders plane [Textures] into an RGB render target.
+type Converter struct {
+	format  PixelFormat
+	device  *wgpu.Device
+	layout  *wgpu.BindGroupLayout
+	sampler *wgpu.Sampler
+	params  *wgpu.Buffer
+
+	pipeline *wgpu.RenderPipeline
+}
+
+// NewConverter creates a converter from frames in format and color space cs
+// to render targets in dstFormat.
+//
+// The output holds the gamma-encoded RGB values of the video, so dstFormat
+// should be a non-sRGB format such as RGBA8Unorm or BGRA8Unorm; an sRGB
+// target would encode them a second time.
+func NewConverter(device *wgpu.Device, format PixelFormat, dstFormat gputypes.TextureFormat, cs ColorSpace) (*Converter, error) {
+	if format.planeCount() == 0 {
+		return nil, fmt.Errorf("video: unknown pixel format %s", format)
+	}
+	matrix, offset, err := yuvToRGB(cs)
+	if err != nil {
+		return nil, err
+	}
+	c := &Converter{format: format, device: device}
+	if err := c.init(matrix, offset, dstFormat); err != nil {
+		c.Release()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Converter) init(matrix [3][3]float32, offset [3]float32, dstFormat gputypes.TextureFormat) error {
+	planeEntry := func(binding uint32) wgpu.BindGroupLayoutEntry {
+		return wgpu.BindGroupLayoutEntry{
+			Binding:    binding,
+			Visibility: gputypes.ShaderStageFragment,
+			Texture: &wgpu.TextureBindingLayout{
+				SampleType:    gputypes.TextureSampleTypeFloat,
+				ViewDimension: gputypes.TextureViewDimension2D,
+			},
+		}
+	}
+	var err error
+	c.layout, err = c.device.CreateBindGroupLayoutSimple([]wgpu.BindGroupLayoutEntry{
+		planeEntry(0),
+		planeEntry(1),
+		planeEntry(2),
+		{
+			Binding:    3,
+			Visibility: gputypes.ShaderStageFragment,
+			Sampler:    &wgpu.SamplerBindingLayout{Type: gputypes.SamplerBindingTypeFiltering},
+		},
+		{
+			Binding:    4,
+			Visibility: gputypes.ShaderStageFragment,
+			Buffer: &wgpu.BufferBindingLayout{
+				Type:           gputypes.BufferBindingTypeUniform,
+				MinBindingSize: paramsSize,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if c.sampler, err = c.device.CreateLinearSampler(); err != nil {
+		return err
+	}
+
+	c.params, err = c.device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "video convert params",
+		Usage: gputypes.BufferUsageUniform | gputypes.BufferUsageCopyDst,
+		Size:  paramsSize,
+	})
+	if err != nil {
+		return err
+	}
+	queue := c.device.Queue()
+	if queue == nil {
+		return errors.New("video: device queue unavailable")
+	}
+	defer queue.Release()
+	if err := queue.WriteBuffer(c.params, 0, encodeParams(matrix, offset)); err != nil {
+		return err
+	}
+
+	shader, err := c.device.CreateShaderModuleFromDesc(&wgpu.ShaderDescriptor{
+		Label: "video convert",
+		WGSL:  convertShader,
+	})
+	if err != nil {
+		return err
+	}
+	defer shader.Release()
+	layout, err := c.device.CreatePipelineLayoutSimple([]*wgpu.BindGroupLayout{c.layout})
+	if err != nil {
+		return err
+	}
+	defer layout.Release()
+	entryPoint := "fs_nv12"
+	if c.format == I420 {
+		entryPoint = "fs_i420"
+	}
+	c.pipeline, err = c.device.CreateRenderPipelineSimple(layout, shader, "vs_main", shader, entryPoint, dstFormat)
+	return err
+}
+
+// Convert records a render pass that draws planes into dst, covering the
+// whole view.
+func (c *Converter) Convert(encoder *wgpu.CommandEncoder, planes *Textures, dst *wgpu.TextureView) error {
+	if planes == nil || planes.Format != c.format {
+		return fmt.Errorf("video: converter expects %s planes", c.format)
+	}
+	views := make([]*wgpu.TextureView, 0, 3)
+	for _, tex := range []*wgpu.Texture{planes.Y, planes.U, planes.V} {
+		if tex == nil {
+			continue
+		}
+		view, err := tex.DefaultView()
+		if err != nil {
+			return err
+		}
+		views = append(views, view)
+	}
+	if len(views) == 2 {
+		views = append(views, views[1]) // NV12: UV plane in both chroma slots
+	}
+
+	group, err := c.device.CreateBindGroupSimple(c.layout, []wgpu.BindGroupEntry{
+		wgpu.TextureBindingEntry(0, views[0]),
+		wgpu.TextureBindingEntry(1, views[1]),
+		wgpu.TextureBindingEntry(2, views[2]),
+		wgpu.SamplerBindingEntry(3, c.sampler),
+		wgpu.BufferBindingEntry(4, c.params, 0, paramsSize),
+	})
+	if err != nil {
+		return err
+	}
+	defer group.Release()
+
+	pass, err := encoder.BeginRenderPass(&wgpu.RenderPassDescriptor{
+		Label: "video convert",
+		ColorAttachments: []wgpu.RenderPassColorAttachment{{
+			View:    dst,
+			LoadOp:  gputypes.LoadOpClear,
+			StoreOp: gputypes.StoreOpStore,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	defer pass.Release()
+	pass.SetPipeline(c.pipeline)
+	pass.SetBindGroup(0, group, nil)
+	pass.Draw(3, 1, 0, 0)
+	pass.End()
+	return nil
+}
+
+// Release releases the GPU objects owned by the converter.
+func (c *Converter) Release() {
+	if c.pipeline != nil {
+		c.pipeline.Release()
+		c.pipeline = nil
+	}
+	if c.params != nil {
+		c.params.Release()
+		c.params = nil
+	}
+	if c.sampler != nil {
+		c.sampler.Release()
+		c.sampler = nil
+	}
+	if c.layout != nil {
+		c.layout.Release()
+		c.layout = nil
+	}
+}
+
+// yuvToRGB returns the matrix and offset mapping normalized YUV samples to
+// RGB as rgb = matrix * (yuv - offset). The matrix is indexed [row][col].
+func yuvToRGB(cs ColorSpace) (matrix [3][3]float32, offset [3]float32, err error) {
+	var kr, kb float64
+	switch cs {
+	case BT601Limited, BT601Full:
+		kr, kb = 0.299, 0.114
+	case BT709Limited, BT709Full:
+		kr, kb = 0.2126, 0.0722
+	default:
+		return matrix, offset, fmt.Errorf("video: unknown color space %d", cs)
+	}
+	kg := 1 - kr - kb
+
+	lumaScale, chromaScale := 1.0, 1.0
+	offset = [3]float32{0, 128.0 / 255, 128.0 / 255}
+	if cs == BT601Limited || cs == BT709Limited {
+		lumaScale, chromaScale = 255.0/219, 255.0/224
+		offset[0] = 16.0 / 255
+	}
+
+	// Columns: Y, U (Cb), V (Cr).
+	rv := 2 * (1 - kr)
+	bu := 2 * (1 - kb)
+	gu := -bu * kb / kg
+	gv := -rv * kr / kg
+	matrix = [3][3]float32{
+		{float32(lumaScale), 0, float32(rv * chromaScale)},
+		{float32(lumaScale), float32(gu * chromaScale), float32(gv * chromaScale)},
+		{float32(lumaScale), float32(bu * chromaScale), 0},
+	}
+	return matrix, offset, nil
+}
+
+// encodeParams lays out Params for the uniform buffer: the matrix as three
+// column vectors padded to 16 bytes, then the offset.
+func encodeParams(matrix [3][3]float32, offset [3]float32) []byte {
+	out := make([]byte, paramsSize)
+	put := func(i int, v float32) { binary.LittleEndian.PutUint32(out[4*i:], math.Float32bits(v)) }
+	for col := 0; col < 3; col++ {
+		for row := 0; row < 3; row++ {
+			put(col*4+row, matrix[row][col])
+		}
+	}
+	for i, v := range offset {
+		put(12+i, v)
+	}
+	return out
+}
@@ -0,0 +1,199 @@
+// Package video uploads planar YUV video frames (NV12 and I420) into
+// textures and converts them to RGB on the GPU.
+//
+// A [Textures] set holds one texture per plane: an R8Unorm luma plane plus
+// either an RG8Unorm interleaved chroma plane (NV12) or two R8Unorm chroma
+// planes (I420), each at half resolution. Shaders that understand YUV can
+// sample the planes directly; everything else can render them into an RGBA
+// target with a [Converter]:
+//
+//	planes, _ := video.NewTextures(device, video.NV12, 1920, 1080)
+//	conv, _ := video.NewConverter(device, video.NV12, gputypes.TextureFormatRGBA8Unorm, video.BT709Limited)
+//
+//	// per frame
+//	planes.Upload(queue, frame)
+//	conv.Convert(encoder, planes, rgbaView)
+package video
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-webgpu/webgpu/wgpu"
+	"github.com/gogpu/gputypes"
+)
+
+// PixelFormat is a planar YUV 4:2:0 layout.
+type PixelFormat uint8
+
+const (
+	// NV12 has a full-resolution Y plane followed by a half-resolution plane
+	// of interleaved U and V samples.
+	NV12 PixelFormat = iota + 1
+	// I420 has a full-resolution Y plane followed by separate
+	// half-resolution U and V planes.
+	I420
+)
+
+// String returns the conventional name of the layout.
+func (f PixelFormat) String() string {
+	switch f {
+	case NV12:
+		return "NV12"
+	case I420:
+		return "I420"
+	}
+	return fmt.Sprintf("PixelFormat(%d)", uint8(f))
+}
+
+// planeCount returns the number of planes of the layout, or 0 if unknown.
+func (f PixelFormat) planeCount() int {
+	switch f {
+	case NV12:
+		return 2
+	case I420:
+		return 3
+	}
+	return 0
+}
+
+// Frame is one planar video frame in CPU memory.
+type Frame struct {
+	Format        PixelFormat
+	Width, Height uint32
+	// Planes holds the Y, U and V planes (Y and UV for NV12).
+	Planes [3][]byte
+	// Strides holds the row pitch of each plane in bytes; 0 means rows are
+	// tightly packed.
+	Strides [3]int
+}
+
+// Textures holds the GPU planes of a frame.
+type Textures struct {
+	Format        PixelFormat
+	Width, Height uint32
+
+	// Y is the luma plane (R8Unorm).
+	Y *wgpu.Texture
+	// U is the chroma plane: interleaved UV (RG8Unorm) for NV12, U only
+	// (R8Unorm) for I420.
+	U *wgpu.Texture
+	// V is the V plane for I420 and nil for NV12.
+	V *wgpu.Texture
+}
+
+// chromaSize returns the size of the chroma planes of a width x height frame.
+func chromaSize(width, height uint32) (uint32, uint32) {
+	return (width + 1) / 2, (height + 1) / 2
+}
+
+// NewTextures creates the plane textures for width x height frames.
+func NewTextures(device *wgpu.Device, format PixelFormat, width, height uint32) (*Textures, error) {
+	if format.planeCount() == 0 {
+		return nil, fmt.Errorf("video: unknown pixel format %s", format)
+	}
+	if width == 0 || height == 0 {
+		return nil, errors.New("video: frame size must be non-zero")
+	}
+	t := &Textures{Format: format, Width: width, Height: height}
+	cw, ch := chromaSize(width, height)
+	var err error
+	if t.Y, err = createPlane(device, "video Y", width, height, gputypes.TextureFormatR8Unorm); err != nil {
+		return nil, err
+	}
+	chroma := gputypes.TextureFormatRG8Unorm
+	if format == I420 {
+		chroma = gputypes.TextureFormatR8Unorm
+	}
+	if t.U, err = createPlane(device, "video U", cw, ch, chroma); err != nil {
+		t.Release()
+		return nil, err
+	}
+	if format == I420 {
+		if t.V, err = createPlane(device, "video V", cw, ch, gputypes.TextureFormatR8Unorm); err != nil {
+			t.Release()
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+func createPlane(device *wgpu.Device, label string, width, height uint32, format gputypes.TextureFormat) (*wgpu.Texture, error) {
+	return device.CreateTexture(&wgpu.TextureDescriptor{
+		Label:         label,
+		Usage:         gputypes.TextureUsageTextureBinding | gputypes.TextureUsageCopyDst,
+		Dimension:     gputypes.TextureDimension2D,
+		Size:          gputypes.Extent3D{Width: width, Height: height, DepthOrArrayLayers: 1},
+		Format:        format,
+		MipLevelCount: 1,
+		SampleCount:   1,
+	})
+}
+
+// Upload writes frame into the plane textures. The frame must have the same
+// format and size as t.
+func (t *Textures) Upload(queue *wgpu.Queue, frame *Frame) error {
+	if frame == nil {
+		return errors.New("video: frame is nil")
+	}
+	if frame.Format != t.Format || frame.Width != t.Width || frame.Height != t.Height {
+		return fmt.Errorf("video: frame is %s %dx%d, textures are %s %dx%d",
+			frame.Format, frame.Width, frame.Height, t.Format, t.Width, t.Height)
+	}
+	cw, ch := chromaSize(t.Width, t.Height)
+	planes := []struct {
+		tex           *wgpu.Texture
+		rowBytes      int
+		width, height uint32
+	}{
+		{t.Y, int(t.Width), t.Width, t.Height},
+		{t.U, int(cw), cw, ch},
+		{t.V, int(cw), cw, ch},
+	}
+	if t.Format == NV12 {
+		planes[1].rowBytes = int(cw) * 2
+	}
+	for i := 0; i < t.Format.planeCount(); i++ {
+		p := planes[i]
+		data, err := packPlane(frame.Planes[i], frame.Strides[i], p.rowBytes, int(p.height))
+		if err != nil {
+			return fmt.Errorf("video: plane %d: %w", i, err)
+		}
+		if err := queue.WriteTextureData(p.tex, 0, gputypes.Origin3D{}, data, p.width, p.height); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// packPlane returns rows tightly packed rows of rowBytes each from a plane
+// with the given stride (0 meaning tightly packed).
+func packPlane(src []byte, stride, rowBytes, rows int) ([]byte, error) {
+	if stride == 0 {
+		stride = rowBytes
+	}
+	if stride < rowBytes {
+		return nil, fmt.Errorf("stride %d is smaller than the row size %d", stride, rowBytes)
+	}
+	if need := stride*(rows-1) + rowBytes; len(src) < need {
+		return nil, fmt.Errorf("got %d bytes, need %d", len(src), need)
+	}
+	if stride == rowBytes {
+		return src[:rowBytes*rows], nil
+	}
+	dst := make([]byte, rowBytes*rows)
+	for y := 0; y < rows; y++ {
+		copy(dst[y*rowBytes:(y+1)*rowBytes], src[y*stride:])
+	}
+	return dst, nil
+}
+
+// Release releases the plane textures.
+func (t *Textures) Release() {
+	for _, tex := range []**wgpu.Texture{&t.Y, &t.U, &t.V} {
+		if *tex != nil {
+			(*tex).Release()
+			*tex = nil
+		}
+	}
+}
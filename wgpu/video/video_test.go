@@ -0,0 +1,98 @@
+package video
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestPackPlane(t *testing.T) {
+	src := []byte{1, 2, 0, 0, 3, 4, 0, 0, 5, 6}
+	got, err := packPlane(src, 4, 2, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(got, []byte{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("got %v", got)
+	}
+	tight := []byte{1, 2, 3, 4}
+	if got, _ := packPlane(tight, 0, 2, 2); &got[0] != &tight[0] {
+		t.Error("tightly packed plane was copied")
+	}
+	if _, err := packPlane(src, 1, 2, 3); err == nil {
+		t.Error("expected error for stride smaller than row")
+	}
+	if _, err := packPlane(src[:8], 4, 2, 3); err == nil {
+		t.Error("expected error for short plane")
+	}
+}
+
+func TestChromaSize(t *testing.T) {
+	if w, h := chromaSize(1920, 1080); w != 960 || h != 540 {
+		t.Errorf("1920x1080 -> %dx%d", w, h)
+	}
+	if w, h := chromaSize(7, 5); w != 4 || h != 3 {
+		t.Errorf("7x5 -> %dx%d", w, h)
+	}
+}
+
+// apply mirrors to_rgb in convertShader.
+func apply(m [3][3]float32, off [3]float32, y, u, v float32) [3]float32 {
+	in := [3]float32{y - off[0], u - off[1], v - off[2]}
+	var out [3]float32
+	for r := 0; r < 3; r++ {
+		out[r] = m[r][0]*in[0] + m[r][1]*in[1] + m[r][2]*in[2]
+	}
+	return out
+}
+
+func near(got [3]float32, want [3]float32) bool {
+	for i := range got {
+		if math.Abs(float64(got[i]-want[i])) > 0.01 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestYUVToRGB(t *testing.T) {
+	tests := []struct {
+		name    string
+		cs      ColorSpace
+		y, u, v float32
+		want    [3]float32
+	}{
+		{"limited white", BT709Limited, 235, 128, 128, [3]float32{1, 1, 1}},
+		{"limited black", BT601Limited, 16, 128, 128, [3]float32{0, 0, 0}},
+		{"full gray", BT601Full, 128, 128, 128, [3]float32{128.0 / 255, 128.0 / 255, 128.0 / 255}},
+		{"BT.601 full red", BT601Full, 76, 85, 255, [3]float32{1, 0, 0}},
+		{"BT.709 limited blue", BT709Limited, 32, 240, 118, [3]float32{0, 0, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, off, err := yuvToRGB(tt.cs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := apply(m, off, tt.y/255, tt.u/255, tt.v/255)
+			if !near(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if _, _, err := yuvToRGB(ColorSpace(99)); err == nil {
+		t.Error("expected error for unknown color space")
+	}
+}
+
+func TestEncodeParamsColumnMajor(t *testing.T) {
+	m := [3][3]float32{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
+	b := encodeParams(m, [3]float32{10, 11, 12})
+	f := func(i int) float32 {
+		return math.Float32frombits(uint32(b[4*i]) | uint32(b[4*i+1])<<8 | uint32(b[4*i+2])<<16 | uint32(b[4*i+3])<<24)
+	}
+	// First column is (1, 4, 7), second starts after 16 bytes.
+	if f(0) != 1 || f(1) != 4 || f(2) != 7 || f(4) != 2 || f(12) != 10 || f(14) != 12 {
+		t.Errorf("unexpected layout: %v", b)
+	}
+}
@@ -0,0 +1,210 @@
+// Package viewport renders WebGPU content offscreen and hands it to a GUI
+// toolkit as an *image.RGBA, so toolkits with their own renderer, such as
+// Gio or Ebiten, can embed a WebGPU view.
+//
+// A [Viewport] owns a color texture to render into and a staging buffer to
+// read it back through. Sharing the texture with the toolkit's GPU context
+// is not portable across backends, so every frame is copied through host
+// memory; keep embedded viewports modest in size.
+//
+//	vp, _ := viewport.New(device, 640, 480)
+//	defer vp.Release()
+//
+//	// per frame
+//	vp.Resize(w, h)
+//	encoder, _ := device.CreateCommandEncoder(nil)
+//	pass, _ := encoder.BeginRenderPass(vp.PassDescriptor(wgpu.Color{A: 1}))
+//	// ... draw with pipelines targeting vp.Format() ...
+//	pass.End()
+//	pass.Release()
+//	img, err = vp.Read(ctx, queue, encoder, img)
+//
+//	// Ebiten: widget.WritePixels(img.Pix)
+//	// Gio:    paint.NewImageOp(img).Add(gtx.Ops), with a nil dst each frame
+package viewport
+
+import (
+	"context"
+	"errors"
+	"image"
+
+	"github.com/go-webgpu/webgpu/wgpu"
+	"github.com/gogpu/gputypes"
+)
+
+// Format is the color format of viewport textures. Shader output is linear
+// and encoded to sRGB on write, which is what image.RGBA consumers expect.
+const Format = gputypes.TextureFormatRGBA8UnormSrgb
+
+// Viewport is an offscreen color target that can be read back as an image.
+// A Viewport is not safe for concurrent use.
+type Viewport struct {
+	device  *wgpu.Device
+	width   uint32
+	height  uint32
+	texture *wgpu.Texture
+	view    *wgpu.TextureView
+	staging *wgpu.Buffer
+	layout  wgpu.ImageDataLayout
+	size    uint64 // bytes of staging used by one frame
+}
+
+// New creates a width x height viewport.
+func New(device *wgpu.Device, width, height uint32) (*Viewport, error) {
+	if device == nil {
+		return nil, errors.New("viewport: device is nil")
+	}
+	vp := &Viewport{device: device}
+	if err := vp.Resize(width, height); err != nil {
+		return nil, err
+	}
+	return vp, nil
+}
+
+// Width returns the width of the viewport in pixels.
+func (vp *Viewport) Width() uint32 { return vp.width }
+
+// Height returns the height of the viewport in pixels.
+func (vp *Viewport) Height() uint32 { return vp.height }
+
+// Format returns the color format of the viewport texture, for pipeline
+// color targets.
+func (vp *Viewport) Format() gputypes.TextureFormat { return Format }
+
+// Texture returns the color texture, for example to sample it in another
+// pass. It changes when the viewport is resized.
+func (vp *Viewport) Texture() *wgpu.Texture { return vp.texture }
+
+// View returns the view of the color texture. It changes when the viewport
+// is resized.
+func (vp *Viewport) View() *wgpu.TextureView { return vp.view }
+
+// Resize recreates the texture and staging buffer if the size changed,
+// typically to follow the widget size the toolkit lays out. The previous
+// contents are lost.
+func (vp *Viewport) Resize(width, height uint32) error {
+	if width == 0 || height == 0 {
+		return errors.New("viewport: width and height must be non-zero")
+	}
+	if vp.texture != nil && width == vp.width && height == vp.height {
+		return nil
+	}
+	size := gputypes.Extent3D{Width: width, Height: height, DepthOrArrayLayers: 1}
+	layout, bufSize, err := wgpu.TextureCopyLayout(Format, size, 0)
+	if err != nil {
+		return err
+	}
+	tex, err := vp.device.CreateTexture(&wgpu.TextureDescriptor{
+		Label: "viewport",
+		Usage: gputypes.TextureUsageRenderAttachment | gputypes.TextureUsageTextureBinding |
+			gputypes.TextureUsageCopySrc,
+		Dimension:     gputypes.TextureDimension2D,
+		Size:          size,
+		Format:        Format,
+		MipLevelCount: 1,
+		SampleCount:   1,
+	})
+	if err != nil {
+		return err
+	}
+	view, err := tex.CreateView(nil)
+	if err != nil {
+		tex.Release()
+		return err
+	}
+	staging, err := vp.device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "viewport readback",
+		Usage: gputypes.BufferUsageMapRead | gputypes.BufferUsageCopyDst,
+		Size:  bufSize,
+	})
+	if err != nil {
+		view.Release()
+		tex.Release()
+		return err
+	}
+	vp.release()
+	vp.width, vp.height = width, height
+	vp.texture, vp.view, vp.staging = tex, view, staging
+	vp.layout, vp.size = layout, bufSize
+	return nil
+}
+
+// PassDescriptor returns a render pass that clears the viewport to clear
+// and stores the result.
+func (vp *Viewport) PassDescriptor(clear wgpu.Color) *wgpu.RenderPassDescriptor {
+	return &wgpu.RenderPassDescriptor{
+		Label: "viewport pass",
+		ColorAttachments: []wgpu.RenderPassColorAttachment{{
+			View:       vp.view,
+			LoadOp:     gputypes.LoadOpClear,
+			StoreOp:    gputypes.StoreOpStore,
+			ClearValue: clear,
+		}},
+	}
+}
+
+// Read copies the viewport into its staging buffer on encoder, finishes
+// and submits encoder on queue, and waits for the pixels. They are written
+// to dst, which is reallocated if nil or of another size, and dst is
+// returned. image.RGBA holds premultiplied alpha, so render translucent
+// content with premultiplied blending. Gio keeps the image given to
+// paint.NewImageOp, so pass a nil dst there rather than reusing the last one.
+//
+// encoder must have no open pass and is consumed: do not use it afterwards.
+func (vp *Viewport) Read(ctx context.Context, queue *wgpu.Queue, encoder *wgpu.CommandEncoder, dst *image.RGBA) (*image.RGBA, error) {
+	encoder.CopyTextureToBufferRegion(
+		&wgpu.ImageCopyTexture{Texture: vp.texture, Aspect: wgpu.TextureAspectAll},
+		vp.staging, 0,
+		gputypes.Extent3D{Width: vp.width, Height: vp.height, DepthOrArrayLayers: 1},
+	)
+	cmd, err := encoder.Finish()
+	if err != nil {
+		return dst, err
+	}
+	defer cmd.Release()
+	if _, err := queue.Submit(cmd); err != nil {
+		return dst, err
+	}
+	if err := vp.staging.Map(ctx, wgpu.MapModeRead, 0, vp.size); err != nil {
+		return dst, err
+	}
+	defer vp.staging.Unmap() //nolint:errcheck
+	rng, err := vp.staging.MappedRange(0, vp.size)
+	if err != nil {
+		return dst, err
+	}
+	if dst == nil || dst.Rect != image.Rect(0, 0, int(vp.width), int(vp.height)) {
+		dst = image.NewRGBA(image.Rect(0, 0, int(vp.width), int(vp.height)))
+	}
+	copyRows(dst, rng.Bytes(), int(vp.layout.BytesPerRow))
+	return dst, nil
+}
+
+// copyRows copies the rows of src, bytesPerRow apart, into dst.
+func copyRows(dst *image.RGBA, src []byte, bytesPerRow int) {
+	rowBytes := dst.Rect.Dx() * 4
+	for y := range dst.Rect.Dy() {
+		copy(dst.Pix[y*dst.Stride:y*dst.Stride+rowBytes], src[y*bytesPerRow:y*bytesPerRow+rowBytes])
+	}
+}
+
+// Release releases the texture, view and staging buffer.
+func (vp *Viewport) Release() {
+	vp.release()
+	vp.width, vp.height = 0, 0
+}
+
+func (vp *Viewport) release() {
+	if vp.staging != nil {
+		vp.staging.Release()
+		vp.staging = nil
+	}
+	if vp.view != nil {
+		vp.view.Release()
+		vp.view = nil
+	}
+	if vp.texture != nil {
+		vp.texture.Release()
+		vp.texture = nil
+	}
+}
@@ -0,0 +1,27 @@
+package viewport
+
+import (
+	"image"
+	"testing"
+)
+
+func TestCopyRows(t *testing.T) {
+	// 2x2 image read back with 256-byte aligned rows.
+	src := make([]byte, 256+8)
+	copy(src[0:], []byte{1, 2, 3, 4, 5, 6, 7, 8})
+	copy(src[256:], []byte{9, 10, 11, 12, 13, 14, 15, 16})
+	dst := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	copyRows(dst, src, 256)
+	for i, want := range []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16} {
+		if dst.Pix[i] != want {
+			t.Fatalf("Pix[%d] = %d, want %d", i, dst.Pix[i], want)
+		}
+	}
+}
+
+func TestResizeRejectsEmpty(t *testing.T) {
+	vp := &Viewport{}
+	if err := vp.Resize(0, 10); err == nil {
+		t.Error("Resize(0, 10) succeeded")
+	}
+}
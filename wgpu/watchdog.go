@@ -0,0 +1,175 @@
+package wgpu
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxWatchedSubmissions bounds the pending submissions a Watchdog keeps for
+// its reports; older ones are dropped from the list but still count as
+// pending.
+const maxWatchedSubmissions = 64
+
+// SubmissionInfo describes a [Queue.Submit] call.
+type SubmissionInfo struct {
+	Index uint64
+	Time  time.Time
+	// CommandBuffers holds the labels of the submitted command buffers.
+	CommandBuffers []string
+}
+
+// HangReport describes a device whose submitted work did not complete
+// within a [Watchdog]'s deadline.
+type HangReport struct {
+	// Stalled is how long the oldest unfinished submission has been pending.
+	Stalled time.Duration
+	// Pending lists the most recent unfinished submissions, oldest first.
+	Pending []SubmissionInfo
+	// LivePasses lists render and compute pass encoders not yet released.
+	// It is only filled in debug mode; see [SetDebugMode].
+	LivePasses []ResourceInfo
+}
+
+// String returns a multi-line summary of the report.
+func (r *HangReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "GPU work stalled for %v, %d submission(s) pending", r.Stalled.Round(time.Millisecond), len(r.Pending))
+	for _, s := range r.Pending {
+		fmt.Fprintf(&b, "\n  submission %d at %s: %q", s.Index, s.Time.Format("15:04:05.000"), s.CommandBuffers)
+	}
+	for _, p := range r.LivePasses {
+		fmt.Fprintf(&b, "\n  live %s %q", p.Type, p.Label)
+	}
+	return b.String()
+}
+
+// Watchdog reports GPU work that stops completing, so an application can
+// log diagnostics or recover instead of freezing silently. It watches the
+// submissions made through the device's queue, and when the oldest
+// unfinished one is older than the deadline, onHang is called once with a
+// [HangReport]. It is called again only after the device makes progress and
+// stalls anew.
+//
+// The watchdog does not poll the device. It learns that work completed from
+// [Queue.OnSubmittedWorkDone] callbacks, which run during the application's
+// own [Device.Poll] or [Instance.ProcessEvents] calls, so a program that
+// stops polling is reported as stalled too.
+//
+// A device has at most one watchdog. Create one with [NewWatchdog] and stop
+// it with [Watchdog.Stop] before releasing the device.
+type Watchdog struct {
+	device   *Device
+	deadline time.Duration
+	onHang   func(*HangReport)
+
+	mu       sync.Mutex
+	pending  []SubmissionInfo
+	oldest   time.Time // submission time of the oldest unfinished submission
+	reported bool      // onHang was called for the current stall
+	stop     chan struct{}
+	once     sync.Once
+}
+
+// NewWatchdog starts watching the submissions of device, replacing any
+// watchdog it had. onHang is called from a goroutine of the watchdog.
+func NewWatchdog(device *Device, deadline time.Duration, onHang func(*HangReport)) (*Watchdog, error) {
+	if err := checkInit(); err != nil {
+		return nil, err
+	}
+	if device == nil || device.handle == 0 {
+		return nil, &WGPUError{Op: "NewWatchdog", Message: "device is nil or released"}
+	}
+	if deadline <= 0 || onHang == nil {
+		return nil, &WGPUError{Op: "NewWatchdog", Message: "deadline must be positive and onHang set"}
+	}
+	w := &Watchdog{
+		device:   device,
+		deadline: deadline,
+		onHang:   onHang,
+		stop:     make(chan struct{}),
+	}
+	if old := device.watchdog.Swap(w); old != nil {
+		old.Stop()
+	}
+	go w.watch()
+	return w, nil
+}
+
+// Stop stops the watchdog.
+func (w *Watchdog) Stop() {
+	if w == nil {
+		return
+	}
+	w.once.Do(func() {
+		w.device.watchdog.CompareAndSwap(w, nil)
+		close(w.stop)
+	})
+}
+
+// submitted records a submission made through the device's queue.
+func (w *Watchdog) submitted(index uint64, commands []*CommandBuffer) {
+	info := SubmissionInfo{Index: index, Time: time.Now(), CommandBuffers: make([]string, len(commands))}
+	for i, cmd := range commands {
+		info.CommandBuffers[i] = cmd.label
+	}
+	w.mu.Lock()
+	if len(w.pending) == 0 {
+		w.oldest = info.Time
+	}
+	if len(w.pending) == maxWatchedSubmissions {
+		w.pending = append(w.pending[:0], w.pending[1:]...)
+	}
+	w.pending = append(w.pending, info)
+	w.mu.Unlock()
+}
+
+// completed forgets the submissions up to index, which the GPU finished.
+func (w *Watchdog) completed(index uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	i := 0
+	for i < len(w.pending) && w.pending[i].Index <= index {
+		i++
+	}
+	w.pending = append(w.pending[:0], w.pending[i:]...)
+	if len(w.pending) > 0 {
+		w.oldest = w.pending[0].Time
+	}
+	w.reported = false
+}
+
+// watch calls onHang when the oldest pending submission passes the deadline.
+func (w *Watchdog) watch() {
+	ticker := time.NewTicker(max(w.deadline/4, time.Millisecond))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case now := <-ticker.C:
+			if report := w.check(now); report != nil {
+				w.onHang(report)
+			}
+		}
+	}
+}
+
+// check returns a report if the work pending at now is stalled and not yet
+// reported.
+func (w *Watchdog) check(now time.Time) *HangReport {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.pending) == 0 || w.reported || now.Sub(w.oldest) < w.deadline {
+		return nil
+	}
+	w.reported = true
+	report := &HangReport{Stalled: now.Sub(w.oldest), Pending: append([]SubmissionInfo(nil), w.pending...)}
+	for _, res := range LiveResources() {
+		if res.Type == "RenderPassEncoder" || res.Type == "ComputePassEncoder" {
+			report.LivePasses = append(report.LivePasses, res)
+		}
+	}
+	return report
+}
@@ -0,0 +1,56 @@
+package wgpu
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatchdogCheck(t *testing.T) {
+	w := &Watchdog{deadline: time.Second}
+	w.submitted(1, []*CommandBuffer{{label: "frame 1"}})
+	w.submitted(2, []*CommandBuffer{{label: "frame 2"}, {label: "ui"}})
+	start := w.oldest
+	w.pending[1].Time = start.Add(2 * time.Second)
+
+	if r := w.check(start.Add(time.Second / 2)); r != nil {
+		t.Fatalf("report before the deadline: %v", r)
+	}
+	r := w.check(start.Add(2 * time.Second))
+	if r == nil {
+		t.Fatal("no report after the deadline")
+	}
+	if len(r.Pending) != 2 || r.Pending[1].Index != 2 || r.Pending[1].CommandBuffers[1] != "ui" {
+		t.Errorf("Pending = %+v", r.Pending)
+	}
+	if !strings.HasPrefix(r.String(), "GPU work stalled for 2s, 2 submission(s) pending") {
+		t.Errorf("String() = %q", r.String())
+	}
+	if r := w.check(start.Add(3 * time.Second)); r != nil {
+		t.Error("stall reported twice")
+	}
+
+	// Progress clears the stall; the remaining submission is measured from
+	// its own submission time.
+	w.completed(1)
+	if r := w.check(start.Add(5 * time.Second / 2)); r != nil {
+		t.Errorf("report for a submission pending %v", time.Second/2)
+	}
+	if r := w.check(start.Add(4 * time.Second)); r == nil || len(r.Pending) != 1 {
+		t.Errorf("report after progress = %v, want the stalled submission 2", r)
+	}
+	w.completed(2)
+	if len(w.pending) != 0 {
+		t.Errorf("pending after completion = %+v", w.pending)
+	}
+}
+
+func TestWatchdogBoundsPending(t *testing.T) {
+	w := &Watchdog{deadline: time.Second}
+	for i := range maxWatchedSubmissions + 10 {
+		w.submitted(uint64(i+1), nil)
+	}
+	if len(w.pending) != maxWatchedSubmissions || w.pending[0].Index != 11 {
+		t.Errorf("kept %d submissions starting at %d", len(w.pending), w.pending[0].Index)
+	}
+}
@@ -42,9 +42,10 @@ var (
 	procDeviceGetLimits      Proc
 
 	// Function pointers - Queue
-	procQueueRelease            Proc
-	procQueueWriteBuffer        Proc
-	procQueueGetTimestampPeriod Proc
+	procQueueRelease             Proc
+	procQueueWriteBuffer         Proc
+	procQueueGetTimestampPeriod  Proc
+	procQueueOnSubmittedWorkDone Proc
 
 	// Function pointers - Instance (global)
 	procGetInstanceFeatures Proc // v29: global instance feature query
@@ -64,8 +65,9 @@ var (
 	procBufferGetMapState      Proc
 
 	// Function pointers - ShaderModule
-	procDeviceCreateShaderModule Proc
-	procShaderModuleRelease      Proc
+	procDeviceCreateShaderModule       Proc
+	procShaderModuleRelease            Proc
+	procShaderModuleGetCompilationInfo Proc
 
 	// Function pointers - BindGroupLayout
 	procDeviceCreateBindGroupLayout Proc
@@ -105,6 +107,9 @@ var (
 	procComputePassEncoderDispatchWorkgroupsIndirect Proc
 	procComputePassEncoderEnd                        Proc
 	procComputePassEncoderRelease                    Proc
+	procComputePassEncoderInsertDebugMarker          Proc
+	procComputePassEncoderPushDebugGroup             Proc
+	procComputePassEncoderPopDebugGroup              Proc
 
 	// Function pointers - CommandBuffer
 	procCommandBufferRelease Proc
@@ -126,6 +131,7 @@ var (
 	// Function pointers - Texture
 	procDeviceCreateTexture                   Proc
 	procTextureRelease                        Proc
+	procTextureAddRef                         Proc
 	procTextureDestroy                        Proc
 	procTextureCreateView                     Proc
 	procTextureViewRelease                    Proc
@@ -133,6 +139,7 @@ var (
 	procTextureGetHeight                      Proc
 	procTextureGetDepthOrArrayLayers          Proc
 	procTextureGetMipLevelCount               Proc
+	procTextureGetDimension                   Proc
 	procTextureGetFormat                      Proc
 	procTextureGetSampleCount                 Proc // v29: new getter
 	procTextureGetUsage                       Proc // v29: new getter
@@ -191,6 +198,19 @@ var (
 	procRenderBundleEncoderRelease             Proc
 	procRenderBundleRelease                    Proc
 	procRenderPassEncoderExecuteBundles        Proc
+
+	// Function pointers - wgpu-native pass extensions
+	procRenderPassEncoderBeginPipelineStatisticsQuery  Proc
+	procRenderPassEncoderEndPipelineStatisticsQuery    Proc
+	procComputePassEncoderBeginPipelineStatisticsQuery Proc
+	procComputePassEncoderEndPipelineStatisticsQuery   Proc
+	procRenderPassEncoderMultiDrawIndirect             Proc
+	procRenderPassEncoderMultiDrawIndexedIndirect      Proc
+	procRenderPassEncoderMultiDrawIndirectCount        Proc
+	procRenderPassEncoderMultiDrawIndexedIndirectCount Proc
+	procRenderPassEncoderSetImmediates                 Proc
+	procComputePassEncoderSetImmediates                Proc
+	procRenderBundleEncoderSetImmediates               Proc
 )
 
 // Init initializes the wgpu library. Called automatically on first use.
@@ -211,7 +231,9 @@ func Init() error {
 			return
 		}
 
+		logger().Debug("wgpu: loaded native library", "path", libPath)
 		initSymbols()
+		logMissingSymbols()
 	})
 	return initErr
 }
@@ -247,6 +269,17 @@ func getLibraryPath() string {
 	return libName
 }
 
+// logMissingSymbols logs the symbols initSymbols looked up that the library
+// does not export. They are not fatal: older wgpu-native builds lack some
+// extensions, and only calls to those fail.
+func logMissingSymbols() {
+	if l, ok := wgpuLib.(symbolLister); ok {
+		for _, name := range l.missingSymbols() {
+			logger().Warn("wgpu: symbol missing from native library", "symbol", name)
+		}
+	}
+}
+
 func initSymbols() {
 	// Instance
 	procCreateInstance = wgpuLib.NewProc("wgpuCreateInstance")
@@ -279,6 +312,7 @@ func initSymbols() {
 	procQueueRelease = wgpuLib.NewProc("wgpuQueueRelease")
 	procQueueWriteBuffer = wgpuLib.NewProc("wgpuQueueWriteBuffer")
 	procQueueGetTimestampPeriod = wgpuLib.NewProc("wgpuQueueGetTimestampPeriod")
+	procQueueOnSubmittedWorkDone = wgpuLib.NewProc("wgpuQueueOnSubmittedWorkDone")
 
 	// Instance global queries (v29)
 	procGetInstanceFeatures = wgpuLib.NewProc("wgpuGetInstanceFeatures")
@@ -300,6 +334,7 @@ func initSymbols() {
 	// ShaderModule
 	procDeviceCreateShaderModule = wgpuLib.NewProc("wgpuDeviceCreateShaderModule")
 	procShaderModuleRelease = wgpuLib.NewProc("wgpuShaderModuleRelease")
+	procShaderModuleGetCompilationInfo = wgpuLib.NewProc("wgpuShaderModuleGetCompilationInfo")
 
 	// BindGroupLayout
 	procDeviceCreateBindGroupLayout = wgpuLib.NewProc("wgpuDeviceCreateBindGroupLayout")
@@ -339,6 +374,9 @@ func initSymbols() {
 	procComputePassEncoderDispatchWorkgroupsIndirect = wgpuLib.NewProc("wgpuComputePassEncoderDispatchWorkgroupsIndirect")
 	procComputePassEncoderEnd = wgpuLib.NewProc("wgpuComputePassEncoderEnd")
 	procComputePassEncoderRelease = wgpuLib.NewProc("wgpuComputePassEncoderRelease")
+	procComputePassEncoderInsertDebugMarker = wgpuLib.NewProc("wgpuComputePassEncoderInsertDebugMarker")
+	procComputePassEncoderPushDebugGroup = wgpuLib.NewProc("wgpuComputePassEncoderPushDebugGroup")
+	procComputePassEncoderPopDebugGroup = wgpuLib.NewProc("wgpuComputePassEncoderPopDebugGroup")
 
 	// CommandBuffer
 	procCommandBufferRelease = wgpuLib.NewProc("wgpuCommandBufferRelease")
@@ -360,6 +398,7 @@ func initSymbols() {
 	// Texture
 	procDeviceCreateTexture = wgpuLib.NewProc("wgpuDeviceCreateTexture")
 	procTextureRelease = wgpuLib.NewProc("wgpuTextureRelease")
+	procTextureAddRef = wgpuLib.NewProc("wgpuTextureAddRef")
 	procTextureDestroy = wgpuLib.NewProc("wgpuTextureDestroy")
 	procTextureCreateView = wgpuLib.NewProc("wgpuTextureCreateView")
 	procTextureViewRelease = wgpuLib.NewProc("wgpuTextureViewRelease")
@@ -367,6 +406,7 @@ func initSymbols() {
 	procTextureGetHeight = wgpuLib.NewProc("wgpuTextureGetHeight")
 	procTextureGetDepthOrArrayLayers = wgpuLib.NewProc("wgpuTextureGetDepthOrArrayLayers")
 	procTextureGetMipLevelCount = wgpuLib.NewProc("wgpuTextureGetMipLevelCount")
+	procTextureGetDimension = wgpuLib.NewProc("wgpuTextureGetDimension")
 	procTextureGetFormat = wgpuLib.NewProc("wgpuTextureGetFormat")
 	procTextureGetSampleCount = wgpuLib.NewProc("wgpuTextureGetSampleCount")                                 // v29
 	procTextureGetUsage = wgpuLib.NewProc("wgpuTextureGetUsage")                                             // v29
@@ -425,6 +465,19 @@ func initSymbols() {
 	procRenderBundleEncoderRelease = wgpuLib.NewProc("wgpuRenderBundleEncoderRelease")
 	procRenderBundleRelease = wgpuLib.NewProc("wgpuRenderBundleRelease")
 	procRenderPassEncoderExecuteBundles = wgpuLib.NewProc("wgpuRenderPassEncoderExecuteBundles")
+
+	// wgpu-native pass extensions
+	procRenderPassEncoderBeginPipelineStatisticsQuery = wgpuLib.NewProc("wgpuRenderPassEncoderBeginPipelineStatisticsQuery")
+	procRenderPassEncoderEndPipelineStatisticsQuery = wgpuLib.NewProc("wgpuRenderPassEncoderEndPipelineStatisticsQuery")
+	procComputePassEncoderBeginPipelineStatisticsQuery = wgpuLib.NewProc("wgpuComputePassEncoderBeginPipelineStatisticsQuery")
+	procComputePassEncoderEndPipelineStatisticsQuery = wgpuLib.NewProc("wgpuComputePassEncoderEndPipelineStatisticsQuery")
+	procRenderPassEncoderMultiDrawIndirect = wgpuLib.NewProc("wgpuRenderPassEncoderMultiDrawIndirect")
+	procRenderPassEncoderMultiDrawIndexedIndirect = wgpuLib.NewProc("wgpuRenderPassEncoderMultiDrawIndexedIndirect")
+	procRenderPassEncoderMultiDrawIndirectCount = wgpuLib.NewProc("wgpuRenderPassEncoderMultiDrawIndirectCount")
+	procRenderPassEncoderMultiDrawIndexedIndirectCount = wgpuLib.NewProc("wgpuRenderPassEncoderMultiDrawIndexedIndirectCount")
+	procRenderPassEncoderSetImmediates = wgpuLib.NewProc("wgpuRenderPassEncoderSetImmediates")
+	procComputePassEncoderSetImmediates = wgpuLib.NewProc("wgpuComputePassEncoderSetImmediates")
+	procRenderBundleEncoderSetImmediates = wgpuLib.NewProc("wgpuRenderBundleEncoderSetImmediates")
 }
 
 // ErrLibraryNotLoaded is returned when wgpu-native library is not loaded or failed to initialize.
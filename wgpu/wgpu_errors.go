@@ -58,3 +58,20 @@ func (e *WGPUError) Is(target error) bool {
 	}
 	return e.Op == t.Op && e.Type == t.Type && e.Message == t.Message
 }
+
+// String returns the lower-case name of the error type.
+func (t ErrorType) String() string {
+	switch t {
+	case ErrorTypeNoError:
+		return "no error"
+	case ErrorTypeValidation:
+		return "validation"
+	case ErrorTypeOutOfMemory:
+		return "out of memory"
+	case ErrorTypeInternal:
+		return "internal"
+	case ErrorTypeUnknown:
+		return "unknown"
+	}
+	return fmt.Sprintf("ErrorType(%d)", uint32(t))
+}
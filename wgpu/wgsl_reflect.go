@@ -0,0 +1,990 @@
+package wgpu
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gogpu/gputypes"
+)
+
+// ShaderBinding describes one resource variable declared in a WGSL module.
+type ShaderBinding struct {
+	Group uint32
+	Name  string
+	// Type is the WGSL store type as written, e.g. "texture_2d<f32>".
+	Type string
+	// Entry is the bind group layout entry matching the declaration. For
+	// buffers Entry.Buffer.MinBindingSize is the size of the store type, or
+	// of its fixed part plus one element when it ends in a runtime-sized
+	// array. Visibility covers the entry points that use the variable, or
+	// every stage the module declares when no entry point uses it.
+	Entry BindGroupLayoutEntry
+	// RuntimeSized reports a binding_array declared without a size. Its
+	// Entry.Count is 1; layouts choose the real element count, which the
+	// shader reads with arrayLength.
+	RuntimeSized bool
+}
+
+// ReflectWGSLBindings parses WGSL source and returns its resource bindings
+// sorted by group and binding number.
+//
+// The parser understands the declarations that affect binding layouts:
+// structs, aliases, integer constants, global variables and functions. It
+// does not validate the shader; compile errors are left to
+// [Device.CreateShaderModuleWGSL].
+func ReflectWGSLBindings(source string) ([]ShaderBinding, error) {
+	p, err := parseWGSL(source)
+	if err != nil {
+		return nil, err
+	}
+	return p.bindings()
+}
+
+// ShaderEntryPoint describes an entry point declared in a WGSL module.
+type ShaderEntryPoint struct {
+	Name  string
+	Stage gputypes.ShaderStage
+	// Bindings lists the resource variables the entry point uses, directly
+	// or through the functions it calls, sorted by group and binding.
+	Bindings []ShaderBinding
+}
+
+// ReflectWGSLEntryPoints parses WGSL source and returns its entry points
+// sorted by name.
+func ReflectWGSLEntryPoints(source string) ([]ShaderEntryPoint, error) {
+	p, err := parseWGSL(source)
+	if err != nil {
+		return nil, err
+	}
+	all, err := p.bindings()
+	if err != nil {
+		return nil, err
+	}
+	var out []ShaderEntryPoint
+	for _, name := range p.fnNames() {
+		stage := p.fns[name].stage
+		if stage == 0 {
+			continue
+		}
+		reached := p.reach(name)
+		ep := ShaderEntryPoint{Name: name, Stage: stage}
+		for _, b := range all {
+			if reached[b.Name] {
+				ep.Bindings = append(ep.Bindings, b)
+			}
+		}
+		out = append(out, ep)
+	}
+	return out, nil
+}
+
+// parseWGSL tokenizes and parses source.
+func parseWGSL(source string) (*wgslParser, error) {
+	p := &wgslParser{
+		toks:    wgslTokenize(source),
+		structs: make(map[string]*wgslStruct),
+		aliases: make(map[string]wgslType),
+		consts:  make(map[string]uint64),
+		fns:     make(map[string]*wgslFunc),
+	}
+	if err := p.parse(); err != nil {
+		return nil, fmt.Errorf("wgsl reflect: %w", err)
+	}
+	return p, nil
+}
+
+// CreateBindGroupLayoutFromShader creates the layout of bind group group as
+// declared in a module created by [Device.CreateShaderModuleWGSL], so layouts
+// no longer have to be written out by hand next to the shader.
+func (d *Device) CreateBindGroupLayoutFromShader(module *ShaderModule, group uint32) (*BindGroupLayout, error) {
+	if module == nil || module.handle == 0 {
+		return nil, &WGPUError{Op: "CreateBindGroupLayoutFromShader", Message: "shader module is nil or released"}
+	}
+	if module.source == "" {
+		return nil, &WGPUError{Op: "CreateBindGroupLayoutFromShader", Message: "shader module was not created from WGSL"}
+	}
+	bindings, err := ReflectWGSLBindings(module.source)
+	if err != nil {
+		return nil, &WGPUError{Op: "CreateBindGroupLayoutFromShader", Type: ErrorTypeValidation, Message: err.Error()}
+	}
+	var entries []BindGroupLayoutEntry
+	for _, b := range bindings {
+		if b.Group == group {
+			entries = append(entries, b.Entry)
+		}
+	}
+	return d.CreateBindGroupLayout(&BindGroupLayoutDescriptor{
+		Label:   fmt.Sprintf("group %d (reflected)", group),
+		Entries: entries,
+	})
+}
+
+// LayoutForShaderGroup creates the layout of bind group group as the given
+// entry points of module use it, which is the layout an auto-layout pipeline
+// built from those entry points derives: only the bindings they reach, each
+// visible to exactly the stages that use it. With no entry points every
+// entry point of the module is included.
+//
+// Unlike [Device.CreateBindGroupLayoutFromShader] it rejects runtime-sized
+// binding arrays, whose element count the shader does not determine; declare
+// those layouts with an explicit Count.
+func (d *Device) LayoutForShaderGroup(module *ShaderModule, group uint32, entryPoints ...string) (*BindGroupLayout, error) {
+	if module == nil || module.handle == 0 {
+		return nil, &WGPUError{Op: "LayoutForShaderGroup", Message: "shader module is nil or released"}
+	}
+	if module.source == "" {
+		return nil, &WGPUError{Op: "LayoutForShaderGroup", Message: "shader module was not created from WGSL"}
+	}
+	entries, err := shaderGroupEntries(module.source, group, entryPoints)
+	if err != nil {
+		return nil, &WGPUError{Op: "LayoutForShaderGroup", Type: ErrorTypeValidation, Message: err.Error()}
+	}
+	return d.CreateBindGroupLayout(&BindGroupLayoutDescriptor{
+		Label:   fmt.Sprintf("group %d (reflected)", group),
+		Entries: entries,
+	})
+}
+
+// shaderGroupEntries returns the layout entries of group as used by the named
+// entry points of source, or by all of them when names is empty.
+func shaderGroupEntries(source string, group uint32, names []string) ([]BindGroupLayoutEntry, error) {
+	eps, err := ReflectWGSLEntryPoints(source)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) > 0 {
+		var picked []ShaderEntryPoint
+		for _, name := range names {
+			i := slices.IndexFunc(eps, func(ep ShaderEntryPoint) bool { return ep.Name == name })
+			if i < 0 {
+				return nil, fmt.Errorf("entry point %q not found in shader module", name)
+			}
+			picked = append(picked, eps[i])
+		}
+		eps = picked
+	}
+	var entries []BindGroupLayoutEntry
+	for _, ep := range eps {
+		for _, b := range ep.Bindings {
+			if b.Group != group {
+				continue
+			}
+			if b.RuntimeSized {
+				return nil, fmt.Errorf("%s (@group(%d) @binding(%d)) is a runtime-sized binding_array; its layout needs an explicit Count",
+					b.Name, b.Group, b.Entry.Binding)
+			}
+			i := slices.IndexFunc(entries, func(e BindGroupLayoutEntry) bool { return e.Binding == b.Entry.Binding })
+			if i >= 0 {
+				entries[i].Visibility |= ep.Stage
+				continue
+			}
+			e := b.Entry
+			e.Visibility = ep.Stage
+			entries = append(entries, e)
+		}
+	}
+	slices.SortFunc(entries, func(a, b BindGroupLayoutEntry) int { return int(a.Binding) - int(b.Binding) })
+	return entries, nil
+}
+
+// =============================================================================
+// Tokenizer
+// =============================================================================
+
+// wgslTokenize splits source into identifiers, numbers and single-character
+// punctuation, dropping whitespace and comments. Template brackets are kept
+// as single characters so ">>" closes two templates.
+func wgslTokenize(src string) []string {
+	var toks []string
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "/*"):
+			// Block comments nest in WGSL.
+			depth := 0
+			for i < len(src) {
+				if strings.HasPrefix(src[i:], "/*") {
+					depth++
+					i += 2
+				} else if strings.HasPrefix(src[i:], "*/") {
+					depth--
+					i += 2
+					if depth == 0 {
+						break
+					}
+				} else {
+					i++
+				}
+			}
+		case isWGSLIdentByte(c):
+			j := i
+			for j < len(src) && (isWGSLIdentByte(src[j]) || src[j] == '.' && c >= '0' && c <= '9') {
+				j++
+			}
+			toks = append(toks, src[i:j])
+			i = j
+		default:
+			toks = append(toks, src[i:i+1])
+			i++
+		}
+	}
+	return toks
+}
+
+func isWGSLIdentByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
+
+func isWGSLIdent(tok string) bool {
+	return tok != "" && isWGSLIdentByte(tok[0]) && (tok[0] < '0' || tok[0] > '9')
+}
+
+// =============================================================================
+// Parser
+// =============================================================================
+
+// wgslType is a parsed type expression: a name with optional template
+// arguments, which are types, integer literals or identifiers.
+type wgslType struct {
+	name string
+	args []wgslType
+}
+
+func (t wgslType) String() string {
+	if len(t.args) == 0 {
+		return t.name
+	}
+	args := make([]string, len(t.args))
+	for i, a := range t.args {
+		args[i] = a.String()
+	}
+	return t.name + "<" + strings.Join(args, ", ") + ">"
+}
+
+type wgslMember struct {
+	typ         wgslType
+	align, size uint64 // from @align and @size; 0 when absent
+}
+
+type wgslStruct struct {
+	members []wgslMember
+}
+
+// wgslVar is a module-scope resource variable.
+type wgslVar struct {
+	name           string
+	group, binding uint32
+	addressSpace   string
+	access         string
+	typ            wgslType
+}
+
+type wgslFunc struct {
+	stage gputypes.ShaderStage // 0 for functions that are not entry points
+	refs  []string             // identifiers used in the body
+}
+
+type wgslParser struct {
+	toks []string
+	pos  int
+
+	structs map[string]*wgslStruct
+	aliases map[string]wgslType
+	consts  map[string]uint64
+	vars    []wgslVar
+	fns     map[string]*wgslFunc
+}
+
+func (p *wgslParser) peek() string {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+	return ""
+}
+
+func (p *wgslParser) next() string {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+func (p *wgslParser) expect(tok string) error {
+	if got := p.next(); got != tok {
+		return fmt.Errorf("expected %q, found %q", tok, got)
+	}
+	return nil
+}
+
+// skipBalanced skips from an opening bracket to its matching close.
+func (p *wgslParser) skipBalanced(open, close string) error {
+	if err := p.expect(open); err != nil {
+		return err
+	}
+	for depth := 1; depth > 0; {
+		switch p.next() {
+		case open:
+			depth++
+		case close:
+			depth--
+		case "":
+			return fmt.Errorf("unterminated %q", open)
+		}
+	}
+	return nil
+}
+
+// skipStatement skips to the ';' ending a declaration, ignoring semicolons
+// nested in brackets.
+func (p *wgslParser) skipStatement() error {
+	depth := 0
+	for {
+		switch p.next() {
+		case "(", "{", "[":
+			depth++
+		case ")", "}", "]":
+			depth--
+		case ";":
+			if depth == 0 {
+				return nil
+			}
+		case "":
+			return nil
+		}
+	}
+}
+
+// attributes parses a run of @name or @name(args) attributes.
+func (p *wgslParser) attributes() map[string][]string {
+	attrs := make(map[string][]string)
+	for p.peek() == "@" {
+		p.next()
+		name := p.next()
+		var args []string
+		if p.peek() == "(" {
+			p.next()
+			for depth := 1; depth > 0 && p.peek() != ""; {
+				tok := p.next()
+				switch tok {
+				case "(":
+					depth++
+				case ")":
+					depth--
+				}
+				if depth > 0 && tok != "," {
+					args = append(args, tok)
+				}
+			}
+		}
+		attrs[name] = args
+	}
+	return attrs
+}
+
+func (p *wgslParser) parse() error {
+	for p.peek() != "" {
+		attrs := p.attributes()
+		var err error
+		switch kw := p.next(); kw {
+		case "struct":
+			err = p.parseStruct()
+		case "alias":
+			err = p.parseAlias()
+		case "const", "override":
+			err = p.parseConst()
+		case "var":
+			err = p.parseVar(attrs)
+		case "fn":
+			err = p.parseFunc(attrs)
+		case ";":
+		case "enable", "requires", "diagnostic", "const_assert":
+			err = p.skipStatement()
+		default:
+			return fmt.Errorf("unexpected %q at module scope", kw)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *wgslParser) parseStruct() error {
+	name := p.next()
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	s := &wgslStruct{}
+	for p.peek() != "}" {
+		if p.peek() == "" {
+			return fmt.Errorf("struct %s: unterminated body", name)
+		}
+		attrs := p.attributes()
+		p.next() // member name
+		if err := p.expect(":"); err != nil {
+			return fmt.Errorf("struct %s: %w", name, err)
+		}
+		typ, err := p.parseType()
+		if err != nil {
+			return fmt.Errorf("struct %s: %w", name, err)
+		}
+		m := wgslMember{typ: typ}
+		if v, ok := attrs["align"]; ok && len(v) == 1 {
+			m.align, _ = p.intValue(v[0])
+		}
+		if v, ok := attrs["size"]; ok && len(v) == 1 {
+			m.size, _ = p.intValue(v[0])
+		}
+		s.members = append(s.members, m)
+		if p.peek() == "," {
+			p.next()
+		}
+	}
+	p.next()
+	p.structs[name] = s
+	return nil
+}
+
+func (p *wgslParser) parseAlias() error {
+	name := p.next()
+	if err := p.expect("="); err != nil {
+		return fmt.Errorf("alias %s: %w", name, err)
+	}
+	typ, err := p.parseType()
+	if err != nil {
+		return fmt.Errorf("alias %s: %w", name, err)
+	}
+	p.aliases[name] = typ
+	return p.skipStatement()
+}
+
+// parseConst records constants initialized with a plain integer literal so
+// they can size arrays; other initializers are skipped.
+func (p *wgslParser) parseConst() error {
+	name := p.next()
+	if p.peek() == ":" {
+		p.next()
+		if _, err := p.parseType(); err != nil {
+			return err
+		}
+	}
+	if p.peek() == "=" {
+		p.next()
+		if v, ok := parseWGSLInt(p.peek()); ok && p.pos+1 < len(p.toks) && p.toks[p.pos+1] == ";" {
+			p.consts[name] = v
+		}
+	}
+	return p.skipStatement()
+}
+
+func (p *wgslParser) parseVar(attrs map[string][]string) error {
+	v := wgslVar{}
+	if p.peek() == "<" {
+		p.next()
+		v.addressSpace = p.next()
+		if p.peek() == "," {
+			p.next()
+			v.access = p.next()
+		}
+		if err := p.expect(">"); err != nil {
+			return err
+		}
+	}
+	v.name = p.next()
+	if p.peek() == ":" {
+		p.next()
+		typ, err := p.parseType()
+		if err != nil {
+			return fmt.Errorf("var %s: %w", v.name, err)
+		}
+		v.typ = typ
+	}
+	if err := p.skipStatement(); err != nil {
+		return err
+	}
+	group, hasGroup := attrs["group"]
+	binding, hasBinding := attrs["binding"]
+	if !hasGroup || !hasBinding {
+		return nil // private or workgroup variable
+	}
+	g, ok1 := p.attrInt(group)
+	b, ok2 := p.attrInt(binding)
+	if !ok1 || !ok2 {
+		return fmt.Errorf("var %s: cannot evaluate @group/@binding", v.name)
+	}
+	v.group, v.binding = uint32(g), uint32(b)
+	p.vars = append(p.vars, v)
+	return nil
+}
+
+func (p *wgslParser) attrInt(args []string) (uint64, bool) {
+	if len(args) != 1 {
+		return 0, false
+	}
+	return p.intValue(args[0])
+}
+
+func (p *wgslParser) parseFunc(attrs map[string][]string) error {
+	name := p.next()
+	f := &wgslFunc{}
+	switch {
+	case hasAttr(attrs, "vertex"):
+		f.stage = gputypes.ShaderStageVertex
+	case hasAttr(attrs, "fragment"):
+		f.stage = gputypes.ShaderStageFragment
+	case hasAttr(attrs, "compute"):
+		f.stage = gputypes.ShaderStageCompute
+	}
+	if err := p.skipBalanced("(", ")"); err != nil {
+		return fmt.Errorf("fn %s: %w", name, err)
+	}
+	for p.peek() != "{" {
+		if p.next() == "" {
+			return fmt.Errorf("fn %s: missing body", name)
+		}
+	}
+	start := p.pos
+	if err := p.skipBalanced("{", "}"); err != nil {
+		return fmt.Errorf("fn %s: %w", name, err)
+	}
+	for _, tok := range p.toks[start:p.pos] {
+		if isWGSLIdent(tok) {
+			f.refs = append(f.refs, tok)
+		}
+	}
+	p.fns[name] = f
+	return nil
+}
+
+func hasAttr(attrs map[string][]string, name string) bool {
+	_, ok := attrs[name]
+	return ok
+}
+
+// parseType parses a type expression with optional template arguments.
+func (p *wgslParser) parseType() (wgslType, error) {
+	name := p.next()
+	if name == "" || !isWGSLIdentByte(name[0]) {
+		return wgslType{}, fmt.Errorf("expected a type, found %q", name)
+	}
+	t := wgslType{name: name}
+	if p.peek() != "<" {
+		return t, nil
+	}
+	p.next()
+	for {
+		arg, err := p.parseType()
+		if err != nil {
+			return wgslType{}, err
+		}
+		t.args = append(t.args, arg)
+		switch p.next() {
+		case ",":
+			if p.peek() == ">" { // trailing comma
+				p.next()
+				return t, nil
+			}
+		case ">":
+			return t, nil
+		default:
+			return wgslType{}, fmt.Errorf("malformed template list of %s", name)
+		}
+	}
+}
+
+// intValue evaluates an integer literal or a recorded constant.
+func (p *wgslParser) intValue(tok string) (uint64, bool) {
+	if v, ok := parseWGSLInt(tok); ok {
+		return v, true
+	}
+	v, ok := p.consts[tok]
+	return v, ok
+}
+
+// parseWGSLInt parses a decimal or hexadecimal literal with an optional
+// i or u suffix.
+func parseWGSLInt(tok string) (uint64, bool) {
+	tok = strings.TrimRight(tok, "iu")
+	v, err := strconv.ParseUint(tok, 0, 64)
+	return v, err == nil
+}
+
+// resolve follows aliases to the underlying type.
+func (p *wgslParser) resolve(t wgslType) wgslType {
+	for i := 0; i < 32; i++ {
+		a, ok := p.aliases[t.name]
+		if !ok {
+			break
+		}
+		t = a
+	}
+	return t
+}
+
+// =============================================================================
+// Layout
+// =============================================================================
+
+// vectorShorthands maps predeclared aliases such as vec4f to their scalar.
+var vectorShorthands = map[byte]string{'f': "f32", 'i': "i32", 'u': "u32", 'h': "f16"}
+
+// typeLayout returns the alignment and size of a host-shareable type. For a
+// runtime-sized array the size is that of one element.
+func (p *wgslParser) typeLayout(t wgslType) (align, size uint64, err error) {
+	t = p.resolve(t)
+	switch t.name {
+	case "f32", "i32", "u32":
+		return 4, 4, nil
+	case "f16":
+		return 2, 2, nil
+	case "atomic":
+		return 4, 4, nil
+	case "array":
+		if len(t.args) == 0 {
+			return 0, 0, fmt.Errorf("array without element type")
+		}
+		ea, es, err := p.typeLayout(t.args[0])
+		if err != nil {
+			return 0, 0, err
+		}
+		stride := roundUp(ea, es)
+		n := uint64(1)
+		if len(t.args) > 1 {
+			var ok bool
+			if n, ok = p.intValue(t.args[1].name); !ok {
+				return 0, 0, fmt.Errorf("cannot evaluate array size %q", t.args[1].name)
+			}
+		}
+		return ea, stride * n, nil
+	}
+
+	if s, ok := p.structs[t.name]; ok {
+		var offset, maxAlign uint64 = 0, 1
+		for _, m := range s.members {
+			ma, ms, err := p.typeLayout(m.typ)
+			if err != nil {
+				return 0, 0, err
+			}
+			if m.align != 0 {
+				ma = m.align
+			}
+			if m.size != 0 {
+				ms = m.size
+			}
+			offset = roundUp(ma, offset) + ms
+			maxAlign = max(maxAlign, ma)
+		}
+		return maxAlign, roundUp(maxAlign, offset), nil
+	}
+
+	scalar, rest := t, t.name
+	if len(t.args) > 0 {
+		scalar = t.args[0]
+	}
+	// vecN<T> / vecNT and matCxR<T> / matCxRT.
+	if strings.HasPrefix(rest, "vec") && len(rest) >= 4 {
+		n := uint64(rest[3] - '0')
+		if len(rest) == 5 {
+			scalar = wgslType{name: vectorShorthands[rest[4]]}
+		}
+		_, ss, err := p.typeLayout(scalar)
+		if err != nil || n < 2 || n > 4 {
+			return 0, 0, fmt.Errorf("unsupported type %s", t)
+		}
+		return vecAlign(n) * ss, n * ss, nil
+	}
+	if strings.HasPrefix(rest, "mat") && len(rest) >= 6 && rest[4] == 'x' {
+		cols, rows := uint64(rest[3]-'0'), uint64(rest[5]-'0')
+		if len(rest) == 7 {
+			scalar = wgslType{name: vectorShorthands[rest[6]]}
+		}
+		_, ss, err := p.typeLayout(scalar)
+		if err != nil || cols < 2 || cols > 4 || rows < 2 || rows > 4 {
+			return 0, 0, fmt.Errorf("unsupported type %s", t)
+		}
+		colAlign := vecAlign(rows) * ss
+		return colAlign, cols * roundUp(colAlign, rows*ss), nil
+	}
+	return 0, 0, fmt.Errorf("type %s is not host-shareable", t)
+}
+
+// vecAlign is the alignment of an n-component vector in scalar units.
+func vecAlign(n uint64) uint64 {
+	if n == 2 {
+		return 2
+	}
+	return 4
+}
+
+func roundUp(align, n uint64) uint64 {
+	if align == 0 {
+		return n
+	}
+	return (n + align - 1) / align * align
+}
+
+// =============================================================================
+// Bindings
+// =============================================================================
+
+var (
+	wgslFormatsOnce sync.Once
+	wgslFormats     map[string]gputypes.TextureFormat
+)
+
+// wgslTexelFormat maps a WGSL texel format name such as rgba8unorm to the
+// texture format; the WGSL names are the lower-case format names.
+func wgslTexelFormat(name string) (gputypes.TextureFormat, bool) {
+	wgslFormatsOnce.Do(func() {
+		wgslFormats = make(map[string]gputypes.TextureFormat)
+		for f := range uncompressedFormats {
+			if !f.HasDepth() && !f.HasStencil() {
+				wgslFormats[strings.ToLower(f.String())] = f
+			}
+		}
+	})
+	f, ok := wgslFormats[name]
+	return f, ok
+}
+
+var wgslViewDimensions = map[string]gputypes.TextureViewDimension{
+	"1d":         gputypes.TextureViewDimension1D,
+	"2d":         gputypes.TextureViewDimension2D,
+	"2d_array":   gputypes.TextureViewDimension2DArray,
+	"3d":         gputypes.TextureViewDimension3D,
+	"cube":       gputypes.TextureViewDimensionCube,
+	"cube_array": gputypes.TextureViewDimensionCubeArray,
+}
+
+var wgslSampleTypes = map[string]gputypes.TextureSampleType{
+	"f32": gputypes.TextureSampleTypeFloat,
+	"i32": gputypes.TextureSampleTypeSint,
+	"u32": gputypes.TextureSampleTypeUint,
+}
+
+var wgslStorageAccess = map[string]gputypes.StorageTextureAccess{
+	"read":       gputypes.StorageTextureAccessReadOnly,
+	"write":      gputypes.StorageTextureAccessWriteOnly,
+	"read_write": gputypes.StorageTextureAccessReadWrite,
+}
+
+// bindings builds the layout entries of every resource variable.
+func (p *wgslParser) bindings() ([]ShaderBinding, error) {
+	used, allStages := p.usage()
+	out := make([]ShaderBinding, 0, len(p.vars))
+	for _, v := range p.vars {
+		e := BindGroupLayoutEntry{Binding: v.binding}
+		if err := p.fillEntry(&e, v); err != nil {
+			return nil, fmt.Errorf("binding %s (@group(%d) @binding(%d)): %w", v.name, v.group, v.binding, err)
+		}
+		e.Visibility = used[v.name]
+		if e.Visibility == 0 {
+			e.Visibility = allStages
+			if writable(e) {
+				e.Visibility &^= gputypes.ShaderStageVertex
+			}
+		}
+		t := p.resolve(v.typ)
+		runtime := t.name == "binding_array" && len(t.args) == 1
+		out = append(out, ShaderBinding{Group: v.group, Name: v.name, Type: v.typ.String(), Entry: e, RuntimeSized: runtime})
+	}
+	slices.SortFunc(out, func(a, b ShaderBinding) int {
+		if a.Group != b.Group {
+			return int(a.Group) - int(b.Group)
+		}
+		return int(a.Entry.Binding) - int(b.Entry.Binding)
+	})
+	return out, nil
+}
+
+// writable reports whether e may be written by the shader, which WebGPU
+// does not allow from the vertex stage.
+func writable(e BindGroupLayoutEntry) bool {
+	if e.Buffer != nil {
+		return e.Buffer.Type == gputypes.BufferBindingTypeStorage
+	}
+	return e.StorageTexture != nil && e.StorageTexture.Access != gputypes.StorageTextureAccessReadOnly
+}
+
+// usage returns the stages whose entry points reach each global through the
+// call graph, and the union of all entry point stages in the module (every
+// stage when there are none).
+func (p *wgslParser) usage() (map[string]gputypes.ShaderStage, gputypes.ShaderStage) {
+	used := make(map[string]gputypes.ShaderStage)
+	var all gputypes.ShaderStage
+	for _, name := range p.fnNames() {
+		stage := p.fns[name].stage
+		if stage == 0 {
+			continue
+		}
+		all |= stage
+		for ref := range p.reach(name) {
+			used[ref] |= stage
+		}
+	}
+	if all == 0 {
+		all = gputypes.ShaderStageVertex | gputypes.ShaderStageFragment | gputypes.ShaderStageCompute
+	}
+	return used, all
+}
+
+// fnNames returns the names of all functions in sorted order.
+func (p *wgslParser) fnNames() []string {
+	names := make([]string, 0, len(p.fns))
+	for name := range p.fns {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// reach returns the non-function identifiers referenced by fn and every
+// function it calls.
+func (p *wgslParser) reach(fn string) map[string]bool {
+	refs := map[string]bool{}
+	seen := map[string]bool{}
+	var walk func(fn string)
+	walk = func(fn string) {
+		if seen[fn] {
+			return
+		}
+		seen[fn] = true
+		for _, ref := range p.fns[fn].refs {
+			if _, isFn := p.fns[ref]; isFn {
+				walk(ref)
+			} else {
+				refs[ref] = true
+			}
+		}
+	}
+	walk(fn)
+	return refs
+}
+
+// fillEntry sets the resource part of e from the declaration of v.
+func (p *wgslParser) fillEntry(e *BindGroupLayoutEntry, v wgslVar) error {
+	switch v.addressSpace {
+	case "uniform", "storage":
+		_, size, err := p.typeLayout(v.typ)
+		if err != nil {
+			return err
+		}
+		bt := gputypes.BufferBindingTypeUniform
+		if v.addressSpace == "storage" {
+			bt = gputypes.BufferBindingTypeReadOnlyStorage
+			if v.access == "read_write" {
+				bt = gputypes.BufferBindingTypeStorage
+			}
+		}
+		e.Buffer = &BufferBindingLayout{Type: bt, MinBindingSize: size}
+		return nil
+	case "":
+	default:
+		return fmt.Errorf("address space %q cannot be bound", v.addressSpace)
+	}
+
+	t := p.resolve(v.typ)
+	if t.name == "binding_array" {
+		if len(t.args) == 0 {
+			return fmt.Errorf("binding_array without element type")
+		}
+		e.Count = 1
+		if len(t.args) > 1 {
+			n, ok := p.intValue(t.args[1].name)
+			if !ok {
+				return fmt.Errorf("cannot evaluate binding_array size %q", t.args[1].name)
+			}
+			e.Count = uint32(n)
+		}
+		t = p.resolve(t.args[0])
+	}
+	return fillHandleEntry(e, t)
+}
+
+// fillHandleEntry handles sampler and texture types.
+func fillHandleEntry(e *BindGroupLayoutEntry, t wgslType) error {
+	switch t.name {
+	case "sampler":
+		e.Sampler = &SamplerBindingLayout{Type: gputypes.SamplerBindingTypeFiltering}
+		return nil
+	case "sampler_comparison":
+		e.Sampler = &SamplerBindingLayout{Type: gputypes.SamplerBindingTypeComparison}
+		return nil
+	case "texture_multisampled_2d":
+		e.Texture = &TextureBindingLayout{
+			SampleType:    multisampledSampleType(t),
+			ViewDimension: gputypes.TextureViewDimension2D,
+			Multisampled:  true,
+		}
+		return nil
+	case "texture_depth_multisampled_2d":
+		e.Texture = &TextureBindingLayout{
+			SampleType:    gputypes.TextureSampleTypeDepth,
+			ViewDimension: gputypes.TextureViewDimension2D,
+			Multisampled:  true,
+		}
+		return nil
+	}
+
+	if t.name == "texture_external" {
+		// webgpu.h declares external texture bindings, but wgpu-native
+		// cannot create the WGPUExternalTexture objects they bind.
+		return fmt.Errorf("texture_external is not supported by wgpu-native; bind the planes as texture_2d<f32> (see package video)")
+	}
+	if dim, ok := strings.CutPrefix(t.name, "texture_depth_"); ok {
+		vd, ok := wgslViewDimensions[dim]
+		if !ok {
+			return fmt.Errorf("unsupported type %s", t)
+		}
+		e.Texture = &TextureBindingLayout{SampleType: gputypes.TextureSampleTypeDepth, ViewDimension: vd}
+		return nil
+	}
+	if dim, ok := strings.CutPrefix(t.name, "texture_storage_"); ok {
+		vd, ok := wgslViewDimensions[dim]
+		if !ok || len(t.args) != 2 {
+			return fmt.Errorf("unsupported type %s", t)
+		}
+		format, ok := wgslTexelFormat(t.args[0].name)
+		if !ok {
+			return fmt.Errorf("unknown texel format %q", t.args[0].name)
+		}
+		access, ok := wgslStorageAccess[t.args[1].name]
+		if !ok {
+			return fmt.Errorf("unknown access mode %q", t.args[1].name)
+		}
+		e.StorageTexture = &StorageTextureBindingLayout{Access: access, Format: format, ViewDimension: vd}
+		return nil
+	}
+	if dim, ok := strings.CutPrefix(t.name, "texture_"); ok {
+		vd, ok := wgslViewDimensions[dim]
+		if !ok || len(t.args) != 1 {
+			return fmt.Errorf("unsupported type %s", t)
+		}
+		st, ok := wgslSampleTypes[t.args[0].name]
+		if !ok {
+			return fmt.Errorf("unsupported sample type %q", t.args[0].name)
+		}
+		e.Texture = &TextureBindingLayout{SampleType: st, ViewDimension: vd}
+		return nil
+	}
+	return fmt.Errorf("type %s cannot be bound", t)
+}
+
+// multisampledSampleType maps the sample type of a multisampled texture,
+// which cannot be filtered.
+func multisampledSampleType(t wgslType) gputypes.TextureSampleType {
+	if len(t.args) == 1 {
+		if st, ok := wgslSampleTypes[t.args[0].name]; ok && st != gputypes.TextureSampleTypeFloat {
+			return st
+		}
+	}
+	return gputypes.TextureSampleTypeUnfilterableFloat
+}
@@ -0,0 +1,273 @@
+package wgpu
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+const reflectTestShader = `
+enable f16;
+
+const LIGHTS = 4u;
+
+struct Light {
+    position: vec3<f32>,
+    intensity: f32,
+}
+
+/* Camera and lights share one uniform block. /* nested */ */
+struct Globals {
+    view_proj: mat4x4f,
+    lights: array<Light, LIGHTS>,
+    time: f32,
+}
+
+alias Particles = array<vec4f>;
+
+struct Counters {
+    count: atomic<u32>,
+    @align(16) items: array<u32>,
+}
+
+@group(0) @binding(0) var<uniform> globals: Globals;
+@group(0) @binding(1) var albedo: texture_2d<f32>;
+@group(0) @binding(2) var albedo_sampler: sampler;
+@group(1) @binding(0) var<storage, read_write> particles: Particles;
+@group(1) @binding(1) var<storage> counters: Counters;
+@group(1) @binding(2) var output: texture_storage_2d<rgba16float, write>;
+@group(2) @binding(0) var shadow: texture_depth_2d_array;
+@group(2) @binding(1) var shadow_sampler: sampler_comparison;
+@group(2) @binding(2) var materials: binding_array<texture_2d<f32>, 64>;
+@group(2) @binding(3) var msaa: texture_multisampled_2d<f32>;
+
+var<private> seed: u32;
+
+fn shade(uv: vec2f) -> vec4f {
+    // albedo_sampler is only reached through this helper.
+    return textureSample(albedo, albedo_sampler, uv);
+}
+
+@vertex
+fn vs_main(@builtin(vertex_index) i: u32) -> @builtin(position) vec4f {
+    return globals.view_proj * vec4f(f32(i), 0.0, 0.0, 1.0);
+}
+
+@fragment
+fn fs_main(@location(0) uv: vec2f) -> @location(0) vec4f {
+    let d = textureSampleCompare(shadow, shadow_sampler, uv, 0, 0.5);
+    return shade(uv) * d * globals.time;
+}
+
+@compute @workgroup_size(64)
+fn simulate(@builtin(global_invocation_id) id: vec3u) {
+    particles[id.x] = particles[id.x] + vec4f(1.0);
+    atomicAdd(&counters.count, 1u);
+}
+`
+
+func TestReflectWGSLBindings(t *testing.T) {
+	bindings, err := ReflectWGSLBindings(reflectTestShader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := make(map[string]ShaderBinding)
+	for _, b := range bindings {
+		byName[b.Name] = b
+	}
+	if len(bindings) != 10 || bindings[0].Name != "globals" || bindings[9].Name != "msaa" {
+		t.Fatalf("got %d bindings starting %q, want 10 sorted by group and binding", len(bindings), bindings[0].Name)
+	}
+
+	vf := gputypes.ShaderStageVertex | gputypes.ShaderStageFragment
+	tests := []struct {
+		name       string
+		group      uint32
+		binding    uint32
+		visibility gputypes.ShaderStage
+		check      func(e BindGroupLayoutEntry) bool
+	}{
+		// mat4x4f(64) + 4 * Light(16) + f32(4), rounded to the 16-byte struct alignment.
+		{"globals", 0, 0, vf, func(e BindGroupLayoutEntry) bool {
+			return e.Buffer.Type == gputypes.BufferBindingTypeUniform && e.Buffer.MinBindingSize == 144
+		}},
+		{"albedo", 0, 1, gputypes.ShaderStageFragment, func(e BindGroupLayoutEntry) bool {
+			return e.Texture.SampleType == gputypes.TextureSampleTypeFloat &&
+				e.Texture.ViewDimension == gputypes.TextureViewDimension2D
+		}},
+		{"albedo_sampler", 0, 2, gputypes.ShaderStageFragment, func(e BindGroupLayoutEntry) bool {
+			return e.Sampler.Type == gputypes.SamplerBindingTypeFiltering
+		}},
+		{"particles", 1, 0, gputypes.ShaderStageCompute, func(e BindGroupLayoutEntry) bool {
+			return e.Buffer.Type == gputypes.BufferBindingTypeStorage && e.Buffer.MinBindingSize == 16
+		}},
+		// atomic (4) then items at offset 16 with one element.
+		{"counters", 1, 1, gputypes.ShaderStageCompute, func(e BindGroupLayoutEntry) bool {
+			return e.Buffer.Type == gputypes.BufferBindingTypeReadOnlyStorage && e.Buffer.MinBindingSize == 32
+		}},
+		// Unused: every stage in the module except vertex, since it is writable.
+		{"output", 1, 2, gputypes.ShaderStageFragment | gputypes.ShaderStageCompute, func(e BindGroupLayoutEntry) bool {
+			return e.StorageTexture.Format == gputypes.TextureFormatRGBA16Float &&
+				e.StorageTexture.Access == gputypes.StorageTextureAccessWriteOnly
+		}},
+		{"shadow", 2, 0, gputypes.ShaderStageFragment, func(e BindGroupLayoutEntry) bool {
+			return e.Texture.SampleType == gputypes.TextureSampleTypeDepth &&
+				e.Texture.ViewDimension == gputypes.TextureViewDimension2DArray
+		}},
+		{"shadow_sampler", 2, 1, gputypes.ShaderStageFragment, func(e BindGroupLayoutEntry) bool {
+			return e.Sampler.Type == gputypes.SamplerBindingTypeComparison
+		}},
+		{"materials", 2, 2, vf | gputypes.ShaderStageCompute, func(e BindGroupLayoutEntry) bool {
+			return e.Texture != nil && e.Count == 64
+		}},
+		{"msaa", 2, 3, vf | gputypes.ShaderStageCompute, func(e BindGroupLayoutEntry) bool {
+			return e.Texture.Multisampled && e.Texture.SampleType == gputypes.TextureSampleTypeUnfilterableFloat
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, ok := byName[tt.name]
+			if !ok {
+				t.Fatal("binding not found")
+			}
+			if b.Group != tt.group || b.Entry.Binding != tt.binding {
+				t.Errorf("at @group(%d) @binding(%d), want (%d, %d)", b.Group, b.Entry.Binding, tt.group, tt.binding)
+			}
+			if b.Entry.Visibility != tt.visibility {
+				t.Errorf("visibility = %#x, want %#x", b.Entry.Visibility, tt.visibility)
+			}
+			if !tt.check(b.Entry) {
+				t.Errorf("entry %+v does not match %s", b.Entry, b.Type)
+			}
+		})
+	}
+}
+
+func TestWGSLTypeLayout(t *testing.T) {
+	p := &wgslParser{structs: map[string]*wgslStruct{}, aliases: map[string]wgslType{}, consts: map[string]uint64{}}
+	tests := []struct {
+		typ         wgslType
+		align, size uint64
+	}{
+		{wgslType{name: "vec3f"}, 16, 12},
+		{wgslType{name: "vec2", args: []wgslType{{name: "f16"}}}, 4, 4},
+		{wgslType{name: "mat3x3f"}, 16, 48},
+		{wgslType{name: "mat2x2f"}, 8, 16},
+		{wgslType{name: "array", args: []wgslType{{name: "vec3f"}, {name: "3"}}}, 16, 48},
+	}
+	for _, tt := range tests {
+		align, size, err := p.typeLayout(tt.typ)
+		if err != nil || align != tt.align || size != tt.size {
+			t.Errorf("%s: align %d size %d err %v, want %d %d", tt.typ, align, size, err, tt.align, tt.size)
+		}
+	}
+}
+
+func TestReflectWGSLBindingsErrors(t *testing.T) {
+	for name, src := range map[string]string{
+		"external texture": "@group(0) @binding(0) var t: texture_external;",
+		"unknown format":   "@group(0) @binding(0) var t: texture_storage_2d<rgba9unorm, write>;",
+		"bool uniform":     "@group(0) @binding(0) var<uniform> b: bool;",
+		"unterminated":     "struct S { a: f32,",
+	} {
+		if _, err := ReflectWGSLBindings(src); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestReflectExternalTextureError(t *testing.T) {
+	_, err := ReflectWGSLBindings("@group(0) @binding(0) var t: texture_external;")
+	if err == nil || !strings.Contains(err.Error(), "not supported by wgpu-native") {
+		t.Errorf("err = %v, want an explanation that external textures are unsupported", err)
+	}
+}
+
+func TestCreateBindGroupLayoutFromShaderNeedsWGSL(t *testing.T) {
+	var d *Device
+	if _, err := d.CreateBindGroupLayoutFromShader(nil, 0); err == nil {
+		t.Error("expected an error for a nil module")
+	}
+	spirv := &ShaderModule{handle: 1}
+	if _, err := d.CreateBindGroupLayoutFromShader(spirv, 0); err == nil {
+		t.Error("expected an error for a module without WGSL source")
+	}
+}
+
+func TestReflectWGSLEntryPoints(t *testing.T) {
+	eps, err := ReflectWGSLEntryPoints(reflectTestShader)
+	if err != nil {
+		t.Fatalf("ReflectWGSLEntryPoints: %v", err)
+	}
+	want := map[string]struct {
+		stage    gputypes.ShaderStage
+		bindings []string
+	}{
+		"fs_main":  {gputypes.ShaderStageFragment, []string{"globals", "albedo", "albedo_sampler", "shadow", "shadow_sampler"}},
+		"simulate": {gputypes.ShaderStageCompute, []string{"particles", "counters"}},
+		"vs_main":  {gputypes.ShaderStageVertex, []string{"globals"}},
+	}
+	if len(eps) != len(want) {
+		t.Fatalf("got %d entry points, want %d", len(eps), len(want))
+	}
+	for _, ep := range eps {
+		w, ok := want[ep.Name]
+		if !ok {
+			t.Errorf("unexpected entry point %q", ep.Name)
+			continue
+		}
+		if ep.Stage != w.stage {
+			t.Errorf("%s: stage = %v, want %v", ep.Name, ep.Stage, w.stage)
+		}
+		var names []string
+		for _, b := range ep.Bindings {
+			names = append(names, b.Name)
+		}
+		if !slices.Equal(names, w.bindings) {
+			t.Errorf("%s: bindings = %v, want %v", ep.Name, names, w.bindings)
+		}
+	}
+}
+
+func TestShaderGroupEntries(t *testing.T) {
+	vf := gputypes.ShaderStageVertex | gputypes.ShaderStageFragment
+	tests := []struct {
+		name  string
+		group uint32
+		eps   []string
+		want  map[uint32]gputypes.ShaderStage
+	}{
+		{"vertex only", 0, []string{"vs_main"}, map[uint32]gputypes.ShaderStage{0: gputypes.ShaderStageVertex}},
+		{"vertex and fragment", 0, []string{"vs_main", "fs_main"}, map[uint32]gputypes.ShaderStage{
+			0: vf, 1: gputypes.ShaderStageFragment, 2: gputypes.ShaderStageFragment,
+		}},
+		{"unused group", 1, []string{"vs_main"}, map[uint32]gputypes.ShaderStage{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := shaderGroupEntries(reflectTestShader, tt.group, tt.eps)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != len(tt.want) {
+				t.Fatalf("got %d entries, want %d", len(entries), len(tt.want))
+			}
+			for _, e := range entries {
+				if vis, ok := tt.want[e.Binding]; !ok || e.Visibility != vis {
+					t.Errorf("binding %d: visibility %v, want %v", e.Binding, e.Visibility, vis)
+				}
+			}
+		})
+	}
+
+	if _, err := shaderGroupEntries(reflectTestShader, 0, []string{"missing"}); err == nil {
+		t.Error("expected an error for an unknown entry point")
+	}
+	runtime := `@group(0) @binding(0) var ts: binding_array<texture_2d<f32>>;
+@fragment fn main() -> @location(0) vec4f { return textureLoad(ts[0], vec2u(), 0); }`
+	if _, err := shaderGroupEntries(runtime, 0, nil); err == nil {
+		t.Error("expected an error for a runtime-sized binding array")
+	}
+}